@@ -84,7 +84,7 @@ type tsdbAggregateAllQuery struct {
 // single-groupby-5-1-1
 // single-groupby-5-8-1
 func (d *Devops) GroupByTime(qi query.Query, nhosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	interval := d.MustRandWindow(timeRange)
 	hostnames, err := d.GetRandomHosts(nhosts)
 	if err != nil {
 		panic(err)
@@ -134,7 +134,7 @@ func (d *Devops) GroupByTime(qi query.Query, nhosts, numMetrics int, timeRange t
 // high-cpu-1
 // high-cpu-all
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	interval := d.MustRandWindow(devops.HighCPUDuration)
 	var hostnames []string
 	if nHosts > 0 {
 		var err error
@@ -186,7 +186,7 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 // cpu-max-all-1
 // cpu-max-all-8
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.MaxAllDuration)
+	interval := d.MustRandWindow(devops.MaxAllDuration)
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
 	startTimestamp := interval.StartUnixNano()
@@ -257,7 +257,7 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(devops.DoubleGroupByDuration)
 	startTimestamp := interval.StartUnixNano()
 	endTimestamp := interval.EndUnixNano()
 
@@ -3,7 +3,12 @@ package clickhouse
 import (
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
 )
@@ -11,6 +16,10 @@ import (
 // BaseGenerator contains settings specific for ClickHouse.
 type BaseGenerator struct {
 	UseTags bool
+
+	// UsePreparedStatements makes queries bind their hosts and time bounds
+	// as arguments of a parameterized template instead of inlining them.
+	UsePreparedStatements bool
 }
 
 // GenerateEmptyQuery returns an empty query.ClickHouse.
@@ -18,13 +27,23 @@ func (g *BaseGenerator) GenerateEmptyQuery() query.Query {
 	return query.NewClickHouse()
 }
 
+// newArgs returns the collector for the arguments of a parameterized query,
+// or nil when values should be inlined as literals.
+func (g *BaseGenerator) newArgs() *databases.SQLArgs {
+	if !g.UsePreparedStatements {
+		return nil
+	}
+	return databases.NewQuestionMarkSQLArgs()
+}
+
 // fill Query fills the query struct with data
-func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, table, sql string) {
+func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, table, sql string, args ...string) {
 	q := qi.(*query.ClickHouse)
 	q.HumanLabel = []byte(humanLabel)
 	q.HumanDescription = []byte(humanDesc)
 	q.Table = []byte(table)
 	q.SqlQuery = []byte(sql)
+	q.SqlArgs = append(q.SqlArgs[:0], args...)
 }
 
 // NewDevops creates a new devops use case query generator.
@@ -42,3 +61,67 @@ func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryG
 
 	return devops, nil
 }
+
+// NewIoT creates a new iot use case query generator.
+func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := iot.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iot := &IoT{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return iot, nil
+}
+
+// NewFinance creates a new finance use case query generator.
+func (g *BaseGenerator) NewFinance(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := finance.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	finance := &Finance{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return finance, nil
+}
+
+// NewLogs creates a new logs use case query generator.
+func (g *BaseGenerator) NewLogs(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := logs.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &Logs{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return logs, nil
+}
+
+// NewSmartMeter creates a new smart-meter use case query generator.
+func (g *BaseGenerator) NewSmartMeter(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := smartmeter.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	smartMeter := &SmartMeter{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return smartMeter, nil
+}
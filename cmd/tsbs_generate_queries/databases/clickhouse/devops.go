@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
 	"github.com/timescale/tsbs/pkg/query"
 )
@@ -23,18 +24,16 @@ type Devops struct {
 }
 
 // getHostWhereWithHostnames creates WHERE SQL statement for multiple hostnames.
+// The hostnames are bound through args, which inlines them when nil.
 // NOTE: 'WHERE' itself is not included, just hostname filter clauses, ready to concatenate to 'WHERE' string
-func (d *Devops) getHostWhereWithHostnames(hostnames []string) string {
+func (d *Devops) getHostWhereWithHostnames(hostnames []string, args *databases.SQLArgs) string {
 	hostnameSelectionClauses := []string{}
 
 	if d.UseTags {
 		// Use separated table for Tags
 		// Need to prepare WHERE with `tags` table
 		// WHERE tags_id IN (SELECT those tag.id FROM separated tags table WHERE )
-		for _, s := range hostnames {
-			hostnameSelectionClauses = append(hostnameSelectionClauses, fmt.Sprintf("'%s'", s))
-		}
-		return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE hostname IN (%s))", strings.Join(hostnameSelectionClauses, ","))
+		return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE hostname IN (%s))", args.BindStrings(hostnames, ","))
 	}
 
 	// Here we DO NOT use tags as a separate table
@@ -44,17 +43,17 @@ func (d *Devops) getHostWhereWithHostnames(hostnames []string) string {
 	// All tags are included into one table
 	// Need to prepare WHERE (hostname = 'host1' OR hostname = 'host2') clause
 	for _, s := range hostnames {
-		hostnameSelectionClauses = append(hostnameSelectionClauses, fmt.Sprintf("hostname = '%s'", s))
+		hostnameSelectionClauses = append(hostnameSelectionClauses, "hostname = "+args.BindString(s))
 	}
 	// (host=h1 OR host=h2)
 	return "(" + strings.Join(hostnameSelectionClauses, " OR ") + ")"
 }
 
 // getHostWhereString gets multiple random hostnames and create WHERE SQL statement for these hostnames.
-func (d *Devops) getHostWhereString(nhosts int) string {
+func (d *Devops) getHostWhereString(nhosts int, args *databases.SQLArgs) string {
 	hostnames, err := d.GetRandomHosts(nhosts)
 	panicIfErr(err)
-	return d.getHostWhereWithHostnames(hostnames)
+	return d.getHostWhereWithHostnames(hostnames, args)
 }
 
 // getSelectClausesAggMetrics gets specified aggregate function clause for multiple memtrics
@@ -86,27 +85,37 @@ const clickhouseTimeStringFormat = "2006-01-02 15:04:05"
 // cpu-max-all-1
 // cpu-max-all-8
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
-	interval := d.Interval.MustRandWindow(duration)
+	d.MaxAllCPUBucket(qi, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *Devops) MaxAllCPUBucket(qi query.Query, nHosts int, duration, bucket time.Duration) {
+	interval := d.MustRandWindow(duration)
 	metrics := devops.GetAllCPUMetrics()
 	selectClauses := d.getSelectClausesAggMetrics("max", metrics)
+	args := d.newArgs()
+	bucketSelect, bucketName := getHourBucket(bucket)
 
 	sql := fmt.Sprintf(`
         SELECT
-            toStartOfHour(created_at) AS hour,
+            %s,
             %s
         FROM cpu
-        WHERE %s AND (created_at >= '%s') AND (created_at < '%s')
-        GROUP BY hour
-        ORDER BY hour
+        WHERE %s AND (created_at >= %s) AND (created_at < %s)
+        GROUP BY %s
+        ORDER BY %s
         `,
+		bucketSelect,
 		strings.Join(selectClauses, ", "),
-		d.getHostWhereString(nHosts),
-		interval.Start().Format(clickhouseTimeStringFormat),
-		interval.End().Format(clickhouseTimeStringFormat))
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		bucketName,
+		bucketName)
 
-	humanLabel := devops.GetMaxAllLabel("ClickHouse", nHosts)
+	humanLabel := devops.GetMaxAllBucketLabel("ClickHouse", nHosts, duration, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByTimeAndPrimaryTag selects the AVG of numMetrics metrics under 'cpu' per device per hour for a day,
@@ -123,9 +132,15 @@ func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
 // double-groupby-5
 // double-groupby-all
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qi, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qi query.Query, numMetrics int, window, bucket time.Duration) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(window)
 
 	selectClauses := make([]string, numMetrics)
 	meanClauses := make([]string, numMetrics)
@@ -139,40 +154,46 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 	if d.UseTags {
 		joinClause = "ANY INNER JOIN tags USING (id)"
 	}
+	args := d.newArgs()
+	bucketSelect, bucketName := getHourBucket(bucket)
 
 	sql := fmt.Sprintf(`
         SELECT
-            hour,
+            %s,
             %s,
             %s
         FROM
         (
             SELECT
-                toStartOfHour(created_at) AS hour,
+                %s,
                 tags_id AS id,
                 %s
             FROM cpu
-            WHERE (created_at >= '%s') AND (created_at < '%s')
+            WHERE (created_at >= %s) AND (created_at < %s)
             GROUP BY
-                hour,
+                %s,
                 id
         ) AS cpu_avg
         %s
         ORDER BY
-            hour ASC,
+            %s ASC,
             %s
         `,
-		hostnameField,                                       // main SELECT %s,
-		strings.Join(meanClauses, ", "),                     // main SELECT %s
-		strings.Join(selectClauses, ", "),                   // cpu_avg SELECT %s
-		interval.Start().Format(clickhouseTimeStringFormat), // cpu_avg time >= '%s'
-		interval.End().Format(clickhouseTimeStringFormat),   // cpu_avg time < '%s'
+		bucketName,                        // main SELECT %s,
+		hostnameField,                     // main SELECT %s,
+		strings.Join(meanClauses, ", "),   // main SELECT %s
+		bucketSelect,                      // cpu_avg SELECT %s,
+		strings.Join(selectClauses, ", "), // cpu_avg SELECT %s
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)), // cpu_avg time >= '%s'
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),   // cpu_avg time < '%s'
+		bucketName,    // cpu_avg GROUP BY %s
 		joinClause,    // JOIN clause
+		bucketName,    // ORDER BY %s ASC
 		hostnameField) // ORDER BY %s
 
-	humanLabel := devops.GetDoubleGroupByLabel("ClickHouse", numMetrics)
+	humanLabel := devops.GetDoubleGroupByWindowLabel("ClickHouse", numMetrics, window, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByOrderByLimit populates a query.Query that has a time WHERE clause, that groups by a truncated date, orders by that date, and takes a limit:
@@ -186,23 +207,30 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // Resultsets:
 // groupby-orderby-limit
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	d.GroupByOrderByLimitN(qi, devops.GroupByOrderByLimitRows)
+}
+
+// GroupByOrderByLimitN is GroupByOrderByLimit with the given limit.
+func (d *Devops) GroupByOrderByLimitN(qi query.Query, limit int) {
+	interval := d.MustRandWindow(time.Hour)
+	args := d.newArgs()
 
 	sql := fmt.Sprintf(`
         SELECT
             toStartOfMinute(created_at) AS minute,
             max(usage_user)
         FROM cpu
-        WHERE created_at < '%s'
+        WHERE created_at < %s
         GROUP BY minute
         ORDER BY minute DESC
-        LIMIT 5
+        LIMIT %d
         `,
-		interval.End().Format(clickhouseTimeStringFormat))
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		limit)
 
-	humanLabel := "ClickHouse max cpu over last 5 min-intervals (random end)"
+	humanLabel := devops.GetGroupByOrderByLimitLabel("ClickHouse", limit)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.EndString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // HighCPUForHosts populates a query that gets CPU metrics when the CPU has high
@@ -218,27 +246,43 @@ func (d *Devops) GroupByOrderByLimit(qi query.Query) {
 // high-cpu-1
 // high-cpu-all
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
+	d.HighCPUForHostsWindow(qi, nHosts, devops.HighCPUDuration)
+}
+
+// HighCPUForHostsWindow is HighCPUForHosts over the given window.
+func (d *Devops) HighCPUForHostsWindow(qi query.Query, nHosts int, window time.Duration) {
+	var hostnames []string
+	if nHosts != 0 {
+		var err error
+		hostnames, err = d.GetRandomHosts(nHosts)
+		panicIfErr(err)
+	}
+	interval := d.MustRandWindow(window)
+
+	// ? placeholders are positional, so values are bound in query order
+	args := d.newArgs()
+	start := args.BindString(interval.Start().Format(clickhouseTimeStringFormat))
+	end := args.BindString(interval.End().Format(clickhouseTimeStringFormat))
 	var hostWhereClause string
 	if nHosts == 0 {
 		hostWhereClause = ""
 	} else {
-		hostWhereClause = fmt.Sprintf("AND (%s)", d.getHostWhereString(nHosts))
+		hostWhereClause = fmt.Sprintf("AND (%s)", d.getHostWhereWithHostnames(hostnames, args))
 	}
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
 
 	sql := fmt.Sprintf(`
         SELECT *
         FROM cpu
-        PREWHERE (usage_user > 90.0) AND (created_at >= '%s') AND (created_at <  '%s') %s
+        PREWHERE (usage_user > 90.0) AND (created_at >= %s) AND (created_at <  %s) %s
         `,
-		interval.Start().Format(clickhouseTimeStringFormat),
-		interval.End().Format(clickhouseTimeStringFormat),
+		start,
+		end,
 		hostWhereClause)
 
-	humanLabel, err := devops.GetHighCPULabel("ClickHouse", nHosts)
+	humanLabel, err := devops.GetHighCPUWindowLabel("ClickHouse", nHosts, window)
 	panicIfErr(err)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // LastPointPerHost finds the last row for every host in the dataset
@@ -304,26 +348,326 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // single-groupby-5-1-1
 // single-groupby-5-8-1
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	d.GroupByTimeBucket(qi, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qi query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	interval := d.MustRandWindow(timeRange)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	selectClauses := d.getSelectClausesAggMetrics("max", metrics)
+	args := d.newArgs()
+
+	bucketSelect := "toStartOfMinute(created_at) AS minute"
+	bucketName := "minute"
+	if bucket != time.Minute {
+		bucketSelect = fmt.Sprintf("toStartOfInterval(created_at, INTERVAL %d second) AS bucket", int64(bucket.Seconds()))
+		bucketName = "bucket"
+	}
 
 	sql := fmt.Sprintf(`
         SELECT
-            toStartOfMinute(created_at) AS minute,
+            %s,
             %s
         FROM cpu
-        WHERE %s AND (created_at >= '%s') AND (created_at < '%s')
-        GROUP BY minute
-        ORDER BY minute ASC
+        WHERE %s AND (created_at >= %s) AND (created_at < %s)
+        GROUP BY %s
+        ORDER BY %s ASC
         `,
+		bucketSelect,
 		strings.Join(selectClauses, ", "),
-		d.getHostWhereString(nHosts),
-		interval.Start().Format(clickhouseTimeStringFormat),
-		interval.End().Format(clickhouseTimeStringFormat))
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		bucketName,
+		bucketName)
 
-	humanLabel := fmt.Sprintf("ClickHouse %d cpu metric(s), random %4d hosts, random %s by 1m", numMetrics, nHosts, timeRange)
+	humanLabel := fmt.Sprintf("ClickHouse %d cpu metric(s), random %4d hosts, random %s by %s", numMetrics, nHosts, timeRange, devops.GetBucketName(bucket))
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// getHourBucket returns the select expression and the name of time buckets of
+// the given size, which are the hours of the default queries
+func getHourBucket(bucket time.Duration) (string, string) {
+	if bucket == time.Hour {
+		return "toStartOfHour(created_at) AS hour", "hour"
+	}
+	return fmt.Sprintf("toStartOfInterval(created_at, INTERVAL %d second) AS bucket", int64(bucket.Seconds())), "bucket"
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT hour, hostname, quantiles(0.5, 0.95, 0.99)(metric1), ...
+// FROM cpu
+// WHERE (hostname = '$HOSTNAME_1' OR ... OR hostname = '$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hour, hostname
+// ORDER BY hour, hostname
+//
+// Resultsets:
+// percentiles-1-1-12
+// percentiles-1-8-12
+// percentiles-5-8-12
+func (d *Devops) GroupByTimePercentiles(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	panicIfErr(err)
+
+	levels := make([]string, len(devops.GetPercentiles()))
+	for i, p := range devops.GetPercentiles() {
+		levels[i] = fmt.Sprintf("%g", p)
+	}
+	selectClauses := make([]string, numMetrics)
+	percentileClauses := make([]string, numMetrics)
+	for i, m := range metrics {
+		percentileClauses[i] = "percentiles_" + m
+		selectClauses[i] = fmt.Sprintf("quantiles(%s)(%s) AS %s", strings.Join(levels, ", "), m, percentileClauses[i])
+	}
+
+	partitionSelect := "hostname"
+	partitionGrouping := "hostname"
+	joinClause := ""
+	if d.UseTags {
+		partitionSelect = "tags_id AS id"
+		partitionGrouping = "id"
+		joinClause = "ANY INNER JOIN tags USING (id)"
+	}
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        SELECT
+            hour,
+            hostname,
+            %s
+        FROM
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                %s,
+                %s
+            FROM cpu
+            WHERE %s AND (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                hour,
+                %s
+        ) AS cpu_percentiles
+        %s
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		strings.Join(percentileClauses, ", "), // main SELECT %s
+		partitionSelect,                       // cpu_percentiles SELECT %s,
+		strings.Join(selectClauses, ", "),     // cpu_percentiles SELECT %s
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		partitionGrouping, // cpu_percentiles GROUP BY %s
+		joinClause)        // JOIN clause
+
+	humanLabel := devops.GetPercentilesLabel("ClickHouse", numMetrics, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in pseudo-SQL:
+//
+// SELECT hostname, avg(usage_user) AS mean_usage_user
+// FROM cpu
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname
+// ORDER BY mean_usage_user DESC
+// LIMIT k
+//
+// Resultsets:
+// top-k-hosts-10
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+
+	partitionSelect := "hostname"
+	partitionGrouping := "hostname"
+	joinClause := ""
+	if d.UseTags {
+		partitionSelect = "tags_id AS id"
+		partitionGrouping = "id"
+		joinClause = "ANY INNER JOIN tags USING (id)"
+	}
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        SELECT
+            hostname,
+            mean_usage_user
+        FROM
+        (
+            SELECT
+                %s,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE (created_at >= %s) AND (created_at < %s)
+            GROUP BY %s
+            ORDER BY mean_usage_user DESC
+            LIMIT %d
+        ) AS host_avg
+        %s
+        ORDER BY mean_usage_user DESC
+        `,
+		partitionSelect, // host_avg SELECT %s,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		partitionGrouping, // host_avg GROUP BY %s
+		k,
+		joinClause) // JOIN clause
+
+	humanLabel := devops.GetTopKHostsLabel("ClickHouse", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// GroupByTimeCrossMeasurement correlates the mean of a metric from each of
+// the cpu, mem and diskio tables per host per hour for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT hour, hostname, mean_usage_user, mean_used_percent, mean_reads
+// FROM (SELECT hour, hostname, avg(usage_user) AS mean_usage_user FROM cpu ...) AS cpu_avg
+// ALL INNER JOIN (SELECT hour, hostname, avg(used_percent) AS mean_used_percent FROM mem ...) AS mem_avg USING (hour, hostname)
+// ALL INNER JOIN (SELECT hour, hostname, avg(reads) AS mean_reads FROM diskio ...) AS diskio_avg USING (hour, hostname)
+// ORDER BY hour, hostname
+//
+// Resultsets:
+// cross-measurement-1-12
+// cross-measurement-8-1
+func (d *Devops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	hostnames, err := d.GetRandomHosts(nHosts)
+	panicIfErr(err)
+
+	partitionSelect := "hostname"
+	partitionGrouping := "hostname"
+	joinClause := ""
+	if d.UseTags {
+		partitionSelect = "tags_id AS id"
+		partitionGrouping = "id"
+		joinClause = "ANY INNER JOIN tags USING (id)"
+	}
+	args := d.newArgs()
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	meanClauses := make([]string, len(metrics))
+	subqueries := make([]string, len(metrics))
+	for i, m := range metrics {
+		meanClauses[i] = "mean_" + m.Metric
+		// Hosts and times are bound once per subquery since ClickHouse
+		// placeholders are positional.
+		subqueries[i] = fmt.Sprintf(`(
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                %s,
+                avg(%s) AS mean_%s
+            FROM %s
+            WHERE %s AND (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                hour,
+                %s
+        ) AS %s_avg`,
+			partitionSelect,
+			m.Metric, m.Metric,
+			m.Measurement,
+			d.getHostWhereWithHostnames(hostnames, args),
+			args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+			args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+			partitionGrouping,
+			m.Measurement)
+		if i > 0 {
+			subqueries[i] = fmt.Sprintf("ALL INNER JOIN\n        %s USING (hour, %s)", subqueries[i], partitionGrouping)
+		}
+	}
+
+	sql := fmt.Sprintf(`
+        SELECT
+            hour,
+            hostname,
+            %s
+        FROM
+        %s
+        %s
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		strings.Join(meanClauses, ", "),        // main SELECT %s
+		strings.Join(subqueries, "\n        "), // joined subqueries
+		joinClause)                             // JOIN clause
+
+	humanLabel := devops.GetCrossMeasurementLabel("ClickHouse", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// getTagsTable returns the table holding the tags of the cpu series.
+func (d *Devops) getTagsTable() string {
+	if d.UseTags {
+		return "tags"
+	}
+	return devops.TableName
+}
+
+// TagValues lists the hostnames of a random region,
+// e.g. in pseudo-SQL:
+//
+// SELECT DISTINCT hostname
+// FROM tags
+// WHERE region = '$REGION'
+// ORDER BY hostname
+//
+// Resultsets:
+// tag-values
+func (d *Devops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        SELECT DISTINCT hostname
+        FROM %s
+        WHERE region = %s
+        ORDER BY hostname
+        `,
+		d.getTagsTable(),
+		args.BindString(region))
+
+	humanLabel := devops.GetTagValuesLabel("ClickHouse")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// SeriesCount counts the series of a random region, one per host,
+// e.g. in pseudo-SQL:
+//
+// SELECT uniqExact(hostname)
+// FROM tags
+// WHERE region = '$REGION'
+//
+// Resultsets:
+// series-count
+func (d *Devops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        SELECT uniqExact(hostname) AS series
+        FROM %s
+        WHERE region = %s
+        `,
+		d.getTagsTable(),
+		args.BindString(region))
+
+	humanLabel := devops.GetSeriesCountLabel("ClickHouse")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
@@ -52,7 +52,7 @@ func TestDevopsGetHostWhereWithHostnames(t *testing.T) {
 		d := dg.(*Devops)
 		d.UseTags = c.useTags
 
-		if got := d.getHostWhereWithHostnames(c.hostnames); got != c.want {
+		if got := d.getHostWhereWithHostnames(c.hostnames, nil); got != c.want {
 			t.Errorf("%s: incorrect output: got %s want %s", c.desc, got, c.want)
 		}
 	}
@@ -459,6 +459,492 @@ func TestGroupByTime(t *testing.T) {
 	runTestCases(t, testFunc, start, end, cases)
 }
 
+func TestGroupByTimeBucket(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			input:              1,
+			expectedHumanLabel: "ClickHouse 1 cpu metric(s), random    1 hosts, random 1s by 5m",
+			expectedHumanDesc:  "ClickHouse 1 cpu metric(s), random    1 hosts, random 1s by 5m: 1970-01-01T01:09:26Z",
+			expectedQuery: `
+        SELECT
+            toStartOfInterval(created_at, INTERVAL 300 second) AS bucket,
+            max(usage_user) AS max_usage_user
+        FROM cpu
+        WHERE (hostname = 'host_9') AND (created_at >= '1970-01-01 01:09:26') AND (created_at < '1970-01-01 01:09:27')
+        GROUP BY bucket
+        ORDER BY bucket ASC
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimeBucket(q, c.input, 1, time.Second, 5*time.Minute)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(2*time.Hour), cases)
+}
+
+func TestGroupByTimeAndPrimaryTagWindow(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			input:              1,
+			expectedHumanLabel: "ClickHouse mean of 1 metrics, all hosts, random 24h0m0s by 10m",
+			expectedHumanDesc:  "ClickHouse mean of 1 metrics, all hosts, random 24h0m0s by 10m: 1970-01-01T18:16:22Z",
+			expectedQuery: `
+        SELECT
+            bucket,
+            hostname,
+            mean_usage_user
+        FROM
+        (
+            SELECT
+                toStartOfInterval(created_at, INTERVAL 600 second) AS bucket,
+                tags_id AS id,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE (created_at >= '1970-01-01 18:16:22') AND (created_at < '1970-01-02 18:16:22')
+            GROUP BY
+                bucket,
+                id
+        ) AS cpu_avg
+        
+        ORDER BY
+            bucket ASC,
+            hostname
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimeAndPrimaryTagWindow(q, c.input, 24*time.Hour, 10*time.Minute)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(2*24*time.Hour), cases)
+}
+
+func TestMaxAllCPUBucket(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			input:              1,
+			expectedHumanLabel: "ClickHouse max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m",
+			expectedHumanDesc:  "ClickHouse max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m: 1970-01-01T20:16:22Z",
+			expectedQuery: `
+        SELECT
+            toStartOfInterval(created_at, INTERVAL 1800 second) AS bucket,
+            max(usage_user) AS max_usage_user, max(usage_system) AS max_usage_system, max(usage_idle) AS max_usage_idle, max(usage_nice) AS max_usage_nice, max(usage_iowait) AS max_usage_iowait, max(usage_irq) AS max_usage_irq, max(usage_softirq) AS max_usage_softirq, max(usage_steal) AS max_usage_steal, max(usage_guest) AS max_usage_guest, max(usage_guest_nice) AS max_usage_guest_nice
+        FROM cpu
+        WHERE (hostname = 'host_9') AND (created_at >= '1970-01-01 20:16:22') AND (created_at < '1970-01-01 22:16:22')
+        GROUP BY bucket
+        ORDER BY bucket
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.MaxAllCPUBucket(q, c.input, 2*time.Hour, 30*time.Minute)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(2*24*time.Hour), cases)
+}
+
+func TestHighCPUForHostsWindow(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			input:              1,
+			expectedHumanLabel: "ClickHouse CPU over threshold, 1 host(s), random 1h0m0s",
+			expectedHumanDesc:  "ClickHouse CPU over threshold, 1 host(s), random 1h0m0s: 1970-01-02T00:54:10Z",
+			expectedQuery: `
+        SELECT *
+        FROM cpu
+        PREWHERE (usage_user > 90.0) AND (created_at >= '1970-01-02 00:54:10') AND (created_at <  '1970-01-02 01:54:10') AND ((hostname = 'host_5'))
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.HighCPUForHostsWindow(q, c.input, time.Hour)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(2*24*time.Hour), cases)
+}
+
+func TestGroupByOrderByLimitN(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			input:              1,
+			expectedHumanLabel: "ClickHouse max cpu over last 10 min-intervals (random end)",
+			expectedHumanDesc:  "ClickHouse max cpu over last 10 min-intervals (random end): 1970-01-02T03:16:22Z",
+			expectedQuery: `
+        SELECT
+            toStartOfMinute(created_at) AS minute,
+            max(usage_user)
+        FROM cpu
+        WHERE created_at < '1970-01-02 03:16:22'
+        GROUP BY minute
+        ORDER BY minute DESC
+        LIMIT 10
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByOrderByLimitN(q, 10*c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(2*24*time.Hour), cases)
+}
+
+func TestGroupByTimePercentiles(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "no tags",
+			input:              2,
+			expectedHumanLabel: "ClickHouse p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "ClickHouse p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z",
+			expectedQuery: `
+        SELECT
+            hour,
+            hostname,
+            percentiles_usage_user
+        FROM
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                hostname,
+                quantiles(0.5, 0.95, 0.99)(usage_user) AS percentiles_usage_user
+            FROM cpu
+            WHERE (hostname = 'host_9' OR hostname = 'host_3') AND (created_at >= '1970-01-01 06:16:22') AND (created_at < '1970-01-01 18:16:22')
+            GROUP BY
+                hour,
+                hostname
+        ) AS cpu_percentiles
+        
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		},
+		{
+			desc:               "use tags",
+			input:              1,
+			devopsUseTags:      true,
+			expectedHumanLabel: "ClickHouse p50/p95/p99 of 1 cpu metric(s), random    1 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "ClickHouse p50/p95/p99 of 1 cpu metric(s), random    1 hosts, random 12h0m0s by 1h: 1970-01-01T04:37:12Z",
+			expectedQuery: `
+        SELECT
+            hour,
+            hostname,
+            percentiles_usage_user
+        FROM
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                tags_id AS id,
+                quantiles(0.5, 0.95, 0.99)(usage_user) AS percentiles_usage_user
+            FROM cpu
+            WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9')) AND (created_at >= '1970-01-01 04:37:12') AND (created_at < '1970-01-01 16:37:12')
+            GROUP BY
+                hour,
+                id
+        ) AS cpu_percentiles
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimePercentiles(q, c.input, 1, 12*time.Hour)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(24 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
+func TestGroupByTimeCrossMeasurement(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "no tags",
+			input:              2,
+			expectedHumanLabel: "ClickHouse mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "ClickHouse mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z",
+			expectedQuery: `
+        SELECT
+            hour,
+            hostname,
+            mean_usage_user, mean_used_percent, mean_reads
+        FROM
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                hostname,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE (hostname = 'host_9' OR hostname = 'host_3') AND (created_at >= '1970-01-01 06:16:22') AND (created_at < '1970-01-01 18:16:22')
+            GROUP BY
+                hour,
+                hostname
+        ) AS cpu_avg
+        ALL INNER JOIN
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                hostname,
+                avg(used_percent) AS mean_used_percent
+            FROM mem
+            WHERE (hostname = 'host_9' OR hostname = 'host_3') AND (created_at >= '1970-01-01 06:16:22') AND (created_at < '1970-01-01 18:16:22')
+            GROUP BY
+                hour,
+                hostname
+        ) AS mem_avg USING (hour, hostname)
+        ALL INNER JOIN
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                hostname,
+                avg(reads) AS mean_reads
+            FROM diskio
+            WHERE (hostname = 'host_9' OR hostname = 'host_3') AND (created_at >= '1970-01-01 06:16:22') AND (created_at < '1970-01-01 18:16:22')
+            GROUP BY
+                hour,
+                hostname
+        ) AS diskio_avg USING (hour, hostname)
+        
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		},
+		{
+			desc:               "use tags",
+			input:              2,
+			devopsUseTags:      true,
+			expectedHumanLabel: "ClickHouse mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "ClickHouse mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h: 1970-01-01T04:37:12Z",
+			expectedQuery: `
+        SELECT
+            hour,
+            hostname,
+            mean_usage_user, mean_used_percent, mean_reads
+        FROM
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                tags_id AS id,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_5')) AND (created_at >= '1970-01-01 04:37:12') AND (created_at < '1970-01-01 16:37:12')
+            GROUP BY
+                hour,
+                id
+        ) AS cpu_avg
+        ALL INNER JOIN
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                tags_id AS id,
+                avg(used_percent) AS mean_used_percent
+            FROM mem
+            WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_5')) AND (created_at >= '1970-01-01 04:37:12') AND (created_at < '1970-01-01 16:37:12')
+            GROUP BY
+                hour,
+                id
+        ) AS mem_avg USING (hour, id)
+        ALL INNER JOIN
+        (
+            SELECT
+                toStartOfHour(created_at) AS hour,
+                tags_id AS id,
+                avg(reads) AS mean_reads
+            FROM diskio
+            WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_5')) AND (created_at >= '1970-01-01 04:37:12') AND (created_at < '1970-01-01 16:37:12')
+            GROUP BY
+                hour,
+                id
+        ) AS diskio_avg USING (hour, id)
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            hour ASC,
+            hostname
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimeCrossMeasurement(q, c.input, 12*time.Hour)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(24 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
+func TestTopKHosts(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "no tags",
+			input:              10,
+			expectedHumanLabel: "ClickHouse top 10 hosts by mean usage_user, random 1h0m0s",
+			expectedHumanDesc:  "ClickHouse top 10 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:16:22Z",
+			expectedQuery: `
+        SELECT
+            hostname,
+            mean_usage_user
+        FROM
+        (
+            SELECT
+                hostname,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE (created_at >= '1970-01-01 00:16:22') AND (created_at < '1970-01-01 01:16:22')
+            GROUP BY hostname
+            ORDER BY mean_usage_user DESC
+            LIMIT 10
+        ) AS host_avg
+        
+        ORDER BY mean_usage_user DESC
+        `,
+		},
+		{
+			desc:               "use tags",
+			input:              5,
+			devopsUseTags:      true,
+			expectedHumanLabel: "ClickHouse top 5 hosts by mean usage_user, random 1h0m0s",
+			expectedHumanDesc:  "ClickHouse top 5 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:54:10Z",
+			expectedQuery: `
+        SELECT
+            hostname,
+            mean_usage_user
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                avg(usage_user) AS mean_usage_user
+            FROM cpu
+            WHERE (created_at >= '1970-01-01 00:54:10') AND (created_at < '1970-01-01 01:54:10')
+            GROUP BY id
+            ORDER BY mean_usage_user DESC
+            LIMIT 5
+        ) AS host_avg
+        ANY INNER JOIN tags USING (id)
+        ORDER BY mean_usage_user DESC
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.TopKHosts(q, c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(2 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
+func TestTagValues(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			expectedHumanLabel: "ClickHouse hostname tag values, random region",
+			expectedHumanDesc:  "ClickHouse hostname tag values, random region: ap-southeast-1",
+			expectedQuery: `
+        SELECT DISTINCT hostname
+        FROM cpu
+        WHERE region = 'ap-southeast-1'
+        ORDER BY hostname
+        `,
+		},
+		{
+			desc:               "use tags",
+			devopsUseTags:      true,
+			expectedHumanLabel: "ClickHouse hostname tag values, random region",
+			expectedHumanDesc:  "ClickHouse hostname tag values, random region: us-east-1",
+			expectedQuery: `
+        SELECT DISTINCT hostname
+        FROM tags
+        WHERE region = 'us-east-1'
+        ORDER BY hostname
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.TagValues(q)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(time.Hour), cases)
+}
+
+func TestSeriesCount(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			expectedHumanLabel: "ClickHouse cpu series count, random region",
+			expectedHumanDesc:  "ClickHouse cpu series count, random region: ap-southeast-1",
+			expectedQuery: `
+        SELECT uniqExact(hostname) AS series
+        FROM cpu
+        WHERE region = 'ap-southeast-1'
+        `,
+		},
+		{
+			desc:               "use tags",
+			devopsUseTags:      true,
+			expectedHumanLabel: "ClickHouse cpu series count, random region",
+			expectedHumanDesc:  "ClickHouse cpu series count, random region: us-east-1",
+			expectedQuery: `
+        SELECT uniqExact(hostname) AS series
+        FROM tags
+        WHERE region = 'us-east-1'
+        `,
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.SeriesCount(q)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runTestCases(t, testFunc, start, start.Add(time.Hour), cases)
+}
+
 type testCase struct {
 	desc               string
 	input              int
@@ -0,0 +1,198 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Finance produces ClickHouse-specific queries for all the finance query types.
+//
+// As for the iot use case, trades and quotes reference the symbol tags through
+// tags_id. created_at only has second precision, so the order of the events
+// within a second is taken from the time column, which sorts as a string.
+type Finance struct {
+	*finance.Core
+	*BaseGenerator
+}
+
+// NewFinance makes a Finance object ready to generate Queries.
+func NewFinance(start, end time.Time, scale int, g *BaseGenerator) *Finance {
+	c, err := finance.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Finance{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// getSymbolsWhereString gets nSymbols random symbols and creates a WHERE SQL
+// clause selecting their tags_id. The symbols are bound through args.
+func (f *Finance) getSymbolsWhereString(nSymbols int, args *databases.SQLArgs) string {
+	symbols, err := f.GetRandomSymbols(nSymbols)
+	panicIfErr(err)
+	return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE symbol IN (%s))", args.BindStrings(symbols, ","))
+}
+
+// getExchangeWhereString creates a WHERE SQL clause selecting the tags_id of
+// the symbols of exchange.
+func (f *Finance) getExchangeWhereString(exchange string, args *databases.SQLArgs) string {
+	return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE symbol IS NOT NULL AND exchange = %s)", args.BindString(exchange))
+}
+
+// OHLCBars fetches the open, high, low and close prices and the traded volume
+// per minute of nSymbols symbols.
+func (f *Finance) OHLCBars(qi query.Query, nSymbols int) {
+	args := f.newArgs()
+	symbolsWhere := f.getSymbolsWhereString(nSymbols, args)
+	interval := f.MustRandWindow(finance.OHLCDuration)
+
+	sql := fmt.Sprintf(`
+        SELECT
+            symbol,
+            minute,
+            open,
+            high,
+            low,
+            close,
+            volume
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                argMin(price, time) AS open,
+                max(price) AS high,
+                min(price) AS low,
+                argMax(price, time) AS close,
+                sum(size) AS volume
+            FROM trades
+            WHERE %s AND (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                id,
+                minute
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            symbol,
+            minute
+        `,
+		symbolsWhere,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)))
+
+	humanLabel := "ClickHouse OHLC bars per minute by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.TradesTableName, sql, args.Values()...)
+}
+
+// VWAP fetches the hourly volume-weighted average price of nSymbols symbols.
+func (f *Finance) VWAP(qi query.Query, nSymbols int) {
+	args := f.newArgs()
+	symbolsWhere := f.getSymbolsWhereString(nSymbols, args)
+	interval := f.MustRandWindow(finance.VWAPDuration)
+
+	sql := fmt.Sprintf(`
+        SELECT
+            symbol,
+            hour,
+            vwap,
+            volume
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfHour(created_at) AS hour,
+                sum(price * size) / sum(size) AS vwap,
+                sum(size) AS volume
+            FROM trades
+            WHERE %s AND (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                id,
+                hour
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            symbol,
+            hour
+        `,
+		symbolsWhere,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)))
+
+	humanLabel := "ClickHouse hourly VWAP by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.TradesTableName, sql, args.Values()...)
+}
+
+// LastQuotePerSymbol finds the latest quote of all the symbols of an exchange.
+func (f *Finance) LastQuotePerSymbol(qi query.Query) {
+	args := f.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            symbol,
+            bid_price,
+            bid_size,
+            ask_price,
+            ask_size
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(bid_price, time) AS bid_price,
+                argMax(bid_size, time) AS bid_size,
+                argMax(ask_price, time) AS ask_price,
+                argMax(ask_size, time) AS ask_size
+            FROM quotes
+            WHERE %s
+            GROUP BY id
+        ) AS q
+        ANY INNER JOIN tags USING (id)
+        `,
+		f.getExchangeWhereString(f.GetRandomExchange(), args))
+
+	humanLabel := "ClickHouse last quote per symbol"
+	humanDesc := humanLabel
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.QuotesTableName, sql, args.Values()...)
+}
+
+// SpreadStats computes the minimum, average and maximum bid-ask spread of all
+// the symbols of an exchange in a time window.
+func (f *Finance) SpreadStats(qi query.Query) {
+	interval := f.MustRandWindow(finance.SpreadDuration)
+	args := f.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            symbol,
+            min_spread,
+            avg_spread,
+            max_spread
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                min(ask_price - bid_price) AS min_spread,
+                avg(ask_price - bid_price) AS avg_spread,
+                max(ask_price - bid_price) AS max_spread
+            FROM quotes
+            WHERE (created_at >= %s) AND (created_at < %s) AND %s
+            GROUP BY id
+        ) AS q
+        ANY INNER JOIN tags USING (id)
+        ORDER BY symbol
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		f.getExchangeWhereString(f.GetRandomExchange(), args))
+
+	humanLabel := "ClickHouse spread statistics per symbol"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.QuotesTableName, sql, args.Values()...)
+}
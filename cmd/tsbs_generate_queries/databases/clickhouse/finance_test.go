@@ -0,0 +1,159 @@
+package clickhouse
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFinanceOHLCBars(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero symbols",
+			input:   0,
+			fail:    true,
+			failMsg: "number of symbols cannot be < 1; got 0",
+		},
+		{
+			desc:               "one symbol",
+			input:              1,
+			expectedHumanLabel: "ClickHouse OHLC bars per minute by specific symbol",
+			expectedHumanDesc:  "ClickHouse OHLC bars per minute by specific symbol: random    1 symbols, 1970-01-01T00:54:10Z",
+			expectedQuery: `
+        SELECT
+            symbol,
+            minute,
+            open,
+            high,
+            low,
+            close,
+            volume
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                argMin(price, time) AS open,
+                max(price) AS high,
+                min(price) AS low,
+                argMax(price, time) AS close,
+                sum(size) AS volume
+            FROM trades
+            WHERE tags_id IN (SELECT id FROM tags WHERE symbol IN ('symbol_5')) AND (created_at >= '1970-01-01 00:54:10') AND (created_at < '1970-01-01 01:54:10')
+            GROUP BY
+                id,
+                minute
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            symbol,
+            minute
+        `,
+		},
+	}
+
+	testFunc := func(f *Finance, c testCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.OHLCBars(q, c.input)
+		return q
+	}
+
+	runFinanceTestCases(t, testFunc, time.Unix(0, 0), time.Unix(0, 0).Add(2*time.Hour), cases)
+}
+
+func TestFinancePreparedStatements(t *testing.T) {
+	rand.Seed(123)
+	b := &BaseGenerator{UsePreparedStatements: true}
+	fg, err := b.NewFinance(time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating finance generator")
+	}
+	f := fg.(*Finance)
+
+	q := f.GenerateEmptyQuery()
+	f.VWAP(q, 2)
+	ch := q.(*query.ClickHouse)
+
+	if got := strings.Count(string(ch.SqlQuery), "?"); got != 4 {
+		t.Errorf("incorrect number of placeholders: got %d want 4", got)
+	}
+	if strings.Contains(string(ch.SqlQuery), "'") {
+		t.Errorf("query contains inlined literals:\n%s", ch.SqlQuery)
+	}
+	want := []string{"symbol_5", "symbol_9", "1970-01-01 05:47:30", "1970-01-01 17:47:30"}
+	if got := strings.Join(ch.SqlArgs, "|"); got != strings.Join(want, "|") {
+		t.Errorf("incorrect args: got %v want %v", ch.SqlArgs, want)
+	}
+}
+
+// TestFinanceAllQueries checks every finance query fills in its labels, table and query.
+func TestFinanceAllQueries(t *testing.T) {
+	rand.Seed(123)
+	b := &BaseGenerator{}
+	fg, err := b.NewFinance(time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating finance generator")
+	}
+	f := fg.(*Finance)
+
+	fills := map[string]func(query.Query){
+		finance.LabelOHLC:        func(q query.Query) { f.OHLCBars(q, 1) },
+		finance.LabelVWAP:        func(q query.Query) { f.VWAP(q, 1) },
+		finance.LabelLastQuote:   f.LastQuotePerSymbol,
+		finance.LabelSpreadStats: f.SpreadStats,
+	}
+	for label, fill := range fills {
+		q := f.GenerateEmptyQuery()
+		fill(q)
+		ch := q.(*query.ClickHouse)
+		if !strings.HasPrefix(string(ch.HumanLabel), "ClickHouse ") {
+			t.Errorf("%s: incorrect human label: %s", label, ch.HumanLabel)
+		}
+		if table := string(ch.Table); table != finance.TradesTableName && table != finance.QuotesTableName {
+			t.Errorf("%s: incorrect table: %s", label, table)
+		}
+		if !strings.Contains(string(ch.SqlQuery), "ANY INNER JOIN tags USING (id)") {
+			t.Errorf("%s: query does not join tags:\n%s", label, ch.SqlQuery)
+		}
+	}
+}
+
+func runFinanceTestCases(t *testing.T, testFunc func(*Finance, testCase) query.Query, s time.Time, e time.Time, cases []testCase) {
+	rand.Seed(123) // Setting seed for testing purposes.
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			b := BaseGenerator{}
+			fg, err := b.NewFinance(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating finance generator")
+			}
+			f := fg.(*Finance)
+
+			if c.fail {
+				func() {
+					defer func() {
+						r := recover()
+						if r == nil {
+							t.Errorf("did not panic when should")
+						}
+
+						if r != c.failMsg {
+							t.Fatalf("incorrect fail message: got %s, want %s", r, c.failMsg)
+						}
+					}()
+
+					testFunc(f, c)
+				}()
+			} else {
+				q := testFunc(f, c)
+
+				verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+			}
+		})
+	}
+}
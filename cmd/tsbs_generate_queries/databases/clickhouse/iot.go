@@ -0,0 +1,552 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// IoT produces ClickHouse-specific queries for all the iot query types.
+//
+// The loader stores every measurement (readings, diagnostics) in its own table
+// referencing the truck tags through tags_id, so truck attributes are always
+// read from the separate tags table, regardless of UseTags.
+type IoT struct {
+	*iot.Core
+	*BaseGenerator
+}
+
+// NewIoT makes an IoT object ready to generate Queries.
+func NewIoT(start, end time.Time, scale int, g *BaseGenerator) *IoT {
+	c, err := iot.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &IoT{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// getTrucksWhereWithNames creates a WHERE SQL clause selecting the tags_id of
+// the given trucks. The names are bound through args, which inlines them when nil.
+func (i *IoT) getTrucksWhereWithNames(names []string, args *databases.SQLArgs) string {
+	return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE name IN (%s))", args.BindStrings(names, ","))
+}
+
+// getTruckWhereString gets multiple random trucks and creates a WHERE SQL clause for them.
+func (i *IoT) getTruckWhereString(nTrucks int, args *databases.SQLArgs) string {
+	names, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+	return i.getTrucksWhereWithNames(names, args)
+}
+
+// getFleetWhereString creates a WHERE SQL clause selecting the tags_id of the
+// named trucks of fleet.
+func (i *IoT) getFleetWhereString(fleet string, args *databases.SQLArgs) string {
+	return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE name IS NOT NULL AND fleet = %s)", args.BindString(fleet))
+}
+
+// LastLocByTruck finds the truck location for nTrucks.
+func (i *IoT) LastLocByTruck(qi query.Query, nTrucks int) {
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver,
+            longitude,
+            latitude
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(longitude, created_at) AS longitude,
+                argMax(latitude, created_at) AS latitude
+            FROM readings
+            WHERE %s
+            GROUP BY id
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		i.getTruckWhereString(nTrucks, args))
+
+	humanLabel := "ClickHouse last location by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks", humanLabel, nTrucks)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// LastLocPerTruck finds all the truck locations along with truck and driver names.
+func (i *IoT) LastLocPerTruck(qi query.Query) {
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver,
+            longitude,
+            latitude
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(longitude, created_at) AS longitude,
+                argMax(latitude, created_at) AS latitude
+            FROM readings
+            WHERE %s
+            GROUP BY id
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "ClickHouse last location per truck"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// TrucksWithLowFuel finds all trucks with low fuel (less than 10%).
+func (i *IoT) TrucksWithLowFuel(qi query.Query) {
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver,
+            fuel_state
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(fuel_state, created_at) AS fuel_state
+            FROM diagnostics
+            WHERE %s
+            GROUP BY id
+        ) AS d
+        ANY INNER JOIN tags USING (id)
+        WHERE fuel_state < 0.1
+        `,
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "ClickHouse trucks with low fuel"
+	humanDesc := fmt.Sprintf("%s: under 10 percent", humanLabel)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql, args.Values()...)
+}
+
+// TrucksWithHighLoad finds all trucks that have load over 90%.
+func (i *IoT) TrucksWithHighLoad(qi query.Query) {
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver,
+            current_load,
+            load_capacity
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(current_load, created_at) AS current_load
+            FROM diagnostics
+            WHERE %s
+            GROUP BY id
+        ) AS d
+        ANY INNER JOIN tags USING (id)
+        WHERE current_load / load_capacity > 0.9
+        `,
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "ClickHouse trucks with high load"
+	humanDesc := fmt.Sprintf("%s: over 90 percent", humanLabel)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql, args.Values()...)
+}
+
+// StationaryTrucks finds all trucks that have low average velocity in a time window.
+func (i *IoT) StationaryTrucks(qi query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                avg(velocity) AS mean_velocity
+            FROM readings
+            WHERE (created_at >= %s) AND (created_at < %s) AND %s
+            GROUP BY id
+            HAVING mean_velocity < 1
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "ClickHouse stationary trucks"
+	humanDesc := fmt.Sprintf("%s: with low avg velocity in last 10 minutes", humanLabel)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// drivingSessionsQuery returns the trucks of a random fleet which were driving
+// in more than periods ten minute periods of a random window of duration.
+func (i *IoT) drivingSessionsQuery(duration time.Duration, periods int) (string, []string) {
+	interval := i.MustRandWindow(duration)
+	args := i.newArgs()
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            driver
+        FROM
+        (
+            SELECT
+                id,
+                count() AS driving_periods
+            FROM
+            (
+                SELECT
+                    tags_id AS id,
+                    toStartOfTenMinutes(created_at) AS ten_minutes
+                FROM readings
+                WHERE (created_at >= %s) AND (created_at < %s) AND %s
+                GROUP BY
+                    id,
+                    ten_minutes
+                HAVING avg(velocity) > 1
+            )
+            GROUP BY id
+            HAVING driving_periods > %d
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		i.getFleetWhereString(i.GetRandomFleet(), args),
+		periods)
+	return sql, args.Values()
+}
+
+// TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
+func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+	sql, args := i.drivingSessionsQuery(iot.LongDrivingSessionDuration, tenMinutePeriods(5, iot.LongDrivingSessionDuration))
+
+	humanLabel := "ClickHouse trucks with longer driving sessions"
+	humanDesc := fmt.Sprintf("%s: stopped less than 20 mins in 4 hour period", humanLabel)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args...)
+}
+
+// TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
+func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+	sql, args := i.drivingSessionsQuery(iot.DailyDrivingDuration, tenMinutePeriods(35, iot.DailyDrivingDuration))
+
+	humanLabel := "ClickHouse trucks with longer daily sessions"
+	humanDesc := fmt.Sprintf("%s: drove more than 10 hours in the last 24 hours", humanLabel)
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args...)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel consumption per fleet.
+func (i *IoT) AvgVsProjectedFuelConsumption(qi query.Query) {
+	sql := `
+        SELECT
+            fleet,
+            avg(fuel_consumption) AS avg_fuel_consumption,
+            avg(nominal_fuel_consumption) AS projected_fuel_consumption
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                fuel_consumption
+            FROM readings
+            WHERE velocity > 1
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        WHERE (fleet IS NOT NULL) AND (nominal_fuel_consumption IS NOT NULL) AND (name IS NOT NULL)
+        GROUP BY fleet
+        `
+
+	humanLabel := "ClickHouse average vs projected fuel consumption per fleet"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver.
+func (i *IoT) AvgDailyDrivingDuration(qi query.Query) {
+	sql := `
+        SELECT
+            fleet,
+            name,
+            driver,
+            avg(hours) AS avg_daily_hours
+        FROM
+        (
+            SELECT
+                id,
+                day,
+                count() / 6 AS hours
+            FROM
+            (
+                SELECT
+                    tags_id AS id,
+                    toStartOfDay(created_at) AS day,
+                    toStartOfTenMinutes(created_at) AS ten_minutes
+                FROM readings
+                GROUP BY
+                    id,
+                    day,
+                    ten_minutes
+                HAVING avg(velocity) > 1
+            )
+            GROUP BY
+                id,
+                day
+        ) AS d
+        ANY INNER JOIN tags USING (id)
+        GROUP BY
+            fleet,
+            name,
+            driver
+        `
+
+	humanLabel := "ClickHouse average driver driving duration per day"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgDailyDrivingSession finds the average driving session without stopping per driver per day.
+//
+// The per truck ten minute driving states are collected into arrays ordered by
+// time, and a session lasts from a change to driving until the next change.
+func (i *IoT) AvgDailyDrivingSession(qi query.Query) {
+	sql := `
+        SELECT
+            name,
+            toStartOfDay(start) AS day,
+            avg(stop - start) AS duration
+        FROM
+        (
+            SELECT
+                id,
+                start,
+                stop,
+                driving
+            FROM
+            (
+                SELECT
+                    id,
+                    arrayFilter((t, i) -> (i > 1) AND (states[i] != states[i - 1]), times, arrayEnumerate(times)) AS changes,
+                    arrayFilter((s, i) -> (i > 1) AND (states[i] != states[i - 1]), states, arrayEnumerate(states)) AS change_states
+                FROM
+                (
+                    SELECT
+                        id,
+                        arraySort(groupArray(ten_minutes)) AS times,
+                        arraySort((s, t) -> t, groupArray(driving), groupArray(ten_minutes)) AS states
+                    FROM
+                    (
+                        SELECT
+                            tags_id AS id,
+                            toStartOfTenMinutes(created_at) AS ten_minutes,
+                            avg(velocity) > 5 AS driving
+                        FROM readings
+                        GROUP BY
+                            id,
+                            ten_minutes
+                    )
+                    GROUP BY id
+                )
+            )
+            ARRAY JOIN
+                changes AS start,
+                arrayPushBack(arrayPopFront(changes), toDateTime(0)) AS stop,
+                change_states AS driving
+            WHERE (driving = 1) AND (stop > start)
+        ) AS d
+        ANY INNER JOIN tags USING (id)
+        WHERE name IS NOT NULL
+        GROUP BY
+            name,
+            day
+        ORDER BY
+            name ASC,
+            day ASC
+        `
+
+	humanLabel := "ClickHouse average driver driving session without stopping per day"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+func (i *IoT) AvgLoad(qi query.Query) {
+	sql := `
+        SELECT
+            fleet,
+            model,
+            load_capacity,
+            avg(avg_load / load_capacity) AS avg_load_percentage
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                avg(current_load) AS avg_load
+            FROM diagnostics
+            GROUP BY id
+        ) AS d
+        ANY INNER JOIN tags USING (id)
+        WHERE name IS NOT NULL
+        GROUP BY
+            fleet,
+            model,
+            load_capacity
+        `
+
+	humanLabel := "ClickHouse average load per truck model per fleet"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// DailyTruckActivity returns the number of hours trucks has been active (not out-of-commission) per day per fleet per model.
+func (i *IoT) DailyTruckActivity(qi query.Query) {
+	sql := `
+        SELECT
+            fleet,
+            model,
+            day,
+            sum(ten_mins_per_day) / 144 AS daily_activity
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfDay(created_at) AS day,
+                toStartOfTenMinutes(created_at) AS ten_minutes,
+                count() AS ten_mins_per_day
+            FROM diagnostics
+            GROUP BY
+                id,
+                day,
+                ten_minutes
+            HAVING avg(status) < 1
+        ) AS y
+        ANY INNER JOIN tags USING (id)
+        WHERE name IS NOT NULL
+        GROUP BY
+            fleet,
+            model,
+            day
+        ORDER BY day ASC
+        `
+
+	humanLabel := "ClickHouse daily truck activity per fleet per model"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// TruckBreakdownFrequency calculates the amount of times a truck model broke down in the last period.
+//
+// A breakdown is a ten minute period in which most diagnostics report a zero
+// status, following one in which they did not.
+func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
+	sql := `
+        SELECT
+            model,
+            sum(breakdowns) AS breakdowns
+        FROM
+        (
+            SELECT
+                id,
+                arraySum((b, i) -> (i > 1) AND (b = 1) AND (states[i - 1] = 0), states, arrayEnumerate(states)) AS breakdowns
+            FROM
+            (
+                SELECT
+                    id,
+                    arraySort((b, t) -> t, groupArray(broken_down), groupArray(ten_minutes)) AS states
+                FROM
+                (
+                    SELECT
+                        tags_id AS id,
+                        toStartOfTenMinutes(created_at) AS ten_minutes,
+                        (countIf(status = 0) / count()) >= 0.5 AS broken_down
+                    FROM diagnostics
+                    GROUP BY
+                        id,
+                        ten_minutes
+                )
+                GROUP BY id
+            )
+        ) AS b
+        ANY INNER JOIN tags USING (id)
+        WHERE name IS NOT NULL
+        GROUP BY model
+        `
+
+	humanLabel := "ClickHouse truck breakdown frequency per model"
+	humanDesc := humanLabel
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// GapfillByTruck fetches the average velocity per minute of nTrucks trucks,
+// filling the minutes without readings with the last observed value.
+func (i *IoT) GapfillByTruck(qi query.Query, nTrucks int) {
+	names, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+	interval := i.MustRandWindow(iot.GapfillDuration)
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+        SELECT
+            name,
+            minute,
+            velocity
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                avg(velocity) AS velocity
+            FROM readings
+            WHERE %s AND (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                id,
+                minute
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            name,
+            minute WITH FILL FROM toStartOfMinute(toDateTime(%s)) TO toDateTime(%s) STEP 60
+        INTERPOLATE (velocity)
+        `,
+		i.getTrucksWhereWithNames(names, args),
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)))
+
+	humanLabel := "ClickHouse gap-filled velocity per minute by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks, %s", humanLabel, nTrucks, interval.StartString())
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// tenMinutePeriods calculates the number of 10 minute periods that can fit in
+// the time duration if we subtract the minutes specified by minutesPerHour value.
+// E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
+func tenMinutePeriods(minutesPerHour float64, duration time.Duration) int {
+	durationMinutes := duration.Minutes()
+	leftover := minutesPerHour * duration.Hours()
+	return int((durationMinutes - leftover) / 10)
+}
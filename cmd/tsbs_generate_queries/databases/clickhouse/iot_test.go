@@ -0,0 +1,256 @@
+package clickhouse
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestIoTLastLocByTruck(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero trucks",
+			input:   0,
+			fail:    true,
+			failMsg: "number of trucks cannot be < 1; got 0",
+		},
+		{
+			desc:    "more trucks than scale",
+			input:   20,
+			fail:    true,
+			failMsg: "number of trucks (20) larger than total trucks. See --scale (10)",
+		},
+		{
+			desc:               "one truck",
+			input:              1,
+			expectedHumanLabel: "ClickHouse last location by specific truck",
+			expectedHumanDesc:  "ClickHouse last location by specific truck: random    1 trucks",
+			expectedQuery: `
+        SELECT
+            name,
+            driver,
+            longitude,
+            latitude
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                argMax(longitude, created_at) AS longitude,
+                argMax(latitude, created_at) AS latitude
+            FROM readings
+            WHERE tags_id IN (SELECT id FROM tags WHERE name IN ('truck_5'))
+            GROUP BY id
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		},
+	}
+
+	testFunc := func(i *IoT, c testCase) query.Query {
+		q := i.GenerateEmptyQuery()
+		i.LastLocByTruck(q, c.input)
+		return q
+	}
+
+	runIoTTestCases(t, testFunc, time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), cases)
+}
+
+func TestIoTStationaryTrucks(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "happy path",
+			expectedHumanLabel: "ClickHouse stationary trucks",
+			expectedHumanDesc:  "ClickHouse stationary trucks: with low avg velocity in last 10 minutes",
+			expectedQuery: `
+        SELECT
+            name,
+            driver
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                avg(velocity) AS mean_velocity
+            FROM readings
+            WHERE (created_at >= '1970-01-01 00:36:22') AND (created_at < '1970-01-01 00:46:22') AND tags_id IN (SELECT id FROM tags WHERE name IS NOT NULL AND fleet = 'West')
+            GROUP BY id
+            HAVING mean_velocity < 1
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        `,
+		},
+	}
+
+	testFunc := func(i *IoT, c testCase) query.Query {
+		q := i.GenerateEmptyQuery()
+		i.StationaryTrucks(q)
+		return q
+	}
+
+	runIoTTestCases(t, testFunc, time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), cases)
+}
+
+func TestIoTGapfillByTruck(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero trucks",
+			input:   0,
+			fail:    true,
+			failMsg: "number of trucks cannot be < 1; got 0",
+		},
+		{
+			desc:               "one truck",
+			input:              1,
+			expectedHumanLabel: "ClickHouse gap-filled velocity per minute by specific truck",
+			expectedHumanDesc:  "ClickHouse gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T00:54:10Z",
+			expectedQuery: `
+        SELECT
+            name,
+            minute,
+            velocity
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                avg(velocity) AS velocity
+            FROM readings
+            WHERE tags_id IN (SELECT id FROM tags WHERE name IN ('truck_5')) AND (created_at >= '1970-01-01 00:54:10') AND (created_at < '1970-01-01 01:54:10')
+            GROUP BY
+                id,
+                minute
+        ) AS r
+        ANY INNER JOIN tags USING (id)
+        ORDER BY
+            name,
+            minute WITH FILL FROM toStartOfMinute(toDateTime('1970-01-01 00:54:10')) TO toDateTime('1970-01-01 01:54:10') STEP 60
+        INTERPOLATE (velocity)
+        `,
+		},
+	}
+
+	testFunc := func(i *IoT, c testCase) query.Query {
+		q := i.GenerateEmptyQuery()
+		i.GapfillByTruck(q, c.input)
+		return q
+	}
+
+	runIoTTestCases(t, testFunc, time.Unix(0, 0), time.Unix(0, 0).Add(2*time.Hour), cases)
+}
+
+func TestIoTPreparedStatements(t *testing.T) {
+	rand.Seed(123)
+	b := &BaseGenerator{UsePreparedStatements: true}
+	ig, err := b.NewIoT(time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	i := ig.(*IoT)
+
+	q := i.GenerateEmptyQuery()
+	i.TrucksWithLongDrivingSessions(q)
+	ch := q.(*query.ClickHouse)
+
+	if got := strings.Count(string(ch.SqlQuery), "?"); got != 3 {
+		t.Errorf("incorrect number of placeholders: got %d want 3", got)
+	}
+	if strings.Contains(string(ch.SqlQuery), "'") {
+		t.Errorf("query contains inlined literals:\n%s", ch.SqlQuery)
+	}
+	want := []string{"1970-01-01 02:16:22", "1970-01-01 06:16:22", "West"}
+	if got := strings.Join(ch.SqlArgs, "|"); got != strings.Join(want, "|") {
+		t.Errorf("incorrect args: got %v want %v", ch.SqlArgs, want)
+	}
+}
+
+// TestIoTAllQueries checks every iot query fills in its labels, table and query.
+func TestIoTAllQueries(t *testing.T) {
+	rand.Seed(123)
+	b := &BaseGenerator{}
+	ig, err := b.NewIoT(time.Unix(0, 0), time.Unix(0, 0).Add(48*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	i := ig.(*IoT)
+
+	fills := map[string]func(query.Query){
+		iot.LabelLastLoc:                       i.LastLocPerTruck,
+		iot.LabelLastLocSingleTruck:            func(q query.Query) { i.LastLocByTruck(q, 1) },
+		iot.LabelLowFuel:                       i.TrucksWithLowFuel,
+		iot.LabelHighLoad:                      i.TrucksWithHighLoad,
+		iot.LabelStationaryTrucks:              i.StationaryTrucks,
+		iot.LabelLongDrivingSessions:           i.TrucksWithLongDrivingSessions,
+		iot.LabelLongDailySessions:             i.TrucksWithLongDailySessions,
+		iot.LabelAvgVsProjectedFuelConsumption: i.AvgVsProjectedFuelConsumption,
+		iot.LabelAvgDailyDrivingDuration:       i.AvgDailyDrivingDuration,
+		iot.LabelAvgDailyDrivingSession:        i.AvgDailyDrivingSession,
+		iot.LabelAvgLoad:                       i.AvgLoad,
+		iot.LabelDailyActivity:                 i.DailyTruckActivity,
+		iot.LabelBreakdownFrequency:            i.TruckBreakdownFrequency,
+		iot.LabelGapfill:                       func(q query.Query) { i.GapfillByTruck(q, 1) },
+	}
+	for label, fill := range fills {
+		q := i.GenerateEmptyQuery()
+		fill(q)
+		ch := q.(*query.ClickHouse)
+		if !strings.HasPrefix(string(ch.HumanLabel), "ClickHouse ") {
+			t.Errorf("%s: incorrect human label: %s", label, ch.HumanLabel)
+		}
+		if table := string(ch.Table); table != iot.ReadingsTableName && table != iot.DiagnosticsTableName {
+			t.Errorf("%s: incorrect table: %s", label, table)
+		} else if !strings.Contains(string(ch.SqlQuery), "FROM "+table+"\n") {
+			t.Errorf("%s: table %s is not the one queried:\n%s", label, table, ch.SqlQuery)
+		}
+		if !strings.Contains(string(ch.SqlQuery), "ANY INNER JOIN tags USING (id)") {
+			t.Errorf("%s: query does not join tags:\n%s", label, ch.SqlQuery)
+		}
+	}
+}
+
+func TestTenMinutePeriods(t *testing.T) {
+	if got := tenMinutePeriods(5, 4*time.Hour); got != 22 {
+		t.Errorf("incorrect periods for 4 hours: got %d want 22", got)
+	}
+	if got := tenMinutePeriods(35, 24*time.Hour); got != 60 {
+		t.Errorf("incorrect periods for 24 hours: got %d want 60", got)
+	}
+}
+
+func runIoTTestCases(t *testing.T, testFunc func(*IoT, testCase) query.Query, s time.Time, e time.Time, cases []testCase) {
+	rand.Seed(123) // Setting seed for testing purposes.
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			b := BaseGenerator{}
+			ig, err := b.NewIoT(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating iot generator")
+			}
+			i := ig.(*IoT)
+
+			if c.fail {
+				func() {
+					defer func() {
+						r := recover()
+						if r == nil {
+							t.Errorf("did not panic when should")
+						}
+
+						if r != c.failMsg {
+							t.Fatalf("incorrect fail message: got %s, want %s", r, c.failMsg)
+						}
+					}()
+
+					testFunc(i, c)
+				}()
+			} else {
+				q := testFunc(i, c)
+
+				verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Logs produces ClickHouse-specific queries for all the logs query types.
+//
+// Events reference the instance tags through tags_id, like the finance
+// events, and are ordered by their time column within a second.
+type Logs struct {
+	*logs.Core
+	*BaseGenerator
+}
+
+// NewLogs makes a Logs object ready to generate Queries.
+func NewLogs(start, end time.Time, scale int, g *BaseGenerator) *Logs {
+	c, err := logs.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Logs{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// ErrorsPerService counts the error events of every service per minute.
+func (l *Logs) ErrorsPerService(qi query.Query) {
+	args := l.newArgs()
+	interval := l.MustRandWindow(logs.ErrorsPerServiceDuration)
+
+	sql := fmt.Sprintf(`
+        SELECT
+            service,
+            minute,
+            sum(errors) AS errors
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                count(*) AS errors
+            FROM logs
+            WHERE (created_at >= %s) AND (created_at < %s) AND (severity = %s)
+            GROUP BY
+                id,
+                minute
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        GROUP BY
+            service,
+            minute
+        ORDER BY
+            service,
+            minute
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		args.BindString(logs.ErrorSeverity))
+
+	humanLabel := "ClickHouse errors per service per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, logs.LogsTableName, sql, args.Values()...)
+}
+
+// MessageSearch finds the latest events whose message contains a random
+// search term.
+func (l *Logs) MessageSearch(qi query.Query) {
+	args := l.newArgs()
+	interval := l.MustRandWindow(logs.MessageSearchDuration)
+
+	sql := fmt.Sprintf(`
+        SELECT
+            time,
+            service,
+            severity,
+            trace_id,
+            message
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                time,
+                severity,
+                trace_id,
+                message
+            FROM logs
+            WHERE (created_at >= %s) AND (created_at < %s) AND (message LIKE %s)
+            ORDER BY time DESC
+            LIMIT %d
+        ) AS l
+        ANY INNER JOIN tags USING (id)
+        ORDER BY time DESC
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		args.BindString("%"+l.GetRandomSearchTerm()+"%"),
+		logs.MessageSearchLimit)
+
+	humanLabel := "ClickHouse latest events with a message containing a term"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, logs.LogsTableName, sql, args.Values()...)
+}
@@ -0,0 +1,137 @@
+package clickhouse
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestLogsErrorsPerService(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "default",
+			expectedHumanLabel: "ClickHouse errors per service per minute",
+			expectedHumanDesc:  "ClickHouse errors per service per minute: 1970-01-01T20:16:22Z",
+			expectedQuery: `
+        SELECT
+            service,
+            minute,
+            sum(errors) AS errors
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfMinute(created_at) AS minute,
+                count(*) AS errors
+            FROM logs
+            WHERE (created_at >= '1970-01-01 20:16:22') AND (created_at < '1970-01-01 21:16:22') AND (severity = 'ERROR')
+            GROUP BY
+                id,
+                minute
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        GROUP BY
+            service,
+            minute
+        ORDER BY
+            service,
+            minute
+        `,
+		},
+	}
+
+	testFunc := func(l *Logs, c testCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.ErrorsPerService(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func TestLogsMessageSearch(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "default",
+			expectedHumanLabel: "ClickHouse latest events with a message containing a term",
+			expectedHumanDesc:  "ClickHouse latest events with a message containing a term: 1970-01-01T20:16:22Z",
+			expectedQuery: `
+        SELECT
+            time,
+            service,
+            severity,
+            trace_id,
+            message
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                time,
+                severity,
+                trace_id,
+                message
+            FROM logs
+            WHERE (created_at >= '1970-01-01 20:16:22') AND (created_at < '1970-01-01 21:16:22') AND (message LIKE '%insufficient funds%')
+            ORDER BY time DESC
+            LIMIT 100
+        ) AS l
+        ANY INNER JOIN tags USING (id)
+        ORDER BY time DESC
+        `,
+		},
+	}
+
+	testFunc := func(l *Logs, c testCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.MessageSearch(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func TestLogsPreparedStatements(t *testing.T) {
+	rand.Seed(123)
+	b := &BaseGenerator{UsePreparedStatements: true}
+	lg, err := b.NewLogs(time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating logs generator")
+	}
+	l := lg.(*Logs)
+
+	q := l.GenerateEmptyQuery()
+	l.MessageSearch(q)
+	ch := q.(*query.ClickHouse)
+
+	if got := strings.Count(string(ch.SqlQuery), "?"); got != 3 {
+		t.Errorf("incorrect number of placeholders: got %d want 3", got)
+	}
+	want := []string{"1970-01-01 20:16:22", "1970-01-01 21:16:22", "%insufficient funds%"}
+	if got := strings.Join(ch.SqlArgs, "|"); got != strings.Join(want, "|") {
+		t.Errorf("incorrect args: got %v want %v", ch.SqlArgs, want)
+	}
+}
+
+func runLogsTestCases(t *testing.T, testFunc func(*Logs, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			lg, err := b.NewLogs(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating logs generator")
+			}
+			l := lg.(*Logs)
+
+			q := testFunc(l, c)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SmartMeter produces ClickHouse-specific queries for all the smart-meter
+// query types.
+//
+// Readings reference the meter tags through tags_id, so the regions are
+// joined in after aggregating per meter.
+type SmartMeter struct {
+	*smartmeter.Core
+	*BaseGenerator
+}
+
+// NewSmartMeter makes a SmartMeter object ready to generate Queries.
+func NewSmartMeter(start, end time.Time, scale int, g *BaseGenerator) *SmartMeter {
+	c, err := smartmeter.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &SmartMeter{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// DailyConsumption sums the energy consumed in every region per day.
+func (s *SmartMeter) DailyConsumption(qi query.Query) {
+	args := s.newArgs()
+	interval := s.GetRandomDays(smartmeter.DailyConsumptionDays)
+
+	sql := fmt.Sprintf(`
+        SELECT
+            region,
+            day,
+            sum(consumption) AS consumption_kwh
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfDay(created_at) AS day,
+                sum(consumption_kwh) AS consumption
+            FROM energy
+            WHERE (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                id,
+                day
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        GROUP BY
+            region,
+            day
+        ORDER BY
+            region,
+            day
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)))
+
+	humanLabel := "ClickHouse daily consumption per region"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, smartmeter.EnergyTableName, sql, args.Values()...)
+}
+
+// PeakHourLoad sums the energy consumed in every region per hour during the
+// evening peak hours of a random day. Readings are stamped at the start of
+// the interval they cover, so the 15-minute and hourly readings of an hour
+// add up to its consumption in kWh, which is the average load in kW.
+// On-demand readings may span several hours and are left out.
+func (s *SmartMeter) PeakHourLoad(qi query.Query) {
+	args := s.newArgs()
+	interval := s.GetRandomPeakHours()
+
+	sql := fmt.Sprintf(`
+        SELECT
+            region,
+            hour,
+            sum(consumption) AS load_kw
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfHour(created_at) AS hour,
+                sum(consumption_kwh) AS consumption
+            FROM energy
+            WHERE (created_at >= %s) AND (created_at < %s)
+            GROUP BY
+                id,
+                hour
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        WHERE cadence != %s
+        GROUP BY
+            region,
+            hour
+        ORDER BY
+            region,
+            hour
+        `,
+		args.BindString(interval.Start().Format(clickhouseTimeStringFormat)),
+		args.BindString(interval.End().Format(clickhouseTimeStringFormat)),
+		args.BindString(smartmeter.OnDemandCadence))
+
+	humanLabel := "ClickHouse hourly load per region during peak hours"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, smartmeter.EnergyTableName, sql, args.Values()...)
+}
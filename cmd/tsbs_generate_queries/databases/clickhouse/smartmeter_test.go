@@ -0,0 +1,117 @@
+package clickhouse
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestSmartMeterDailyConsumption(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "default",
+			expectedHumanLabel: "ClickHouse daily consumption per region",
+			expectedHumanDesc:  "ClickHouse daily consumption per region: 1970-01-02T00:00:00Z",
+			expectedQuery: `
+        SELECT
+            region,
+            day,
+            sum(consumption) AS consumption_kwh
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfDay(created_at) AS day,
+                sum(consumption_kwh) AS consumption
+            FROM energy
+            WHERE (created_at >= '1970-01-02 00:00:00') AND (created_at < '1970-01-04 00:00:00')
+            GROUP BY
+                id,
+                day
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        GROUP BY
+            region,
+            day
+        ORDER BY
+            region,
+            day
+        `,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c testCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.DailyConsumption(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func TestSmartMeterPeakHourLoad(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "default",
+			expectedHumanLabel: "ClickHouse hourly load per region during peak hours",
+			expectedHumanDesc:  "ClickHouse hourly load per region during peak hours: 1970-01-01T17:00:00Z",
+			expectedQuery: `
+        SELECT
+            region,
+            hour,
+            sum(consumption) AS load_kw
+        FROM
+        (
+            SELECT
+                tags_id AS id,
+                toStartOfHour(created_at) AS hour,
+                sum(consumption_kwh) AS consumption
+            FROM energy
+            WHERE (created_at >= '1970-01-01 17:00:00') AND (created_at < '1970-01-01 21:00:00')
+            GROUP BY
+                id,
+                hour
+        ) AS e
+        ANY INNER JOIN tags USING (id)
+        WHERE cadence != 'on-demand'
+        GROUP BY
+            region,
+            hour
+        ORDER BY
+            region,
+            hour
+        `,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c testCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.PeakHourLoad(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func runSmartMeterTestCases(t *testing.T, testFunc func(*SmartMeter, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(96 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			sg, err := b.NewSmartMeter(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating smart-meter generator")
+			}
+			sm := sg.(*SmartMeter)
+
+			q := testFunc(sm, c)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+		})
+	}
+}
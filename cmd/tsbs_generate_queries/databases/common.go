@@ -1,5 +1,10 @@
 package databases
 
+import (
+	"fmt"
+	"strings"
+)
+
 // PanicIfErr panics when passed a non-nil error
 // TODO: Remove the need for this by continuing to bubble up errors
 func PanicIfErr(err error) {
@@ -7,3 +12,53 @@ func PanicIfErr(err error) {
 		panic(err.Error())
 	}
 }
+
+// SQLArgs collects the arguments of a parameterized SQL query. Generators
+// bind every value through it: Bind returns a placeholder and records the
+// value, so the same code path builds either a literal statement or a
+// template plus argument list. A nil *SQLArgs inlines the literals instead.
+type SQLArgs struct {
+	placeholder func(n int) string
+	values      []string
+}
+
+// NewDollarSQLArgs returns a SQLArgs using PostgreSQL-style $N placeholders.
+func NewDollarSQLArgs() *SQLArgs {
+	return &SQLArgs{placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }}
+}
+
+// NewQuestionMarkSQLArgs returns a SQLArgs using positional ? placeholders.
+func NewQuestionMarkSQLArgs() *SQLArgs {
+	return &SQLArgs{placeholder: func(int) string { return "?" }}
+}
+
+// Bind returns the placeholder for value, or literal when a is nil.
+func (a *SQLArgs) Bind(literal, value string) string {
+	if a == nil {
+		return literal
+	}
+	a.values = append(a.values, value)
+	return a.placeholder(len(a.values))
+}
+
+// BindString binds a string value which is otherwise inlined single-quoted.
+func (a *SQLArgs) BindString(s string) string {
+	return a.Bind("'"+s+"'", s)
+}
+
+// BindStrings binds each string and joins the results with sep.
+func (a *SQLArgs) BindStrings(ss []string, sep string) string {
+	bound := make([]string, len(ss))
+	for i, s := range ss {
+		bound[i] = a.BindString(s)
+	}
+	return strings.Join(bound, sep)
+}
+
+// Values returns the bound argument values in placeholder order.
+func (a *SQLArgs) Values() []string {
+	if a == nil {
+		return nil
+	}
+	return a.values
+}
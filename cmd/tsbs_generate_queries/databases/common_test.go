@@ -0,0 +1,45 @@
+package databases
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSQLArgs(t *testing.T) {
+	cases := []struct {
+		desc       string
+		args       *SQLArgs
+		wantSQL    string
+		wantValues []string
+	}{
+		{
+			desc:    "nil args inline literals",
+			args:    nil,
+			wantSQL: "hostname IN ('host_1','host_2') AND time < '2016-01-01' AND ts > 42",
+		},
+		{
+			desc:       "dollar placeholders",
+			args:       NewDollarSQLArgs(),
+			wantSQL:    "hostname IN ($1,$2) AND time < $3 AND ts > $4",
+			wantValues: []string{"host_1", "host_2", "2016-01-01", "2016-01-01T00:00:00Z"},
+		},
+		{
+			desc:       "question mark placeholders",
+			args:       NewQuestionMarkSQLArgs(),
+			wantSQL:    "hostname IN (?,?) AND time < ? AND ts > ?",
+			wantValues: []string{"host_1", "host_2", "2016-01-01", "2016-01-01T00:00:00Z"},
+		},
+	}
+
+	for _, c := range cases {
+		got := "hostname IN (" + c.args.BindStrings([]string{"host_1", "host_2"}, ",") + ")" +
+			" AND time < " + c.args.BindString("2016-01-01") +
+			" AND ts > " + c.args.Bind("42", "2016-01-01T00:00:00Z")
+		if got != c.wantSQL {
+			t.Errorf("%s: incorrect SQL: got %s want %s", c.desc, got, c.wantSQL)
+		}
+		if got := strings.Join(c.args.Values(), ","); got != strings.Join(c.wantValues, ",") {
+			t.Errorf("%s: incorrect values: got %s want %s", c.desc, got, strings.Join(c.wantValues, ","))
+		}
+	}
+}
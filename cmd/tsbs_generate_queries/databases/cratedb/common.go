@@ -3,13 +3,18 @@ package cratedb
 import (
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
 )
 
 // BaseGenerator contains settings specific for CrateDB
 type BaseGenerator struct {
+	// UsePreparedStatements makes queries bind their hosts and time bounds
+	// as arguments of a parameterized template instead of inlining them.
+	UsePreparedStatements bool
 }
 
 // GenerateEmptyQuery returns an empty query.CrateDB.
@@ -17,13 +22,23 @@ func (g *BaseGenerator) GenerateEmptyQuery() query.Query {
 	return query.NewCrateDB()
 }
 
+// newArgs returns the collector for the arguments of a parameterized query,
+// or nil when values should be inlined as literals.
+func (g *BaseGenerator) newArgs() *databases.SQLArgs {
+	if !g.UsePreparedStatements {
+		return nil
+	}
+	return databases.NewDollarSQLArgs()
+}
+
 // fillInQuery fills the query struct with data.
-func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, sql string) {
+func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, table, sql string, args ...string) {
 	q := qi.(*query.CrateDB)
 	q.HumanLabel = []byte(humanLabel)
 	q.HumanDescription = []byte(humanDesc)
-	q.Table = []byte("cpu")
+	q.Table = []byte(table)
 	q.SqlQuery = []byte(sql)
+	q.SqlArgs = append(q.SqlArgs[:0], args...)
 }
 
 // NewDevops creates a new devops use case query generator.
@@ -41,3 +56,19 @@ func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryG
 
 	return devops, nil
 }
+
+// NewIoT creates a new iot use case query generator.
+func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := iot.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iot := &IoT{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return iot, nil
+}
@@ -2,9 +2,11 @@ package cratedb
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
 	"github.com/timescale/tsbs/pkg/query"
 )
@@ -22,7 +24,17 @@ type Devops struct {
 	*devops.Core
 }
 
-const hostnameField = "tags['hostname']"
+const (
+	hostnameField = "tags['hostname']"
+
+	// crateTimeFmt is how bound timestamp arguments are sent to CrateDB
+	crateTimeFmt = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// bindTime binds a timestamp which is otherwise inlined as epoch millis.
+func bindTime(args *databases.SQLArgs, t time.Time) string {
+	return args.Bind(strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10), t.Format(crateTimeFmt))
+}
 
 // getSelectAggClauses builds specified aggregate function clauses for
 // a set of column idents.
@@ -45,30 +57,31 @@ func (d *Devops) getSelectAggClauses(aggFunc string, idents []string) []string {
 // cpu-max-all-1
 // cpu-max-all-8
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.MaxAllDuration)
+	interval := d.MustRandWindow(devops.MaxAllDuration)
 	selectClauses := d.getSelectAggClauses("max", devops.GetAllCPUMetrics())
 	hosts, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
+	args := d.newArgs()
 
 	sql := fmt.Sprintf(`
 		SELECT
 			date_trunc('hour', ts) AS hour,
 			%s
 		FROM cpu
-		WHERE %s IN ('%s')
-		  AND ts >= %d
-		  AND ts < %d
+		WHERE %s IN (%s)
+		  AND ts >= %s
+		  AND ts < %s
 		GROUP BY hour
 		ORDER BY hour`,
 		strings.Join(selectClauses, ", "),
 		hostnameField,
-		strings.Join(hosts, "', '"),
-		interval.StartUnixMillis(),
-		interval.EndUnixMillis())
+		args.BindStrings(hosts, ", "),
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()))
 
 	humanLabel := devops.GetMaxAllLabel("CrateDB", nHosts)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByTimeAndPrimaryTag selects the AVG of metrics in the group `cpu` per device
@@ -81,26 +94,27 @@ func (d *Devops) MaxAllCPU(qi query.Query, nHosts int) {
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(devops.DoubleGroupByDuration)
 	selectClauses := d.getSelectAggClauses("mean", metrics)
+	args := d.newArgs()
 
 	sql := fmt.Sprintf(`
 		SELECT
 			date_trunc('hour', ts) AS hour,
 			%s
 		FROM cpu
-		WHERE ts >= %d
-		  AND ts < %d
+		WHERE ts >= %s
+		  AND ts < %s
 		GROUP BY hour, %s
 		ORDER BY hour`,
 		strings.Join(selectClauses, ", "),
-		interval.StartUnixMillis(),
-		interval.EndUnixMillis(),
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()),
 		hostnameField)
 
 	humanLabel := devops.GetDoubleGroupByLabel("CrateDB", numMetrics)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByOrderByLimit populates a query.Query that has a time WHERE clause,
@@ -109,21 +123,22 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // Queries:
 // groupby-orderby-limit
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	interval := d.MustRandWindow(time.Hour)
+	args := d.newArgs()
 	sql := fmt.Sprintf(`
 		SELECT
 			date_trunc('minute', ts) as minute,
 			max(usage_user)
 		FROM cpu
-		WHERE ts < %d
+		WHERE ts < %s
 		GROUP BY minute
 		ORDER BY minute DESC
 		LIMIT 5`,
-		interval.EndUnixMillis())
+		bindTime(args, interval.End()))
 
 	humanLabel := "CrateDB max cpu over last 5 min-intervals (random end)"
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.EndString())
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // LastPointPerHost finds the last row for every host in the dataset
@@ -141,7 +156,7 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 
 	humanLabel := "CrateDB last row per host"
 	humanDesc := humanLabel
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
 }
 
 // HighCPUForHosts populates a query that gets CPU metrics when the CPU has
@@ -152,26 +167,27 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // high-cpu-1
 // high-cpu-all
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	interval := d.MustRandWindow(devops.HighCPUDuration)
 	hosts, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
+	args := d.newArgs()
 
 	sql := fmt.Sprintf(`
 		SELECT *
 		FROM cpu
 		WHERE usage_user > 90.0
-		  AND ts >= %d
-		  AND ts < %d
-		  AND %s IN ('%s')`,
-		interval.StartUnixMillis(),
-		interval.EndUnixMillis(),
+		  AND ts >= %s
+		  AND ts < %s
+		  AND %s IN (%s)`,
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()),
 		hostnameField,
-		strings.Join(hosts, "', '"))
+		args.BindStrings(hosts, ", "))
 
 	humanLabel, err := devops.GetHighCPULabel("CrateDB", nHosts)
 	panicIfErr(err)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByTime selects the MAX for metrics under 'cpu', per minute for N random
@@ -185,32 +201,119 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 // single-groupby-5-1-1
 // single-groupby-5-8-1
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	interval := d.MustRandWindow(timeRange)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	selectClauses := d.getSelectAggClauses("max", metrics)
 	hosts, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
+	args := d.newArgs()
 
 	sql := fmt.Sprintf(`
 		SELECT
 			date_trunc('minute', ts) as minute,
 			%s
 		FROM cpu
-		WHERE %s IN ('%s')
-		  AND ts >= %d
-		  AND ts < %d
+		WHERE %s IN (%s)
+		  AND ts >= %s
+		  AND ts < %s
 		GROUP BY minute
 		ORDER BY minute ASC`,
 		strings.Join(selectClauses, ", "),
 		hostnameField,
-		strings.Join(hosts, "', '"),
-		interval.StartUnixMillis(),
-		interval.EndUnixMillis())
+		args.BindStrings(hosts, ", "),
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()))
 
 	humanLabel := fmt.Sprintf(
 		"CrateDB %d cpu metric(s), random %4d hosts, random %s by 1m",
 		numMetrics, nHosts, timeRange)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// GroupByTimeCrossMeasurement correlates the mean of a metric from each of
+// the cpu, mem and diskio tables per host per hour for N random hosts
+//
+// Queries:
+// cross-measurement-1-12
+// cross-measurement-8-1
+func (d *Devops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	hosts, err := d.GetRandomHosts(nHosts)
+	panicIfErr(err)
+	args := d.newArgs()
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	first := metrics[0].Measurement + "_avg"
+	meanClauses := make([]string, len(metrics))
+	subqueries := make([]string, len(metrics))
+	for i, m := range metrics {
+		table := m.Measurement + "_avg"
+		meanClauses[i] = "mean_" + m.Metric
+		subqueries[i] = fmt.Sprintf(`(
+			SELECT
+				date_trunc('hour', ts) AS hour,
+				%s AS host,
+				avg(%s) AS mean_%s
+			FROM %s
+			WHERE %s IN (%s)
+			  AND ts >= %s
+			  AND ts < %s
+			GROUP BY hour, host
+		  ) %s`,
+			hostnameField,
+			m.Metric, m.Metric,
+			m.Measurement,
+			hostnameField,
+			args.BindStrings(hosts, ", "),
+			bindTime(args, interval.Start()),
+			bindTime(args, interval.End()),
+			table)
+		if i > 0 {
+			subqueries[i] = fmt.Sprintf("JOIN %s\n\t\t  ON %s.hour = %s.hour AND %s.host = %s.host",
+				subqueries[i], table, first, table, first)
+		}
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s.hour, %s.host, %s
+		FROM %s
+		ORDER BY %s.hour, %s.host`,
+		first, first, strings.Join(meanClauses, ", "),
+		strings.Join(subqueries, "\n\t\t"),
+		first, first)
+
+	humanLabel := devops.GetCrossMeasurementLabel("CrateDB", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour
+//
+// Queries:
+// top-k-hosts-10
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT
+			%s AS host,
+			avg(usage_user) AS mean_usage_user
+		FROM cpu
+		WHERE ts >= %s
+		  AND ts < %s
+		GROUP BY host
+		ORDER BY mean_usage_user DESC
+		LIMIT %d`,
+		hostnameField,
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()),
+		k)
+
+	humanLabel := devops.GetTopKHostsLabel("CrateDB", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
@@ -259,3 +259,98 @@ func TestDevopsGroupByTimeQuery(t *testing.T) {
 			got.SqlQuery, want.SqlQuery)
 	}
 }
+
+func TestDevopsTopKHostsQuery(t *testing.T) {
+	rand.Seed(100)
+	start := time.Date(2006, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2006, 1, 10, 20, 0, 0, 0, time.UTC)
+	d := assertNewDevops(t, start, end)
+
+	want := &query.CrateDB{
+		Table: []byte("cpu"),
+		SqlQuery: []byte(`
+		SELECT
+			tags['hostname'] AS host,
+			avg(usage_user) AS mean_usage_user
+		FROM cpu
+		WHERE ts >= 1136447713823
+		  AND ts < 1136451313823
+		GROUP BY host
+		ORDER BY mean_usage_user DESC
+		LIMIT 10`),
+	}
+
+	got := &query.CrateDB{}
+	d.TopKHosts(got, 10)
+
+	if !reflect.DeepEqual(want.SqlQuery, got.SqlQuery) {
+		t.Errorf("incorrect sql query:\ngot: %s\n want:\n %s",
+			got.SqlQuery, want.SqlQuery)
+	}
+	if !reflect.DeepEqual(want.Table, got.Table) {
+		t.Errorf("incorrect table:\ngot: %s\n want:\n %s",
+			got.SqlQuery, want.SqlQuery)
+	}
+}
+
+func TestDevopsGroupByTimeCrossMeasurementQuery(t *testing.T) {
+	// return the same set of random hosts deterministic
+	rand.Seed(100)
+	start := time.Date(2006, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2006, 1, 10, 20, 0, 0, 0, time.UTC)
+	d := assertNewDevops(t, start, end)
+
+	want := &query.CrateDB{
+		Table: []byte("cpu"),
+		SqlQuery: []byte(`
+		SELECT cpu_avg.hour, cpu_avg.host, mean_usage_user, mean_used_percent, mean_reads
+		FROM (
+			SELECT
+				date_trunc('hour', ts) AS hour,
+				tags['hostname'] AS host,
+				avg(usage_user) AS mean_usage_user
+			FROM cpu
+			WHERE tags['hostname'] IN ('host_8', 'host_0')
+			  AND ts >= 1136357713823
+			  AND ts < 1136400913823
+			GROUP BY hour, host
+		  ) cpu_avg
+		JOIN (
+			SELECT
+				date_trunc('hour', ts) AS hour,
+				tags['hostname'] AS host,
+				avg(used_percent) AS mean_used_percent
+			FROM mem
+			WHERE tags['hostname'] IN ('host_8', 'host_0')
+			  AND ts >= 1136357713823
+			  AND ts < 1136400913823
+			GROUP BY hour, host
+		  ) mem_avg
+		  ON mem_avg.hour = cpu_avg.hour AND mem_avg.host = cpu_avg.host
+		JOIN (
+			SELECT
+				date_trunc('hour', ts) AS hour,
+				tags['hostname'] AS host,
+				avg(reads) AS mean_reads
+			FROM diskio
+			WHERE tags['hostname'] IN ('host_8', 'host_0')
+			  AND ts >= 1136357713823
+			  AND ts < 1136400913823
+			GROUP BY hour, host
+		  ) diskio_avg
+		  ON diskio_avg.hour = cpu_avg.hour AND diskio_avg.host = cpu_avg.host
+		ORDER BY cpu_avg.hour, cpu_avg.host`),
+	}
+
+	got := &query.CrateDB{}
+	d.GroupByTimeCrossMeasurement(got, 2, 12*time.Hour)
+
+	if !reflect.DeepEqual(want.SqlQuery, got.SqlQuery) {
+		t.Errorf("incorrect sql query:\ngot: %s\n want:\n %s",
+			got.SqlQuery, want.SqlQuery)
+	}
+	if !reflect.DeepEqual(want.Table, got.Table) {
+		t.Errorf("incorrect table:\ngot: %s\n want:\n %s",
+			got.SqlQuery, want.SqlQuery)
+	}
+}
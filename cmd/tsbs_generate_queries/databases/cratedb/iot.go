@@ -0,0 +1,407 @@
+package cratedb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// IoT produces CrateDB-specific queries for all the iot query types.
+//
+// The loader stores the truck tags in the `tags` object column of both the
+// readings and diagnostics tables, so no join is needed.
+type IoT struct {
+	*iot.Core
+	*BaseGenerator
+}
+
+// NewIoT makes an IoT object ready to generate Queries.
+func NewIoT(start, end time.Time, scale int, g *BaseGenerator) *IoT {
+	c, err := iot.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &IoT{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+const tenMinutesBin = "date_bin('10 minutes'::INTERVAL, ts, 0)"
+
+// tag returns the column of the tags object holding tag key.
+func tag(key string) string {
+	return fmt.Sprintf("tags['%s']", key)
+}
+
+// getFleetWhereString creates a WHERE SQL clause for the named trucks of fleet.
+func (i *IoT) getFleetWhereString(fleet string, args *databases.SQLArgs) string {
+	return fmt.Sprintf("%s = %s\n\t\t  AND %s IS NOT NULL", tag("fleet"), args.BindString(fleet), tag("name"))
+}
+
+// LastLocByTruck finds the truck location for nTrucks.
+//
+// Queries:
+// single-last-loc
+func (i *IoT) LastLocByTruck(qi query.Query, nTrucks int) {
+	trucks, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS name, %s AS driver,
+			max_by(longitude, ts) AS longitude,
+			max_by(latitude, ts) AS latitude
+		FROM readings
+		WHERE %s IN (%s)
+		GROUP BY name, driver`,
+		tag("name"),
+		tag("driver"),
+		tag("name"),
+		args.BindStrings(trucks, ", "))
+
+	humanLabel := "CrateDB last location by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks", humanLabel, nTrucks)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// LastLocPerTruck finds all the truck locations along with truck and driver names.
+//
+// Queries:
+// last-loc
+func (i *IoT) LastLocPerTruck(qi query.Query) {
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS name, %s AS driver,
+			max_by(longitude, ts) AS longitude,
+			max_by(latitude, ts) AS latitude
+		FROM readings
+		WHERE %s
+		GROUP BY name, driver`,
+		tag("name"),
+		tag("driver"),
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "CrateDB last location per truck"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// TrucksWithLowFuel finds all trucks with low fuel (less than 10%).
+//
+// Queries:
+// low-fuel
+func (i *IoT) TrucksWithLowFuel(qi query.Query) {
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS name, %s AS driver,
+			max_by(fuel_state, ts) AS fuel_state
+		FROM diagnostics
+		WHERE %s
+		GROUP BY name, driver
+		HAVING max_by(fuel_state, ts) < 0.1`,
+		tag("name"),
+		tag("driver"),
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "CrateDB trucks with low fuel"
+	humanDesc := fmt.Sprintf("%s: under 10 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql, args.Values()...)
+}
+
+// TrucksWithHighLoad finds all trucks that have load over 90%.
+//
+// Queries:
+// high-load
+func (i *IoT) TrucksWithHighLoad(qi query.Query) {
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS name, %s AS driver, %s AS load_capacity,
+			max_by(current_load, ts) AS current_load
+		FROM diagnostics
+		WHERE %s
+		GROUP BY name, driver, load_capacity
+		HAVING max_by(current_load, ts) / %s > 0.9`,
+		tag("name"),
+		tag("driver"),
+		tag("load_capacity"),
+		i.getFleetWhereString(i.GetRandomFleet(), args),
+		tag("load_capacity"))
+
+	humanLabel := "CrateDB trucks with high load"
+	humanDesc := fmt.Sprintf("%s: over 90 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql, args.Values()...)
+}
+
+// StationaryTrucks finds all trucks that have low average velocity in a time window.
+//
+// Queries:
+// stationary-trucks
+func (i *IoT) StationaryTrucks(qi query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS name, %s AS driver
+		FROM readings
+		WHERE ts >= %s
+		  AND ts < %s
+		  AND %s
+		GROUP BY name, driver
+		HAVING avg(velocity) < 1`,
+		tag("name"),
+		tag("driver"),
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()),
+		i.getFleetWhereString(i.GetRandomFleet(), args))
+
+	humanLabel := "CrateDB stationary trucks"
+	humanDesc := fmt.Sprintf("%s: with low avg velocity in last 10 minutes", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args.Values()...)
+}
+
+// drivingSessionsQuery returns the trucks of a random fleet which were driving
+// in more than periods ten minute periods of a random window of duration.
+func (i *IoT) drivingSessionsQuery(duration time.Duration, periods int) (string, []string) {
+	interval := i.MustRandWindow(duration)
+	args := i.newArgs()
+
+	sql := fmt.Sprintf(`
+		SELECT name, driver
+		FROM (
+			SELECT %s AS name, %s AS driver, %s AS ten_minutes
+			FROM readings
+			WHERE ts >= %s
+			  AND ts < %s
+			  AND %s
+			GROUP BY name, driver, ten_minutes
+			HAVING avg(velocity) > 1
+		) AS r
+		GROUP BY name, driver
+		HAVING count(*) > %d`,
+		tag("name"),
+		tag("driver"),
+		tenMinutesBin,
+		bindTime(args, interval.Start()),
+		bindTime(args, interval.End()),
+		i.getFleetWhereString(i.GetRandomFleet(), args),
+		periods)
+	return sql, args.Values()
+}
+
+// TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
+//
+// Queries:
+// long-driving-sessions
+func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+	sql, args := i.drivingSessionsQuery(iot.LongDrivingSessionDuration, tenMinutePeriods(5, iot.LongDrivingSessionDuration))
+
+	humanLabel := "CrateDB trucks with longer driving sessions"
+	humanDesc := fmt.Sprintf("%s: stopped less than 20 mins in 4 hour period", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args...)
+}
+
+// TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
+//
+// Queries:
+// long-daily-sessions
+func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+	sql, args := i.drivingSessionsQuery(iot.DailyDrivingDuration, tenMinutePeriods(35, iot.DailyDrivingDuration))
+
+	humanLabel := "CrateDB trucks with longer daily sessions"
+	humanDesc := fmt.Sprintf("%s: drove more than 10 hours in the last 24 hours", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql, args...)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel consumption per fleet.
+//
+// Queries:
+// avg-vs-projected-fuel-consumption
+func (i *IoT) AvgVsProjectedFuelConsumption(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT %[1]s AS fleet,
+			avg(fuel_consumption) AS avg_fuel_consumption,
+			avg(%[2]s) AS projected_fuel_consumption
+		FROM readings
+		WHERE velocity > 1
+		  AND %[1]s IS NOT NULL
+		  AND %[2]s IS NOT NULL
+		  AND %[3]s IS NOT NULL
+		GROUP BY fleet`,
+		tag("fleet"),
+		tag("nominal_fuel_consumption"),
+		tag("name"))
+
+	humanLabel := "CrateDB average vs projected fuel consumption per fleet"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver.
+//
+// Queries:
+// avg-daily-driving-duration
+func (i *IoT) AvgDailyDrivingDuration(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT fleet, name, driver, avg(hours) AS avg_daily_hours
+		FROM (
+			SELECT fleet, name, driver, day, count(*) / 6 AS hours
+			FROM (
+				SELECT %s AS fleet, %s AS name, %s AS driver,
+					date_trunc('day', ts) AS day,
+					%s AS ten_minutes
+				FROM readings
+				GROUP BY fleet, name, driver, day, ten_minutes
+				HAVING avg(velocity) > 1
+			) AS s
+			GROUP BY fleet, name, driver, day
+		) AS d
+		GROUP BY fleet, name, driver`,
+		tag("fleet"),
+		tag("name"),
+		tag("driver"),
+		tenMinutesBin)
+
+	humanLabel := "CrateDB average driver driving duration per day"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgDailyDrivingSession finds the average driving session without stopping per driver per day.
+//
+// Queries:
+// avg-daily-driving-session
+func (i *IoT) AvgDailyDrivingSession(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT name, date_trunc('day', start) AS day,
+			avg(stop::BIGINT - start::BIGINT) / 60000.0 AS duration_minutes
+		FROM (
+			SELECT name, ten_minutes AS start,
+				lead(ten_minutes) OVER (PARTITION BY name ORDER BY ten_minutes) AS stop,
+				driving
+			FROM (
+				SELECT name, ten_minutes, driving,
+					lag(driving) OVER (PARTITION BY name ORDER BY ten_minutes) AS prev_driving
+				FROM (
+					SELECT %s AS name, %s AS ten_minutes, avg(velocity) > 5 AS driving
+					FROM readings
+					WHERE %s IS NOT NULL
+					GROUP BY name, ten_minutes
+				) AS s
+			) AS x
+			WHERE driving <> prev_driving
+		) AS c
+		WHERE driving = true
+		  AND stop IS NOT NULL
+		GROUP BY name, day
+		ORDER BY name, day`,
+		tag("name"),
+		tenMinutesBin,
+		tag("name"))
+
+	humanLabel := "CrateDB average driver driving session without stopping per day"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+//
+// Queries:
+// avg-load
+func (i *IoT) AvgLoad(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT fleet, model, load_capacity,
+			avg(avg_load / load_capacity) AS avg_load_percentage
+		FROM (
+			SELECT %s AS fleet, %s AS model, %s AS load_capacity, %s AS name,
+				avg(current_load) AS avg_load
+			FROM diagnostics
+			WHERE %s IS NOT NULL
+			GROUP BY fleet, model, load_capacity, name
+		) AS d
+		GROUP BY fleet, model, load_capacity`,
+		tag("fleet"),
+		tag("model"),
+		tag("load_capacity"),
+		tag("name"),
+		tag("name"))
+
+	humanLabel := "CrateDB average load per truck model per fleet"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// DailyTruckActivity returns the number of hours trucks has been active (not out-of-commission) per day per fleet per model.
+//
+// Queries:
+// daily-activity
+func (i *IoT) DailyTruckActivity(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT fleet, model, day, count(*) / 144.0 AS daily_activity
+		FROM (
+			SELECT %s AS fleet, %s AS model, %s AS name,
+				date_trunc('day', ts) AS day,
+				%s AS ten_minutes
+			FROM diagnostics
+			WHERE %s IS NOT NULL
+			GROUP BY fleet, model, name, day, ten_minutes
+			HAVING avg(status) < 1
+		) AS y
+		GROUP BY fleet, model, day
+		ORDER BY day`,
+		tag("fleet"),
+		tag("model"),
+		tag("name"),
+		tenMinutesBin,
+		tag("name"))
+
+	humanLabel := "CrateDB daily truck activity per fleet per model"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// TruckBreakdownFrequency calculates the amount of times a truck model broke down in the last period.
+//
+// Queries:
+// breakdown-frequency
+func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT model, count(*) AS breakdowns
+		FROM (
+			SELECT model, broken_down,
+				lead(broken_down) OVER (PARTITION BY name ORDER BY ten_minutes) AS next_broken_down
+			FROM (
+				SELECT %s AS model, %s AS name, %s AS ten_minutes,
+					sum(CASE WHEN status = 0 THEN 1.0 ELSE 0.0 END) / count(*) >= 0.5 AS broken_down
+				FROM diagnostics
+				WHERE %s IS NOT NULL
+				GROUP BY model, name, ten_minutes
+			) AS t
+		) AS b
+		WHERE broken_down = false
+		  AND next_broken_down = true
+		GROUP BY model`,
+		tag("model"),
+		tag("name"),
+		tenMinutesBin,
+		tag("name"))
+
+	humanLabel := "CrateDB truck breakdown frequency per model"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
+}
+
+// tenMinutePeriods calculates the number of 10 minute periods that can fit in
+// the time duration if we subtract the minutes specified by minutesPerHour value.
+// E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
+func tenMinutePeriods(minutesPerHour float64, duration time.Duration) int {
+	durationMinutes := duration.Minutes()
+	leftover := minutesPerHour * duration.Hours()
+	return int((durationMinutes - leftover) / 10)
+}
@@ -0,0 +1,136 @@
+package cratedb
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func assertNewIoT(t *testing.T, start, end time.Time, usePrepared bool) *IoT {
+	b := BaseGenerator{UsePreparedStatements: usePrepared}
+	ig, err := b.NewIoT(start, end, testScale)
+	if err != nil {
+		t.Fatalf("error while creating iot generator")
+	}
+
+	return ig.(*IoT)
+}
+
+func TestIoTLastLocByTruckQuery(t *testing.T) {
+	rand.Seed(123)
+	i := assertNewIoT(t, time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), false)
+
+	want := `
+		SELECT tags['name'] AS name, tags['driver'] AS driver,
+			max_by(longitude, ts) AS longitude,
+			max_by(latitude, ts) AS latitude
+		FROM readings
+		WHERE tags['name'] IN ('truck_5')
+		GROUP BY name, driver`
+
+	got := i.GenerateEmptyQuery().(*query.CrateDB)
+	i.LastLocByTruck(got, 1)
+
+	if string(got.SqlQuery) != want {
+		t.Errorf("incorrect sql query:\ngot: %s\n want:\n %s", got.SqlQuery, want)
+	}
+	if string(got.Table) != iot.ReadingsTableName {
+		t.Errorf("incorrect table: got %s want %s", got.Table, iot.ReadingsTableName)
+	}
+}
+
+func TestIoTStationaryTrucksQuery(t *testing.T) {
+	rand.Seed(123)
+	i := assertNewIoT(t, time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), false)
+
+	want := `
+		SELECT tags['name'] AS name, tags['driver'] AS driver
+		FROM readings
+		WHERE ts >= 2182646
+		  AND ts < 2782646
+		  AND tags['fleet'] = 'West'
+		  AND tags['name'] IS NOT NULL
+		GROUP BY name, driver
+		HAVING avg(velocity) < 1`
+
+	got := i.GenerateEmptyQuery().(*query.CrateDB)
+	i.StationaryTrucks(got)
+
+	if string(got.SqlQuery) != want {
+		t.Errorf("incorrect sql query:\ngot: %s\n want:\n %s", got.SqlQuery, want)
+	}
+	if wantLabel := "CrateDB stationary trucks"; string(got.HumanLabel) != wantLabel {
+		t.Errorf("incorrect human label: got %s want %s", got.HumanLabel, wantLabel)
+	}
+}
+
+func TestIoTPreparedStatements(t *testing.T) {
+	rand.Seed(123)
+	i := assertNewIoT(t, time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), true)
+
+	got := i.GenerateEmptyQuery().(*query.CrateDB)
+	i.TrucksWithLongDrivingSessions(got)
+
+	for _, p := range []string{"$1", "$2", "$3"} {
+		if !strings.Contains(string(got.SqlQuery), p) {
+			t.Errorf("query is missing placeholder %s:\n%s", p, got.SqlQuery)
+		}
+	}
+	if strings.Contains(string(got.SqlQuery), "'West'") {
+		t.Errorf("query contains inlined fleet:\n%s", got.SqlQuery)
+	}
+	want := []string{"1970-01-01T02:16:22.646Z", "1970-01-01T06:16:22.646Z", "West"}
+	if strings.Join(got.SqlArgs, "|") != strings.Join(want, "|") {
+		t.Errorf("incorrect args: got %v want %v", got.SqlArgs, want)
+	}
+}
+
+// TestIoTAllQueries checks every iot query fills in its labels, table and query.
+func TestIoTAllQueries(t *testing.T) {
+	rand.Seed(123)
+	i := assertNewIoT(t, time.Unix(0, 0), time.Unix(0, 0).Add(48*time.Hour), false)
+
+	fills := map[string]func(query.Query){
+		iot.LabelLastLoc:                       i.LastLocPerTruck,
+		iot.LabelLastLocSingleTruck:            func(q query.Query) { i.LastLocByTruck(q, 1) },
+		iot.LabelLowFuel:                       i.TrucksWithLowFuel,
+		iot.LabelHighLoad:                      i.TrucksWithHighLoad,
+		iot.LabelStationaryTrucks:              i.StationaryTrucks,
+		iot.LabelLongDrivingSessions:           i.TrucksWithLongDrivingSessions,
+		iot.LabelLongDailySessions:             i.TrucksWithLongDailySessions,
+		iot.LabelAvgVsProjectedFuelConsumption: i.AvgVsProjectedFuelConsumption,
+		iot.LabelAvgDailyDrivingDuration:       i.AvgDailyDrivingDuration,
+		iot.LabelAvgDailyDrivingSession:        i.AvgDailyDrivingSession,
+		iot.LabelAvgLoad:                       i.AvgLoad,
+		iot.LabelDailyActivity:                 i.DailyTruckActivity,
+		iot.LabelBreakdownFrequency:            i.TruckBreakdownFrequency,
+	}
+	for label, fill := range fills {
+		q := i.GenerateEmptyQuery()
+		fill(q)
+		c := q.(*query.CrateDB)
+		if !strings.HasPrefix(string(c.HumanLabel), "CrateDB ") {
+			t.Errorf("%s: incorrect human label: %s", label, c.HumanLabel)
+		}
+		table := string(c.Table)
+		if table != iot.ReadingsTableName && table != iot.DiagnosticsTableName {
+			t.Errorf("%s: incorrect table: %s", label, table)
+		}
+		if !strings.Contains(string(c.SqlQuery), "FROM "+table) {
+			t.Errorf("%s: query does not read table %s:\n%s", label, table, c.SqlQuery)
+		}
+	}
+}
+
+func TestTenMinutePeriods(t *testing.T) {
+	if got := tenMinutePeriods(5, 4*time.Hour); got != 22 {
+		t.Errorf("incorrect periods for 4 hours: got %d want 22", got)
+	}
+	if got := tenMinutePeriods(35, 24*time.Hour); got != 60 {
+		t.Errorf("incorrect periods for 24 hours: got %d want 60", got)
+	}
+}
@@ -6,13 +6,30 @@ import (
 	"time"
 
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
 )
 
+const (
+	// FluxQueryPath is the path of the InfluxDB 2.x query API.
+	FluxQueryPath = "/api/v2/query"
+
+	errFluxUnsupportedUseCaseFmt = "use case %s has no Flux queries"
+)
+
 // BaseGenerator contains settings specific for Influx database.
 type BaseGenerator struct {
+	// UseFlux makes the devops and iot queries Flux queries to the
+	// InfluxDB 2.x query API instead of InfluxQL queries.
+	UseFlux bool
+	// Bucket is the bucket read by Flux queries.
+	Bucket string
 }
 
 // GenerateEmptyQuery returns an empty query.HTTP.
@@ -33,6 +50,18 @@ func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, influ
 	q.Body = nil
 }
 
+// fillInFluxQuery fills the query struct with a Flux query sent as the body
+// of a request to the query API.
+func (g *BaseGenerator) fillInFluxQuery(qi query.Query, humanLabel, humanDesc, flux string) {
+	q := qi.(*query.HTTP)
+	q.HumanLabel = []byte(humanLabel)
+	q.RawQuery = []byte(flux)
+	q.HumanDescription = []byte(humanDesc)
+	q.Method = []byte("POST")
+	q.Path = []byte(FluxQueryPath)
+	q.Body = []byte(flux)
+}
+
 // NewDevops creates a new devops use case query generator.
 func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryGenerator, error) {
 	core, err := devops.NewCore(start, end, scale)
@@ -41,6 +70,13 @@ func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryG
 		return nil, err
 	}
 
+	if g.UseFlux {
+		return &FluxDevops{
+			BaseGenerator: g,
+			Core:          core,
+		}, nil
+	}
+
 	devops := &Devops{
 		BaseGenerator: g,
 		Core:          core,
@@ -57,6 +93,13 @@ func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGene
 		return nil, err
 	}
 
+	if g.UseFlux {
+		return &FluxIoT{
+			BaseGenerator: g,
+			Core:          core,
+		}, nil
+	}
+
 	devops := &IoT{
 		BaseGenerator: g,
 		Core:          core,
@@ -64,3 +107,103 @@ func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGene
 
 	return devops, nil
 }
+
+// NewDevopsGeneric creates a new devops-generic use case query generator.
+func (g *BaseGenerator) NewDevopsGeneric(start, end time.Time, scale, maxMetricCount int) (utils.QueryGenerator, error) {
+	if g.UseFlux {
+		return nil, fmt.Errorf(errFluxUnsupportedUseCaseFmt, "devops-generic")
+	}
+
+	core, err := devopsgeneric.NewCore(start, end, scale, maxMetricCount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	devopsGeneric := &DevopsGeneric{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return devopsGeneric, nil
+}
+
+// NewFinance creates a new finance use case query generator.
+func (g *BaseGenerator) NewFinance(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	if g.UseFlux {
+		return nil, fmt.Errorf(errFluxUnsupportedUseCaseFmt, "finance")
+	}
+
+	core, err := finance.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	finance := &Finance{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return finance, nil
+}
+
+// NewK8s creates a new k8s use case query generator.
+func (g *BaseGenerator) NewK8s(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	if g.UseFlux {
+		return nil, fmt.Errorf(errFluxUnsupportedUseCaseFmt, "k8s")
+	}
+
+	core, err := k8s.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	k8s := &K8s{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return k8s, nil
+}
+
+// NewLogs creates a new logs use case query generator.
+func (g *BaseGenerator) NewLogs(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	if g.UseFlux {
+		return nil, fmt.Errorf(errFluxUnsupportedUseCaseFmt, "logs")
+	}
+
+	core, err := logs.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &Logs{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return logs, nil
+}
+
+// NewSmartMeter creates a new smart-meter use case query generator.
+func (g *BaseGenerator) NewSmartMeter(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	if g.UseFlux {
+		return nil, fmt.Errorf(errFluxUnsupportedUseCaseFmt, "smart-meter")
+	}
+
+	core, err := smartmeter.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	smartMeter := &SmartMeter{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return smartMeter, nil
+}
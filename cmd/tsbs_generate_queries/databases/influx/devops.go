@@ -51,15 +51,22 @@ func (d *Devops) getSelectClausesAggMetrics(agg string, metrics []string) []stri
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY minute ORDER BY minute ASC
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	d.GroupByTimeBucket(qi, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qi query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	interval := d.MustRandWindow(timeRange)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	databases.PanicIfErr(err)
 	selectClauses := d.getSelectClausesAggMetrics("max", metrics)
 	whereHosts := d.getHostWhereString(nHosts)
 
-	humanLabel := fmt.Sprintf("Influx %d cpu metric(s), random %4d hosts, random %s by 1m", numMetrics, nHosts, timeRange)
+	bucketName := devops.GetBucketName(bucket)
+
+	humanLabel := fmt.Sprintf("Influx %d cpu metric(s), random %4d hosts, random %s by %s", numMetrics, nHosts, timeRange, bucketName)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	influxql := fmt.Sprintf("SELECT %s from cpu where %s and time >= '%s' and time < '%s' group by time(1m)", strings.Join(selectClauses, ", "), whereHosts, interval.StartString(), interval.EndString())
+	influxql := fmt.Sprintf("SELECT %s from cpu where %s and time >= '%s' and time < '%s' group by time(%s)", strings.Join(selectClauses, ", "), whereHosts, interval.StartString(), interval.EndString(), bucketName)
 	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
 
@@ -69,12 +76,17 @@ func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange t
 // GROUP BY t ORDER BY t DESC
 // LIMIT $LIMIT
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	d.GroupByOrderByLimitN(qi, devops.GroupByOrderByLimitRows)
+}
+
+// GroupByOrderByLimitN is GroupByOrderByLimit with the given limit.
+func (d *Devops) GroupByOrderByLimitN(qi query.Query, limit int) {
+	interval := d.MustRandWindow(time.Hour)
 	where := fmt.Sprintf("WHERE time < '%s'", interval.EndString())
 
-	humanLabel := "Influx max cpu over last 5 min-intervals (random end)"
+	humanLabel := devops.GetGroupByOrderByLimitLabel("Influx", limit)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	influxql := fmt.Sprintf(`SELECT max(usage_user) from cpu %s group by time(1m) limit 5`, where)
+	influxql := fmt.Sprintf(`SELECT max(usage_user) from cpu %s group by time(1m) limit %d`, where, limit)
 	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
 
@@ -86,14 +98,20 @@ func (d *Devops) GroupByOrderByLimit(qi query.Query) {
 // WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour, hostname ORDER BY hour, hostname
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qi, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qi query.Query, numMetrics int, window, bucket time.Duration) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	databases.PanicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(window)
 	selectClauses := d.getSelectClausesAggMetrics("mean", metrics)
 
-	humanLabel := devops.GetDoubleGroupByLabel("Influx", numMetrics)
+	humanLabel := devops.GetDoubleGroupByWindowLabel("Influx", numMetrics, window, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	influxql := fmt.Sprintf("SELECT %s from cpu where time >= '%s' and time < '%s' group by time(1h),hostname", strings.Join(selectClauses, ", "), interval.StartString(), interval.EndString())
+	influxql := fmt.Sprintf("SELECT %s from cpu where time >= '%s' and time < '%s' group by time(%s),hostname", strings.Join(selectClauses, ", "), interval.StartString(), interval.EndString(), devops.GetBucketName(bucket))
 	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
 
@@ -105,13 +123,18 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour ORDER BY hour
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
-	interval := d.Interval.MustRandWindow(duration)
+	d.MaxAllCPUBucket(qi, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *Devops) MaxAllCPUBucket(qi query.Query, nHosts int, duration, bucket time.Duration) {
+	interval := d.MustRandWindow(duration)
 	whereHosts := d.getHostWhereString(nHosts)
 	selectClauses := d.getSelectClausesAggMetrics("max", devops.GetAllCPUMetrics())
 
-	humanLabel := devops.GetMaxAllLabel("Influx", nHosts)
+	humanLabel := devops.GetMaxAllBucketLabel("Influx", nHosts, duration, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	influxql := fmt.Sprintf("SELECT %s from cpu where %s and time >= '%s' and time < '%s' group by time(1h)", strings.Join(selectClauses, ","), whereHosts, interval.StartString(), interval.EndString())
+	influxql := fmt.Sprintf("SELECT %s from cpu where %s and time >= '%s' and time < '%s' group by time(%s)", strings.Join(selectClauses, ","), whereHosts, interval.StartString(), interval.EndString(), devops.GetBucketName(bucket))
 	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
 
@@ -132,7 +155,12 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // AND time >= '$TIME_START' AND time < '$TIME_END'
 // AND (hostname = '$HOST' OR hostname = '$HOST2'...)
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	d.HighCPUForHostsWindow(qi, nHosts, devops.HighCPUDuration)
+}
+
+// HighCPUForHostsWindow is HighCPUForHosts over the given window.
+func (d *Devops) HighCPUForHostsWindow(qi query.Query, nHosts int, window time.Duration) {
+	interval := d.MustRandWindow(window)
 
 	var hostWhereClause string
 	if nHosts == 0 {
@@ -141,9 +169,115 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 		hostWhereClause = fmt.Sprintf("and %s", d.getHostWhereString(nHosts))
 	}
 
-	humanLabel, err := devops.GetHighCPULabel("Influx", nHosts)
+	humanLabel, err := devops.GetHighCPUWindowLabel("Influx", nHosts, window)
 	databases.PanicIfErr(err)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
 	influxql := fmt.Sprintf("SELECT * from cpu where usage_user > 90.0 %s and time >= '%s' and time < '%s'", hostWhereClause, interval.StartString(), interval.EndString())
 	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT PERCENTILE(metric1, 50), PERCENTILE(metric1, 95), ...
+// FROM cpu
+// WHERE (hostname = '$HOSTNAME_1' OR ... OR hostname = '$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hour, hostname
+func (d *Devops) GroupByTimePercentiles(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	databases.PanicIfErr(err)
+	whereHosts := d.getHostWhereString(nHosts)
+
+	var selectClauses []string
+	for _, m := range metrics {
+		for _, p := range devops.GetPercentiles() {
+			selectClauses = append(selectClauses,
+				fmt.Sprintf("percentile(%s, %g) as %s_%s", m, p*100, devops.GetPercentileName(p), m))
+		}
+	}
+
+	humanLabel := devops.GetPercentilesLabel("Influx", numMetrics, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT %s from cpu where %s and time >= '%s' and time < '%s' group by time(1h),hostname",
+		strings.Join(selectClauses, ", "), whereHosts, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// GroupByTimeCrossMeasurement selects the mean of a metric from each of the
+// cpu, mem and diskio measurements per host per hour for nHosts hosts.
+// InfluxQL has no joins, so the measurements are read by a single statement
+// which returns one series per measurement and host,
+// e.g. in pseudo-SQL:
+//
+// SELECT mean(usage_user), mean(used_percent), mean(reads)
+// FROM cpu, mem, diskio
+// WHERE (hostname = '$HOSTNAME_1' OR ... OR hostname = '$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hour, hostname
+func (d *Devops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	whereHosts := d.getHostWhereString(nHosts)
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	selectClauses := make([]string, len(metrics))
+	measurements := make([]string, len(metrics))
+	for i, m := range metrics {
+		selectClauses[i] = fmt.Sprintf("mean(%[1]s) as mean_%[1]s", m.Metric)
+		measurements[i] = m.Measurement
+	}
+
+	humanLabel := devops.GetCrossMeasurementLabel("Influx", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT %s from %s where %s and time >= '%s' and time < '%s' group by time(1h),hostname",
+		strings.Join(selectClauses, ", "), strings.Join(measurements, ", "), whereHosts, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in pseudo-SQL:
+//
+// SELECT top(mean_usage_user, hostname, k) FROM (
+// SELECT mean(usage_user) AS mean_usage_user FROM cpu
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname
+// )
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+
+	humanLabel := devops.GetTopKHostsLabel("Influx", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT top(mean_usage_user, hostname, %d) from "+
+		"(SELECT mean(usage_user) as mean_usage_user from cpu where time >= '%s' and time < '%s' group by \"hostname\")",
+		k, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// TagValues lists the hostnames of a random region,
+// e.g. in InfluxQL:
+//
+// SHOW TAG VALUES FROM "cpu" WITH KEY = "hostname" WHERE "region" = '$REGION'
+func (d *Devops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+
+	humanLabel := devops.GetTagValuesLabel("Influx")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	influxql := fmt.Sprintf("SHOW TAG VALUES FROM \"cpu\" WITH KEY = \"hostname\" WHERE \"region\" = '%s'", region)
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// SeriesCount counts the cpu series of a random region,
+// e.g. in InfluxQL:
+//
+// SHOW SERIES EXACT CARDINALITY FROM "cpu" WHERE "region" = '$REGION'
+func (d *Devops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+
+	humanLabel := devops.GetSeriesCountLabel("Influx")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	influxql := fmt.Sprintf("SHOW SERIES EXACT CARDINALITY FROM \"cpu\" WHERE \"region\" = '%s'", region)
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
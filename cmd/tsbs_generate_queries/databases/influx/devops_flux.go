@@ -0,0 +1,308 @@
+package influx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// FluxDevops produces Flux queries for all the devops query types.
+type FluxDevops struct {
+	*BaseGenerator
+	*devops.Core
+}
+
+func (d *FluxDevops) getHostPredicate(nHosts int) string {
+	hostnames, err := d.GetRandomHosts(nHosts)
+	databases.PanicIfErr(err)
+	return fluxIn("hostname", hostnames...)
+}
+
+// GroupByTime selects the MAX for numMetrics metrics under 'cpu',
+// per minute for nhosts hosts,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and (r._field == "metric1" or ...) and (r.hostname == "$HOSTNAME_1" or ...))
+// |> group(columns: ["_field"]) |> aggregateWindow(every: 1m, fn: max, createEmpty: false)
+func (d *FluxDevops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	d.GroupByTimeBucket(qi, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *FluxDevops) GroupByTimeBucket(qi query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	databases.PanicIfErr(err)
+	hostPredicate := d.getHostPredicate(nHosts)
+
+	bucketName := devops.GetBucketName(bucket)
+
+	humanLabel := fmt.Sprintf("%s %d cpu metric(s), random %4d hosts, random %s by %s", fluxLabel, numMetrics, nHosts, timeRange, bucketName)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, fluxIn("_field", metrics...), hostPredicate),
+		fluxGroup("_field"),
+		fmt.Sprintf("aggregateWindow(every: %s, fn: max, createEmpty: false)", bucketName),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// GroupByOrderByLimit benchmarks a query that has a time WHERE clause, that groups by a truncated date, orders by that date, and takes a limit,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $START, stop: $TIME)
+// |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user") |> group()
+// |> aggregateWindow(every: 1m, fn: max, createEmpty: false) |> sort(columns: ["_time"], desc: true) |> limit(n: 5)
+func (d *FluxDevops) GroupByOrderByLimit(qi query.Query) {
+	d.GroupByOrderByLimitN(qi, devops.GroupByOrderByLimitRows)
+}
+
+// GroupByOrderByLimitN is GroupByOrderByLimit with the given limit.
+func (d *FluxDevops) GroupByOrderByLimitN(qi query.Query, limit int) {
+	interval := d.MustRandWindow(time.Hour)
+
+	humanLabel := devops.GetGroupByOrderByLimitLabel(fluxLabel, limit)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(d.Interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, `r._field == "usage_user"`),
+		"group()",
+		"aggregateWindow(every: 1m, fn: max, createEmpty: false)",
+		`sort(columns: ["_time"], desc: true)`,
+		fmt.Sprintf("limit(n: %d)", limit),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// GroupByTimeAndPrimaryTag selects the AVG of numMetrics metrics under 'cpu' per device per hour for a day,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and (r._field == "metric1" or ...))
+// |> group(columns: ["hostname", "_field"]) |> aggregateWindow(every: 1h, fn: mean, createEmpty: false)
+func (d *FluxDevops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qi, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *FluxDevops) GroupByTimeAndPrimaryTagWindow(qi query.Query, numMetrics int, window, bucket time.Duration) {
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	databases.PanicIfErr(err)
+	interval := d.MustRandWindow(window)
+
+	humanLabel := devops.GetDoubleGroupByWindowLabel(fluxLabel, numMetrics, window, bucket)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, fluxIn("_field", metrics...)),
+		fluxGroup("hostname", "_field"),
+		fmt.Sprintf("aggregateWindow(every: %s, fn: mean, createEmpty: false)", devops.GetBucketName(bucket)),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// MaxAllCPU selects the MAX of all metrics under 'cpu' per hour for nhosts hosts,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and (r.hostname == "$HOSTNAME_1" or ...))
+// |> group(columns: ["_field"]) |> aggregateWindow(every: 1h, fn: max, createEmpty: false)
+func (d *FluxDevops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
+	d.MaxAllCPUBucket(qi, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *FluxDevops) MaxAllCPUBucket(qi query.Query, nHosts int, duration, bucket time.Duration) {
+	interval := d.MustRandWindow(duration)
+	hostPredicate := d.getHostPredicate(nHosts)
+
+	humanLabel := devops.GetMaxAllBucketLabel(fluxLabel, nHosts, duration, bucket)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, fluxIn("_field", devops.GetAllCPUMetrics()...), hostPredicate),
+		fluxGroup("_field"),
+		fmt.Sprintf("aggregateWindow(every: %s, fn: max, createEmpty: false)", devops.GetBucketName(bucket)),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// LastPointPerHost finds the last row for every host in the dataset,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $START, stop: $END)
+// |> filter(fn: (r) => r._measurement == "cpu") |> last() |> group(columns: ["hostname"]) |> pivot(...)
+func (d *FluxDevops) LastPointPerHost(qi query.Query) {
+	humanLabel := fluxLabel + " last row per host"
+	humanDesc := humanLabel + ": cpu"
+	flux := fluxPipe(
+		d.fluxFrom(d.Interval.Start(), d.Interval.End()),
+		fluxFilter(`r._measurement == "cpu"`),
+		"last()",
+		fluxGroup("hostname"),
+		fluxPivotFields,
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// HighCPUForHosts populates a query that gets CPU metrics when the CPU has high
+// usage between a time period for a number of hosts (if 0, it will search all hosts),
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $TIME_START, stop: $TIME_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and (r.hostname == "$HOST" or ...))
+// |> pivot(...) |> filter(fn: (r) => r.usage_user > 90.0)
+func (d *FluxDevops) HighCPUForHosts(qi query.Query, nHosts int) {
+	d.HighCPUForHostsWindow(qi, nHosts, devops.HighCPUDuration)
+}
+
+// HighCPUForHostsWindow is HighCPUForHosts over the given window.
+func (d *FluxDevops) HighCPUForHostsWindow(qi query.Query, nHosts int, window time.Duration) {
+	interval := d.MustRandWindow(window)
+
+	predicates := []string{`r._measurement == "cpu"`}
+	if nHosts != 0 {
+		predicates = append(predicates, d.getHostPredicate(nHosts))
+	}
+
+	humanLabel, err := devops.GetHighCPUWindowLabel(fluxLabel, nHosts, window)
+	databases.PanicIfErr(err)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(predicates...),
+		fluxPivotFields,
+		fluxFilter("r.usage_user > 90.0"),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts. The hourly windows are
+// read once and each percentile is computed over them,
+// e.g. in Flux:
+//
+// data = from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and (r._field == "metric1" or ...) and (r.hostname == "$HOSTNAME_1" or ...))
+// |> group(columns: ["hostname", "_field"]) |> window(every: 1h)
+// union(tables: [data |> quantile(q: 0.5) |> set(key: "percentile", value: "p50"), ...])
+func (d *FluxDevops) GroupByTimePercentiles(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	databases.PanicIfErr(err)
+	hostPredicate := d.getHostPredicate(nHosts)
+
+	percentiles := make([]string, len(devops.GetPercentiles()))
+	for i, p := range devops.GetPercentiles() {
+		percentiles[i] = fluxPipe("data",
+			fmt.Sprintf("quantile(q: %g)", p),
+			fmt.Sprintf("set(key: \"percentile\", value: %q)", devops.GetPercentileName(p)))
+	}
+
+	humanLabel := devops.GetPercentilesLabel(fluxLabel, numMetrics, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := "data = " + fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, fluxIn("_field", metrics...), hostPredicate),
+		fluxGroup("hostname", "_field"),
+		"window(every: 1h)",
+	) + fmt.Sprintf("\nunion(tables: [%s])", strings.Join(percentiles, ", "))
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// GroupByTimeCrossMeasurement selects the mean of a metric from each of the
+// cpu, mem and diskio measurements per host per hour for nHosts hosts, and
+// joins them into one row per host and hour,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => ((r._measurement == "cpu" and r._field == "usage_user") or ...) and (r.hostname == "$HOSTNAME_1" or ...))
+// |> aggregateWindow(every: 1h, fn: mean, createEmpty: false) |> group(columns: ["hostname"]) |> pivot(...)
+func (d *FluxDevops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	hostPredicate := d.getHostPredicate(nHosts)
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	clauses := make([]string, len(metrics))
+	for i, m := range metrics {
+		clauses[i] = fmt.Sprintf("(%s and %s)", fluxIn("_measurement", m.Measurement), fluxIn("_field", m.Metric))
+	}
+
+	humanLabel := devops.GetCrossMeasurementLabel(fluxLabel, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter("("+strings.Join(clauses, " or ")+")", hostPredicate),
+		"aggregateWindow(every: 1h, fn: mean, createEmpty: false)",
+		fluxGroup("hostname"),
+		fluxPivotFields,
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $HOUR_START, stop: $HOUR_END)
+// |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")
+// |> group(columns: ["hostname"]) |> mean() |> group() |> top(n: k)
+func (d *FluxDevops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+
+	humanLabel := devops.GetTopKHostsLabel(fluxLabel, k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	flux := fluxPipe(
+		d.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, `r._field == "usage_user"`),
+		fluxGroup("hostname"),
+		"mean()",
+		"group()",
+		fmt.Sprintf("top(n: %d)", k),
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TagValues lists the hostnames of a random region,
+// e.g. in Flux:
+//
+// import "influxdata/influxdb/schema"
+// schema.tagValues(bucket: "benchmark", tag: "hostname", predicate: (r) => r._measurement == "cpu" and r.region == "$REGION", start: $START, stop: $END)
+func (d *FluxDevops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+
+	humanLabel := devops.GetTagValuesLabel(fluxLabel)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	flux := fmt.Sprintf("import \"influxdata/influxdb/schema\"\n"+
+		"schema.tagValues(bucket: %q, tag: \"hostname\", predicate: (r) => r._measurement == \"cpu\" and %s, start: %s, stop: %s)",
+		d.Bucket, fluxIn("region", region), d.Interval.StartString(), d.Interval.EndString())
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// SeriesCount counts the cpu series of a random region, one per host,
+// e.g. in Flux:
+//
+// from(bucket: "benchmark") |> range(start: $START, stop: $END)
+// |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user" and r.region == "$REGION")
+// |> last() |> group() |> count()
+func (d *FluxDevops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+
+	humanLabel := devops.GetSeriesCountLabel(fluxLabel)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	flux := fluxPipe(
+		d.fluxFrom(d.Interval.Start(), d.Interval.End()),
+		fluxFilter(`r._measurement == "cpu"`, `r._field == "usage_user"`, fluxIn("region", region)),
+		"last()",
+		"group()",
+		"count()",
+	)
+	d.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
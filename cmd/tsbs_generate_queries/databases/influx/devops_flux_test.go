@@ -0,0 +1,193 @@
+package influx
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFluxDevopsQueries(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fn                 func(d *FluxDevops, q query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc: "GroupByTime",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.GroupByTime(q, 2, 2, time.Hour)
+			},
+			expectedHumanLabel: "Influx Flux 2 cpu metric(s), random    2 hosts, random 1h0m0s by 1m",
+			expectedHumanDesc:  "Influx Flux 2 cpu metric(s), random    2 hosts, random 1h0m0s by 1m: 1970-01-01T20:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T20:16:22Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and (r._field == "usage_user" or r._field == "usage_system") and (r.hostname == "host_9" or r.hostname == "host_3")) |> ` +
+				`group(columns: ["_field"]) |> aggregateWindow(every: 1m, fn: max, createEmpty: false)`,
+		},
+		{
+			desc: "GroupByTimePercentiles",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.GroupByTimePercentiles(q, 1, 1, time.Hour)
+			},
+			expectedHumanLabel: "Influx Flux p50/p95/p99 of 1 cpu metric(s), random    1 hosts, random 1h0m0s by 1h",
+			expectedHumanDesc:  "Influx Flux p50/p95/p99 of 1 cpu metric(s), random    1 hosts, random 1h0m0s by 1h: 1970-01-01T20:16:22Z",
+			expectedQuery: `data = from(bucket: "benchmark") |> range(start: 1970-01-01T20:16:22Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user" and r.hostname == "host_9") |> ` +
+				`group(columns: ["hostname", "_field"]) |> window(every: 1h)` +
+				"\n" +
+				`union(tables: [data |> quantile(q: 0.5) |> set(key: "percentile", value: "p50"), ` +
+				`data |> quantile(q: 0.95) |> set(key: "percentile", value: "p95"), ` +
+				`data |> quantile(q: 0.99) |> set(key: "percentile", value: "p99")])`,
+		},
+		{
+			desc: "GroupByTimeCrossMeasurement",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.GroupByTimeCrossMeasurement(q, 1, time.Hour)
+			},
+			expectedHumanLabel: "Influx Flux mean of cpu usage_user, mem used_percent, diskio reads, random    1 hosts, random 1h0m0s by 1h",
+			expectedHumanDesc:  "Influx Flux mean of cpu usage_user, mem used_percent, diskio reads, random    1 hosts, random 1h0m0s by 1h: 1970-01-01T20:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T20:16:22Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => ((r._measurement == "cpu" and r._field == "usage_user") or (r._measurement == "mem" and r._field == "used_percent") or ` +
+				`(r._measurement == "diskio" and r._field == "reads")) and r.hostname == "host_9") |> ` +
+				`aggregateWindow(every: 1h, fn: mean, createEmpty: false) |> group(columns: ["hostname"]) |> ` +
+				`pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`,
+		},
+		{
+			desc: "HighCPUForHosts all hosts",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.HighCPUForHosts(q, 0)
+			},
+			expectedHumanLabel: "Influx Flux CPU over threshold, all hosts",
+			expectedHumanDesc:  "Influx Flux CPU over threshold, all hosts: 1970-01-01T06:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T06:16:22Z, stop: 1970-01-01T18:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu") |> ` +
+				`pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value") |> filter(fn: (r) => r.usage_user > 90.0)`,
+		},
+		{
+			desc: "GroupByTimeAndPrimaryTagWindow",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.GroupByTimeAndPrimaryTagWindow(q, 1, 2*time.Hour, 10*time.Minute)
+			},
+			expectedHumanLabel: "Influx Flux mean of 1 metrics, all hosts, random 2h0m0s by 10m",
+			expectedHumanDesc:  "Influx Flux mean of 1 metrics, all hosts, random 2h0m0s by 10m: 1970-01-01T06:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T06:16:22Z, stop: 1970-01-01T08:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user") |> ` +
+				`group(columns: ["hostname", "_field"]) |> aggregateWindow(every: 10m, fn: mean, createEmpty: false)`,
+		},
+		{
+			desc: "MaxAllCPUBucket",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.MaxAllCPUBucket(q, 1, 2*time.Hour, 30*time.Minute)
+			},
+			expectedHumanLabel: "Influx Flux max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m",
+			expectedHumanDesc:  "Influx Flux max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m: 1970-01-01T06:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T06:16:22Z, stop: 1970-01-01T08:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and (r._field == "usage_user" or r._field == "usage_system" or r._field == "usage_idle" or ` +
+				`r._field == "usage_nice" or r._field == "usage_iowait" or r._field == "usage_irq" or r._field == "usage_softirq" or ` +
+				`r._field == "usage_steal" or r._field == "usage_guest" or r._field == "usage_guest_nice") and r.hostname == "host_9") |> ` +
+				`group(columns: ["_field"]) |> aggregateWindow(every: 30m, fn: max, createEmpty: false)`,
+		},
+		{
+			desc: "HighCPUForHostsWindow",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.HighCPUForHostsWindow(q, 0, time.Hour)
+			},
+			expectedHumanLabel: "Influx Flux CPU over threshold, all hosts, random 1h0m0s",
+			expectedHumanDesc:  "Influx Flux CPU over threshold, all hosts, random 1h0m0s: 1970-01-01T20:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T20:16:22Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu") |> ` +
+				`pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value") |> filter(fn: (r) => r.usage_user > 90.0)`,
+		},
+		{
+			desc: "GroupByOrderByLimitN",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.GroupByOrderByLimitN(q, 10)
+			},
+			expectedHumanLabel: "Influx Flux max cpu over last 10 min-intervals (random end)",
+			expectedHumanDesc:  "Influx Flux max cpu over last 10 min-intervals (random end): 1970-01-01T20:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user") |> group() |> ` +
+				`aggregateWindow(every: 1m, fn: max, createEmpty: false) |> sort(columns: ["_time"], desc: true) |> limit(n: 10)`,
+		},
+		{
+			desc: "TopKHosts",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.TopKHosts(q, 5)
+			},
+			expectedHumanLabel: "Influx Flux top 5 hosts by mean usage_user, random 1h0m0s",
+			expectedHumanDesc:  "Influx Flux top 5 hosts by mean usage_user, random 1h0m0s: 1970-01-01T20:16:22Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T20:16:22Z, stop: 1970-01-01T21:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user") |> ` +
+				`group(columns: ["hostname"]) |> mean() |> group() |> top(n: 5)`,
+		},
+		{
+			desc: "TagValues",
+			fn: func(d *FluxDevops, q query.Query) {
+				d.TagValues(q)
+			},
+			expectedHumanLabel: "Influx Flux hostname tag values, random region",
+			expectedHumanDesc:  "Influx Flux hostname tag values, random region: ap-southeast-1",
+			expectedQuery: `import "influxdata/influxdb/schema"` +
+				"\n" +
+				`schema.tagValues(bucket: "benchmark", tag: "hostname", predicate: (r) => r._measurement == "cpu" and r.region == "ap-southeast-1", ` +
+				`start: 1970-01-01T00:00:00Z, stop: 1970-01-02T00:00:00Z)`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+			dq, err := b.NewDevops(s, s.Add(24*time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*FluxDevops)
+
+			q := d.GenerateEmptyQuery()
+			c.fn(d, q)
+
+			verifyFluxQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+		})
+	}
+}
+
+func TestFluxDevopsGenericUnsupported(t *testing.T) {
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	s := time.Unix(0, 0)
+	if _, err := b.NewDevopsGeneric(s, s.Add(time.Hour), 10, 10); err == nil {
+		t.Errorf("expected an error for devops-generic Flux queries")
+	}
+}
+
+func verifyFluxQuery(t *testing.T, q query.Query, humanLabel, humanDesc, flux string) {
+	fluxQuery, ok := q.(*query.HTTP)
+
+	if !ok {
+		t.Fatal("Filled query is not *query.HTTP type")
+	}
+
+	if got := string(fluxQuery.HumanLabel); got != humanLabel {
+		t.Errorf("incorrect human label:\ngot\n%s\nwant\n%s", got, humanLabel)
+	}
+
+	if got := string(fluxQuery.HumanDescription); got != humanDesc {
+		t.Errorf("incorrect human description:\ngot\n%s\nwant\n%s", got, humanDesc)
+	}
+
+	if got := string(fluxQuery.Method); got != "POST" {
+		t.Errorf("incorrect method:\ngot\n%s\nwant POST", got)
+	}
+
+	if got := string(fluxQuery.Path); got != FluxQueryPath {
+		t.Errorf("incorrect path:\ngot\n%s\nwant\n%s", got, FluxQueryPath)
+	}
+
+	if got := string(fluxQuery.Body); got != flux {
+		t.Errorf("incorrect query:\ngot\n%s\nwant\n%s", got, flux)
+	}
+}
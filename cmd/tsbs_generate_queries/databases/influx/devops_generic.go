@@ -0,0 +1,91 @@
+package influx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// DevopsGeneric produces Influx-specific queries for all the devops-generic query types.
+type DevopsGeneric struct {
+	*BaseGenerator
+	*devopsgeneric.Core
+}
+
+// devops returns a Devops generator over the same hosts, used to reuse its
+// host filtering helpers.
+func (d *DevopsGeneric) devops() *Devops {
+	return &Devops{BaseGenerator: d.BaseGenerator, Core: d.Core.Core}
+}
+
+// GroupByTimeSingleMetric selects the MAX of a random generic metric per
+// minute for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT max(metric_N) FROM generic_metrics
+// WHERE (hostname = '$HOSTNAME_1' OR ... OR hostname = '$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY time(1m)
+func (d *DevopsGeneric) GroupByTimeSingleMetric(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metric := d.GetRandomMetric()
+	whereHosts := d.devops().getHostWhereString(nHosts)
+
+	humanLabel := devopsgeneric.GetSingleMetricGroupbyLabel("Influx", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT max(%s) from %s where %s and time >= '%s' and time < '%s' group by time(1m)",
+		metric, devopsgeneric.TableName, whereHosts, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// LastPointPerHost finds the last value of a random generic metric for every
+// host in the dataset.
+func (d *DevopsGeneric) LastPointPerHost(qi query.Query) {
+	metric := d.GetRandomMetric()
+
+	humanLabel := devopsgeneric.GetLastpointLabel("Influx")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, metric)
+	influxql := fmt.Sprintf("SELECT last(%s) from %s group by \"hostname\"", metric, devopsgeneric.TableName)
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// TopKHosts finds the k hosts with the highest average of a random generic
+// metric in a random window,
+// e.g. in pseudo-SQL:
+//
+// SELECT top(mean_metric_N, hostname, k) FROM (
+// SELECT mean(metric_N) AS mean_metric_N FROM generic_metrics
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname
+// )
+func (d *DevopsGeneric) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devopsgeneric.TopKDuration)
+	metric := d.GetRandomMetric()
+
+	humanLabel := devopsgeneric.GetTopKHostsLabel("Influx", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT top(mean_%[1]s, hostname, %[2]d) from "+
+		"(SELECT mean(%[1]s) as mean_%[1]s from %[3]s where time >= '%[4]s' and time < '%[5]s' group by \"hostname\")",
+		metric, k, devopsgeneric.TableName, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// MetricDiscovery finds which generic metrics a random host reported in a
+// random window by counting the values of every field,
+// e.g. in pseudo-SQL:
+//
+// SELECT count(*) FROM generic_metrics
+// WHERE hostname = '$HOSTNAME'
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+func (d *DevopsGeneric) MetricDiscovery(qi query.Query) {
+	interval := d.MustRandWindow(devopsgeneric.MetricDiscoveryDuration)
+	whereHosts := d.devops().getHostWhereString(1)
+
+	humanLabel := devopsgeneric.GetMetricDiscoveryLabel("Influx")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	influxql := fmt.Sprintf("SELECT count(*) from %s where %s and time >= '%s' and time < '%s'",
+		devopsgeneric.TableName, whereHosts, interval.StartString(), interval.EndString())
+	d.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
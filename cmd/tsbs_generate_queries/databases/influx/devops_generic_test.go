@@ -0,0 +1,81 @@
+package influx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestDevopsGenericQueries(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fill               func(*DevopsGeneric, query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc: "single metric groupby",
+			fill: func(d *DevopsGeneric, q query.Query) { d.GroupByTimeSingleMetric(q, 2, time.Hour) },
+
+			expectedHumanLabel: "Influx 1 generic metric, random    2 hosts, random 1h0m0s by 1m",
+			expectedHumanDesc:  "Influx 1 generic metric, random    2 hosts, random 1h0m0s by 1m: 1970-01-01T00:16:22Z",
+			expectedQuery: "SELECT max(metric_0) from generic_metrics " +
+				"where (hostname = 'host_3' or hostname = 'host_5') and " +
+				"time >= '1970-01-01T00:16:22Z' and time < '1970-01-01T01:16:22Z' group by time(1m)",
+		},
+		{
+			desc: "lastpoint",
+			fill: func(d *DevopsGeneric, q query.Query) { d.LastPointPerHost(q) },
+
+			expectedHumanLabel: "Influx last value of a generic metric per host",
+			expectedHumanDesc:  "Influx last value of a generic metric per host: metric_2",
+			expectedQuery:      `SELECT last(metric_2) from generic_metrics group by "hostname"`,
+		},
+		{
+			desc: "top-k hosts",
+			fill: func(d *DevopsGeneric, q query.Query) { d.TopKHosts(q, 5) },
+
+			expectedHumanLabel: "Influx top 5 hosts by mean of a generic metric, random 1h0m0s",
+			expectedHumanDesc:  "Influx top 5 hosts by mean of a generic metric, random 1h0m0s: 1970-01-01T00:16:22Z",
+			expectedQuery: "SELECT top(mean_metric_0, hostname, 5) from " +
+				"(SELECT mean(metric_0) as mean_metric_0 from generic_metrics " +
+				"where time >= '1970-01-01T00:16:22Z' and time < '1970-01-01T01:16:22Z' group by \"hostname\")",
+		},
+		{
+			desc: "metric discovery",
+			fill: func(d *DevopsGeneric, q query.Query) { d.MetricDiscovery(q) },
+
+			expectedHumanLabel: "Influx metrics reported by a random host, random 1h0m0s",
+			expectedHumanDesc:  "Influx metrics reported by a random host, random 1h0m0s: 1970-01-01T00:16:22Z",
+			expectedQuery: "SELECT count(*) from generic_metrics where (hostname = 'host_9') and " +
+				"time >= '1970-01-01T00:16:22Z' and time < '1970-01-01T01:16:22Z'",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{}
+			dq, err := b.NewDevopsGeneric(s, s.Add(2*time.Hour), 10, 3)
+			if err != nil {
+				t.Fatalf("Error while creating devops-generic generator")
+			}
+			d := dq.(*DevopsGeneric)
+
+			q := d.GenerateEmptyQuery()
+			c.fill(d, q)
+
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
@@ -166,6 +166,33 @@ func TestDevopsGroupByTime(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
 }
 
+func TestDevopsGroupByTimeBucket(t *testing.T) {
+	expectedHumanLabel := "Influx 1 cpu metric(s), random    1 hosts, random 1s by 5m"
+	expectedHumanDesc := "Influx 1 cpu metric(s), random    1 hosts, random 1s by 5m: 1970-01-01T00:05:58Z"
+	expectedQuery := "SELECT max(usage_user) from cpu " +
+		"where (hostname = 'host_9') and " +
+		"time >= '1970-01-01T00:05:58Z' and time < '1970-01-01T00:05:59Z' " +
+		"group by time(5m)"
+
+	v := url.Values{}
+	v.Set("q", expectedQuery)
+	expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimeBucket(q, 1, 1, time.Second, 5*time.Minute)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
+}
+
 func TestDevopsGroupByOrderByLimit(t *testing.T) {
 	expectedHumanLabel := "Influx max cpu over last 5 min-intervals (random end)"
 	expectedHumanDesc := "Influx max cpu over last 5 min-intervals (random end): 1970-01-01T00:16:22Z"
@@ -192,6 +219,81 @@ func TestDevopsGroupByOrderByLimit(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
 }
 
+func TestDevopsOverrides(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fn                 func(d *Devops, q query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc: "GroupByTimeAndPrimaryTagWindow",
+			fn: func(d *Devops, q query.Query) {
+				d.GroupByTimeAndPrimaryTagWindow(q, 1, 2*time.Hour, 10*time.Minute)
+			},
+			expectedHumanLabel: "Influx mean of 1 metrics, all hosts, random 2h0m0s by 10m",
+			expectedHumanDesc:  "Influx mean of 1 metrics, all hosts, random 2h0m0s by 10m: 1970-01-01T06:16:22Z",
+			expectedQuery: "SELECT mean(usage_user) from cpu " +
+				"where time >= '1970-01-01T06:16:22Z' and time < '1970-01-01T08:16:22Z' " +
+				"group by time(10m),hostname",
+		},
+		{
+			desc: "MaxAllCPUBucket",
+			fn: func(d *Devops, q query.Query) {
+				d.MaxAllCPUBucket(q, 1, 2*time.Hour, 30*time.Minute)
+			},
+			expectedHumanLabel: "Influx max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m",
+			expectedHumanDesc:  "Influx max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m: 1970-01-01T06:16:22Z",
+			expectedQuery: "SELECT max(usage_user),max(usage_system),max(usage_idle),max(usage_nice),max(usage_iowait),max(usage_irq),max(usage_softirq),max(usage_steal),max(usage_guest),max(usage_guest_nice) from cpu " +
+				"where (hostname = 'host_9') and time >= '1970-01-01T06:16:22Z' and time < '1970-01-01T08:16:22Z' " +
+				"group by time(30m)",
+		},
+		{
+			desc: "HighCPUForHostsWindow",
+			fn: func(d *Devops, q query.Query) {
+				d.HighCPUForHostsWindow(q, 1, time.Hour)
+			},
+			expectedHumanLabel: "Influx CPU over threshold, 1 host(s), random 1h0m0s",
+			expectedHumanDesc:  "Influx CPU over threshold, 1 host(s), random 1h0m0s: 1970-01-01T20:16:22Z",
+			expectedQuery: "SELECT * from cpu where usage_user > 90.0 and (hostname = 'host_9') " +
+				"and time >= '1970-01-01T20:16:22Z' and time < '1970-01-01T21:16:22Z'",
+		},
+		{
+			desc: "GroupByOrderByLimitN",
+			fn: func(d *Devops, q query.Query) {
+				d.GroupByOrderByLimitN(q, 10)
+			},
+			expectedHumanLabel: "Influx max cpu over last 10 min-intervals (random end)",
+			expectedHumanDesc:  "Influx max cpu over last 10 min-intervals (random end): 1970-01-01T20:16:22Z",
+			expectedQuery: "SELECT max(usage_user) from cpu " +
+				"WHERE time < '1970-01-01T21:16:22Z' group by time(1m) limit 10",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{}
+			dq, err := b.NewDevops(s, s.Add(24*time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			c.fn(d, q)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
+
 func TestDevopsGroupByTimeAndPrimaryTag(t *testing.T) {
 	cases := []testCase{
 		{
@@ -354,6 +456,138 @@ func TestHighCPUForHosts(t *testing.T) {
 	runTestCases(t, testFunc, start, end, cases)
 }
 
+func TestDevopsGroupByTimePercentiles(t *testing.T) {
+	expectedHumanLabel := "Influx p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h"
+	expectedHumanDesc := "Influx p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z"
+	expectedQuery := "SELECT percentile(usage_user, 50) as p50_usage_user, percentile(usage_user, 95) as p95_usage_user, " +
+		"percentile(usage_user, 99) as p99_usage_user from cpu " +
+		"where (hostname = 'host_9' or hostname = 'host_3') and " +
+		"time >= '1970-01-01T06:16:22Z' and time < '1970-01-01T18:16:22Z' " +
+		"group by time(1h),hostname"
+
+	v := url.Values{}
+	v.Set("q", expectedQuery)
+	expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimePercentiles(q, 2, 1, 12*time.Hour)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
+}
+
+func TestDevopsGroupByTimeCrossMeasurement(t *testing.T) {
+	expectedHumanLabel := "Influx mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h"
+	expectedHumanDesc := "Influx mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z"
+	expectedQuery := "SELECT mean(usage_user) as mean_usage_user, mean(used_percent) as mean_used_percent, " +
+		"mean(reads) as mean_reads from cpu, mem, diskio " +
+		"where (hostname = 'host_9' or hostname = 'host_3') and " +
+		"time >= '1970-01-01T06:16:22Z' and time < '1970-01-01T18:16:22Z' " +
+		"group by time(1h),hostname"
+
+	v := url.Values{}
+	v.Set("q", expectedQuery)
+	expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimeCrossMeasurement(q, 2, 12*time.Hour)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
+}
+
+func TestDevopsTopKHosts(t *testing.T) {
+	expectedHumanLabel := "Influx top 10 hosts by mean usage_user, random 1h0m0s"
+	expectedHumanDesc := "Influx top 10 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:16:22Z"
+	expectedQuery := "SELECT top(mean_usage_user, hostname, 10) from " +
+		"(SELECT mean(usage_user) as mean_usage_user from cpu " +
+		"where time >= '1970-01-01T00:16:22Z' and time < '1970-01-01T01:16:22Z' group by \"hostname\")"
+
+	v := url.Values{}
+	v.Set("q", expectedQuery)
+	expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(2 * time.Hour)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.TopKHosts(q, 10)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedPath)
+}
+
+func TestDevopsTagValuesAndSeriesCount(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fill               func(*Devops, query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc:               "tag values",
+			fill:               (*Devops).TagValues,
+			expectedHumanLabel: "Influx hostname tag values, random region",
+			expectedHumanDesc:  "Influx hostname tag values, random region: ap-southeast-1",
+			expectedQuery:      "SHOW TAG VALUES FROM \"cpu\" WITH KEY = \"hostname\" WHERE \"region\" = 'ap-southeast-1'",
+		},
+		{
+			desc:               "series count",
+			fill:               (*Devops).SeriesCount,
+			expectedHumanLabel: "Influx cpu series count, random region",
+			expectedHumanDesc:  "Influx cpu series count, random region: ap-southeast-1",
+			expectedQuery:      "SHOW SERIES EXACT CARDINALITY FROM \"cpu\" WHERE \"region\" = 'ap-southeast-1'",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{}
+			dq, err := b.NewDevops(s, s.Add(time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			c.fill(d, q)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
+
 func TestDevopsFillInQuery(t *testing.T) {
 	humanLabel := "this is my label"
 	humanDesc := "and now my description"
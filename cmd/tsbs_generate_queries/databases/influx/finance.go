@@ -0,0 +1,115 @@
+package influx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Finance produces Influx-specific queries for all the finance query types.
+type Finance struct {
+	*finance.Core
+	*BaseGenerator
+}
+
+// NewFinance makes a Finance object ready to generate Queries.
+func NewFinance(start, end time.Time, scale int, g *BaseGenerator) *Finance {
+	c, err := finance.NewCore(start, end, scale)
+	databases.PanicIfErr(err)
+	return &Finance{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+func (f *Finance) getSymbolWhereString(nSymbols int) string {
+	symbols, err := f.GetRandomSymbols(nSymbols)
+	databases.PanicIfErr(err)
+
+	symbolClauses := []string{}
+	for _, s := range symbols {
+		symbolClauses = append(symbolClauses, fmt.Sprintf("\"symbol\" = '%s'", s))
+	}
+	return "(" + strings.Join(symbolClauses, " or ") + ")"
+}
+
+// OHLCBars fetches the open, high, low and close prices and the traded volume
+// per minute of nSymbols symbols.
+func (f *Finance) OHLCBars(qi query.Query, nSymbols int) {
+	whereSymbols := f.getSymbolWhereString(nSymbols)
+	interval := f.MustRandWindow(finance.OHLCDuration)
+	influxql := fmt.Sprintf(`SELECT first("price") AS open, max("price") AS high, min("price") AS low, last("price") AS close, sum("size") AS volume
+		FROM "trades"
+		WHERE %s AND time >= '%s' AND time < '%s'
+		GROUP BY time(1m),"symbol"`,
+		whereSymbols,
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx OHLC bars per minute by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// VWAP fetches the hourly volume-weighted average price of nSymbols symbols.
+// InfluxQL cannot aggregate an expression, so the notional value of each
+// trade is computed in a subquery.
+func (f *Finance) VWAP(qi query.Query, nSymbols int) {
+	whereSymbols := f.getSymbolWhereString(nSymbols)
+	interval := f.MustRandWindow(finance.VWAPDuration)
+	start, end := interval.Start().Format(time.RFC3339), interval.End().Format(time.RFC3339)
+	influxql := fmt.Sprintf(`SELECT sum("notional") / sum("size") AS vwap, sum("size") AS volume
+		FROM (SELECT "price" * "size" AS "notional", "size"
+			FROM "trades"
+			WHERE %s AND time >= '%s' AND time < '%s'
+			GROUP BY "symbol")
+		WHERE time >= '%s' AND time < '%s'
+		GROUP BY time(1h),"symbol"`,
+		whereSymbols, start, end, start, end)
+
+	humanLabel := "Influx hourly VWAP by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// LastQuotePerSymbol finds the latest quote of all the symbols of an exchange.
+func (f *Finance) LastQuotePerSymbol(qi query.Query) {
+	influxql := fmt.Sprintf(`SELECT "bid_price", "bid_size", "ask_price", "ask_size"
+		FROM "quotes"
+		WHERE "exchange"='%s'
+		GROUP BY "symbol"
+		ORDER BY time DESC
+		LIMIT 1`,
+		f.GetRandomExchange())
+
+	humanLabel := "Influx last quote per symbol"
+	humanDesc := humanLabel
+
+	f.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// SpreadStats computes the minimum, average and maximum bid-ask spread of all
+// the symbols of an exchange in a time window.
+func (f *Finance) SpreadStats(qi query.Query) {
+	interval := f.MustRandWindow(finance.SpreadDuration)
+	influxql := fmt.Sprintf(`SELECT min("spread") AS min_spread, mean("spread") AS avg_spread, max("spread") AS max_spread
+		FROM (SELECT "ask_price" - "bid_price" AS "spread"
+			FROM "quotes"
+			WHERE "exchange"='%s' AND time >= '%s' AND time < '%s'
+			GROUP BY "symbol")
+		GROUP BY "symbol"`,
+		f.GetRandomExchange(),
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx spread statistics per symbol"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
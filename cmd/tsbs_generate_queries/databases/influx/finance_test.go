@@ -0,0 +1,170 @@
+package influx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFinanceOHLCBars(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc:    "zero symbols",
+			input:   0,
+			fail:    true,
+			failMsg: "number of symbols cannot be < 1; got 0",
+		},
+		{
+			desc:  "two symbols",
+			input: 2,
+
+			expectedHumanLabel: "Influx OHLC bars per minute by specific symbol",
+			expectedHumanDesc:  "Influx OHLC bars per minute by specific symbol: random    2 symbols, 1970-01-01T00:47:30Z",
+			expectedQuery: `SELECT first("price") AS open, max("price") AS high, min("price") AS low, last("price") AS close, sum("size") AS volume
+		FROM "trades"
+		WHERE ("symbol" = 'symbol_5' or "symbol" = 'symbol_9') AND time >= '1970-01-01T00:47:30Z' AND time < '1970-01-01T01:47:30Z'
+		GROUP BY time(1m),"symbol"`,
+		},
+	}
+
+	testFunc := func(f *Finance, c IoTTestCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.OHLCBars(q, c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runFinanceTestCases(t, testFunc, start, start.Add(2*time.Hour), cases)
+}
+
+func TestFinanceVWAP(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc:  "one symbol",
+			input: 1,
+
+			expectedHumanLabel: "Influx hourly VWAP by specific symbol",
+			expectedHumanDesc:  "Influx hourly VWAP by specific symbol: random    1 symbols, 1970-01-01T11:54:10Z",
+			expectedQuery: `SELECT sum("notional") / sum("size") AS vwap, sum("size") AS volume
+		FROM (SELECT "price" * "size" AS "notional", "size"
+			FROM "trades"
+			WHERE ("symbol" = 'symbol_5') AND time >= '1970-01-01T11:54:10Z' AND time < '1970-01-01T23:54:10Z'
+			GROUP BY "symbol")
+		WHERE time >= '1970-01-01T11:54:10Z' AND time < '1970-01-01T23:54:10Z'
+		GROUP BY time(1h),"symbol"`,
+		},
+	}
+
+	testFunc := func(f *Finance, c IoTTestCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.VWAP(q, c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runFinanceTestCases(t, testFunc, start, start.Add(24*time.Hour), cases)
+}
+
+func TestFinanceLastQuotePerSymbol(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "last quote",
+
+			expectedHumanLabel: "Influx last quote per symbol",
+			expectedHumanDesc:  "Influx last quote per symbol",
+			expectedQuery: `SELECT "bid_price", "bid_size", "ask_price", "ask_size"
+		FROM "quotes"
+		WHERE "exchange"='TSE'
+		GROUP BY "symbol"
+		ORDER BY time DESC
+		LIMIT 1`,
+		},
+	}
+
+	testFunc := func(f *Finance, c IoTTestCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.LastQuotePerSymbol(q)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runFinanceTestCases(t, testFunc, start, start.Add(time.Hour), cases)
+}
+
+func TestFinanceSpreadStats(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "spread stats",
+
+			expectedHumanLabel: "Influx spread statistics per symbol",
+			expectedHumanDesc:  "Influx spread statistics per symbol: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT min("spread") AS min_spread, mean("spread") AS avg_spread, max("spread") AS max_spread
+		FROM (SELECT "ask_price" - "bid_price" AS "spread"
+			FROM "quotes"
+			WHERE "exchange"='NASDAQ' AND time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+			GROUP BY "symbol")
+		GROUP BY "symbol"`,
+		},
+	}
+
+	testFunc := func(f *Finance, c IoTTestCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.SpreadStats(q)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runFinanceTestCases(t, testFunc, start, start.Add(24*time.Hour), cases)
+}
+
+func TestFluxFinanceUnsupported(t *testing.T) {
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	s := time.Unix(0, 0)
+	if _, err := b.NewFinance(s, s.Add(time.Hour), 10); err == nil {
+		t.Errorf("expected an error for finance Flux queries")
+	}
+}
+
+func runFinanceTestCases(t *testing.T, testFunc func(*Finance, IoTTestCase) query.Query, s time.Time, e time.Time, cases []IoTTestCase) {
+	rand.Seed(123) // Setting seed for testing purposes.
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			b := BaseGenerator{}
+			fq, err := b.NewFinance(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating finance generator")
+			}
+			f := fq.(*Finance)
+
+			if c.fail {
+				func() {
+					defer func() {
+						r := recover()
+						if r == nil {
+							t.Fatalf("did not panic when should")
+						}
+
+						if r != c.failMsg {
+							t.Fatalf("incorrect fail message: got %s, want %s", r, c.failMsg)
+						}
+					}()
+
+					testFunc(f, c)
+				}()
+			} else {
+				q := testFunc(f, c)
+
+				v := url.Values{}
+				v.Set("q", c.expectedQuery)
+				expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+				verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+			}
+		})
+	}
+}
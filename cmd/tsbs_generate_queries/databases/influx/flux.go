@@ -0,0 +1,57 @@
+package influx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// fluxLabel is the database name used in the labels of Flux queries.
+const fluxLabel = "Influx Flux"
+
+// fluxFrom returns the start of a Flux query reading the bucket from start
+// until end.
+func (g *BaseGenerator) fluxFrom(start, end time.Time) string {
+	return fmt.Sprintf("from(bucket: %q) |> range(start: %s, stop: %s)",
+		g.Bucket, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+}
+
+// fluxPipe joins the stages of a Flux query with the pipe-forward operator.
+func fluxPipe(stages ...string) string {
+	return strings.Join(stages, " |> ")
+}
+
+// fluxFilter returns a filter keeping the records matching all predicates.
+func fluxFilter(predicates ...string) string {
+	return fmt.Sprintf("filter(fn: (r) => %s)", strings.Join(predicates, " and "))
+}
+
+// fluxIn returns a predicate matching the records whose column equals one of
+// the values.
+func fluxIn(column string, values ...string) string {
+	clauses := make([]string, len(values))
+	for i, v := range values {
+		clauses[i] = fmt.Sprintf("r.%s == %q", column, v)
+	}
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return "(" + strings.Join(clauses, " or ") + ")"
+}
+
+// fluxGroup returns a regrouping of the records by the columns.
+func fluxGroup(columns ...string) string {
+	return fmt.Sprintf("group(columns: %s)", fluxColumns(columns))
+}
+
+// fluxColumns returns the columns as a Flux array of strings.
+func fluxColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = fmt.Sprintf("%q", c)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// fluxPivotFields turns the fields of a table into columns, one row per time.
+const fluxPivotFields = `pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`
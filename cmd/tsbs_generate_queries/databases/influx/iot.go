@@ -113,7 +113,7 @@ func (i *IoT) TrucksWithHighLoad(qi query.Query) {
 
 // StationaryTrucks finds all trucks that have low average velocity in a time window.
 func (i *IoT) StationaryTrucks(qi query.Query) {
-	interval := i.Interval.MustRandWindow(iot.StationaryDuration)
+	interval := i.MustRandWindow(iot.StationaryDuration)
 	influxql := fmt.Sprintf(`SELECT "name", "driver" 
 		FROM(SELECT mean("velocity") as mean_velocity 
 		 FROM "readings" 
@@ -134,7 +134,7 @@ func (i *IoT) StationaryTrucks(qi query.Query) {
 
 // TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
 func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
-	interval := i.Interval.MustRandWindow(iot.LongDrivingSessionDuration)
+	interval := i.MustRandWindow(iot.LongDrivingSessionDuration)
 	influxql := fmt.Sprintf(`SELECT "name","driver" 
 		FROM(SELECT count(*) AS ten_min 
 		 FROM(SELECT mean("velocity") AS mean_velocity 
@@ -158,7 +158,7 @@ func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
 
 // TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
 func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
-	interval := i.Interval.MustRandWindow(iot.DailyDrivingDuration)
+	interval := i.MustRandWindow(iot.DailyDrivingDuration)
 	influxql := fmt.Sprintf(`SELECT "name","driver" 
 		FROM(SELECT count(*) AS ten_min 
 		 FROM(SELECT mean("velocity") AS mean_velocity 
@@ -315,6 +315,25 @@ func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
 	i.fillInQuery(qi, humanLabel, humanDesc, influxql)
 }
 
+// GapfillByTruck returns the per minute average velocity of nTrucks in a time
+// window, carrying the previous value over the minutes without readings.
+func (i *IoT) GapfillByTruck(qi query.Query, nTrucks int) {
+	whereTrucks := i.getTruckWhereString(nTrucks)
+	interval := i.MustRandWindow(iot.GapfillDuration)
+	influxql := fmt.Sprintf(`SELECT mean("velocity") AS mean_velocity
+		FROM "readings"
+		WHERE %s AND time >= '%s' AND time < '%s'
+		GROUP BY time(1m),"name" fill(previous)`,
+		whereTrucks,
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx gap-filled velocity per minute by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks, %s", humanLabel, nTrucks, interval.StartString())
+
+	i.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
 // tenMinutePeriods calculates the number of 10 minute periods that can fit in
 // the time duration if we subtract the minutes specified by minutesPerHour value.
 // E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
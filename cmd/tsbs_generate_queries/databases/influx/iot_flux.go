@@ -0,0 +1,286 @@
+package influx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// FluxIoT produces Flux queries for all the iot query types. Queries
+// without a time window read the whole time range, as Flux requires one.
+// The numeric truck tags, such as load_capacity, are stored as fields, so
+// they are filtered and pivoted like the other fields they are used with.
+type FluxIoT struct {
+	*iot.Core
+	*BaseGenerator
+}
+
+func (i *FluxIoT) getTruckPredicate(nTrucks int) string {
+	names, err := i.GetRandomTrucks(nTrucks)
+	databases.PanicIfErr(err)
+	return fluxIn("name", names...)
+}
+
+// fromAll returns the start of a query over the whole time range.
+func (i *FluxIoT) fromAll() string {
+	return i.fluxFrom(i.Interval.Start(), i.Interval.End())
+}
+
+// LastLocByTruck finds the truck location for nTrucks.
+func (i *FluxIoT) LastLocByTruck(qi query.Query, nTrucks int) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "readings"`, fluxIn("_field", "latitude", "longitude"), i.getTruckPredicate(nTrucks)),
+		"last()",
+		fluxGroup("name", "driver"),
+		fluxPivotFields,
+	)
+
+	humanLabel := fluxLabel + " last location by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks", humanLabel, nTrucks)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// LastLocPerTruck finds all the truck locations along with truck and driver names.
+func (i *FluxIoT) LastLocPerTruck(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "readings"`, fluxIn("_field", "latitude", "longitude"), fluxIn("fleet", i.GetRandomFleet())),
+		"last()",
+		fluxGroup("name", "driver"),
+		fluxPivotFields,
+	)
+
+	humanLabel := fluxLabel + " last location per truck"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TrucksWithLowFuel finds all trucks with low fuel (less than 10%).
+func (i *FluxIoT) TrucksWithLowFuel(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "diagnostics"`, `r._field == "fuel_state"`, fluxIn("fleet", i.GetRandomFleet())),
+		"last()",
+		fluxFilter("r._value <= 0.1"),
+	)
+
+	humanLabel := fluxLabel + " trucks with low fuel"
+	humanDesc := fmt.Sprintf("%s: under 10 percent", humanLabel)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TrucksWithHighLoad finds all trucks that have load over 90%.
+func (i *FluxIoT) TrucksWithHighLoad(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "diagnostics"`, fluxIn("_field", "current_load", "load_capacity"), fluxIn("fleet", i.GetRandomFleet())),
+		"last()",
+		fluxPivotFields,
+		fluxFilter("r.current_load >= 0.9 * r.load_capacity"),
+	)
+
+	humanLabel := fluxLabel + " trucks with high load"
+	humanDesc := fmt.Sprintf("%s: over 90 percent", humanLabel)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// StationaryTrucks finds all trucks that have low average velocity in a time window.
+func (i *FluxIoT) StationaryTrucks(qi query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+	flux := fluxPipe(
+		i.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "readings"`, `r._field == "velocity"`, fluxIn("fleet", i.GetRandomFleet())),
+		fluxGroup("name", "driver"),
+		"mean()",
+		fluxFilter("r._value < 1.0"),
+	)
+
+	humanLabel := fluxLabel + " stationary trucks"
+	humanDesc := fmt.Sprintf("%s: with low avg velocity in last 10 minutes", humanLabel)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
+func (i *FluxIoT) TrucksWithLongDrivingSessions(qi query.Query) {
+	interval := i.MustRandWindow(iot.LongDrivingSessionDuration)
+	flux := i.getDrivingSessionsQuery(interval.Start(), interval.End(),
+		// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+		tenMinutePeriods(5, iot.LongDrivingSessionDuration))
+
+	humanLabel := fluxLabel + " trucks with longer driving sessions"
+	humanDesc := fmt.Sprintf("%s: stopped less than 20 mins in 4 hour period", humanLabel)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
+func (i *FluxIoT) TrucksWithLongDailySessions(qi query.Query) {
+	interval := i.MustRandWindow(iot.DailyDrivingDuration)
+	flux := i.getDrivingSessionsQuery(interval.Start(), interval.End(),
+		// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+		tenMinutePeriods(35, iot.DailyDrivingDuration))
+
+	humanLabel := fluxLabel + " trucks with longer daily sessions"
+	humanDesc := fmt.Sprintf("%s: drove more than 10 hours in the last 24 hours", humanLabel)
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// getDrivingSessionsQuery returns a query finding the trucks of a random
+// fleet which were driving for more than periods 10 minute periods.
+func (i *FluxIoT) getDrivingSessionsQuery(start, end time.Time, periods int) string {
+	return fluxPipe(
+		i.fluxFrom(start, end),
+		fluxFilter(`r._measurement == "readings"`, `r._field == "velocity"`, fluxIn("fleet", i.GetRandomFleet())),
+		fluxGroup("name", "driver"),
+		"aggregateWindow(every: 10m, fn: mean, createEmpty: false)",
+		fluxFilter("r._value > 1.0"),
+		"count()",
+		fluxFilter(fmt.Sprintf("r._value > %d", periods)),
+	)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel consumption per fleet.
+func (i *FluxIoT) AvgVsProjectedFuelConsumption(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "readings"`, fluxIn("_field", "velocity", "fuel_consumption", "nominal_fuel_consumption")),
+		fluxPivotFields,
+		fluxFilter("r.velocity > 1.0"),
+		fluxGroup("fleet"),
+		"reduce(identity: {count: 0.0, fuel: 0.0, nominal: 0.0}, fn: (r, accumulator) => "+
+			"({count: accumulator.count + 1.0, fuel: accumulator.fuel + r.fuel_consumption, "+
+			"nominal: accumulator.nominal + r.nominal_fuel_consumption}))",
+		"map(fn: (r) => ({fleet: r.fleet, mean_fuel_consumption: r.fuel / r.count, nominal_fuel_consumption: r.nominal / r.count}))",
+	)
+
+	humanLabel := fluxLabel + " average vs projected fuel consumption per fleet"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver.
+func (i *FluxIoT) AvgDailyDrivingDuration(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "readings"`, `r._field == "velocity"`),
+		fluxGroup("fleet", "name", "driver"),
+		"aggregateWindow(every: 10m, fn: mean, createEmpty: false)",
+		"aggregateWindow(every: 1d, fn: count, createEmpty: false)",
+		"map(fn: (r) => ({r with _value: float(v: r._value) / 6.0}))",
+	)
+
+	humanLabel := fluxLabel + " average driver driving duration per day"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// AvgDailyDrivingSession finds the average driving session without stopping per driver per day.
+// The 10 minute periods are marked as driving or not, and the time elapsed
+// between the start and the end of each session is averaged per day.
+func (i *FluxIoT) AvgDailyDrivingSession(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "readings"`, `r._field == "velocity"`, `r.name != ""`),
+		fluxGroup("name"),
+		"aggregateWindow(every: 10m, fn: mean, createEmpty: false)",
+		"map(fn: (r) => ({r with _value: if r._value > 1.0 then 1 else 0}))",
+		"difference()",
+		fluxFilter("r._value != 0"),
+		"elapsed(unit: 1m)",
+		fluxFilter("r._value == -1"),
+		`aggregateWindow(every: 1d, column: "elapsed", fn: mean, createEmpty: false)`,
+	)
+
+	humanLabel := fluxLabel + " average driver driving session without stopping per day"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+func (i *FluxIoT) AvgLoad(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "diagnostics"`, fluxIn("_field", "current_load", "load_capacity")),
+		fluxPivotFields,
+		"map(fn: (r) => ({r with _value: r.current_load / r.load_capacity}))",
+		fluxGroup("fleet", "model"),
+		"mean()",
+	)
+
+	humanLabel := fluxLabel + " average load per truck model per fleet"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// DailyTruckActivity returns the number of hours trucks has been active (not out-of-commission) per day per fleet per model.
+func (i *FluxIoT) DailyTruckActivity(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "diagnostics"`, `r._field == "status"`),
+		fluxGroup("fleet", "model"),
+		"aggregateWindow(every: 10m, fn: mean, createEmpty: false)",
+		fluxFilter("r._value < 1.0"),
+		"aggregateWindow(every: 1d, fn: count, createEmpty: false)",
+		"map(fn: (r) => ({r with _value: float(v: r._value) / 144.0}))",
+	)
+
+	humanLabel := fluxLabel + " daily truck activity per fleet per model"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// TruckBreakdownFrequency calculates the amount of times a truck model broke down in the last period.
+// A model is broken down in a 10 minute period when at least half of its
+// statuses are not zero.
+func (i *FluxIoT) TruckBreakdownFrequency(qi query.Query) {
+	flux := fluxPipe(
+		i.fromAll(),
+		fluxFilter(`r._measurement == "diagnostics"`, `r._field == "status"`),
+		"map(fn: (r) => ({r with _value: if r._value != 0.0 then 1.0 else 0.0}))",
+		fluxGroup("model"),
+		"aggregateWindow(every: 10m, fn: mean, createEmpty: false)",
+		"map(fn: (r) => ({r with _value: if r._value >= 0.5 then 1 else 0}))",
+		"difference()",
+		fluxFilter("r._value == 1"),
+		"count()",
+	)
+
+	humanLabel := fluxLabel + " truck breakdown frequency per model"
+	humanDesc := humanLabel
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
+
+// GapfillByTruck returns the per minute average velocity of nTrucks in a time
+// window, carrying the previous value over the minutes without readings.
+func (i *FluxIoT) GapfillByTruck(qi query.Query, nTrucks int) {
+	truckPredicate := i.getTruckPredicate(nTrucks)
+	interval := i.MustRandWindow(iot.GapfillDuration)
+	flux := fluxPipe(
+		i.fluxFrom(interval.Start(), interval.End()),
+		fluxFilter(`r._measurement == "readings"`, `r._field == "velocity"`, truckPredicate),
+		fluxGroup("name"),
+		"aggregateWindow(every: 1m, fn: mean)",
+		"fill(usePrevious: true)",
+	)
+
+	humanLabel := fluxLabel + " gap-filled velocity per minute by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks, %s", humanLabel, nTrucks, interval.StartString())
+
+	i.fillInFluxQuery(qi, humanLabel, humanDesc, flux)
+}
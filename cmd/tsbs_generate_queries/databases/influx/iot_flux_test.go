@@ -0,0 +1,172 @@
+package influx
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+	"time"
+
+	iotdata "github.com/timescale/tsbs/pkg/data/usecases/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFluxIoTQueries(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fn                 func(i *FluxIoT, q query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc: "LastLocByTruck",
+			fn: func(i *FluxIoT, q query.Query) {
+				i.LastLocByTruck(q, 2)
+			},
+			expectedHumanLabel: "Influx Flux last location by specific truck",
+			expectedHumanDesc:  "Influx Flux last location by specific truck: random    2 trucks",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T00:00:00Z, stop: 1970-01-02T00:00:00Z) |> ` +
+				`filter(fn: (r) => r._measurement == "readings" and (r._field == "latitude" or r._field == "longitude") and (r.name == "truck_5" or r.name == "truck_9")) |> ` +
+				`last() |> group(columns: ["name", "driver"]) |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`,
+		},
+		{
+			desc: "TrucksWithHighLoad",
+			fn: func(i *FluxIoT, q query.Query) {
+				i.TrucksWithHighLoad(q)
+			},
+			expectedHumanLabel: "Influx Flux trucks with high load",
+			expectedHumanDesc:  "Influx Flux trucks with high load: over 90 percent",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T00:00:00Z, stop: 1970-01-02T00:00:00Z) |> ` +
+				`filter(fn: (r) => r._measurement == "diagnostics" and (r._field == "current_load" or r._field == "load_capacity") and r.fleet == "South") |> ` +
+				`last() |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value") |> ` +
+				`filter(fn: (r) => r.current_load >= 0.9 * r.load_capacity)`,
+		},
+		{
+			desc: "TrucksWithLongDrivingSessions",
+			fn: func(i *FluxIoT, q query.Query) {
+				i.TrucksWithLongDrivingSessions(q)
+			},
+			expectedHumanLabel: "Influx Flux trucks with longer driving sessions",
+			expectedHumanDesc:  "Influx Flux trucks with longer driving sessions: stopped less than 20 mins in 4 hour period",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T02:16:22Z, stop: 1970-01-01T06:16:22Z) |> ` +
+				`filter(fn: (r) => r._measurement == "readings" and r._field == "velocity" and r.fleet == "West") |> ` +
+				`group(columns: ["name", "driver"]) |> aggregateWindow(every: 10m, fn: mean, createEmpty: false) |> ` +
+				`filter(fn: (r) => r._value > 1.0) |> count() |> filter(fn: (r) => r._value > 22)`,
+		},
+		{
+			desc: "AvgDailyDrivingSession",
+			fn: func(i *FluxIoT, q query.Query) {
+				i.AvgDailyDrivingSession(q)
+			},
+			expectedHumanLabel: "Influx Flux average driver driving session without stopping per day",
+			expectedHumanDesc:  "Influx Flux average driver driving session without stopping per day",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T00:00:00Z, stop: 1970-01-02T00:00:00Z) |> ` +
+				`filter(fn: (r) => r._measurement == "readings" and r._field == "velocity" and r.name != "") |> ` +
+				`group(columns: ["name"]) |> aggregateWindow(every: 10m, fn: mean, createEmpty: false) |> ` +
+				`map(fn: (r) => ({r with _value: if r._value > 1.0 then 1 else 0})) |> difference() |> filter(fn: (r) => r._value != 0) |> ` +
+				`elapsed(unit: 1m) |> filter(fn: (r) => r._value == -1) |> ` +
+				`aggregateWindow(every: 1d, column: "elapsed", fn: mean, createEmpty: false)`,
+		},
+		{
+			desc: "GapfillByTruck",
+			fn: func(i *FluxIoT, q query.Query) {
+				i.GapfillByTruck(q, 1)
+			},
+			expectedHumanLabel: "Influx Flux gap-filled velocity per minute by specific truck",
+			expectedHumanDesc:  "Influx Flux gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T04:54:10Z",
+			expectedQuery: `from(bucket: "benchmark") |> range(start: 1970-01-01T04:54:10Z, stop: 1970-01-01T05:54:10Z) |> ` +
+				`filter(fn: (r) => r._measurement == "readings" and r._field == "velocity" and r.name == "truck_5") |> ` +
+				`group(columns: ["name"]) |> aggregateWindow(every: 1m, fn: mean) |> fill(usePrevious: true)`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+			iq, err := b.NewIoT(s, s.Add(24*time.Hour), testScale)
+			if err != nil {
+				t.Fatalf("Error while creating iot generator")
+			}
+			i := iq.(*FluxIoT)
+
+			q := i.GenerateEmptyQuery()
+			c.fn(i, q)
+
+			verifyFluxQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+		})
+	}
+}
+
+// TestFluxIoTFields checks the Flux iot queries only filter fields the trucks
+// write, and only read the fields they filter. The numeric truck tags are
+// written as fields by the line protocol serializer.
+func TestFluxIoTFields(t *testing.T) {
+	s := time.Unix(0, 0)
+	sc := &iotdata.SimulatorConfig{
+		Start:                s,
+		End:                  s.Add(time.Hour),
+		InitGeneratorScale:   1,
+		GeneratorScale:       1,
+		GeneratorConstructor: iotdata.NewTruck,
+	}
+	headers := sc.NewSimulator(time.Minute, 0).Headers()
+	truckFields := map[string]bool{}
+	for _, fields := range headers.FieldKeys {
+		for _, f := range fields {
+			truckFields[f] = true
+		}
+	}
+	for i, tag := range headers.TagKeys {
+		if headers.TagTypes[i] != "string" {
+			truckFields[tag] = true
+		}
+	}
+	if !truckFields["load_capacity"] || !truckFields["nominal_fuel_consumption"] {
+		t.Fatalf("numeric truck tags missing from the truck fields: %v", truckFields)
+	}
+
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	iq, err := b.NewIoT(s, s.Add(48*time.Hour), testScale)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	i := iq.(*FluxIoT)
+	fills := map[string]func(query.Query){
+		"LastLocByTruck":                func(q query.Query) { i.LastLocByTruck(q, 1) },
+		"LastLocPerTruck":               i.LastLocPerTruck,
+		"TrucksWithLowFuel":             i.TrucksWithLowFuel,
+		"TrucksWithHighLoad":            i.TrucksWithHighLoad,
+		"StationaryTrucks":              i.StationaryTrucks,
+		"TrucksWithLongDrivingSessions": i.TrucksWithLongDrivingSessions,
+		"TrucksWithLongDailySessions":   i.TrucksWithLongDailySessions,
+		"AvgVsProjectedFuelConsumption": i.AvgVsProjectedFuelConsumption,
+		"AvgDailyDrivingDuration":       i.AvgDailyDrivingDuration,
+		"AvgDailyDrivingSession":        i.AvgDailyDrivingSession,
+		"AvgLoad":                       i.AvgLoad,
+		"DailyTruckActivity":            i.DailyTruckActivity,
+		"TruckBreakdownFrequency":       i.TruckBreakdownFrequency,
+		"GapfillByTruck":                func(q query.Query) { i.GapfillByTruck(q, 1) },
+	}
+	filteredRe := regexp.MustCompile(`r\._field == "([a-z_]+)"`)
+	columnRe := regexp.MustCompile(`\br\.([a-z][a-z_]*)`)
+	for name, fill := range fills {
+		rand.Seed(123) // Setting seed for testing purposes.
+		q := i.GenerateEmptyQuery()
+		fill(q)
+		flux := string(q.(*query.HTTP).RawQuery)
+		filtered := map[string]bool{}
+		for _, m := range filteredRe.FindAllStringSubmatch(flux, -1) {
+			if !truckFields[m[1]] {
+				t.Errorf("%s: query filters field %s, which the trucks do not write", name, m[1])
+			}
+			filtered[m[1]] = true
+		}
+		for _, m := range columnRe.FindAllStringSubmatch(flux, -1) {
+			if truckFields[m[1]] && !filtered[m[1]] {
+				t.Errorf("%s: query reads field %s without filtering it", name, m[1])
+			}
+		}
+	}
+}
@@ -471,6 +471,37 @@ func TestTruckBreakdownFrequency(t *testing.T) {
 	}
 }
 
+func TestGapfillByTruck(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc:    "zero trucks",
+			input:   0,
+			fail:    true,
+			failMsg: "number of trucks cannot be < 1; got 0",
+		},
+		{
+			desc:  "one truck",
+			input: 1,
+
+			expectedHumanLabel: "Influx gap-filled velocity per minute by specific truck",
+			expectedHumanDesc:  "Influx gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T00:54:10Z",
+			expectedQuery: `SELECT mean("velocity") AS mean_velocity
+		FROM "readings"
+		WHERE ("name" = 'truck_5') AND time >= '1970-01-01T00:54:10Z' AND time < '1970-01-01T01:54:10Z'
+		GROUP BY time(1m),"name" fill(previous)`,
+		},
+	}
+
+	testFunc := func(i *IoT, c IoTTestCase) query.Query {
+		q := i.GenerateEmptyQuery()
+		i.GapfillByTruck(q, c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	runIoTTestCases(t, testFunc, start, start.Add(2*time.Hour), cases)
+}
+
 func TestTenMinutePeriods(t *testing.T) {
 	cases := []struct {
 		minutesPerHour float64
@@ -0,0 +1,90 @@
+package influx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// K8s produces Influx-specific queries for all the k8s query types.
+type K8s struct {
+	*k8s.Core
+	*BaseGenerator
+}
+
+// NewK8s makes a K8s object ready to generate Queries.
+func NewK8s(start, end time.Time, scale int, g *BaseGenerator) *K8s {
+	c, err := k8s.NewCore(start, end, scale)
+	databases.PanicIfErr(err)
+	return &K8s{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// NamespaceCPU fetches the CPU usage of every namespace per minute. The usage
+// is averaged per container in a subquery first, as there can be several
+// readings of a container in a minute.
+func (k *K8s) NamespaceCPU(qi query.Query) {
+	interval := k.MustRandWindow(k8s.NamespaceCPUDuration)
+	start, end := interval.Start().Format(time.RFC3339), interval.End().Format(time.RFC3339)
+	influxql := fmt.Sprintf(`SELECT sum("cpu_usage") AS cpu_usage
+		FROM (SELECT mean("cpu_usage") AS "cpu_usage"
+			FROM "container"
+			WHERE time >= '%s' AND time < '%s'
+			GROUP BY time(1m),"namespace","pod","container")
+		WHERE time >= '%s' AND time < '%s'
+		GROUP BY time(1m),"namespace"`,
+		start, end, start, end)
+
+	humanLabel := "Influx CPU usage per namespace per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// TopPods finds the k pods with the highest average CPU usage, adding up the
+// usage of their containers.
+func (k *K8s) TopPods(qi query.Query, topK int) {
+	interval := k.MustRandWindow(k8s.TopPodsDuration)
+	start, end := interval.Start().Format(time.RFC3339), interval.End().Format(time.RFC3339)
+	influxql := fmt.Sprintf(`SELECT top("cpu_usage", "namespace", "pod", %d) AS cpu_usage
+		FROM (SELECT sum("cpu_usage") AS "cpu_usage"
+			FROM (SELECT mean("cpu_usage") AS "cpu_usage"
+				FROM "container"
+				WHERE time >= '%s' AND time < '%s'
+				GROUP BY "namespace","pod","container")
+			WHERE time >= '%s' AND time < '%s'
+			GROUP BY "namespace","pod")
+		WHERE time >= '%s' AND time < '%s'`,
+		topK, start, end, start, end, start, end)
+
+	humanLabel := fmt.Sprintf("Influx top %d pods by CPU usage", topK)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// Restarts finds the containers of a random namespace which restarted, with
+// their number of restarts.
+func (k *K8s) Restarts(qi query.Query) {
+	interval := k.MustRandWindow(k8s.RestartsDuration)
+	influxql := fmt.Sprintf(`SELECT "restarts"
+		FROM (SELECT spread("restarts") AS "restarts"
+			FROM "container"
+			WHERE "namespace"='%s' AND time >= '%s' AND time < '%s'
+			GROUP BY "pod","container")
+		WHERE "restarts" > 0
+		GROUP BY "pod","container"`,
+		k.GetRandomNamespace(),
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx container restarts in a namespace"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
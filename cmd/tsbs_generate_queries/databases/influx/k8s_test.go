@@ -0,0 +1,123 @@
+package influx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestK8sNamespaceCPU(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "namespace cpu",
+
+			expectedHumanLabel: "Influx CPU usage per namespace per minute",
+			expectedHumanDesc:  "Influx CPU usage per namespace per minute: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT sum("cpu_usage") AS cpu_usage
+		FROM (SELECT mean("cpu_usage") AS "cpu_usage"
+			FROM "container"
+			WHERE time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+			GROUP BY time(1m),"namespace","pod","container")
+		WHERE time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+		GROUP BY time(1m),"namespace"`,
+		},
+	}
+
+	testFunc := func(k *K8s, c IoTTestCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.NamespaceCPU(q)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func TestK8sTopPods(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc:  "top 10",
+			input: 10,
+
+			expectedHumanLabel: "Influx top 10 pods by CPU usage",
+			expectedHumanDesc:  "Influx top 10 pods by CPU usage: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT top("cpu_usage", "namespace", "pod", 10) AS cpu_usage
+		FROM (SELECT sum("cpu_usage") AS "cpu_usage"
+			FROM (SELECT mean("cpu_usage") AS "cpu_usage"
+				FROM "container"
+				WHERE time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+				GROUP BY "namespace","pod","container")
+			WHERE time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+			GROUP BY "namespace","pod")
+		WHERE time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'`,
+		},
+	}
+
+	testFunc := func(k *K8s, c IoTTestCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.TopPods(q, c.input)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func TestK8sRestarts(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "restarts",
+
+			expectedHumanLabel: "Influx container restarts in a namespace",
+			expectedHumanDesc:  "Influx container restarts in a namespace: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT "restarts"
+		FROM (SELECT spread("restarts") AS "restarts"
+			FROM "container"
+			WHERE "namespace"='kube-system' AND time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+			GROUP BY "pod","container")
+		WHERE "restarts" > 0
+		GROUP BY "pod","container"`,
+		},
+	}
+
+	testFunc := func(k *K8s, c IoTTestCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.Restarts(q)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func TestFluxK8sUnsupported(t *testing.T) {
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	s := time.Unix(0, 0)
+	if _, err := b.NewK8s(s, s.Add(time.Hour), 10); err == nil {
+		t.Errorf("expected an error for k8s Flux queries")
+	}
+}
+
+func runK8sTestCases(t *testing.T, testFunc func(*K8s, IoTTestCase) query.Query, cases []IoTTestCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			kq, err := b.NewK8s(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating k8s generator")
+			}
+			q := testFunc(kq.(*K8s), c)
+
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
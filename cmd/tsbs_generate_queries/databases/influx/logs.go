@@ -0,0 +1,64 @@
+package influx
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Logs produces Influx-specific queries for all the logs query types.
+type Logs struct {
+	*logs.Core
+	*BaseGenerator
+}
+
+// NewLogs makes a Logs object ready to generate Queries.
+func NewLogs(start, end time.Time, scale int, g *BaseGenerator) *Logs {
+	c, err := logs.NewCore(start, end, scale)
+	databases.PanicIfErr(err)
+	return &Logs{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// ErrorsPerService counts the error events of every service per minute.
+func (l *Logs) ErrorsPerService(qi query.Query) {
+	interval := l.MustRandWindow(logs.ErrorsPerServiceDuration)
+	influxql := fmt.Sprintf(`SELECT count("severity") AS errors
+		FROM "logs"
+		WHERE "severity" = '%s' AND time >= '%s' AND time < '%s'
+		GROUP BY time(1m),"service"`,
+		logs.ErrorSeverity,
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx errors per service per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// MessageSearch finds the latest events whose message contains a random
+// search term, matched with a regular expression.
+func (l *Logs) MessageSearch(qi query.Query) {
+	interval := l.MustRandWindow(logs.MessageSearchDuration)
+	influxql := fmt.Sprintf(`SELECT "service", "severity", "trace_id", "message"
+		FROM "logs"
+		WHERE "message" =~ /%s/ AND time >= '%s' AND time < '%s'
+		ORDER BY time DESC
+		LIMIT %d`,
+		regexp.QuoteMeta(l.GetRandomSearchTerm()),
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339),
+		logs.MessageSearchLimit)
+
+	humanLabel := "Influx latest events with a message containing a term"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
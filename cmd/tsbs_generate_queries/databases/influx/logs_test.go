@@ -0,0 +1,89 @@
+package influx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestLogsErrorsPerService(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "errors per service",
+
+			expectedHumanLabel: "Influx errors per service per minute",
+			expectedHumanDesc:  "Influx errors per service per minute: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT count("severity") AS errors
+		FROM "logs"
+		WHERE "severity" = 'ERROR' AND time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+		GROUP BY time(1m),"service"`,
+		},
+	}
+
+	testFunc := func(l *Logs, c IoTTestCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.ErrorsPerService(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func TestLogsMessageSearch(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "message search",
+
+			expectedHumanLabel: "Influx latest events with a message containing a term",
+			expectedHumanDesc:  "Influx latest events with a message containing a term: 1970-01-01T20:16:22Z",
+			expectedQuery: `SELECT "service", "severity", "trace_id", "message"
+		FROM "logs"
+		WHERE "message" =~ /insufficient funds/ AND time >= '1970-01-01T20:16:22Z' AND time < '1970-01-01T21:16:22Z'
+		ORDER BY time DESC
+		LIMIT 100`,
+		},
+	}
+
+	testFunc := func(l *Logs, c IoTTestCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.MessageSearch(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func TestFluxLogsUnsupported(t *testing.T) {
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	s := time.Unix(0, 0)
+	if _, err := b.NewLogs(s, s.Add(time.Hour), 10); err == nil {
+		t.Errorf("expected an error for logs Flux queries")
+	}
+}
+
+func runLogsTestCases(t *testing.T, testFunc func(*Logs, IoTTestCase) query.Query, cases []IoTTestCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			lq, err := b.NewLogs(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating logs generator")
+			}
+			q := testFunc(lq.(*Logs), c)
+
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package influx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SmartMeter produces Influx-specific queries for all the smart-meter query
+// types.
+type SmartMeter struct {
+	*smartmeter.Core
+	*BaseGenerator
+}
+
+// NewSmartMeter makes a SmartMeter object ready to generate Queries.
+func NewSmartMeter(start, end time.Time, scale int, g *BaseGenerator) *SmartMeter {
+	c, err := smartmeter.NewCore(start, end, scale)
+	databases.PanicIfErr(err)
+	return &SmartMeter{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// DailyConsumption sums the energy consumed in every region per day.
+func (s *SmartMeter) DailyConsumption(qi query.Query) {
+	interval := s.GetRandomDays(smartmeter.DailyConsumptionDays)
+	influxql := fmt.Sprintf(`SELECT sum("consumption_kwh") AS consumption_kwh
+		FROM "energy"
+		WHERE time >= '%s' AND time < '%s'
+		GROUP BY time(1d),"region"`,
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339))
+
+	humanLabel := "Influx daily consumption per region"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
+
+// PeakHourLoad sums the energy consumed in every region per hour during the
+// evening peak hours of a random day. Readings are stamped at the start of
+// the interval they cover, so the 15-minute and hourly readings of an hour
+// add up to its consumption in kWh, which is the average load in kW.
+// On-demand readings may span several hours and are left out.
+func (s *SmartMeter) PeakHourLoad(qi query.Query) {
+	interval := s.GetRandomPeakHours()
+	influxql := fmt.Sprintf(`SELECT sum("consumption_kwh") AS load_kw
+		FROM "energy"
+		WHERE time >= '%s' AND time < '%s' AND "cadence" != '%s'
+		GROUP BY time(1h),"region"`,
+		interval.Start().Format(time.RFC3339),
+		interval.End().Format(time.RFC3339),
+		smartmeter.OnDemandCadence)
+
+	humanLabel := "Influx hourly load per region during peak hours"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, influxql)
+}
@@ -0,0 +1,88 @@
+package influx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestSmartMeterDailyConsumption(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "daily consumption",
+
+			expectedHumanLabel: "Influx daily consumption per region",
+			expectedHumanDesc:  "Influx daily consumption per region: 1970-01-02T00:00:00Z",
+			expectedQuery: `SELECT sum("consumption_kwh") AS consumption_kwh
+		FROM "energy"
+		WHERE time >= '1970-01-02T00:00:00Z' AND time < '1970-01-04T00:00:00Z'
+		GROUP BY time(1d),"region"`,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c IoTTestCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.DailyConsumption(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func TestSmartMeterPeakHourLoad(t *testing.T) {
+	cases := []IoTTestCase{
+		{
+			desc: "peak hour load",
+
+			expectedHumanLabel: "Influx hourly load per region during peak hours",
+			expectedHumanDesc:  "Influx hourly load per region during peak hours: 1970-01-01T17:00:00Z",
+			expectedQuery: `SELECT sum("consumption_kwh") AS load_kw
+		FROM "energy"
+		WHERE time >= '1970-01-01T17:00:00Z' AND time < '1970-01-01T21:00:00Z' AND "cadence" != 'on-demand'
+		GROUP BY time(1h),"region"`,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c IoTTestCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.PeakHourLoad(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func TestFluxSmartMeterUnsupported(t *testing.T) {
+	b := BaseGenerator{UseFlux: true, Bucket: "benchmark"}
+	s := time.Unix(0, 0)
+	if _, err := b.NewSmartMeter(s, s.Add(96*time.Hour), 10); err == nil {
+		t.Errorf("expected an error for smart-meter Flux queries")
+	}
+}
+
+func runSmartMeterTestCases(t *testing.T, testFunc func(*SmartMeter, IoTTestCase) query.Query, cases []IoTTestCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(96 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			sq, err := b.NewSmartMeter(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating smart-meter generator")
+			}
+			q := testFunc(sq.(*SmartMeter), c)
+
+			v := url.Values{}
+			v.Set("q", c.expectedQuery)
+			expectedPath := fmt.Sprintf("/query?%s", v.Encode())
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, expectedPath)
+		})
+	}
+}
@@ -35,7 +35,7 @@ type NaiveDevops struct {
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY minute ORDER BY minute ASC
 func (d *NaiveDevops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	interval := d.MustRandWindow(timeRange)
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
@@ -98,7 +98,7 @@ func (d *NaiveDevops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRa
 // WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour, hostname ORDER BY hour, hostname
 func (d *NaiveDevops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(devops.DoubleGroupByDuration)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	bucketNano := time.Hour.Nanoseconds()
@@ -159,3 +159,73 @@ func (d *NaiveDevops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 	q.CollectionName = []byte("point_data")
 	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, interval.StartString(), q.CollectionName))
 }
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in pseudo-SQL:
+//
+// SELECT hostname, avg(usage_user) AS mean_usage_user
+// FROM cpu
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname ORDER BY mean_usage_user DESC LIMIT k
+func (d *NaiveDevops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	pipelineQuery := []bson.M{
+		{
+			"$match": bson.M{
+				"measurement": "cpu",
+				"timestamp_ns": bson.M{
+					"$gte": interval.StartUnixNano(),
+					"$lt":  interval.EndUnixNano(),
+				},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":             "$tags.hostname",
+				"mean_usage_user": bson.M{"$avg": "$fields.usage_user"},
+			},
+		},
+		{"$sort": bson.M{"mean_usage_user": -1}},
+		{"$limit": k},
+	}
+
+	humanLabel := devops.GetTopKHostsLabel("Mongo [NAIVE]", k)
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, interval.StartString(), q.CollectionName))
+}
+
+// TagValues lists the hostnames of a random region,
+// e.g. in pseudo-SQL:
+//
+// SELECT DISTINCT hostname FROM cpu WHERE region = '$REGION' ORDER BY hostname
+func (d *NaiveDevops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+	pipelineQuery := tagValuesPipeline(region)
+
+	humanLabel := devops.GetTagValuesLabel("Mongo [NAIVE]")
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, region, q.CollectionName))
+}
+
+// SeriesCount counts the cpu series of a random region, one per host,
+// e.g. in pseudo-SQL:
+//
+// SELECT count(DISTINCT hostname) FROM cpu WHERE region = '$REGION'
+func (d *NaiveDevops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+	pipelineQuery := seriesCountPipeline(region)
+
+	humanLabel := devops.GetSeriesCountLabel("Mongo [NAIVE]")
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, region, q.CollectionName))
+}
@@ -99,7 +99,7 @@ func getTimeFilterDocs(interval *utils.TimeInterval) []interface{} {
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY minute ORDER BY minute ASC
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	interval := d.MustRandWindow(timeRange)
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
@@ -169,7 +169,7 @@ func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange t
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour ORDER BY hour
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
-	interval := d.Interval.MustRandWindow(duration)
+	interval := d.MustRandWindow(duration)
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
 	docs := getTimeFilterDocs(interval)
@@ -238,7 +238,7 @@ func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
 // WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour, hostname ORDER BY hour, hostname
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(devops.DoubleGroupByDuration)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	docs := getTimeFilterDocs(interval)
@@ -319,7 +319,7 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // AND time >= '$TIME_START' AND time < '$TIME_END'
 // AND (hostname = '$HOST' OR hostname = '$HOST2'...)
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	interval := d.MustRandWindow(devops.HighCPUDuration)
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
 	docs := getTimeFilterDocs(interval)
@@ -452,7 +452,7 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // GROUP BY t ORDER BY t DESC
 // LIMIT $LIMIT
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	interval := d.MustRandWindow(time.Hour)
 	interval, err := utils.NewTimeInterval(d.Interval.Start(), interval.End())
 	if err != nil {
 		panic(err.Error())
@@ -508,3 +508,104 @@ func (d *Devops) GroupByOrderByLimit(qi query.Query) {
 	q.CollectionName = []byte("point_data")
 	q.HumanDescription = []byte(fmt.Sprintf("%s: %s", humanLabel, interval.EndString()))
 }
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in pseudo-SQL:
+//
+// SELECT hostname, avg(usage_user) AS mean_usage_user
+// FROM cpu
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname ORDER BY mean_usage_user DESC LIMIT k
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	docs := getTimeFilterDocs(interval)
+
+	pipelineQuery := []bson.M{
+		{
+			"$match": bson.M{
+				"measurement": "cpu",
+				"key_id": bson.M{
+					"$in": docs,
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":    0,
+				"events": 1,
+				"key_id": 1,
+				"tags":   "$tags.hostname",
+			},
+		},
+	}
+	pipelineQuery = append(pipelineQuery, getTimeFilterPipeline(interval)...)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id":             "$tags",
+				"mean_usage_user": bson.M{"$avg": "$events.usage_user"},
+			},
+		},
+		{"$sort": bson.M{"mean_usage_user": -1}},
+		{"$limit": k},
+	}...)
+
+	humanLabel := devops.GetTopKHostsLabel("Mongo", k)
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, interval.StartString(), q.CollectionName))
+}
+
+// tagValuesPipeline lists the hostnames of the cpu documents of the region.
+// Both document formats keep the tags of a point under tags.
+func tagValuesPipeline(region string) []bson.M {
+	return []bson.M{
+		{"$match": bson.M{"measurement": "cpu", "tags.region": region}},
+		{"$group": bson.M{"_id": "$tags.hostname"}},
+		{"$sort": bson.M{"_id": 1}},
+	}
+}
+
+// seriesCountPipeline counts the hostnames of the cpu documents of the region.
+func seriesCountPipeline(region string) []bson.M {
+	return []bson.M{
+		{"$match": bson.M{"measurement": "cpu", "tags.region": region}},
+		{"$group": bson.M{"_id": "$tags.hostname"}},
+		{"$count": "series"},
+	}
+}
+
+// TagValues lists the hostnames of a random region,
+// e.g. in pseudo-SQL:
+//
+// SELECT DISTINCT hostname FROM cpu WHERE region = '$REGION' ORDER BY hostname
+func (d *Devops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+	pipelineQuery := tagValuesPipeline(region)
+
+	humanLabel := devops.GetTagValuesLabel("Mongo")
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, region, q.CollectionName))
+}
+
+// SeriesCount counts the cpu series of a random region, one per host,
+// e.g. in pseudo-SQL:
+//
+// SELECT count(DISTINCT hostname) FROM cpu WHERE region = '$REGION'
+func (d *Devops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+	pipelineQuery := seriesCountPipeline(region)
+
+	humanLabel := devops.GetSeriesCountLabel("Mongo")
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s: %s (%s)", humanLabel, region, q.CollectionName))
+}
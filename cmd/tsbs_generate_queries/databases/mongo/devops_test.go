@@ -0,0 +1,158 @@
+package mongo
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+type testDevops interface {
+	devops.TopKHostsFiller
+	devops.TagValuesFiller
+	devops.SeriesCountFiller
+	GenerateEmptyQuery() query.Query
+}
+
+func newTestDevops(t *testing.T, useNaive bool) testDevops {
+	b := &BaseGenerator{UseNaive: useNaive}
+	dg, err := b.NewDevops(time.Unix(0, 0), time.Unix(0, 0).Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	return dg.(testDevops)
+}
+
+func TestDevopsTopKHosts(t *testing.T) {
+	cases := []struct {
+		useNaive  bool
+		wantLabel string
+		wantTail  []bson.M
+	}{
+		{
+			useNaive:  true,
+			wantLabel: devops.GetTopKHostsLabel("Mongo [NAIVE]", 3),
+			wantTail: []bson.M{
+				{"$group": bson.M{
+					"_id":             "$tags.hostname",
+					"mean_usage_user": bson.M{"$avg": "$fields.usage_user"},
+				}},
+				{"$sort": bson.M{"mean_usage_user": -1}},
+				{"$limit": 3},
+			},
+		},
+		{
+			useNaive:  false,
+			wantLabel: devops.GetTopKHostsLabel("Mongo", 3),
+			wantTail: []bson.M{
+				{"$group": bson.M{
+					"_id":             "$tags",
+					"mean_usage_user": bson.M{"$avg": "$events.usage_user"},
+				}},
+				{"$sort": bson.M{"mean_usage_user": -1}},
+				{"$limit": 3},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		rand.Seed(123) // Setting seed for testing purposes.
+		d := newTestDevops(t, c.useNaive)
+		q := d.GenerateEmptyQuery().(*query.Mongo)
+		d.TopKHosts(q, 3)
+
+		if got := string(q.HumanLabel); got != c.wantLabel {
+			t.Errorf("naive %v: incorrect label: got %s want %s", c.useNaive, got, c.wantLabel)
+		}
+		if got := string(q.CollectionName); got != "point_data" {
+			t.Errorf("naive %v: incorrect collection: %s", c.useNaive, got)
+		}
+		if len(q.BsonDoc) < len(c.wantTail) {
+			t.Fatalf("naive %v: pipeline too short: %v", c.useNaive, q.BsonDoc)
+		}
+		tail := q.BsonDoc[len(q.BsonDoc)-len(c.wantTail):]
+		if !reflect.DeepEqual(tail, c.wantTail) {
+			t.Errorf("naive %v: incorrect aggregation:\ngot\n%v\nwant\n%v", c.useNaive, tail, c.wantTail)
+		}
+
+		match := q.BsonDoc[0]["$match"].(bson.M)
+		if got := match["measurement"]; got != "cpu" {
+			t.Errorf("naive %v: incorrect measurement: %v", c.useNaive, got)
+		}
+		if c.useNaive {
+			ts := match["timestamp_ns"].(bson.M)
+			if ts["$lt"].(int64)-ts["$gte"].(int64) != int64(devops.TopKHostsDuration) {
+				t.Errorf("incorrect time window: %v", ts)
+			}
+		} else if docs := match["key_id"].(bson.M)["$in"].([]interface{}); len(docs) != 2 {
+			// A random hour spans two hourly documents
+			t.Errorf("incorrect documents: %v", docs)
+		}
+	}
+}
+
+func TestDevopsTagValues(t *testing.T) {
+	for _, c := range []struct {
+		useNaive  bool
+		wantLabel string
+	}{
+		{useNaive: true, wantLabel: devops.GetTagValuesLabel("Mongo [NAIVE]")},
+		{useNaive: false, wantLabel: devops.GetTagValuesLabel("Mongo")},
+	} {
+		rand.Seed(123) // Setting seed for testing purposes.
+		d := newTestDevops(t, c.useNaive)
+		q := d.GenerateEmptyQuery().(*query.Mongo)
+		d.TagValues(q)
+
+		if got := string(q.HumanLabel); got != c.wantLabel {
+			t.Errorf("naive %v: incorrect label: got %s want %s", c.useNaive, got, c.wantLabel)
+		}
+		region := q.BsonDoc[0]["$match"].(bson.M)["tags.region"].(string)
+		want := []bson.M{
+			{"$match": bson.M{"measurement": "cpu", "tags.region": region}},
+			{"$group": bson.M{"_id": "$tags.hostname"}},
+			{"$sort": bson.M{"_id": 1}},
+		}
+		if !reflect.DeepEqual(q.BsonDoc, want) {
+			t.Errorf("naive %v: incorrect pipeline:\ngot\n%v\nwant\n%v", c.useNaive, q.BsonDoc, want)
+		}
+		if wantDesc := c.wantLabel + ": " + region + " (point_data)"; string(q.HumanDescription) != wantDesc {
+			t.Errorf("naive %v: incorrect description: got %s want %s", c.useNaive, q.HumanDescription, wantDesc)
+		}
+	}
+}
+
+func TestDevopsSeriesCount(t *testing.T) {
+	for _, c := range []struct {
+		useNaive  bool
+		wantLabel string
+	}{
+		{useNaive: true, wantLabel: devops.GetSeriesCountLabel("Mongo [NAIVE]")},
+		{useNaive: false, wantLabel: devops.GetSeriesCountLabel("Mongo")},
+	} {
+		rand.Seed(123) // Setting seed for testing purposes.
+		d := newTestDevops(t, c.useNaive)
+		q := d.GenerateEmptyQuery().(*query.Mongo)
+		d.SeriesCount(q)
+
+		if got := string(q.HumanLabel); got != c.wantLabel {
+			t.Errorf("naive %v: incorrect label: got %s want %s", c.useNaive, got, c.wantLabel)
+		}
+		region := q.BsonDoc[0]["$match"].(bson.M)["tags.region"].(string)
+		want := []bson.M{
+			{"$match": bson.M{"measurement": "cpu", "tags.region": region}},
+			{"$group": bson.M{"_id": "$tags.hostname"}},
+			{"$count": "series"},
+		}
+		if !reflect.DeepEqual(q.BsonDoc, want) {
+			t.Errorf("naive %v: incorrect pipeline:\ngot\n%v\nwant\n%v", c.useNaive, q.BsonDoc, want)
+		}
+		if got := string(q.CollectionName); got != "point_data" {
+			t.Errorf("naive %v: incorrect collection: %s", c.useNaive, got)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+package mongo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// IoT produces Mongo-specific queries for the iot use case.
+//
+// Unlike the devops queries, the same aggregation pipelines serve both
+// storage layouts: the stages selecting the readings differ depending on
+// UseNaive (one document per event or hourly aggregate documents), the rest
+// of each pipeline works on one document per reading.
+//
+// Numeric truck tags are stored as strings and are converted with $toDouble;
+// AvgDailyDrivingSession and TruckBreakdownFrequency use $setWindowFields,
+// which requires MongoDB 5.0.
+type IoT struct {
+	*BaseGenerator
+	*iot.Core
+}
+
+const (
+	tenMinutesNano = int64(10 * time.Minute)
+	dayNano        = int64(24 * time.Hour)
+)
+
+// readingsPipeline returns the stages selecting the documents of measurement
+// matching match, within interval unless it is nil, as one document per
+// reading.
+func (i *IoT) readingsPipeline(measurement string, match bson.M, interval *utils.TimeInterval) []bson.M {
+	m := bson.M{"measurement": measurement}
+	for k, v := range match {
+		m[k] = v
+	}
+
+	if i.UseNaive {
+		if interval != nil {
+			m["timestamp_ns"] = bson.M{
+				"$gte": interval.StartUnixNano(),
+				"$lt":  interval.EndUnixNano(),
+			}
+		}
+		return []bson.M{{"$match": m}}
+	}
+
+	if interval != nil {
+		m["key_id"] = bson.M{"$in": getTimeFilterDocs(interval)}
+		return append([]bson.M{{"$match": m}}, getTimeFilterPipeline(interval)...)
+	}
+	return []bson.M{
+		{"$match": m},
+		{"$unwind": "$events"},
+		{"$unwind": "$events"},
+		// skip the empty placeholders of the aggregate documents
+		{"$match": bson.M{"events.timestamp_ns": bson.M{"$exists": true}}},
+	}
+}
+
+// fieldPath returns the path of a reading field in the documents returned by
+// readingsPipeline.
+func (i *IoT) fieldPath(field string) string {
+	if i.UseNaive {
+		return "fields." + field
+	}
+	return "events." + field
+}
+
+// field returns the expression referring to a reading field.
+func (i *IoT) field(field string) string {
+	return "$" + i.fieldPath(field)
+}
+
+// timestampPath returns the path of the reading timestamp.
+func (i *IoT) timestampPath() string {
+	if i.UseNaive {
+		return "timestamp_ns"
+	}
+	return "events.timestamp_ns"
+}
+
+// timestamp returns the expression referring to the reading timestamp.
+func (i *IoT) timestamp() string {
+	return "$" + i.timestampPath()
+}
+
+// bucket returns the expression truncating the reading timestamp to a
+// multiple of bucketNano.
+func (i *IoT) bucket(bucketNano int64) bson.M {
+	return bson.M{
+		"$subtract": []interface{}{
+			i.timestamp(),
+			bson.M{"$mod": []interface{}{i.timestamp(), bucketNano}},
+		},
+	}
+}
+
+// numericTag returns the expression converting a numeric tag to a double.
+func numericTag(tag string) bson.M {
+	return bson.M{"$toDouble": "$tags." + tag}
+}
+
+// namedTrucks matches the readings of trucks with a name.
+func namedTrucks() bson.M {
+	return bson.M{"tags.name": bson.M{"$exists": true}}
+}
+
+// fleetTrucks matches the readings of the named trucks of fleet.
+func fleetTrucks(fleet string) bson.M {
+	return bson.M{
+		"tags.fleet": fleet,
+		"tags.name":  bson.M{"$exists": true},
+	}
+}
+
+func (i *IoT) labelPrefix() string {
+	if i.UseNaive {
+		return "Mongo [NAIVE]"
+	}
+	return "Mongo"
+}
+
+func (i *IoT) fillInQuery(qi query.Query, humanLabel, humanDesc string, pipelineQuery []bson.M) {
+	q := qi.(*query.Mongo)
+	q.HumanLabel = []byte(humanLabel)
+	q.BsonDoc = pipelineQuery
+	q.CollectionName = []byte("point_data")
+	q.HumanDescription = []byte(fmt.Sprintf("%s (%s)", humanDesc, q.CollectionName))
+}
+
+// lastReadingPipeline returns the stages keeping the last reading of every
+// truck along with the truck tags in tags, set to the last value of the
+// reading fields.
+func (i *IoT) lastReadingPipeline(tags bson.M, fields ...string) []bson.M {
+	group := bson.M{
+		"_id":    "$tags.name",
+		"driver": bson.M{"$first": "$tags.driver"},
+	}
+	for k, v := range tags {
+		// the tags are the same for all the readings of a truck
+		group[k] = bson.M{"$first": v}
+	}
+	for _, f := range fields {
+		group[f] = bson.M{"$first": i.field(f)}
+	}
+	return []bson.M{
+		{"$sort": bson.M{i.timestampPath(): -1}},
+		{"$group": group},
+	}
+}
+
+// LastLocByTruck finds the truck location for nTrucks.
+//
+// Queries:
+// single-last-loc
+func (i *IoT) LastLocByTruck(qi query.Query, nTrucks int) {
+	trucks, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, bson.M{
+		"tags.name": bson.M{"$in": trucks},
+	}, nil)
+	pipelineQuery = append(pipelineQuery, i.lastReadingPipeline(nil, "longitude", "latitude")...)
+
+	humanLabel := fmt.Sprintf("%s last location by specific truck", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: random %4d trucks", humanLabel, nTrucks)
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// LastLocPerTruck finds all the truck locations along with truck and driver names.
+//
+// Queries:
+// last-loc
+func (i *IoT) LastLocPerTruck(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, fleetTrucks(i.GetRandomFleet()), nil)
+	pipelineQuery = append(pipelineQuery, i.lastReadingPipeline(nil, "longitude", "latitude")...)
+
+	humanLabel := fmt.Sprintf("%s last location per truck", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// TrucksWithLowFuel finds all trucks with low fuel (less than 10%).
+//
+// Queries:
+// low-fuel
+func (i *IoT) TrucksWithLowFuel(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.DiagnosticsTableName, fleetTrucks(i.GetRandomFleet()), nil)
+	pipelineQuery = append(pipelineQuery, i.lastReadingPipeline(nil, "fuel_state")...)
+	pipelineQuery = append(pipelineQuery, bson.M{
+		"$match": bson.M{"fuel_state": bson.M{"$lt": 0.1}},
+	})
+
+	humanLabel := fmt.Sprintf("%s trucks with low fuel", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: under 10 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// TrucksWithHighLoad finds all trucks that have load over 90%.
+//
+// Queries:
+// high-load
+func (i *IoT) TrucksWithHighLoad(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.DiagnosticsTableName, fleetTrucks(i.GetRandomFleet()), nil)
+	pipelineQuery = append(pipelineQuery, i.lastReadingPipeline(bson.M{
+		"load_capacity": numericTag("load_capacity"),
+	}, "current_load")...)
+	pipelineQuery = append(pipelineQuery, bson.M{
+		"$match": bson.M{
+			"$expr": bson.M{
+				"$gt": []interface{}{
+					bson.M{"$divide": []interface{}{"$current_load", "$load_capacity"}},
+					0.9,
+				},
+			},
+		},
+	})
+
+	humanLabel := fmt.Sprintf("%s trucks with high load", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: over 90 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// StationaryTrucks finds all trucks that have low average velocity in a time window.
+//
+// Queries:
+// stationary-trucks
+func (i *IoT) StationaryTrucks(qi query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, fleetTrucks(i.GetRandomFleet()), interval)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id":           "$tags.name",
+				"driver":        bson.M{"$first": "$tags.driver"},
+				"mean_velocity": bson.M{"$avg": i.field("velocity")},
+			},
+		},
+		{"$match": bson.M{"mean_velocity": bson.M{"$lt": 1}}},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s stationary trucks", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: with low avg velocity in last 10 minutes: %s", humanLabel, interval.StartString())
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// drivingSessionsPipeline returns the trucks of a random fleet which were
+// driving in more than periods ten minute periods of a random window of
+// duration.
+func (i *IoT) drivingSessionsPipeline(duration time.Duration, periods int) ([]bson.M, *utils.TimeInterval) {
+	interval := i.MustRandWindow(duration)
+
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, fleetTrucks(i.GetRandomFleet()), interval)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"name":        "$tags.name",
+					"ten_minutes": i.bucket(tenMinutesNano),
+				},
+				"driver":        bson.M{"$first": "$tags.driver"},
+				"mean_velocity": bson.M{"$avg": i.field("velocity")},
+			},
+		},
+		{"$match": bson.M{"mean_velocity": bson.M{"$gt": 1}}},
+		{
+			"$group": bson.M{
+				"_id":     "$_id.name",
+				"driver":  bson.M{"$first": "$driver"},
+				"periods": bson.M{"$sum": 1},
+			},
+		},
+		{"$match": bson.M{"periods": bson.M{"$gt": periods}}},
+	}...)
+	return pipelineQuery, interval
+}
+
+// TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
+//
+// Queries:
+// long-driving-sessions
+func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+	pipelineQuery, interval := i.drivingSessionsPipeline(iot.LongDrivingSessionDuration, tenMinutePeriods(5, iot.LongDrivingSessionDuration))
+
+	humanLabel := fmt.Sprintf("%s trucks with longer driving sessions", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: stopped less than 20 mins in 4 hour period: %s", humanLabel, interval.StartString())
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
+//
+// Queries:
+// long-daily-sessions
+func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+	pipelineQuery, interval := i.drivingSessionsPipeline(iot.DailyDrivingDuration, tenMinutePeriods(35, iot.DailyDrivingDuration))
+
+	humanLabel := fmt.Sprintf("%s trucks with longer daily sessions", i.labelPrefix())
+	humanDesc := fmt.Sprintf("%s: drove more than 10 hours in the last 24 hours: %s", humanLabel, interval.StartString())
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel consumption per fleet.
+//
+// Queries:
+// avg-vs-projected-fuel-consumption
+func (i *IoT) AvgVsProjectedFuelConsumption(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, bson.M{
+		"tags.name":                     bson.M{"$exists": true},
+		"tags.fleet":                    bson.M{"$exists": true},
+		"tags.nominal_fuel_consumption": bson.M{"$exists": true},
+	}, nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{"$match": bson.M{i.fieldPath("velocity"): bson.M{"$gt": 1}}},
+		{
+			"$group": bson.M{
+				"_id":                        "$tags.fleet",
+				"avg_fuel_consumption":       bson.M{"$avg": i.field("fuel_consumption")},
+				"projected_fuel_consumption": bson.M{"$avg": numericTag("nominal_fuel_consumption")},
+			},
+		},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s average vs projected fuel consumption per fleet", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver.
+//
+// Queries:
+// avg-daily-driving-duration
+func (i *IoT) AvgDailyDrivingDuration(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, namedTrucks(), nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet":       "$tags.fleet",
+					"name":        "$tags.name",
+					"driver":      "$tags.driver",
+					"day":         i.bucket(dayNano),
+					"ten_minutes": i.bucket(tenMinutesNano),
+				},
+				"mean_velocity": bson.M{"$avg": i.field("velocity")},
+			},
+		},
+		{"$match": bson.M{"mean_velocity": bson.M{"$gt": 1}}},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet":  "$_id.fleet",
+					"name":   "$_id.name",
+					"driver": "$_id.driver",
+					"day":    "$_id.day",
+				},
+				"periods": bson.M{"$sum": 1},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet":  "$_id.fleet",
+					"name":   "$_id.name",
+					"driver": "$_id.driver",
+				},
+				"avg_daily_hours": bson.M{"$avg": bson.M{"$divide": []interface{}{"$periods", 6}}},
+			},
+		},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s average driver driving duration per day", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// AvgDailyDrivingSession finds the average driving session without stopping per driver per day.
+//
+// Queries:
+// avg-daily-driving-session
+func (i *IoT) AvgDailyDrivingSession(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.ReadingsTableName, namedTrucks(), nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"name":        "$tags.name",
+					"ten_minutes": i.bucket(tenMinutesNano),
+				},
+				"mean_velocity": bson.M{"$avg": i.field("velocity")},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":         0,
+				"name":        "$_id.name",
+				"ten_minutes": "$_id.ten_minutes",
+				"driving":     bson.M{"$gt": []interface{}{"$mean_velocity", 5}},
+			},
+		},
+		{
+			"$setWindowFields": bson.M{
+				"partitionBy": "$name",
+				"sortBy":      bson.M{"ten_minutes": 1},
+				"output": bson.M{
+					"prev_driving": bson.M{"$shift": bson.M{"output": "$driving", "by": -1}},
+				},
+			},
+		},
+		// keep the periods where a truck starts or stops driving
+		{"$match": bson.M{"$expr": bson.M{"$ne": []interface{}{"$driving", "$prev_driving"}}}},
+		{
+			"$setWindowFields": bson.M{
+				"partitionBy": "$name",
+				"sortBy":      bson.M{"ten_minutes": 1},
+				"output": bson.M{
+					"stop": bson.M{"$shift": bson.M{"output": "$ten_minutes", "by": 1}},
+				},
+			},
+		},
+		{"$match": bson.M{"driving": true, "stop": bson.M{"$type": "number"}}},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"name": "$name",
+					"day": bson.M{
+						"$subtract": []interface{}{
+							"$ten_minutes",
+							bson.M{"$mod": []interface{}{"$ten_minutes", dayNano}},
+						},
+					},
+				},
+				"duration_minutes": bson.M{
+					"$avg": bson.M{
+						"$divide": []interface{}{
+							bson.M{"$subtract": []interface{}{"$stop", "$ten_minutes"}},
+							int64(time.Minute),
+						},
+					},
+				},
+			},
+		},
+		{"$sort": bson.M{"_id.name": 1, "_id.day": 1}},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s average driver driving session without stopping per day", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+//
+// Queries:
+// avg-load
+func (i *IoT) AvgLoad(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.DiagnosticsTableName, namedTrucks(), nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet": "$tags.fleet",
+					"model": "$tags.model",
+					"name":  "$tags.name",
+				},
+				"avg_load":      bson.M{"$avg": i.field("current_load")},
+				"load_capacity": bson.M{"$first": numericTag("load_capacity")},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet": "$_id.fleet",
+					"model": "$_id.model",
+				},
+				"avg_load_percentage": bson.M{
+					"$avg": bson.M{"$divide": []interface{}{"$avg_load", "$load_capacity"}},
+				},
+			},
+		},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s average load per truck model per fleet", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// DailyTruckActivity returns the number of hours trucks has been active (not out-of-commission) per day per fleet per model.
+//
+// Queries:
+// daily-activity
+func (i *IoT) DailyTruckActivity(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.DiagnosticsTableName, namedTrucks(), nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet":       "$tags.fleet",
+					"model":       "$tags.model",
+					"name":        "$tags.name",
+					"day":         i.bucket(dayNano),
+					"ten_minutes": i.bucket(tenMinutesNano),
+				},
+				"mean_status": bson.M{"$avg": i.field("status")},
+			},
+		},
+		{"$match": bson.M{"mean_status": bson.M{"$lt": 1}}},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"fleet": "$_id.fleet",
+					"model": "$_id.model",
+					"day":   "$_id.day",
+				},
+				"periods": bson.M{"$sum": 1},
+			},
+		},
+		{
+			"$project": bson.M{
+				"daily_activity": bson.M{"$divide": []interface{}{"$periods", 144}},
+			},
+		},
+		{"$sort": bson.M{"_id.day": 1}},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s daily truck activity per fleet per model", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// TruckBreakdownFrequency calculates the amount of times a truck model broke down in the last period.
+//
+// Queries:
+// breakdown-frequency
+func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
+	pipelineQuery := i.readingsPipeline(iot.DiagnosticsTableName, namedTrucks(), nil)
+	pipelineQuery = append(pipelineQuery, []bson.M{
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"model":       "$tags.model",
+					"name":        "$tags.name",
+					"ten_minutes": i.bucket(tenMinutesNano),
+				},
+				"broken_down_share": bson.M{
+					"$avg": bson.M{
+						"$cond": []interface{}{
+							bson.M{"$eq": []interface{}{i.field("status"), 0}}, 1, 0,
+						},
+					},
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":         0,
+				"model":       "$_id.model",
+				"name":        "$_id.name",
+				"ten_minutes": "$_id.ten_minutes",
+				"broken_down": bson.M{"$gte": []interface{}{"$broken_down_share", 0.5}},
+			},
+		},
+		{
+			"$setWindowFields": bson.M{
+				"partitionBy": "$name",
+				"sortBy":      bson.M{"ten_minutes": 1},
+				"output": bson.M{
+					"next_broken_down": bson.M{"$shift": bson.M{"output": "$broken_down", "by": 1}},
+				},
+			},
+		},
+		{"$match": bson.M{"broken_down": false, "next_broken_down": true}},
+		{
+			"$group": bson.M{
+				"_id":        "$model",
+				"breakdowns": bson.M{"$sum": 1},
+			},
+		},
+	}...)
+
+	humanLabel := fmt.Sprintf("%s truck breakdown frequency per model", i.labelPrefix())
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, pipelineQuery)
+}
+
+// tenMinutePeriods calculates the number of 10 minute periods that can fit in
+// the time duration if we subtract the minutes specified by minutesPerHour value.
+// E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
+func tenMinutePeriods(minutesPerHour float64, duration time.Duration) int {
+	durationMinutes := duration.Minutes()
+	leftover := minutesPerHour * duration.Hours()
+	return int((durationMinutes - leftover) / 10)
+}
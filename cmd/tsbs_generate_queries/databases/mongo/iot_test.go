@@ -0,0 +1,153 @@
+package mongo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func newTestIoT(t *testing.T, useNaive bool) *IoT {
+	b := &BaseGenerator{UseNaive: useNaive}
+	ig, err := b.NewIoT(time.Unix(0, 0), time.Unix(0, 0).Add(48*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	return ig.(*IoT)
+}
+
+func TestIoTLastLocByTruck(t *testing.T) {
+	cases := []struct {
+		useNaive bool
+		want     []bson.M
+	}{
+		{
+			useNaive: true,
+			want: []bson.M{
+				{"$match": bson.M{"measurement": "readings", "tags.name": bson.M{"$in": []string{"truck_5"}}}},
+				{"$sort": bson.M{"timestamp_ns": -1}},
+				{"$group": bson.M{
+					"_id":       "$tags.name",
+					"driver":    bson.M{"$first": "$tags.driver"},
+					"longitude": bson.M{"$first": "$fields.longitude"},
+					"latitude":  bson.M{"$first": "$fields.latitude"},
+				}},
+			},
+		},
+		{
+			useNaive: false,
+			want: []bson.M{
+				{"$match": bson.M{"measurement": "readings", "tags.name": bson.M{"$in": []string{"truck_5"}}}},
+				{"$unwind": "$events"},
+				{"$unwind": "$events"},
+				{"$match": bson.M{"events.timestamp_ns": bson.M{"$exists": true}}},
+				{"$sort": bson.M{"events.timestamp_ns": -1}},
+				{"$group": bson.M{
+					"_id":       "$tags.name",
+					"driver":    bson.M{"$first": "$tags.driver"},
+					"longitude": bson.M{"$first": "$events.longitude"},
+					"latitude":  bson.M{"$first": "$events.latitude"},
+				}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		rand.Seed(123) // Setting seed for testing purposes.
+		i := newTestIoT(t, c.useNaive)
+		q := i.GenerateEmptyQuery().(*query.Mongo)
+		i.LastLocByTruck(q, 1)
+
+		if !reflect.DeepEqual(q.BsonDoc, c.want) {
+			t.Errorf("naive %v: incorrect pipeline:\ngot\n%v\nwant\n%v", c.useNaive, q.BsonDoc, c.want)
+		}
+		if got := string(q.CollectionName); got != "point_data" {
+			t.Errorf("naive %v: incorrect collection: %s", c.useNaive, got)
+		}
+	}
+}
+
+func TestIoTStationaryTrucksTimeFilter(t *testing.T) {
+	rand.Seed(123)
+	i := newTestIoT(t, true)
+	q := i.GenerateEmptyQuery().(*query.Mongo)
+	i.StationaryTrucks(q)
+
+	match := q.BsonDoc[0]["$match"].(bson.M)
+	ts := match["timestamp_ns"].(bson.M)
+	if ts["$lt"].(int64)-ts["$gte"].(int64) != int64(iot.StationaryDuration) {
+		t.Errorf("incorrect time window: %v", ts)
+	}
+	want := bson.M{
+		"measurement":  "readings",
+		"tags.fleet":   "West",
+		"tags.name":    bson.M{"$exists": true},
+		"timestamp_ns": ts,
+	}
+	if !reflect.DeepEqual(match, want) {
+		t.Errorf("incorrect match:\ngot\n%v\nwant\n%v", match, want)
+	}
+
+	rand.Seed(123)
+	i = newTestIoT(t, false)
+	q = i.GenerateEmptyQuery().(*query.Mongo)
+	i.StationaryTrucks(q)
+	if _, ok := q.BsonDoc[0]["$match"].(bson.M)["key_id"]; !ok {
+		t.Errorf("aggregate layout does not filter documents by key_id:\n%v", q.BsonDoc[0])
+	}
+}
+
+// TestIoTAllQueries checks every iot query of both layouts fills in its
+// labels and a pipeline that can be encoded in a query file.
+func TestIoTAllQueries(t *testing.T) {
+	for _, useNaive := range []bool{true, false} {
+		rand.Seed(123)
+		i := newTestIoT(t, useNaive)
+
+		fills := map[string]func(query.Query){
+			iot.LabelLastLoc:                       i.LastLocPerTruck,
+			iot.LabelLastLocSingleTruck:            func(q query.Query) { i.LastLocByTruck(q, 1) },
+			iot.LabelLowFuel:                       i.TrucksWithLowFuel,
+			iot.LabelHighLoad:                      i.TrucksWithHighLoad,
+			iot.LabelStationaryTrucks:              i.StationaryTrucks,
+			iot.LabelLongDrivingSessions:           i.TrucksWithLongDrivingSessions,
+			iot.LabelLongDailySessions:             i.TrucksWithLongDailySessions,
+			iot.LabelAvgVsProjectedFuelConsumption: i.AvgVsProjectedFuelConsumption,
+			iot.LabelAvgDailyDrivingDuration:       i.AvgDailyDrivingDuration,
+			iot.LabelAvgDailyDrivingSession:        i.AvgDailyDrivingSession,
+			iot.LabelAvgLoad:                       i.AvgLoad,
+			iot.LabelDailyActivity:                 i.DailyTruckActivity,
+			iot.LabelBreakdownFrequency:            i.TruckBreakdownFrequency,
+		}
+		for label, fill := range fills {
+			q := i.GenerateEmptyQuery()
+			fill(q)
+			m := q.(*query.Mongo)
+			if got := strings.HasPrefix(string(m.HumanLabel), "Mongo [NAIVE] "); got != useNaive {
+				t.Errorf("%s: incorrect human label: %s", label, m.HumanLabel)
+			}
+			if len(m.BsonDoc) == 0 {
+				t.Errorf("%s: empty pipeline", label)
+			}
+			if err := gob.NewEncoder(new(bytes.Buffer)).Encode(m); err != nil {
+				t.Errorf("%s: cannot encode query: %v", label, err)
+			}
+		}
+	}
+}
+
+func TestTenMinutePeriods(t *testing.T) {
+	if got := tenMinutePeriods(5, 4*time.Hour); got != 22 {
+		t.Errorf("incorrect periods for 4 hours: got %d want 22", got)
+	}
+	if got := tenMinutePeriods(35, 24*time.Hour); got != 60 {
+		t.Errorf("incorrect periods for 24 hours: got %d want 60", got)
+	}
+}
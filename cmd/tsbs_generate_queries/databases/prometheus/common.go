@@ -0,0 +1,144 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	iutils "github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	// RemoteReadPath is the path of the remote-read endpoint of Prometheus
+	RemoteReadPath = "/api/v1/read"
+	// remoteReadLabelPrefix marks the labels of remote-read queries, as they
+	// read the raw samples instead of evaluating the PromQL query
+	remoteReadLabelPrefix = "remote read "
+)
+
+// BaseGenerator contains settings specific for Prometheus
+type BaseGenerator struct {
+	// UseRemoteRead makes the queries read the raw samples of their series
+	// through the remote-read protocol instead of the HTTP query API
+	UseRemoteRead bool
+}
+
+// GenerateEmptyQuery returns an empty query.HTTP.
+func (g *BaseGenerator) GenerateEmptyQuery() query.Query {
+	return query.NewHTTP()
+}
+
+// NewDevops creates a new devops use case query generator.
+func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := devops.NewCore(start, end, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &Devops{
+		BaseGenerator: g,
+		Core:          core,
+	}, nil
+}
+
+// NewK8s creates a new k8s use case query generator.
+func (g *BaseGenerator) NewK8s(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := k8s.NewCore(start, end, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &K8s{
+		BaseGenerator: g,
+		Core:          core,
+	}, nil
+}
+
+type queryInfo struct {
+	// prometheus query
+	query string
+	// matchers selecting the series the query reads, used for remote read
+	matchers []*prompb.LabelMatcher
+	// label to describe type of query
+	label string
+	// detail appended to the label in the description of the query instead
+	// of the start of the interval
+	desc string
+	// time range for query executing
+	interval *iutils.TimeInterval
+	// time period to group by in seconds; an empty step makes an instant
+	// query evaluated at the end of the interval
+	step string
+	// label to list the values of across the series matched by query over
+	// the interval, instead of evaluating query
+	labelName string
+}
+
+// fillInQuery fills the query struct with data
+func (g *BaseGenerator) fillInQuery(qq query.Query, qi *queryInfo) {
+	q := qq.(*query.HTTP)
+	label := qi.label
+	if g.UseRemoteRead {
+		label = remoteReadLabelPrefix + label
+	}
+	q.HumanLabel = []byte(label)
+	if qi.desc != "" {
+		q.HumanDescription = []byte(fmt.Sprintf("%s: %s", label, qi.desc))
+	} else {
+		q.HumanDescription = []byte(fmt.Sprintf("%s: %s", label, qi.interval.StartString()))
+	}
+	q.StartTimestamp = qi.interval.StartUnixNano()
+	q.EndTimestamp = qi.interval.EndUnixNano()
+
+	if g.UseRemoteRead {
+		q.Method = []byte("POST")
+		q.Path = []byte(RemoteReadPath)
+		q.Body = newReadRequest(qi)
+		return
+	}
+
+	q.Method = []byte("GET")
+	v := url.Values{}
+	if qi.labelName != "" {
+		v.Set("match[]", qi.query)
+		v.Set("start", strconv.FormatInt(qi.interval.StartUnixNano()/1e9, 10))
+		v.Set("end", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		q.Path = []byte(fmt.Sprintf("/api/v1/label/%s/values?%s", qi.labelName, v.Encode()))
+		q.Body = nil
+		return
+	}
+	v.Set("query", qi.query)
+	if qi.step == "" {
+		v.Set("time", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		q.Path = []byte(fmt.Sprintf("/api/v1/query?%s", v.Encode()))
+	} else {
+		v.Set("start", strconv.FormatInt(qi.interval.StartUnixNano()/1e9, 10))
+		v.Set("end", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		v.Set("step", qi.step)
+		q.Path = []byte(fmt.Sprintf("/api/v1/query_range?%s", v.Encode()))
+	}
+	q.Body = nil
+}
+
+// newReadRequest returns the snappy-compressed remote-read request for the
+// series selected by the matchers of qi over its interval.
+func newReadRequest(qi *queryInfo) []byte {
+	rr := &prompb.ReadRequest{
+		Queries: []*prompb.Query{{
+			StartTimestampMs: qi.interval.StartUnixMillis(),
+			EndTimestampMs:   qi.interval.EndUnixMillis(),
+			Matchers:         qi.matchers,
+		}},
+	}
+	b, err := proto.Marshal(rr)
+	if err != nil {
+		panic(fmt.Sprintf("cannot marshal remote read request: %v", err))
+	}
+	return snappy.Encode(nil, b)
+}
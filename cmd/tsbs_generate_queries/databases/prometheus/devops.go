@@ -0,0 +1,255 @@
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Devops produces PromQL queries for the devops query types. The series are
+// named after the fields alone, as tsbs_load_prometheus writes them, e.g.
+// usage_user{hostname="host_0", ...}.
+type Devops struct {
+	*BaseGenerator
+	*devops.Core
+}
+
+// mustGetRandomHosts is the form of GetRandomHosts that cannot error; if it does error,
+// it causes a panic.
+func (d *Devops) mustGetRandomHosts(nHosts int) []string {
+	hosts, err := d.GetRandomHosts(nHosts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hosts
+}
+
+// GroupByTime selects the MAX for numMetrics metrics under 'cpu'
+// per minute for nhosts hosts,
+// e.g. in PromQL:
+//
+// label_replace(max(max_over_time(metric1{hostname=~"hostname1|...|hostnameN"}[1m])), "__name__", "metric1", "", "")
+// or ...
+// or label_replace(max(max_over_time(metricN{hostname=~"hostname1|...|hostnameN"}[1m])), "__name__", "metricN", "", "")
+func (d *Devops) GroupByTime(qq query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	d.GroupByTimeBucket(qq, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qq query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	metrics := mustGetCPUMetricsSlice(numMetrics)
+	hosts := d.mustGetRandomHosts(nHosts)
+	bucketName := devops.GetBucketName(bucket)
+	qi := &queryInfo{
+		query: perMetric(metrics, func(m string) string {
+			return fmt.Sprintf("max(max_over_time(%s[%s]))", getSelector(m, hosts), bucketName)
+		}),
+		matchers: getMatchers(metrics, hosts),
+		label:    fmt.Sprintf("Prometheus %d cpu metric(s), random %4d hosts, random %s by %s", numMetrics, nHosts, timeRange, bucketName),
+		interval: d.MustRandWindow(timeRange),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// GroupByTimeAndPrimaryTag selects the AVG of numMetrics metrics under 'cpu' per device per hour for a day,
+// e.g. in PromQL:
+//
+// label_replace(avg(avg_over_time(metric1[1h])) by (hostname), "__name__", "metric1", "", "")
+// or ...
+// or label_replace(avg(avg_over_time(metricN[1h])) by (hostname), "__name__", "metricN", "", "")
+func (d *Devops) GroupByTimeAndPrimaryTag(qq query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qq, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qq query.Query, numMetrics int, window, bucket time.Duration) {
+	metrics := mustGetCPUMetricsSlice(numMetrics)
+	bucketName := devops.GetBucketName(bucket)
+	qi := &queryInfo{
+		query: perMetric(metrics, func(m string) string {
+			return fmt.Sprintf("avg(avg_over_time(%s[%s])) by (hostname)", getSelector(m, nil), bucketName)
+		}),
+		matchers: getMatchers(metrics, nil),
+		label:    devops.GetDoubleGroupByWindowLabel("Prometheus", numMetrics, window, bucket),
+		interval: d.MustRandWindow(window),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// MaxAllCPU selects the MAX of all metrics under 'cpu' per hour for nhosts hosts,
+// e.g. in PromQL:
+//
+// label_replace(max(max_over_time(metric1{hostname=~"hostname1|...|hostnameN"}[1h])), "__name__", "metric1", "", "")
+// or ...
+func (d *Devops) MaxAllCPU(qq query.Query, nHosts int, duration time.Duration) {
+	d.MaxAllCPUBucket(qq, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *Devops) MaxAllCPUBucket(qq query.Query, nHosts int, duration, bucket time.Duration) {
+	metrics := devops.GetAllCPUMetrics()
+	hosts := d.mustGetRandomHosts(nHosts)
+	bucketName := devops.GetBucketName(bucket)
+	qi := &queryInfo{
+		query: perMetric(metrics, func(m string) string {
+			return fmt.Sprintf("max(max_over_time(%s[%s]))", getSelector(m, hosts), bucketName)
+		}),
+		matchers: getMatchers(metrics, hosts),
+		label:    devops.GetMaxAllBucketLabel("Prometheus", nHosts, duration, bucket),
+		interval: d.MustRandWindow(duration),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts,
+// e.g. in PromQL:
+//
+// label_replace(label_replace(quantile_over_time(0.5, metric1{hostname=~"hostname1|...|hostnameN"}[1h]), "quantile", "0.5", "", ""), "__name__", "metric1", "", "")
+// or ...
+func (d *Devops) GroupByTimePercentiles(qq query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics := mustGetCPUMetricsSlice(numMetrics)
+	hosts := d.mustGetRandomHosts(nHosts)
+	qi := &queryInfo{
+		query: perMetric(metrics, func(m string) string {
+			quantiles := make([]string, len(devops.GetPercentiles()))
+			for i, p := range devops.GetPercentiles() {
+				quantiles[i] = fmt.Sprintf("label_replace(quantile_over_time(%g, %s[1h]), \"quantile\", \"%g\", \"\", \"\")",
+					p, getSelector(m, hosts), p)
+			}
+			return strings.Join(quantiles, " or ")
+		}),
+		matchers: getMatchers(metrics, hosts),
+		label:    devops.GetPercentilesLabel("Prometheus", numMetrics, nHosts, timeRange),
+		interval: interval,
+		step:     "3600",
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in PromQL:
+//
+// topk(k, avg_over_time(usage_user[1h]))
+func (d *Devops) TopKHosts(qq query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	qi := &queryInfo{
+		query:    fmt.Sprintf("topk(%d, avg_over_time(usage_user[%s]))", k, getDuration(devops.TopKHostsDuration)),
+		matchers: getMatchers([]string{"usage_user"}, nil),
+		label:    devops.GetTopKHostsLabel("Prometheus", k),
+		interval: interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// TagValues lists the hostnames of a random region over the whole time range
+// through the label values API,
+// e.g.:
+//
+// /api/v1/label/hostname/values?match[]=usage_user{region="region1"}
+func (d *Devops) TagValues(qq query.Query) {
+	region := d.GetRandomRegion()
+	qi := &queryInfo{
+		query:     fmt.Sprintf("usage_user{region='%s'}", region),
+		matchers:  getRegionMatchers(region),
+		label:     devops.GetTagValuesLabel("Prometheus"),
+		desc:      region,
+		interval:  d.Interval,
+		labelName: "hostname",
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// SeriesCount counts the cpu series of a random region over the whole time
+// range, one per host,
+// e.g. in PromQL:
+//
+// count(last_over_time(usage_user{region="region1"}[range]))
+func (d *Devops) SeriesCount(qq query.Query) {
+	region := d.GetRandomRegion()
+	qi := &queryInfo{
+		query: fmt.Sprintf("count(last_over_time(usage_user{region='%s'}[%s]))",
+			region, getDuration(d.Interval.Duration())),
+		matchers: getRegionMatchers(region),
+		label:    devops.GetSeriesCountLabel("Prometheus"),
+		desc:     region,
+		interval: d.Interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// perMetric joins the query built for each of the metrics. As functions over
+// time drop the metric name, it is set again to tell the results apart.
+func perMetric(metrics []string, fn func(metric string) string) string {
+	if len(metrics) == 1 {
+		return fn(metrics[0])
+	}
+	parts := make([]string, len(metrics))
+	for i, m := range metrics {
+		parts[i] = fmt.Sprintf("label_replace(%s, \"__name__\", \"%s\", \"\", \"\")", fn(m), m)
+	}
+	return strings.Join(parts, " or ")
+}
+
+func getHostClause(hostnames []string) string {
+	if len(hostnames) == 1 {
+		return fmt.Sprintf("hostname='%s'", hostnames[0])
+	}
+	return fmt.Sprintf("hostname=~'%s'", strings.Join(hostnames, "|"))
+}
+
+func getSelector(metric string, hosts []string) string {
+	if len(hosts) == 0 {
+		return metric
+	}
+	return fmt.Sprintf("%s{%s}", metric, getHostClause(hosts))
+}
+
+// getMatchers returns the remote-read matchers of the series of the metrics
+// for the hosts, or for all hosts if there are none.
+func getMatchers(metrics, hosts []string) []*prompb.LabelMatcher {
+	matchers := []*prompb.LabelMatcher{getMatcher("__name__", metrics)}
+	if len(hosts) > 0 {
+		matchers = append(matchers, getMatcher("hostname", hosts))
+	}
+	return matchers
+}
+
+func getRegionMatchers(region string) []*prompb.LabelMatcher {
+	return []*prompb.LabelMatcher{
+		getMatcher("__name__", []string{"usage_user"}),
+		getMatcher("region", []string{region}),
+	}
+}
+
+func getMatcher(name string, values []string) *prompb.LabelMatcher {
+	if len(values) == 1 {
+		return &prompb.LabelMatcher{Type: prompb.LabelMatcher_EQ, Name: name, Value: values[0]}
+	}
+	return &prompb.LabelMatcher{Type: prompb.LabelMatcher_RE, Name: name, Value: strings.Join(values, "|")}
+}
+
+func getDuration(d time.Duration) string {
+	return strconv.FormatInt(int64(d.Seconds()), 10) + "s"
+}
+
+// mustGetCPUMetricsSlice is the form of GetCPUMetricsSlice that cannot error; if it does error,
+// it causes a panic.
+func mustGetCPUMetricsSlice(numMetrics int) []string {
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	if err != nil {
+		panic(err.Error())
+	}
+	return metrics
+}
@@ -0,0 +1,237 @@
+package prometheus
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestDevopsQueries(t *testing.T) {
+	testCases := map[string]struct {
+		fn        func(g *Devops, q *query.HTTP)
+		expPath   string
+		expQuery  string
+		expStep   string
+		expToFail bool
+	}{
+		"GroupByTime_1_1": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTime(q, 1, 1, time.Hour)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "max(max_over_time(usage_user{hostname='host_5'}[1m]))",
+			expStep:  "60",
+		},
+		"GroupByTime_5_2": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTime(q, 5, 2, time.Hour)
+			},
+			expPath: "/api/v1/query_range",
+			expQuery: `label_replace(max(max_over_time(usage_user{hostname=~'host_5|host_9|host_3|host_1|host_7'}[1m])), "__name__", "usage_user", "", "")` +
+				` or label_replace(max(max_over_time(usage_system{hostname=~'host_5|host_9|host_3|host_1|host_7'}[1m])), "__name__", "usage_system", "", "")`,
+			expStep: "60",
+		},
+		"GroupByTimeBucket": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimeBucket(q, 1, 1, time.Hour, 5*time.Minute)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "max(max_over_time(usage_user{hostname='host_5'}[5m]))",
+			expStep:  "300",
+		},
+		"GroupByTimeAndPrimaryTag": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimeAndPrimaryTag(q, 2)
+			},
+			expPath: "/api/v1/query_range",
+			expQuery: `label_replace(avg(avg_over_time(usage_user[1h])) by (hostname), "__name__", "usage_user", "", "")` +
+				` or label_replace(avg(avg_over_time(usage_system[1h])) by (hostname), "__name__", "usage_system", "", "")`,
+			expStep: "3600",
+		},
+		"MaxAllCPU": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.MaxAllCPU(q, 1, devops.MaxAllDuration)
+			},
+			expPath: "/api/v1/query_range",
+			expQuery: `label_replace(max(max_over_time(usage_user{hostname='host_5'}[1h])), "__name__", "usage_user", "", "")` +
+				` or label_replace(max(max_over_time(usage_system{hostname='host_5'}[1h])), "__name__", "usage_system", "", "")` +
+				` or label_replace(max(max_over_time(usage_idle{hostname='host_5'}[1h])), "__name__", "usage_idle", "", "")` +
+				` or label_replace(max(max_over_time(usage_nice{hostname='host_5'}[1h])), "__name__", "usage_nice", "", "")` +
+				` or label_replace(max(max_over_time(usage_iowait{hostname='host_5'}[1h])), "__name__", "usage_iowait", "", "")` +
+				` or label_replace(max(max_over_time(usage_irq{hostname='host_5'}[1h])), "__name__", "usage_irq", "", "")` +
+				` or label_replace(max(max_over_time(usage_softirq{hostname='host_5'}[1h])), "__name__", "usage_softirq", "", "")` +
+				` or label_replace(max(max_over_time(usage_steal{hostname='host_5'}[1h])), "__name__", "usage_steal", "", "")` +
+				` or label_replace(max(max_over_time(usage_guest{hostname='host_5'}[1h])), "__name__", "usage_guest", "", "")` +
+				` or label_replace(max(max_over_time(usage_guest_nice{hostname='host_5'}[1h])), "__name__", "usage_guest_nice", "", "")`,
+			expStep: "3600",
+		},
+		"GroupByTimeAndPrimaryTagWindow": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimeAndPrimaryTagWindow(q, 1, 2*time.Hour, 10*time.Minute)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "avg(avg_over_time(usage_user[10m])) by (hostname)",
+			expStep:  "600",
+		},
+		"MaxAllCPUBucket": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.MaxAllCPUBucket(q, 1, devops.MaxAllDuration, 30*time.Minute)
+			},
+			expPath: "/api/v1/query_range",
+			expQuery: `label_replace(max(max_over_time(usage_user{hostname='host_5'}[30m])), "__name__", "usage_user", "", "")` +
+				` or label_replace(max(max_over_time(usage_system{hostname='host_5'}[30m])), "__name__", "usage_system", "", "")` +
+				` or label_replace(max(max_over_time(usage_idle{hostname='host_5'}[30m])), "__name__", "usage_idle", "", "")` +
+				` or label_replace(max(max_over_time(usage_nice{hostname='host_5'}[30m])), "__name__", "usage_nice", "", "")` +
+				` or label_replace(max(max_over_time(usage_iowait{hostname='host_5'}[30m])), "__name__", "usage_iowait", "", "")` +
+				` or label_replace(max(max_over_time(usage_irq{hostname='host_5'}[30m])), "__name__", "usage_irq", "", "")` +
+				` or label_replace(max(max_over_time(usage_softirq{hostname='host_5'}[30m])), "__name__", "usage_softirq", "", "")` +
+				` or label_replace(max(max_over_time(usage_steal{hostname='host_5'}[30m])), "__name__", "usage_steal", "", "")` +
+				` or label_replace(max(max_over_time(usage_guest{hostname='host_5'}[30m])), "__name__", "usage_guest", "", "")` +
+				` or label_replace(max(max_over_time(usage_guest_nice{hostname='host_5'}[30m])), "__name__", "usage_guest_nice", "", "")`,
+			expStep: "1800",
+		},
+		"GroupByTimePercentiles": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimePercentiles(q, 1, 1, 12*time.Hour)
+			},
+			expPath: "/api/v1/query_range",
+			expQuery: `label_replace(quantile_over_time(0.5, usage_user{hostname='host_9'}[1h]), "quantile", "0.5", "", "")` +
+				` or label_replace(quantile_over_time(0.95, usage_user{hostname='host_9'}[1h]), "quantile", "0.95", "", "")` +
+				` or label_replace(quantile_over_time(0.99, usage_user{hostname='host_9'}[1h]), "quantile", "0.99", "", "")`,
+			expStep: "3600",
+		},
+		"TopKHosts": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.TopKHosts(q, 10)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "topk(10, avg_over_time(usage_user[3600s]))",
+		},
+		"SeriesCount": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.SeriesCount(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "count(last_over_time(usage_user{region='ap-southeast-1'}[86400s]))",
+		},
+		"GroupByTime_negative_metrics": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTime(q, 1, -1, time.Hour)
+			},
+			expToFail: true,
+		},
+		"GroupByTime_negative_hosts": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTime(q, -1, 1, time.Hour)
+			},
+			expToFail: true,
+		},
+	}
+	g := acquireGenerator(t, time.Hour*24, 10, false)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			q := g.GenerateEmptyQuery().(*query.HTTP)
+			if tc.expToFail {
+				func() {
+					defer func() {
+						if recover() == nil {
+							t.Errorf("expected to panic")
+						}
+					}()
+					tc.fn(g, q)
+				}()
+				return
+			}
+
+			tc.fn(g, q)
+			parts := strings.SplitN(string(q.Path), "?", 2)
+			checkEqual(t, "path", tc.expPath, parts[0])
+			vals, err := url.ParseQuery(parts[1])
+			if err != nil {
+				t.Fatalf("unexpected err while parsing query: %s", err)
+			}
+			checkEqual(t, "query", tc.expQuery, vals.Get("query"))
+			checkEqual(t, "step", tc.expStep, vals.Get("step"))
+			checkEqual(t, "method", http.MethodGet, string(q.Method))
+		})
+	}
+}
+
+func TestTagValues(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10, false)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.TagValues(q)
+
+	parts := strings.SplitN(string(q.Path), "?", 2)
+	checkEqual(t, "path", "/api/v1/label/hostname/values", parts[0])
+	vals, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatalf("unexpected err while parsing query: %s", err)
+	}
+	checkEqual(t, "match", "usage_user{region='ap-southeast-1'}", vals.Get("match[]"))
+	checkEqual(t, "start", "0", vals.Get("start"))
+	checkEqual(t, "end", "7200", vals.Get("end"))
+	checkEqual(t, "desc", "Prometheus hostname tag values, random region: ap-southeast-1", string(q.HumanDescription))
+}
+
+func TestRemoteRead(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10, true)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.GroupByTime(q, 2, 2, time.Hour)
+
+	checkEqual(t, "path", RemoteReadPath, string(q.Path))
+	checkEqual(t, "method", http.MethodPost, string(q.Method))
+	checkEqual(t, "label", "remote read Prometheus 2 cpu metric(s), random    2 hosts, random 1h0m0s by 1m", string(q.HumanLabel))
+
+	b, err := snappy.Decode(nil, q.Body)
+	if err != nil {
+		t.Fatalf("unexpected err while decompressing body: %s", err)
+	}
+	rr := &prompb.ReadRequest{}
+	if err := proto.Unmarshal(b, rr); err != nil {
+		t.Fatalf("unexpected err while unmarshaling body: %s", err)
+	}
+	want := &prompb.ReadRequest{
+		Queries: []*prompb.Query{{
+			StartTimestampMs: 2850894,
+			EndTimestampMs:   6450894,
+			Matchers: []*prompb.LabelMatcher{
+				{Type: prompb.LabelMatcher_RE, Name: "__name__", Value: "usage_user|usage_system"},
+				{Type: prompb.LabelMatcher_RE, Name: "hostname", Value: "host_5|host_9"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(rr, want) {
+		t.Errorf("incorrect read request:\ngot\n%v\nwant\n%v", rr, want)
+	}
+}
+
+func checkEqual(t *testing.T, name, a, b string) {
+	if a != b {
+		t.Fatalf("values for %q are not equal \na: %q \nb: %q", name, a, b)
+	}
+}
+
+func acquireGenerator(t *testing.T, interval time.Duration, scale int, useRemoteRead bool) *Devops {
+	b := &BaseGenerator{UseRemoteRead: useRemoteRead}
+	s := time.Unix(0, 0)
+	e := s.Add(interval)
+	g, err := b.NewDevops(s, e, scale)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	return g.(*Devops)
+}
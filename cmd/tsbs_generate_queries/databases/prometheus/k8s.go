@@ -0,0 +1,68 @@
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// K8s produces PromQL queries for the k8s query types. The series are named
+// after the fields alone, e.g. cpu_usage{pod="deployment_0-...", ...}.
+type K8s struct {
+	*BaseGenerator
+	*k8s.Core
+}
+
+// NamespaceCPU selects the CPU usage of every namespace per minute,
+// e.g. in PromQL:
+//
+// sum(avg_over_time(cpu_usage[1m])) by (namespace)
+func (k *K8s) NamespaceCPU(qq query.Query) {
+	qi := &queryInfo{
+		query:    "sum(avg_over_time(cpu_usage[1m])) by (namespace)",
+		matchers: getMatchers([]string{"cpu_usage"}, nil),
+		label:    "Prometheus CPU usage per namespace per minute",
+		interval: k.MustRandWindow(k8s.NamespaceCPUDuration),
+		step:     "60",
+	}
+	k.fillInQuery(qq, qi)
+}
+
+// TopPods finds the k pods with the highest average CPU usage in a random
+// window of one hour,
+// e.g. in PromQL:
+//
+// topk(k, sum(avg_over_time(cpu_usage[1h])) by (namespace, pod))
+func (k *K8s) TopPods(qq query.Query, topK int) {
+	qi := &queryInfo{
+		query: fmt.Sprintf("topk(%d, sum(avg_over_time(cpu_usage[%s])) by (namespace, pod))",
+			topK, getDuration(k8s.TopPodsDuration)),
+		matchers: getMatchers([]string{"cpu_usage"}, nil),
+		label:    fmt.Sprintf("Prometheus top %d pods by CPU usage", topK),
+		interval: k.MustRandWindow(k8s.TopPodsDuration),
+	}
+	k.fillInQuery(qq, qi)
+}
+
+// Restarts finds the containers of a random namespace which restarted in a
+// random window of one hour,
+// e.g. in PromQL:
+//
+// increase(restarts{namespace="namespace1"}[1h]) > 0
+func (k *K8s) Restarts(qq query.Query) {
+	interval := k.MustRandWindow(k8s.RestartsDuration)
+	namespace := k.GetRandomNamespace()
+	qi := &queryInfo{
+		query: fmt.Sprintf("increase(restarts{namespace='%s'}[%s]) > 0",
+			namespace, getDuration(k8s.RestartsDuration)),
+		matchers: []*prompb.LabelMatcher{
+			getMatcher("__name__", []string{"restarts"}),
+			getMatcher("namespace", []string{namespace}),
+		},
+		label:    "Prometheus container restarts in a namespace",
+		interval: interval,
+	}
+	k.fillInQuery(qq, qi)
+}
@@ -0,0 +1,113 @@
+package prometheus
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestK8sQueries(t *testing.T) {
+	testCases := map[string]struct {
+		fn       func(g *K8s, q *query.HTTP)
+		expPath  string
+		expQuery string
+		expStep  string
+		expLabel string
+	}{
+		"NamespaceCPU": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.NamespaceCPU(q)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "sum(avg_over_time(cpu_usage[1m])) by (namespace)",
+			expStep:  "60",
+			expLabel: "Prometheus CPU usage per namespace per minute",
+		},
+		"TopPods": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.TopPods(q, 10)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "topk(10, sum(avg_over_time(cpu_usage[3600s])) by (namespace, pod))",
+			expLabel: "Prometheus top 10 pods by CPU usage",
+		},
+		"Restarts": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.Restarts(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "increase(restarts{namespace='kube-system'}[3600s]) > 0",
+			expLabel: "Prometheus container restarts in a namespace",
+		},
+	}
+	g := acquireK8sGenerator(t, false)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			q := g.GenerateEmptyQuery().(*query.HTTP)
+			tc.fn(g, q)
+
+			parts := strings.SplitN(string(q.Path), "?", 2)
+			checkEqual(t, "path", tc.expPath, parts[0])
+			vals, err := url.ParseQuery(parts[1])
+			if err != nil {
+				t.Fatalf("unexpected err while parsing query: %s", err)
+			}
+			checkEqual(t, "query", tc.expQuery, vals.Get("query"))
+			checkEqual(t, "step", tc.expStep, vals.Get("step"))
+			checkEqual(t, "label", tc.expLabel, string(q.HumanLabel))
+			checkEqual(t, "method", http.MethodGet, string(q.Method))
+		})
+	}
+}
+
+func TestK8sRemoteRead(t *testing.T) {
+	g := acquireK8sGenerator(t, true)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.Restarts(q)
+
+	checkEqual(t, "path", RemoteReadPath, string(q.Path))
+	checkEqual(t, "method", http.MethodPost, string(q.Method))
+
+	b, err := snappy.Decode(nil, q.Body)
+	if err != nil {
+		t.Fatalf("unexpected err while decompressing body: %s", err)
+	}
+	rr := &prompb.ReadRequest{}
+	if err := proto.Unmarshal(b, rr); err != nil {
+		t.Fatalf("unexpected err while unmarshaling body: %s", err)
+	}
+	want := &prompb.ReadRequest{
+		Queries: []*prompb.Query{{
+			StartTimestampMs: 72982646,
+			EndTimestampMs:   76582646,
+			Matchers: []*prompb.LabelMatcher{
+				{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "restarts"},
+				{Type: prompb.LabelMatcher_EQ, Name: "namespace", Value: "kube-system"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(rr, want) {
+		t.Errorf("incorrect read request:\ngot\n%v\nwant\n%v", rr, want)
+	}
+}
+
+func acquireK8sGenerator(t *testing.T, useRemoteRead bool) *K8s {
+	b := &BaseGenerator{UseRemoteRead: useRemoteRead}
+	s := time.Unix(0, 0)
+	g, err := b.NewK8s(s, s.Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating k8s generator")
+	}
+	return g.(*K8s)
+}
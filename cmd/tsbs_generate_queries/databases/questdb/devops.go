@@ -43,7 +43,7 @@ func (d *Devops) getSelectAggClauses(aggFunc string, idents []string) []string {
 // cpu-max-all-1
 // cpu-max-all-8
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.MaxAllDuration)
+	interval := d.MustRandWindow(devops.MaxAllDuration)
 	selectClauses := d.getSelectAggClauses("max", devops.GetAllCPUMetrics())
 	hosts, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
@@ -75,9 +75,15 @@ func (d *Devops) MaxAllCPU(qi query.Query, nHosts int) {
 // double-groupby-5
 // double-groupby-all
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qi, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qi query.Query, numMetrics int, window, bucket time.Duration) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(window)
 	selectClauses := d.getSelectAggClauses("avg", metrics)
 
 	sql := fmt.Sprintf(`
@@ -86,13 +92,14 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 		FROM cpu
 		WHERE timestamp >= '%s'
 		  AND timestamp < '%s'
-		SAMPLE BY 1h
+		SAMPLE BY %s
 		GROUP BY timestamp, hostname`,
 		strings.Join(selectClauses, ", "),
 		interval.StartString(),
-		interval.EndString())
+		interval.EndString(),
+		devops.GetBucketName(bucket))
 
-	humanLabel := devops.GetDoubleGroupByLabel("QuestDB", numMetrics)
+	humanLabel := devops.GetDoubleGroupByWindowLabel("QuestDB", numMetrics, window, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
 	d.fillInQuery(qi, humanLabel, humanDesc, sql)
 }
@@ -103,17 +110,23 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // Queries:
 // groupby-orderby-limit
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	d.GroupByOrderByLimitN(qi, devops.GroupByOrderByLimitRows)
+}
+
+// GroupByOrderByLimitN is GroupByOrderByLimit with the given limit.
+func (d *Devops) GroupByOrderByLimitN(qi query.Query, limit int) {
+	interval := d.MustRandWindow(time.Hour)
 	sql := fmt.Sprintf(`
 		SELECT timestamp AS minute,
 			max(usage_user)
 		FROM cpu
 		WHERE timestamp < '%s'
 		SAMPLE BY 1m
-		LIMIT 5`,
-		interval.EndString())
+		LIMIT %d`,
+		interval.EndString(),
+		limit)
 
-	humanLabel := "QuestDB max cpu over last 5 min-intervals (random end)"
+	humanLabel := devops.GetGroupByOrderByLimitLabel("QuestDB", limit)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.EndString())
 	d.fillInQuery(qi, humanLabel, humanDesc, sql)
 }
@@ -138,7 +151,12 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // high-cpu-1
 // high-cpu-all
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	d.HighCPUForHostsWindow(qi, nHosts, devops.HighCPUDuration)
+}
+
+// HighCPUForHostsWindow is HighCPUForHosts over the given window.
+func (d *Devops) HighCPUForHostsWindow(qi query.Query, nHosts int, window time.Duration) {
+	interval := d.MustRandWindow(window)
 	sql := ""
 	if nHosts > 0 {
 		hosts, err := d.GetRandomHosts(nHosts)
@@ -165,7 +183,7 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 			interval.EndString())
 	}
 
-	humanLabel, err := devops.GetHighCPULabel("QuestDB", nHosts)
+	humanLabel, err := devops.GetHighCPUWindowLabel("QuestDB", nHosts, window)
 	panicIfErr(err)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
 	d.fillInQuery(qi, humanLabel, humanDesc, sql)
@@ -182,7 +200,12 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 // single-groupby-5-1-1
 // single-groupby-5-8-1
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	d.GroupByTimeBucket(qi, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qi query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	interval := d.MustRandWindow(timeRange)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	selectClauses := d.getSelectAggClauses("max", metrics)
@@ -196,15 +219,134 @@ func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange t
 		WHERE hostname IN ('%s')
 		  AND timestamp >= '%s'
 		  AND timestamp < '%s'
-		SAMPLE BY 1m`,
+		SAMPLE BY %s`,
 		strings.Join(selectClauses, ", "),
 		strings.Join(hosts, "', '"),
 		interval.StartString(),
-		interval.EndString())
+		interval.EndString(),
+		devops.GetBucketName(bucket))
 
 	humanLabel := fmt.Sprintf(
-		"QuestDB %d cpu metric(s), random %4d hosts, random %s by 1m",
-		numMetrics, nHosts, timeRange)
+		"QuestDB %d cpu metric(s), random %4d hosts, random %s by %s",
+		numMetrics, nHosts, timeRange, devops.GetBucketName(bucket))
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of metrics under 'cpu'
+// per host per hour for N random hosts, using QuestDB's approximate
+// percentiles
+//
+// Queries:
+// percentiles-1-1-12
+// percentiles-1-8-12
+// percentiles-5-8-12
+func (d *Devops) GroupByTimePercentiles(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	panicIfErr(err)
+	hosts, err := d.GetRandomHosts(nHosts)
+	panicIfErr(err)
+
+	var selectClauses []string
+	for _, m := range metrics {
+		for _, p := range devops.GetPercentiles() {
+			selectClauses = append(selectClauses,
+				fmt.Sprintf("approx_percentile(%s, %g) AS %s_%s", m, p, devops.GetPercentileName(p), m))
+		}
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, hostname,
+			%s
+		FROM cpu
+		WHERE hostname IN ('%s')
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1h
+		GROUP BY timestamp, hostname`,
+		strings.Join(selectClauses, ", "),
+		strings.Join(hosts, "', '"),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := devops.GetPercentilesLabel("QuestDB", numMetrics, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// GroupByTimeCrossMeasurement correlates the mean of a metric from each of
+// the cpu, mem and diskio tables per host per hour for N random hosts,
+// joining the sampled tables on timestamp and hostname
+//
+// Queries:
+// cross-measurement-1-12
+// cross-measurement-8-1
+func (d *Devops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	hosts, err := d.GetRandomHosts(nHosts)
+	panicIfErr(err)
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	first := metrics[0].Measurement + "_avg"
+	meanClauses := make([]string, len(metrics))
+	subqueries := make([]string, len(metrics))
+	for i, m := range metrics {
+		table := m.Measurement + "_avg"
+		meanClauses[i] = "mean_" + m.Metric
+		subqueries[i] = fmt.Sprintf(`(
+			SELECT timestamp, hostname, avg(%s) AS mean_%s
+			FROM %s
+			WHERE hostname IN ('%s')
+			  AND timestamp >= '%s'
+			  AND timestamp < '%s'
+			SAMPLE BY 1h
+			GROUP BY timestamp, hostname
+		) %s`,
+			m.Metric, m.Metric,
+			m.Measurement,
+			strings.Join(hosts, "', '"),
+			interval.StartString(),
+			interval.EndString(),
+			table)
+		if i > 0 {
+			subqueries[i] = fmt.Sprintf("JOIN %s\n\t\tON %s.timestamp = %s.timestamp AND %s.hostname = %s.hostname",
+				subqueries[i], table, first, table, first)
+		}
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s.timestamp, %s.hostname, %s
+		FROM %s
+		ORDER BY %s.timestamp, %s.hostname`,
+		first, first, strings.Join(meanClauses, ", "),
+		strings.Join(subqueries, "\n\t\t"),
+		first, first)
+
+	humanLabel := devops.GetCrossMeasurementLabel("QuestDB", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour, relying on QuestDB's implicit grouping by hostname
+//
+// Queries:
+// top-k-hosts-10
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	sql := fmt.Sprintf(`
+		SELECT hostname, avg(usage_user) AS mean_usage_user
+		FROM cpu
+		WHERE timestamp >= '%s'
+		  AND timestamp < '%s'
+		ORDER BY mean_usage_user DESC
+		LIMIT %d`,
+		interval.StartString(),
+		interval.EndString(),
+		k)
+
+	humanLabel := devops.GetTopKHostsLabel("QuestDB", k)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
 	d.fillInQuery(qi, humanLabel, humanDesc, sql)
 }
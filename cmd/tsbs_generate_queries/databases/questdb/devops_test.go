@@ -37,6 +37,86 @@ func TestDevopsGroupByTime(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
 }
 
+func TestDevopsGroupByTimeBucket(t *testing.T) {
+	expectedHumanLabel := "QuestDB 1 cpu metric(s), random    1 hosts, random 1s by 5m"
+	expectedHumanDesc := "QuestDB 1 cpu metric(s), random    1 hosts, random 1s by 5m: 1970-01-01T00:05:58Z"
+	expectedQuery := "SELECT timestamp, max(usage_user) AS max_usage_user FROM cpu " +
+		"WHERE hostname IN ('host_9') AND timestamp >= '1970-01-01T00:05:58Z' AND timestamp < '1970-01-01T00:05:59Z' SAMPLE BY 5m"
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimeBucket(q, 1, 1, time.Second, 5*time.Minute)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestDevopsOverrides(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fn                 func(d *Devops, q query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedQuery      string
+	}{
+		{
+			desc: "GroupByTimeAndPrimaryTagWindow",
+			fn: func(d *Devops, q query.Query) {
+				d.GroupByTimeAndPrimaryTagWindow(q, 1, 2*time.Hour, 10*time.Minute)
+			},
+			expectedHumanLabel: "QuestDB mean of 1 metrics, all hosts, random 2h0m0s by 10m",
+			expectedHumanDesc:  "QuestDB mean of 1 metrics, all hosts, random 2h0m0s by 10m: 1970-01-01T06:16:22Z",
+			expectedQuery: "SELECT timestamp, hostname, avg(usage_user) AS avg_usage_user FROM cpu " +
+				"WHERE timestamp >= '1970-01-01T06:16:22Z' AND timestamp < '1970-01-01T08:16:22Z' SAMPLE BY 10m GROUP BY timestamp, hostname",
+		},
+		{
+			desc: "HighCPUForHostsWindow",
+			fn: func(d *Devops, q query.Query) {
+				d.HighCPUForHostsWindow(q, 1, time.Hour)
+			},
+			expectedHumanLabel: "QuestDB CPU over threshold, 1 host(s), random 1h0m0s",
+			expectedHumanDesc:  "QuestDB CPU over threshold, 1 host(s), random 1h0m0s: 1970-01-01T20:16:22Z",
+			expectedQuery: "SELECT * FROM cpu WHERE usage_user > 90.0 AND hostname IN ('host_9') " +
+				"AND timestamp >= '1970-01-01T20:16:22Z' AND timestamp < '1970-01-01T21:16:22Z'",
+		},
+		{
+			desc: "GroupByOrderByLimitN",
+			fn: func(d *Devops, q query.Query) {
+				d.GroupByOrderByLimitN(q, 10)
+			},
+			expectedHumanLabel: "QuestDB max cpu over last 10 min-intervals (random end)",
+			expectedHumanDesc:  "QuestDB max cpu over last 10 min-intervals (random end): 1970-01-01T21:16:22Z",
+			expectedQuery: "SELECT timestamp AS minute, max(usage_user) FROM cpu " +
+				"WHERE timestamp < '1970-01-01T21:16:22Z' SAMPLE BY 1m LIMIT 10",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{}
+			dq, err := b.NewDevops(s, s.Add(24*time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			c.fn(d, q)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedQuery)
+		})
+	}
+}
+
 func TestDevopsGroupByOrderByLimit(t *testing.T) {
 	expectedHumanLabel := "QuestDB max cpu over last 5 min-intervals (random end)"
 	expectedHumanDesc := "QuestDB max cpu over last 5 min-intervals (random end): 1970-01-01T01:16:22Z"
@@ -59,6 +139,29 @@ func TestDevopsGroupByOrderByLimit(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
 }
 
+func TestDevopsTopKHosts(t *testing.T) {
+	expectedHumanLabel := "QuestDB top 10 hosts by mean usage_user, random 1h0m0s"
+	expectedHumanDesc := "QuestDB top 10 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:16:22Z"
+	expectedQuery := "SELECT hostname, avg(usage_user) AS mean_usage_user FROM cpu " +
+		"WHERE timestamp >= '1970-01-01T00:16:22Z' AND timestamp < '1970-01-01T01:16:22Z' " +
+		"ORDER BY mean_usage_user DESC LIMIT 10"
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(2 * time.Hour)
+	b := BaseGenerator{}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.TopKHosts(q, 10)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
 func TestDevopsGroupByTimeAndPrimaryTag(t *testing.T) {
 	cases := []testCase{
 		{
@@ -99,6 +202,65 @@ func TestDevopsGroupByTimeAndPrimaryTag(t *testing.T) {
 	runTestCases(t, testFunc, start, end, cases)
 }
 
+func TestDevopsGroupByTimePercentiles(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero metrics",
+			input:   0,
+			fail:    true,
+			failMsg: "cannot get 0 metrics",
+		},
+		{
+			desc:               "1 metric",
+			input:              1,
+			expectedHumanLabel: "QuestDB p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "QuestDB p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h: 1970-01-01T11:54:10Z",
+			expectedQuery: "SELECT timestamp, hostname, approx_percentile(usage_user, 0.5) AS p50_usage_user, " +
+				"approx_percentile(usage_user, 0.95) AS p95_usage_user, approx_percentile(usage_user, 0.99) AS p99_usage_user FROM cpu " +
+				"WHERE hostname IN ('host_3', 'host_5') AND timestamp >= '1970-01-01T11:54:10Z' AND timestamp < '1970-01-01T23:54:10Z' " +
+				"SAMPLE BY 1h GROUP BY timestamp, hostname",
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimePercentiles(q, 2, c.input, 12*time.Hour)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(24 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
+func TestDevopsGroupByTimeCrossMeasurement(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:               "2 hosts",
+			input:              2,
+			expectedHumanLabel: "QuestDB mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h",
+			expectedHumanDesc:  "QuestDB mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z",
+			expectedQuery: "SELECT cpu_avg.timestamp, cpu_avg.hostname, mean_usage_user, mean_used_percent, mean_reads FROM " +
+				"( SELECT timestamp, hostname, avg(usage_user) AS mean_usage_user FROM cpu WHERE hostname IN ('host_9', 'host_3') AND timestamp >= '1970-01-01T06:16:22Z' AND timestamp < '1970-01-01T18:16:22Z' SAMPLE BY 1h GROUP BY timestamp, hostname ) cpu_avg " +
+				"JOIN ( SELECT timestamp, hostname, avg(used_percent) AS mean_used_percent FROM mem WHERE hostname IN ('host_9', 'host_3') AND timestamp >= '1970-01-01T06:16:22Z' AND timestamp < '1970-01-01T18:16:22Z' SAMPLE BY 1h GROUP BY timestamp, hostname ) mem_avg ON mem_avg.timestamp = cpu_avg.timestamp AND mem_avg.hostname = cpu_avg.hostname " +
+				"JOIN ( SELECT timestamp, hostname, avg(reads) AS mean_reads FROM diskio WHERE hostname IN ('host_9', 'host_3') AND timestamp >= '1970-01-01T06:16:22Z' AND timestamp < '1970-01-01T18:16:22Z' SAMPLE BY 1h GROUP BY timestamp, hostname ) diskio_avg ON diskio_avg.timestamp = cpu_avg.timestamp AND diskio_avg.hostname = cpu_avg.hostname " +
+				"ORDER BY cpu_avg.timestamp, cpu_avg.hostname",
+		},
+	}
+
+	testFunc := func(d *Devops, c testCase) query.Query {
+		q := d.GenerateEmptyQuery()
+		d.GroupByTimeCrossMeasurement(q, c.input, 12*time.Hour)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(24 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
 func TestMaxAllCPU(t *testing.T) {
 	cases := []testCase{
 		{
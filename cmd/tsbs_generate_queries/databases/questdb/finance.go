@@ -0,0 +1,125 @@
+package questdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Finance produces QuestDB-specific queries for all the finance query types.
+//
+// As for the iot use case, the symbol tags (symbol, exchange, sector) are
+// SYMBOL columns of both the trades and quotes tables.
+type Finance struct {
+	*finance.Core
+	*BaseGenerator
+}
+
+// NewFinance makes a Finance object ready to generate Queries.
+func NewFinance(start, end time.Time, scale int, g *BaseGenerator) *Finance {
+	c, err := finance.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Finance{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// OHLCBars fetches the open, high, low and close prices and the traded volume
+// per minute of nSymbols symbols.
+//
+// Queries:
+// ohlc-1, ohlc-10
+func (f *Finance) OHLCBars(qi query.Query, nSymbols int) {
+	symbols, err := f.GetRandomSymbols(nSymbols)
+	panicIfErr(err)
+	interval := f.MustRandWindow(finance.OHLCDuration)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, symbol,
+		  first(price) AS open, max(price) AS high, min(price) AS low, last(price) AS close, sum(size) AS volume
+		FROM trades
+		WHERE symbol IN ('%s')
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1m`,
+		strings.Join(symbols, "', '"),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB OHLC bars per minute by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+	f.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// VWAP fetches the hourly volume-weighted average price of nSymbols symbols.
+//
+// Queries:
+// vwap-1, vwap-10
+func (f *Finance) VWAP(qi query.Query, nSymbols int) {
+	symbols, err := f.GetRandomSymbols(nSymbols)
+	panicIfErr(err)
+	interval := f.MustRandWindow(finance.VWAPDuration)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, symbol, sum(price * size) / sum(size) AS vwap, sum(size) AS volume
+		FROM trades
+		WHERE symbol IN ('%s')
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1h`,
+		strings.Join(symbols, "', '"),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB hourly VWAP by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+	f.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// LastQuotePerSymbol finds the latest quote of all the symbols of an exchange.
+//
+// Queries:
+// last-quote
+func (f *Finance) LastQuotePerSymbol(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT symbol, bid_price, bid_size, ask_price, ask_size
+		FROM quotes
+		WHERE exchange = '%s'
+		  AND symbol IS NOT NULL
+		LATEST ON timestamp PARTITION BY symbol`,
+		f.GetRandomExchange())
+
+	humanLabel := "QuestDB last quote per symbol"
+	humanDesc := humanLabel
+	f.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// SpreadStats computes the minimum, average and maximum bid-ask spread of all
+// the symbols of an exchange in a time window.
+//
+// Queries:
+// spread-stats
+func (f *Finance) SpreadStats(qi query.Query) {
+	interval := f.MustRandWindow(finance.SpreadDuration)
+	sql := fmt.Sprintf(`
+		SELECT symbol,
+		  min(ask_price - bid_price) AS min_spread,
+		  avg(ask_price - bid_price) AS avg_spread,
+		  max(ask_price - bid_price) AS max_spread
+		FROM quotes
+		WHERE exchange = '%s'
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		ORDER BY symbol`,
+		f.GetRandomExchange(),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB spread statistics per symbol"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	f.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
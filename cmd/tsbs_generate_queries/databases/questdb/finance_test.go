@@ -0,0 +1,69 @@
+package questdb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFinanceOHLCBars(t *testing.T) {
+	expectedHumanLabel := "QuestDB OHLC bars per minute by specific symbol"
+	expectedHumanDesc := "QuestDB OHLC bars per minute by specific symbol: random    1 symbols, 1970-01-01T00:54:10Z"
+	expectedQuery := "SELECT timestamp, symbol, " +
+		"first(price) AS open, max(price) AS high, min(price) AS low, last(price) AS close, sum(size) AS volume " +
+		"FROM trades " +
+		"WHERE symbol IN ('symbol_5') AND timestamp >= '1970-01-01T00:54:10Z' AND timestamp < '1970-01-01T01:54:10Z' " +
+		"SAMPLE BY 1m"
+
+	f := newTestFinance(t, 2*time.Hour)
+	q := f.GenerateEmptyQuery()
+	f.OHLCBars(q, 1)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestFinanceLastQuotePerSymbol(t *testing.T) {
+	expectedHumanLabel := "QuestDB last quote per symbol"
+	expectedHumanDesc := "QuestDB last quote per symbol"
+	expectedQuery := "SELECT symbol, bid_price, bid_size, ask_price, ask_size FROM quotes " +
+		"WHERE exchange = 'TSE' AND symbol IS NOT NULL " +
+		"LATEST ON timestamp PARTITION BY symbol"
+
+	f := newTestFinance(t, time.Hour)
+	q := f.GenerateEmptyQuery()
+	f.LastQuotePerSymbol(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+// TestFinanceAllQueries checks every finance query produces a labelled query.
+func TestFinanceAllQueries(t *testing.T) {
+	f := newTestFinance(t, 24*time.Hour)
+	fills := map[string]func(query.Query){
+		finance.LabelOHLC:        func(q query.Query) { f.OHLCBars(q, 1) },
+		finance.LabelVWAP:        func(q query.Query) { f.VWAP(q, 1) },
+		finance.LabelLastQuote:   f.LastQuotePerSymbol,
+		finance.LabelSpreadStats: f.SpreadStats,
+	}
+	for label, fill := range fills {
+		q := f.GenerateEmptyQuery().(*query.HTTP)
+		fill(q)
+		if len(q.HumanLabel) == 0 || len(q.RawQuery) == 0 || len(q.Path) == 0 {
+			t.Errorf("%s: query not filled in: %s", label, q)
+		}
+	}
+}
+
+func newTestFinance(t *testing.T, d time.Duration) *Finance {
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	fg, err := b.NewFinance(s, s.Add(d), 10)
+	if err != nil {
+		t.Fatalf("Error while creating finance generator")
+	}
+	return fg.(*Finance)
+}
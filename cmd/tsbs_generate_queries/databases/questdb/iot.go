@@ -0,0 +1,370 @@
+package questdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// IoT produces QuestDB-specific queries for all the iot query types.
+//
+// Data is loaded over InfluxDB line protocol, so the string truck tags (name,
+// fleet, driver, ...) are SYMBOL columns and the numeric ones (load_capacity,
+// nominal_fuel_consumption, ...) are regular columns of both the readings and
+// diagnostics tables; no join is needed.
+type IoT struct {
+	*iot.Core
+	*BaseGenerator
+}
+
+// NewIoT makes an IoT object ready to generate Queries.
+func NewIoT(start, end time.Time, scale int, g *BaseGenerator) *IoT {
+	c, err := iot.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &IoT{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// LastLocByTruck finds the truck location for nTrucks.
+//
+// Queries:
+// single-last-loc
+func (i *IoT) LastLocByTruck(qi query.Query, nTrucks int) {
+	trucks, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+
+	sql := fmt.Sprintf(`
+		SELECT name, driver, longitude, latitude
+		FROM readings
+		WHERE name IN ('%s')
+		LATEST ON timestamp PARTITION BY name`,
+		strings.Join(trucks, "', '"))
+
+	humanLabel := "QuestDB last location by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks", humanLabel, nTrucks)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// LastLocPerTruck finds all the truck locations along with truck and driver names.
+//
+// Queries:
+// last-loc
+func (i *IoT) LastLocPerTruck(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT name, driver, longitude, latitude
+		FROM readings
+		WHERE fleet = '%s'
+		  AND name IS NOT NULL
+		LATEST ON timestamp PARTITION BY name`,
+		i.GetRandomFleet())
+
+	humanLabel := "QuestDB last location per truck"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// TrucksWithLowFuel finds all trucks with low fuel (less than 10%).
+//
+// Queries:
+// low-fuel
+func (i *IoT) TrucksWithLowFuel(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT name, driver, fuel_state
+		FROM (
+			SELECT name, driver, fuel_state
+			FROM diagnostics
+			WHERE fleet = '%s'
+			  AND name IS NOT NULL
+			LATEST ON timestamp PARTITION BY name
+		)
+		WHERE fuel_state < 0.1`,
+		i.GetRandomFleet())
+
+	humanLabel := "QuestDB trucks with low fuel"
+	humanDesc := fmt.Sprintf("%s: under 10 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// TrucksWithHighLoad finds all trucks that have load over 90%.
+//
+// Queries:
+// high-load
+func (i *IoT) TrucksWithHighLoad(qi query.Query) {
+	sql := fmt.Sprintf(`
+		SELECT name, driver, current_load, load_capacity
+		FROM (
+			SELECT name, driver, current_load, load_capacity
+			FROM diagnostics
+			WHERE fleet = '%s'
+			  AND name IS NOT NULL
+			LATEST ON timestamp PARTITION BY name
+		)
+		WHERE current_load / load_capacity > 0.9`,
+		i.GetRandomFleet())
+
+	humanLabel := "QuestDB trucks with high load"
+	humanDesc := fmt.Sprintf("%s: over 90 percent", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// StationaryTrucks finds all trucks that have low average velocity in a time window.
+//
+// Queries:
+// stationary-trucks
+func (i *IoT) StationaryTrucks(qi query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+	sql := fmt.Sprintf(`
+		SELECT name, driver
+		FROM (
+			SELECT name, driver, avg(velocity) AS mean_velocity
+			FROM readings
+			WHERE fleet = '%s'
+			  AND name IS NOT NULL
+			  AND timestamp >= '%s'
+			  AND timestamp < '%s'
+		)
+		WHERE mean_velocity < 1`,
+		i.GetRandomFleet(),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB stationary trucks"
+	humanDesc := fmt.Sprintf("%s: with low avg velocity in last 10 minutes", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// drivingSessionsQuery returns the trucks of a random fleet which were driving
+// in more than periods ten minute periods of a random window of duration.
+func (i *IoT) drivingSessionsQuery(duration time.Duration, periods int) string {
+	interval := i.MustRandWindow(duration)
+	return fmt.Sprintf(`
+		SELECT name, driver
+		FROM (
+			SELECT name, driver, count() AS driving_periods
+			FROM (
+				SELECT timestamp, name, driver, avg(velocity) AS mean_velocity
+				FROM readings
+				WHERE fleet = '%s'
+				  AND name IS NOT NULL
+				  AND timestamp >= '%s'
+				  AND timestamp < '%s'
+				SAMPLE BY 10m
+			)
+			WHERE mean_velocity > 1
+		)
+		WHERE driving_periods > %d`,
+		i.GetRandomFleet(),
+		interval.StartString(),
+		interval.EndString(),
+		periods)
+}
+
+// TrucksWithLongDrivingSessions finds all trucks that have not stopped at least 20 mins in the last 4 hours.
+//
+// Queries:
+// long-driving-sessions
+func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+	sql := i.drivingSessionsQuery(iot.LongDrivingSessionDuration, tenMinutePeriods(5, iot.LongDrivingSessionDuration))
+
+	humanLabel := "QuestDB trucks with longer driving sessions"
+	humanDesc := fmt.Sprintf("%s: stopped less than 20 mins in 4 hour period", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// TrucksWithLongDailySessions finds all trucks that have driven more than 10 hours in the last 24 hours.
+//
+// Queries:
+// long-daily-sessions
+func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+	sql := i.drivingSessionsQuery(iot.DailyDrivingDuration, tenMinutePeriods(35, iot.DailyDrivingDuration))
+
+	humanLabel := "QuestDB trucks with longer daily sessions"
+	humanDesc := fmt.Sprintf("%s: drove more than 10 hours in the last 24 hours", humanLabel)
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel consumption per fleet.
+//
+// Queries:
+// avg-vs-projected-fuel-consumption
+func (i *IoT) AvgVsProjectedFuelConsumption(qi query.Query) {
+	sql := `
+		SELECT fleet,
+			avg(fuel_consumption) AS avg_fuel_consumption,
+			avg(nominal_fuel_consumption) AS projected_fuel_consumption
+		FROM readings
+		WHERE velocity > 1
+		  AND fleet IS NOT NULL
+		  AND name IS NOT NULL`
+
+	humanLabel := "QuestDB average vs projected fuel consumption per fleet"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver.
+//
+// Queries:
+// avg-daily-driving-duration
+func (i *IoT) AvgDailyDrivingDuration(qi query.Query) {
+	sql := `
+		SELECT fleet, name, driver, avg(hours) AS avg_daily_hours
+		FROM (
+			SELECT timestamp, fleet, name, driver, count() / 6 AS hours
+			FROM (
+				SELECT timestamp, fleet, name, driver, avg(velocity) AS mean_velocity
+				FROM readings
+				WHERE name IS NOT NULL
+				SAMPLE BY 10m
+			) timestamp(timestamp)
+			WHERE mean_velocity > 1
+			SAMPLE BY 1d
+		)`
+
+	humanLabel := "QuestDB average driver driving duration per day"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// AvgDailyDrivingSession finds the average driving session without stopping per driver per day.
+//
+// Queries:
+// avg-daily-driving-session
+func (i *IoT) AvgDailyDrivingSession(qi query.Query) {
+	sql := `
+		WITH driver_status AS (
+			SELECT timestamp, name, avg(velocity) > 5 AS driving
+			FROM readings
+			WHERE name IS NOT NULL
+			SAMPLE BY 10m
+		), driver_status_change AS (
+			SELECT name, timestamp AS start,
+				lead(timestamp) OVER (PARTITION BY name ORDER BY timestamp) AS stop,
+				driving
+			FROM (
+				SELECT timestamp, name, driving,
+					lag(driving) OVER (PARTITION BY name ORDER BY timestamp) AS prev_driving
+				FROM driver_status
+			)
+			WHERE driving != prev_driving
+		)
+		SELECT name, timestamp_floor('d', start) AS day,
+			avg(datediff('m', start, stop)) AS duration_minutes
+		FROM driver_status_change
+		WHERE driving = true
+		  AND stop IS NOT NULL
+		ORDER BY name, day`
+
+	humanLabel := "QuestDB average driver driving session without stopping per day"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+//
+// Queries:
+// avg-load
+func (i *IoT) AvgLoad(qi query.Query) {
+	sql := `
+		SELECT fleet, model, load_capacity,
+			avg(avg_load / load_capacity) AS avg_load_percentage
+		FROM (
+			SELECT name, fleet, model, load_capacity, avg(current_load) AS avg_load
+			FROM diagnostics
+			WHERE name IS NOT NULL
+		)`
+
+	humanLabel := "QuestDB average load per truck model per fleet"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// DailyTruckActivity returns the number of hours trucks has been active (not out-of-commission) per day per fleet per model.
+//
+// Queries:
+// daily-activity
+func (i *IoT) DailyTruckActivity(qi query.Query) {
+	sql := `
+		SELECT timestamp AS day, fleet, model, count() / 144.0 AS daily_activity
+		FROM (
+			SELECT timestamp, name, fleet, model, avg(status) AS mean_status
+			FROM diagnostics
+			WHERE name IS NOT NULL
+			SAMPLE BY 10m
+		) timestamp(timestamp)
+		WHERE mean_status < 1
+		SAMPLE BY 1d`
+
+	humanLabel := "QuestDB daily truck activity per fleet per model"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// TruckBreakdownFrequency calculates the amount of times a truck model broke down in the last period.
+//
+// Queries:
+// breakdown-frequency
+func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
+	sql := `
+		WITH breakdown_per_truck_per_ten_minutes AS (
+			SELECT timestamp, name, model,
+				sum(CASE WHEN status = 0 THEN 1.0 ELSE 0.0 END) / count() >= 0.5 AS broken_down
+			FROM diagnostics
+			WHERE name IS NOT NULL
+			SAMPLE BY 10m
+		), breakdowns_per_truck AS (
+			SELECT model, broken_down,
+				lead(broken_down) OVER (PARTITION BY name ORDER BY timestamp) AS next_broken_down
+			FROM breakdown_per_truck_per_ten_minutes
+		)
+		SELECT model, count() AS breakdowns
+		FROM breakdowns_per_truck
+		WHERE broken_down = false
+		  AND next_broken_down = true`
+
+	humanLabel := "QuestDB truck breakdown frequency per model"
+	humanDesc := humanLabel
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// GapfillByTruck returns the per minute average velocity of nTrucks in a time
+// window, carrying the previous value over the minutes without readings.
+//
+// Queries:
+// gapfill
+func (i *IoT) GapfillByTruck(qi query.Query, nTrucks int) {
+	trucks, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+	interval := i.MustRandWindow(iot.GapfillDuration)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, name, avg(velocity) AS velocity
+		FROM readings
+		WHERE name IN ('%s')
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1m FILL(PREV)`,
+		strings.Join(trucks, "', '"),
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB gap-filled velocity per minute by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks, %s", humanLabel, nTrucks, interval.StartString())
+	i.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// tenMinutePeriods calculates the number of 10 minute periods that can fit in
+// the time duration if we subtract the minutes specified by minutesPerHour value.
+// E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
+func tenMinutePeriods(minutesPerHour float64, duration time.Duration) int {
+	durationMinutes := duration.Minutes()
+	leftover := minutesPerHour * duration.Hours()
+	return int((durationMinutes - leftover) / 10)
+}
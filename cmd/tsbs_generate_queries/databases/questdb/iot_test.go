@@ -0,0 +1,104 @@
+package questdb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestIoTLastLocByTruck(t *testing.T) {
+	expectedHumanLabel := "QuestDB last location by specific truck"
+	expectedHumanDesc := "QuestDB last location by specific truck: random    2 trucks"
+	expectedQuery := "SELECT name, driver, longitude, latitude FROM readings " +
+		"WHERE name IN ('truck_5', 'truck_9') LATEST ON timestamp PARTITION BY name"
+
+	i := newTestIoT(t, time.Hour)
+	q := i.GenerateEmptyQuery()
+	i.LastLocByTruck(q, 2)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestIoTTrucksWithLowFuel(t *testing.T) {
+	expectedHumanLabel := "QuestDB trucks with low fuel"
+	expectedHumanDesc := "QuestDB trucks with low fuel: under 10 percent"
+	expectedQuery := "SELECT name, driver, fuel_state FROM ( SELECT name, driver, fuel_state FROM diagnostics " +
+		"WHERE fleet = 'South' AND name IS NOT NULL LATEST ON timestamp PARTITION BY name ) WHERE fuel_state < 0.1"
+
+	i := newTestIoT(t, time.Hour)
+	q := i.GenerateEmptyQuery()
+	i.TrucksWithLowFuel(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestIoTTrucksWithLongDrivingSessions(t *testing.T) {
+	expectedHumanLabel := "QuestDB trucks with longer driving sessions"
+	expectedHumanDesc := "QuestDB trucks with longer driving sessions: stopped less than 20 mins in 4 hour period"
+	expectedQuery := "SELECT name, driver FROM ( SELECT name, driver, count() AS driving_periods FROM ( " +
+		"SELECT timestamp, name, driver, avg(velocity) AS mean_velocity FROM readings " +
+		"WHERE fleet = 'West' AND name IS NOT NULL AND timestamp >= '1970-01-01T02:16:22Z' AND timestamp < '1970-01-01T06:16:22Z' " +
+		"SAMPLE BY 10m ) WHERE mean_velocity > 1 ) WHERE driving_periods > 22"
+
+	i := newTestIoT(t, 24*time.Hour)
+	q := i.GenerateEmptyQuery()
+	i.TrucksWithLongDrivingSessions(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestIoTGapfillByTruck(t *testing.T) {
+	expectedHumanLabel := "QuestDB gap-filled velocity per minute by specific truck"
+	expectedHumanDesc := "QuestDB gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T00:54:10Z"
+	expectedQuery := "SELECT timestamp, name, avg(velocity) AS velocity FROM readings " +
+		"WHERE name IN ('truck_5') AND timestamp >= '1970-01-01T00:54:10Z' AND timestamp < '1970-01-01T01:54:10Z' " +
+		"SAMPLE BY 1m FILL(PREV)"
+
+	i := newTestIoT(t, 2*time.Hour)
+	q := i.GenerateEmptyQuery()
+	i.GapfillByTruck(q, 1)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+// TestIoTAllQueries checks every iot query produces a labelled query.
+func TestIoTAllQueries(t *testing.T) {
+	i := newTestIoT(t, 48*time.Hour)
+	fills := map[string]func(query.Query){
+		iot.LabelLastLoc:                       i.LastLocPerTruck,
+		iot.LabelLastLocSingleTruck:            func(q query.Query) { i.LastLocByTruck(q, 1) },
+		iot.LabelLowFuel:                       i.TrucksWithLowFuel,
+		iot.LabelHighLoad:                      i.TrucksWithHighLoad,
+		iot.LabelStationaryTrucks:              i.StationaryTrucks,
+		iot.LabelLongDrivingSessions:           i.TrucksWithLongDrivingSessions,
+		iot.LabelLongDailySessions:             i.TrucksWithLongDailySessions,
+		iot.LabelAvgVsProjectedFuelConsumption: i.AvgVsProjectedFuelConsumption,
+		iot.LabelAvgDailyDrivingDuration:       i.AvgDailyDrivingDuration,
+		iot.LabelAvgDailyDrivingSession:        i.AvgDailyDrivingSession,
+		iot.LabelAvgLoad:                       i.AvgLoad,
+		iot.LabelDailyActivity:                 i.DailyTruckActivity,
+		iot.LabelBreakdownFrequency:            i.TruckBreakdownFrequency,
+		iot.LabelGapfill:                       func(q query.Query) { i.GapfillByTruck(q, 1) },
+	}
+	for label, fill := range fills {
+		q := i.GenerateEmptyQuery().(*query.HTTP)
+		fill(q)
+		if len(q.HumanLabel) == 0 || len(q.RawQuery) == 0 || len(q.Path) == 0 {
+			t.Errorf("%s: query not filled in: %s", label, q)
+		}
+	}
+}
+
+func newTestIoT(t *testing.T, d time.Duration) *IoT {
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	ig, err := b.NewIoT(s, s.Add(d), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	return ig.(*IoT)
+}
@@ -0,0 +1,77 @@
+package questdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Logs produces QuestDB-specific queries for all the logs query types.
+//
+// The instance tags are SYMBOL columns and the string fields are STRING
+// columns of the logs table.
+type Logs struct {
+	*logs.Core
+	*BaseGenerator
+}
+
+// NewLogs makes a Logs object ready to generate Queries.
+func NewLogs(start, end time.Time, scale int, g *BaseGenerator) *Logs {
+	c, err := logs.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Logs{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// ErrorsPerService counts the error events of every service per minute.
+//
+// Queries:
+// errors-per-service
+func (l *Logs) ErrorsPerService(qi query.Query) {
+	interval := l.MustRandWindow(logs.ErrorsPerServiceDuration)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, service, count() AS errors
+		FROM logs
+		WHERE severity = '%s'
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1m`,
+		logs.ErrorSeverity,
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB errors per service per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	l.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// MessageSearch finds the latest events whose message contains a random
+// search term.
+//
+// Queries:
+// message-search
+func (l *Logs) MessageSearch(qi query.Query) {
+	interval := l.MustRandWindow(logs.MessageSearchDuration)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, service, severity, trace_id, message
+		FROM logs
+		WHERE message LIKE '%%%s%%'
+		  AND timestamp >= '%s'
+		  AND timestamp < '%s'
+		ORDER BY timestamp DESC
+		LIMIT %d`,
+		l.GetRandomSearchTerm(),
+		interval.StartString(),
+		interval.EndString(),
+		logs.MessageSearchLimit)
+
+	humanLabel := "QuestDB latest events with a message containing a term"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	l.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
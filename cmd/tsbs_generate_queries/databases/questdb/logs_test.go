@@ -0,0 +1,49 @@
+package questdb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestLogsErrorsPerService(t *testing.T) {
+	expectedHumanLabel := "QuestDB errors per service per minute"
+	expectedHumanDesc := "QuestDB errors per service per minute: 1970-01-01T20:16:22Z"
+	expectedQuery := "SELECT timestamp, service, count() AS errors " +
+		"FROM logs " +
+		"WHERE severity = 'ERROR' AND timestamp >= '1970-01-01T20:16:22Z' AND timestamp < '1970-01-01T21:16:22Z' " +
+		"SAMPLE BY 1m"
+
+	l := newTestLogs(t)
+	q := l.GenerateEmptyQuery()
+	l.ErrorsPerService(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestLogsMessageSearch(t *testing.T) {
+	expectedHumanLabel := "QuestDB latest events with a message containing a term"
+	expectedHumanDesc := "QuestDB latest events with a message containing a term: 1970-01-01T20:16:22Z"
+	expectedQuery := "SELECT timestamp, service, severity, trace_id, message " +
+		"FROM logs " +
+		"WHERE message LIKE '%insufficient funds%' AND timestamp >= '1970-01-01T20:16:22Z' AND timestamp < '1970-01-01T21:16:22Z' " +
+		"ORDER BY timestamp DESC " +
+		"LIMIT 100"
+
+	l := newTestLogs(t)
+	q := l.GenerateEmptyQuery()
+	l.MessageSearch(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func newTestLogs(t *testing.T) *Logs {
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	lg, err := b.NewLogs(s, s.Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating logs generator")
+	}
+	return lg.(*Logs)
+}
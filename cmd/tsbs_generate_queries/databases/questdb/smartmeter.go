@@ -0,0 +1,77 @@
+package questdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SmartMeter produces QuestDB-specific queries for all the smart-meter query
+// types.
+//
+// The SAMPLE BY buckets are aligned to the calendar, like the query windows
+// which start at midnight or at a whole hour.
+type SmartMeter struct {
+	*smartmeter.Core
+	*BaseGenerator
+}
+
+// NewSmartMeter makes a SmartMeter object ready to generate Queries.
+func NewSmartMeter(start, end time.Time, scale int, g *BaseGenerator) *SmartMeter {
+	c, err := smartmeter.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &SmartMeter{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// DailyConsumption sums the energy consumed in every region per day.
+//
+// Queries:
+// daily-consumption
+func (s *SmartMeter) DailyConsumption(qi query.Query) {
+	interval := s.GetRandomDays(smartmeter.DailyConsumptionDays)
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, region, sum(consumption_kwh) AS consumption_kwh
+		FROM energy
+		WHERE timestamp >= '%s'
+		  AND timestamp < '%s'
+		SAMPLE BY 1d ALIGN TO CALENDAR`,
+		interval.StartString(),
+		interval.EndString())
+
+	humanLabel := "QuestDB daily consumption per region"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	s.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
+
+// PeakHourLoad sums the energy consumed in every region per hour during the
+// evening peak hours of a random day. Readings are stamped at the start of
+// the interval they cover, so the 15-minute and hourly readings of an hour
+// add up to its consumption in kWh, which is the average load in kW.
+// On-demand readings may span several hours and are left out.
+//
+// Queries:
+// peak-hour-load
+func (s *SmartMeter) PeakHourLoad(qi query.Query) {
+	interval := s.GetRandomPeakHours()
+
+	sql := fmt.Sprintf(`
+		SELECT timestamp, region, sum(consumption_kwh) AS load_kw
+		FROM energy
+		WHERE timestamp >= '%s'
+		  AND timestamp < '%s'
+		  AND cadence != '%s'
+		SAMPLE BY 1h ALIGN TO CALENDAR`,
+		interval.StartString(),
+		interval.EndString(),
+		smartmeter.OnDemandCadence)
+
+	humanLabel := "QuestDB hourly load per region during peak hours"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	s.fillInQuery(qi, humanLabel, humanDesc, sql)
+}
@@ -0,0 +1,49 @@
+package questdb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSmartMeterDailyConsumption(t *testing.T) {
+	expectedHumanLabel := "QuestDB daily consumption per region"
+	expectedHumanDesc := "QuestDB daily consumption per region: 1970-01-02T00:00:00Z"
+	expectedQuery := "SELECT timestamp, region, sum(consumption_kwh) AS consumption_kwh " +
+		"FROM energy " +
+		"WHERE timestamp >= '1970-01-02T00:00:00Z' AND timestamp < '1970-01-04T00:00:00Z' " +
+		"SAMPLE BY 1d ALIGN TO CALENDAR"
+
+	s := newTestSmartMeter(t)
+	q := s.GenerateEmptyQuery()
+	s.DailyConsumption(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func TestSmartMeterPeakHourLoad(t *testing.T) {
+	expectedHumanLabel := "QuestDB hourly load per region during peak hours"
+	expectedHumanDesc := "QuestDB hourly load per region during peak hours: 1970-01-01T17:00:00Z"
+	expectedQuery := "SELECT timestamp, region, sum(consumption_kwh) AS load_kw " +
+		"FROM energy " +
+		"WHERE timestamp >= '1970-01-01T17:00:00Z' AND timestamp < '1970-01-01T21:00:00Z' " +
+		"AND cadence != 'on-demand' " +
+		"SAMPLE BY 1h ALIGN TO CALENDAR"
+
+	s := newTestSmartMeter(t)
+	q := s.GenerateEmptyQuery()
+	s.PeakHourLoad(q)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedQuery)
+}
+
+func newTestSmartMeter(t *testing.T) *SmartMeter {
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{}
+	sg, err := b.NewSmartMeter(s, s.Add(96*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating smart-meter generator")
+	}
+	return sg.(*SmartMeter)
+}
@@ -1,10 +1,17 @@
 package timescaledb
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
 )
@@ -16,6 +23,10 @@ type BaseGenerator struct {
 	UseJSON       bool
 	UseTags       bool
 	UseTimeBucket bool
+
+	// UsePreparedStatements makes queries bind their hosts and time bounds
+	// as arguments of a parameterized template instead of inlining them.
+	UsePreparedStatements bool
 }
 
 // GenerateEmptyQuery returns an empty query.TimescaleDB.
@@ -23,13 +34,38 @@ func (g *BaseGenerator) GenerateEmptyQuery() query.Query {
 	return query.NewTimescaleDB()
 }
 
+// newArgs returns the collector for the arguments of a parameterized query,
+// or nil when values should be inlined as literals.
+func (g *BaseGenerator) newArgs() *databases.SQLArgs {
+	if !g.UsePreparedStatements {
+		return nil
+	}
+	return databases.NewDollarSQLArgs()
+}
+
+// columnSelect returns the expression reading the tag column of the tags
+// table, which is a key of the tagset column when tags are stored as JSON.
+func (g *BaseGenerator) columnSelect(column string) string {
+	if g.UseJSON {
+		return fmt.Sprintf("tagset->>'%[1]s'", column)
+	}
+
+	return column
+}
+
+// withAlias returns the expression reading the tag column, named after it.
+func (g *BaseGenerator) withAlias(column string) string {
+	return fmt.Sprintf("%s AS %s", g.columnSelect(column), column)
+}
+
 // fillInQuery fills the query struct with data.
-func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, table, sql string) {
+func (g *BaseGenerator) fillInQuery(qi query.Query, humanLabel, humanDesc, table, sql string, args ...string) {
 	q := qi.(*query.TimescaleDB)
 	q.HumanLabel = []byte(humanLabel)
 	q.HumanDescription = []byte(humanDesc)
 	q.Hypertable = []byte(table)
 	q.SqlQuery = []byte(sql)
+	q.SqlArgs = append(q.SqlArgs[:0], args...)
 }
 
 // NewDevops creates a new devops use case query generator.
@@ -63,3 +99,83 @@ func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGene
 
 	return iot, nil
 }
+
+// NewDevopsGeneric creates a new devops-generic use case query generator.
+func (g *BaseGenerator) NewDevopsGeneric(start, end time.Time, scale, maxMetricCount int) (utils.QueryGenerator, error) {
+	core, err := devopsgeneric.NewCore(start, end, scale, maxMetricCount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	devopsGeneric := &DevopsGeneric{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return devopsGeneric, nil
+}
+
+// NewFinance creates a new finance use case query generator.
+func (g *BaseGenerator) NewFinance(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := finance.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	finance := &Finance{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return finance, nil
+}
+
+// NewK8s creates a new k8s use case query generator.
+func (g *BaseGenerator) NewK8s(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := k8s.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	k8s := &K8s{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return k8s, nil
+}
+
+// NewLogs creates a new logs use case query generator.
+func (g *BaseGenerator) NewLogs(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := logs.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &Logs{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return logs, nil
+}
+
+// NewSmartMeter creates a new smart-meter use case query generator.
+func (g *BaseGenerator) NewSmartMeter(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := smartmeter.NewCore(start, end, scale)
+
+	if err != nil {
+		return nil, err
+	}
+
+	smartMeter := &SmartMeter{
+		BaseGenerator: g,
+		Core:          core,
+	}
+
+	return smartMeter, nil
+}
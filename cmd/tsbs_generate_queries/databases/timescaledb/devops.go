@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
 	"github.com/timescale/tsbs/pkg/query"
 )
@@ -31,34 +32,30 @@ type Devops struct {
 }
 
 // getHostWhereWithHostnames creates WHERE SQL statement for multiple hostnames.
+// The hostnames are bound through args, which inlines them when nil.
 // NOTE 'WHERE' itself is not included, just hostname filter clauses, ready to concatenate to 'WHERE' string
-func (d *Devops) getHostWhereWithHostnames(hostnames []string) string {
+func (d *Devops) getHostWhereWithHostnames(hostnames []string, args *databases.SQLArgs) string {
 	var hostnameClauses []string
 	if d.UseJSON {
 		for _, s := range hostnames {
-			hostnameClauses = append(hostnameClauses, fmt.Sprintf("tagset @> '{\"hostname\": \"%s\"}'", s))
+			tagset := fmt.Sprintf("{\"hostname\": \"%s\"}", s)
+			hostnameClauses = append(hostnameClauses, "tagset @> "+args.BindString(tagset))
 		}
 		return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE %s)", strings.Join(hostnameClauses, " OR "))
 	} else if d.UseTags {
-		for _, s := range hostnames {
-			hostnameClauses = append(hostnameClauses, fmt.Sprintf("'%s'", s))
-		}
-		return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE hostname IN (%s))", strings.Join(hostnameClauses, ","))
+		return fmt.Sprintf("tags_id IN (SELECT id FROM tags WHERE hostname IN (%s))", args.BindStrings(hostnames, ","))
 	} else {
-		for _, s := range hostnames {
-			hostnameClauses = append(hostnameClauses, fmt.Sprintf("'%s'", s))
-		}
 		// using the OR logic here is an anti-pattern for the query planner. Doing
 		// the IN will get translated to an ANY query and do better
-		return fmt.Sprintf("hostname IN (%s)", strings.Join(hostnameClauses, ","))
+		return fmt.Sprintf("hostname IN (%s)", args.BindStrings(hostnames, ","))
 	}
 }
 
 // getHostWhereString gets multiple random hostnames and creates a WHERE SQL statement for these hostnames.
-func (d *Devops) getHostWhereString(nHosts int) string {
+func (d *Devops) getHostWhereString(nHosts int, args *databases.SQLArgs) string {
 	hostnames, err := d.GetRandomHosts(nHosts)
 	panicIfErr(err)
-	return d.getHostWhereWithHostnames(hostnames)
+	return d.getHostWhereWithHostnames(hostnames, args)
 }
 
 func (d *Devops) getTimeBucket(seconds int) string {
@@ -87,28 +84,40 @@ func (d *Devops) getSelectClausesAggMetrics(agg string, metrics []string) []stri
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY minute ORDER BY minute ASC
 func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
-	interval := d.Interval.MustRandWindow(timeRange)
+	d.GroupByTimeBucket(qi, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qi query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
+	interval := d.MustRandWindow(timeRange)
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
 	selectClauses := d.getSelectClausesAggMetrics("max", metrics)
 	if len(selectClauses) < 1 {
 		panic(fmt.Sprintf("invalid number of select clauses: got %d", len(selectClauses)))
 	}
+	args := d.newArgs()
+
+	bucketName := "minute"
+	if bucket != time.Minute {
+		bucketName = "bucket"
+	}
 
-	sql := fmt.Sprintf(`SELECT %s AS minute,
+	sql := fmt.Sprintf(`SELECT %s AS %s,
         %s
         FROM cpu
-        WHERE %s AND time >= '%s' AND time < '%s'
-        GROUP BY minute ORDER BY minute ASC`,
-		d.getTimeBucket(oneMinute),
+        WHERE %s AND time >= %s AND time < %s
+        GROUP BY %s ORDER BY %s ASC`,
+		d.getTimeBucket(int(bucket.Seconds())), bucketName,
 		strings.Join(selectClauses, ", "),
-		d.getHostWhereString(nHosts),
-		interval.Start().Format(goTimeFmt),
-		interval.End().Format(goTimeFmt))
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		bucketName, bucketName)
 
-	humanLabel := fmt.Sprintf("TimescaleDB %d cpu metric(s), random %4d hosts, random %s by 1m", numMetrics, nHosts, timeRange)
+	humanLabel := fmt.Sprintf("TimescaleDB %d cpu metric(s), random %4d hosts, random %s by %s", numMetrics, nHosts, timeRange, devops.GetBucketName(bucket))
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByOrderByLimit populates a query.Query that has a time WHERE clause, that groups by a truncated date, orders by that date, and takes a limit:
@@ -117,19 +126,26 @@ func (d *Devops) GroupByTime(qi query.Query, nHosts, numMetrics int, timeRange t
 // GROUP BY t ORDER BY t DESC
 // LIMIT $LIMIT
 func (d *Devops) GroupByOrderByLimit(qi query.Query) {
-	interval := d.Interval.MustRandWindow(time.Hour)
+	d.GroupByOrderByLimitN(qi, devops.GroupByOrderByLimitRows)
+}
+
+// GroupByOrderByLimitN is GroupByOrderByLimit with the given limit.
+func (d *Devops) GroupByOrderByLimitN(qi query.Query, limit int) {
+	interval := d.MustRandWindow(time.Hour)
+	args := d.newArgs()
 	sql := fmt.Sprintf(`SELECT %s AS minute, max(usage_user)
         FROM cpu
-        WHERE time < '%s'
+        WHERE time < %s
         GROUP BY minute
         ORDER BY minute DESC
-        LIMIT 5`,
+        LIMIT %d`,
 		d.getTimeBucket(oneMinute),
-		interval.End().Format(goTimeFmt))
+		args.BindString(interval.End().Format(goTimeFmt)),
+		limit)
 
-	humanLabel := "TimescaleDB max cpu over last 5 min-intervals (random end)"
+	humanLabel := devops.GetGroupByOrderByLimitLabel("TimescaleDB", limit)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.EndString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // GroupByTimeAndPrimaryTag selects the AVG of numMetrics metrics under 'cpu' per device per hour for a day,
@@ -140,9 +156,15 @@ func (d *Devops) GroupByOrderByLimit(qi query.Query) {
 // WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour, hostname ORDER BY hour
 func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qi, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qi query.Query, numMetrics int, window, bucket time.Duration) {
 	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
 	panicIfErr(err)
-	interval := d.Interval.MustRandWindow(devops.DoubleGroupByDuration)
+	interval := d.MustRandWindow(window)
 
 	selectClauses := make([]string, numMetrics)
 	meanClauses := make([]string, numMetrics)
@@ -163,29 +185,35 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 		joinStr = "JOIN tags ON cpu_avg.tags_id = tags.id"
 		partitionGrouping = "tags_id"
 	}
+	args := d.newArgs()
+
+	bucketName := "hour"
+	if bucket != time.Hour {
+		bucketName = "bucket"
+	}
 
 	sql := fmt.Sprintf(`
         WITH cpu_avg AS (
-          SELECT %s as hour, %s,
+          SELECT %s as %s, %s,
           %s
           FROM cpu
-          WHERE time >= '%s' AND time < '%s'
+          WHERE time >= %s AND time < %s
           GROUP BY 1, 2
         )
-        SELECT hour, %s, %s
+        SELECT %s, %s, %s
         FROM cpu_avg
         %s
-        ORDER BY hour, %s`,
-		d.getTimeBucket(oneHour),
+        ORDER BY %s, %s`,
+		d.getTimeBucket(int(bucket.Seconds())), bucketName,
 		partitionGrouping,
 		strings.Join(selectClauses, ", "),
-		interval.Start().Format(goTimeFmt),
-		interval.End().Format(goTimeFmt),
-		hostnameField, strings.Join(meanClauses, ", "),
-		joinStr, hostnameField)
-	humanLabel := devops.GetDoubleGroupByLabel("TimescaleDB", numMetrics)
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		bucketName, hostnameField, strings.Join(meanClauses, ", "),
+		joinStr, bucketName, hostnameField)
+	humanLabel := devops.GetDoubleGroupByWindowLabel("TimescaleDB", numMetrics, window, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // MaxAllCPU selects the MAX of all metrics under 'cpu' per hour for nhosts hosts,
@@ -196,25 +224,37 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qi query.Query, numMetrics int) {
 // AND time >= '$HOUR_START' AND time < '$HOUR_END'
 // GROUP BY hour ORDER BY hour
 func (d *Devops) MaxAllCPU(qi query.Query, nHosts int, duration time.Duration) {
-	interval := d.Interval.MustRandWindow(duration)
+	d.MaxAllCPUBucket(qi, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *Devops) MaxAllCPUBucket(qi query.Query, nHosts int, duration, bucket time.Duration) {
+	interval := d.MustRandWindow(duration)
 
 	metrics := devops.GetAllCPUMetrics()
 	selectClauses := d.getSelectClausesAggMetrics("max", metrics)
+	args := d.newArgs()
 
-	sql := fmt.Sprintf(`SELECT %s AS hour,
+	bucketName := "hour"
+	if bucket != time.Hour {
+		bucketName = "bucket"
+	}
+
+	sql := fmt.Sprintf(`SELECT %s AS %s,
         %s
         FROM cpu
-        WHERE %s AND time >= '%s' AND time < '%s'
-        GROUP BY hour ORDER BY hour`,
-		d.getTimeBucket(oneHour),
+        WHERE %s AND time >= %s AND time < %s
+        GROUP BY %s ORDER BY %s`,
+		d.getTimeBucket(int(bucket.Seconds())), bucketName,
 		strings.Join(selectClauses, ", "),
-		d.getHostWhereString(nHosts),
-		interval.Start().Format(goTimeFmt),
-		interval.End().Format(goTimeFmt))
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		bucketName, bucketName)
 
-	humanLabel := devops.GetMaxAllLabel("TimescaleDB", nHosts)
+	humanLabel := devops.GetMaxAllBucketLabel("TimescaleDB", nHosts, duration, bucket)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
 }
 
 // LastPointPerHost finds the last row for every host in the dataset
@@ -242,19 +282,244 @@ func (d *Devops) LastPointPerHost(qi query.Query) {
 // AND time >= '$TIME_START' AND time < '$TIME_END'
 // AND (hostname = '$HOST' OR hostname = '$HOST2'...)
 func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
+	d.HighCPUForHostsWindow(qi, nHosts, devops.HighCPUDuration)
+}
+
+// HighCPUForHostsWindow is HighCPUForHosts over the given window.
+func (d *Devops) HighCPUForHostsWindow(qi query.Query, nHosts int, window time.Duration) {
+	args := d.newArgs()
 	var hostWhereClause string
 	if nHosts == 0 {
 		hostWhereClause = ""
 	} else {
-		hostWhereClause = fmt.Sprintf("AND %s", d.getHostWhereString(nHosts))
+		hostWhereClause = fmt.Sprintf("AND %s", d.getHostWhereString(nHosts, args))
 	}
-	interval := d.Interval.MustRandWindow(devops.HighCPUDuration)
+	interval := d.MustRandWindow(window)
 
-	sql := fmt.Sprintf(`SELECT * FROM cpu WHERE usage_user > 90.0 and time >= '%s' AND time < '%s' %s`,
-		interval.Start().Format(goTimeFmt), interval.End().Format(goTimeFmt), hostWhereClause)
+	sql := fmt.Sprintf(`SELECT * FROM cpu WHERE usage_user > 90.0 and time >= %s AND time < %s %s`,
+		args.BindString(interval.Start().Format(goTimeFmt)), args.BindString(interval.End().Format(goTimeFmt)), hostWhereClause)
 
-	humanLabel, err := devops.GetHighCPULabel("TimescaleDB", nHosts)
+	humanLabel, err := devops.GetHighCPUWindowLabel("TimescaleDB", nHosts, window)
 	panicIfErr(err)
 	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
-	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql)
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT hour, hostname,
+// percentile_cont(0.5) WITHIN GROUP (ORDER BY metric1), ...
+// FROM cpu
+// WHERE hostname IN ('$HOSTNAME_1',...,'$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hour, hostname ORDER BY hour, hostname
+func (d *Devops) GroupByTimePercentiles(qi query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics, err := devops.GetCPUMetricsSlice(numMetrics)
+	panicIfErr(err)
+
+	var selectClauses, percentileClauses []string
+	for _, m := range metrics {
+		for _, p := range devops.GetPercentiles() {
+			name := fmt.Sprintf("%s_%s", devops.GetPercentileName(p), m)
+			percentileClauses = append(percentileClauses, name)
+			selectClauses = append(selectClauses, fmt.Sprintf("percentile_cont(%g) WITHIN GROUP (ORDER BY %s) AS %s", p, m, name))
+		}
+	}
+
+	hostnameField := "hostname"
+	joinStr := ""
+	partitionGrouping := hostnameField
+	if d.UseJSON || d.UseTags {
+		if d.UseTags {
+			hostnameField = "tags.hostname"
+		} else {
+			hostnameField = "tags.tagset->>'hostname'"
+		}
+		joinStr = "JOIN tags ON cpu_percentiles.tags_id = tags.id"
+		partitionGrouping = "tags_id"
+	}
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        WITH cpu_percentiles AS (
+          SELECT %s AS hour, %s,
+          %s
+          FROM cpu
+          WHERE %s AND time >= %s AND time < %s
+          GROUP BY 1, 2
+        )
+        SELECT hour, %s, %s
+        FROM cpu_percentiles
+        %s
+        ORDER BY hour, %s`,
+		d.getTimeBucket(oneHour),
+		partitionGrouping,
+		strings.Join(selectClauses, ", "),
+		d.getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		hostnameField, strings.Join(percentileClauses, ", "),
+		joinStr, hostnameField)
+
+	humanLabel := devops.GetPercentilesLabel("TimescaleDB", numMetrics, nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in pseudo-SQL:
+//
+// SELECT hostname, avg(usage_user) AS mean_usage_user
+// FROM cpu
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname ORDER BY mean_usage_user DESC LIMIT k
+func (d *Devops) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+
+	hostnameField := "hostname"
+	joinStr := ""
+	partitionGrouping := hostnameField
+	if d.UseJSON || d.UseTags {
+		if d.UseTags {
+			hostnameField = "tags.hostname"
+		} else {
+			hostnameField = "tags.tagset->>'hostname'"
+		}
+		joinStr = "JOIN tags ON host_avg.tags_id = tags.id"
+		partitionGrouping = "tags_id"
+	}
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`
+        WITH host_avg AS (
+          SELECT %s, avg(usage_user) AS mean_usage_user
+          FROM cpu
+          WHERE time >= %s AND time < %s
+          GROUP BY 1
+          ORDER BY mean_usage_user DESC
+          LIMIT %d
+        )
+        SELECT %s, mean_usage_user
+        FROM host_avg
+        %s
+        ORDER BY mean_usage_user DESC`,
+		partitionGrouping,
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		k,
+		hostnameField, joinStr)
+
+	humanLabel := devops.GetTopKHostsLabel("TimescaleDB", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// GroupByTimeCrossMeasurement correlates the mean of a metric from each of
+// the cpu, mem and diskio tables per host per hour for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// WITH cpu_avg AS (
+// SELECT hour, hostname, avg(usage_user) AS mean_usage_user FROM cpu
+// WHERE hostname IN ('$HOSTNAME_1',...,'$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hour, hostname
+// ), mem_avg AS (...), diskio_avg AS (...)
+// SELECT hour, hostname, mean_usage_user, mean_used_percent, mean_reads
+// FROM cpu_avg JOIN mem_avg USING (hour, hostname) JOIN diskio_avg USING (hour, hostname)
+// ORDER BY hour, hostname
+func (d *Devops) GroupByTimeCrossMeasurement(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	hostnames, err := d.GetRandomHosts(nHosts)
+	panicIfErr(err)
+
+	hostnameField := "hostname"
+	partitionGrouping := hostnameField
+	if d.UseJSON || d.UseTags {
+		if d.UseTags {
+			hostnameField = "tags.hostname"
+		} else {
+			hostnameField = "tags.tagset->>'hostname'"
+		}
+		partitionGrouping = "tags_id"
+	}
+	args := d.newArgs()
+	hostWhere := d.getHostWhereWithHostnames(hostnames, args)
+	start := args.BindString(interval.Start().Format(goTimeFmt))
+	end := args.BindString(interval.End().Format(goTimeFmt))
+
+	metrics := devops.GetCrossMeasurementMetrics()
+	ctes := make([]string, len(metrics))
+	meanClauses := make([]string, len(metrics))
+	var joins []string
+	for i, m := range metrics {
+		table := m.Measurement + "_avg"
+		ctes[i] = fmt.Sprintf(`%s AS (
+          SELECT %s AS hour, %s, avg(%s) AS mean_%s
+          FROM %s
+          WHERE %s AND time >= %s AND time < %s
+          GROUP BY 1, 2
+        )`,
+			table, d.getTimeBucket(oneHour), partitionGrouping, m.Metric, m.Metric,
+			m.Measurement, hostWhere, start, end)
+		meanClauses[i] = "mean_" + m.Metric
+		if i > 0 {
+			joins = append(joins, fmt.Sprintf("JOIN %s USING (hour, %s)", table, partitionGrouping))
+		}
+	}
+	if d.UseJSON || d.UseTags {
+		joins = append(joins, fmt.Sprintf("JOIN tags ON %s_avg.tags_id = tags.id", metrics[0].Measurement))
+	}
+
+	sql := fmt.Sprintf(`
+        WITH %s
+        SELECT hour, %s, %s
+        FROM %s_avg
+        %s
+        ORDER BY hour, %s`,
+		strings.Join(ctes, ", "),
+		hostnameField, strings.Join(meanClauses, ", "),
+		metrics[0].Measurement,
+		strings.Join(joins, "\n        "),
+		hostnameField)
+
+	humanLabel := devops.GetCrossMeasurementLabel("TimescaleDB", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devops.TableName, sql, args.Values()...)
+}
+
+// TagValues lists the hostnames of a random region from the tags table,
+// e.g. in pseudo-SQL:
+//
+// SELECT DISTINCT hostname FROM tags WHERE region = '$REGION' ORDER BY 1
+func (d *Devops) TagValues(qi query.Query) {
+	region := d.GetRandomRegion()
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`SELECT DISTINCT %s FROM tags WHERE %s = %s ORDER BY 1`,
+		d.columnSelect("hostname"), d.columnSelect("region"), args.BindString(region))
+
+	humanLabel := devops.GetTagValuesLabel("TimescaleDB")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	d.fillInQuery(qi, humanLabel, humanDesc, "tags", sql, args.Values()...)
+}
+
+// SeriesCount counts the series of a random region, one per host, from the
+// tags table,
+// e.g. in pseudo-SQL:
+//
+// SELECT count(*) FROM tags WHERE region = '$REGION'
+func (d *Devops) SeriesCount(qi query.Query) {
+	region := d.GetRandomRegion()
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`SELECT count(*) AS series FROM tags WHERE %s = %s`,
+		d.columnSelect("region"), args.BindString(region))
+
+	humanLabel := devops.GetSeriesCountLabel("TimescaleDB")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, region)
+	d.fillInQuery(qi, humanLabel, humanDesc, "tags", sql, args.Values()...)
 }
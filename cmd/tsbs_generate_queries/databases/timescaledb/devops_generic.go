@@ -0,0 +1,166 @@
+package timescaledb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// DevopsGeneric produces TimescaleDB-specific queries for all the devops-generic query types.
+type DevopsGeneric struct {
+	*BaseGenerator
+	*devopsgeneric.Core
+}
+
+// devops returns a Devops generator over the same hosts, used to reuse its
+// host filtering and time bucketing helpers.
+func (d *DevopsGeneric) devops() *Devops {
+	return &Devops{BaseGenerator: d.BaseGenerator, Core: d.Core.Core}
+}
+
+// getHostnameField returns the expression selecting the hostname of a row of
+// the tags table, or of the metrics table if tags are stored inline.
+func (d *DevopsGeneric) getHostnameField() string {
+	if d.UseTags {
+		return "tags.hostname"
+	} else if d.UseJSON {
+		return "tags.tagset->>'hostname'"
+	}
+	return "hostname"
+}
+
+// GroupByTimeSingleMetric selects the MAX of a random generic metric per
+// minute for nHosts hosts,
+// e.g. in pseudo-SQL:
+//
+// SELECT minute, max(metric_N)
+// FROM generic_metrics
+// WHERE hostname IN ('$HOSTNAME_1',...,'$HOSTNAME_N')
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY minute ORDER BY minute ASC
+func (d *DevopsGeneric) GroupByTimeSingleMetric(qi query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metric := d.GetRandomMetric()
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`SELECT %s AS minute,
+        max(%[2]s) as max_%[2]s
+        FROM %[3]s
+        WHERE %[4]s AND time >= %[5]s AND time < %[6]s
+        GROUP BY minute ORDER BY minute ASC`,
+		d.devops().getTimeBucket(oneMinute),
+		metric,
+		devopsgeneric.TableName,
+		d.devops().getHostWhereString(nHosts, args),
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)))
+
+	humanLabel := devopsgeneric.GetSingleMetricGroupbyLabel("TimescaleDB", nHosts, timeRange)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devopsgeneric.TableName, sql, args.Values()...)
+}
+
+// LastPointPerHost finds the last value of a random generic metric for every
+// host in the dataset. Hosts only report a subset of the metrics, so rows
+// where the metric is missing are skipped.
+func (d *DevopsGeneric) LastPointPerHost(qi query.Query) {
+	metric := d.GetRandomMetric()
+	var sql string
+	if d.UseTags || d.UseJSON {
+		hostnameField := "t.hostname"
+		if !d.UseTags {
+			hostnameField = "t.tagset->>'hostname'"
+		}
+		sql = fmt.Sprintf("SELECT DISTINCT ON (%[1]s) %[1]s AS hostname, b.time, b.%[2]s FROM tags t INNER JOIN LATERAL("+
+			"SELECT time, %[2]s FROM %[3]s g WHERE g.tags_id = t.id AND %[2]s IS NOT NULL ORDER BY time DESC LIMIT 1) AS b ON true "+
+			"ORDER BY %[1]s, b.time DESC", hostnameField, metric, devopsgeneric.TableName)
+	} else {
+		sql = fmt.Sprintf("SELECT DISTINCT ON (hostname) hostname, time, %[1]s FROM %[2]s WHERE %[1]s IS NOT NULL "+
+			"ORDER BY hostname, time DESC", metric, devopsgeneric.TableName)
+	}
+
+	humanLabel := devopsgeneric.GetLastpointLabel("TimescaleDB")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, metric)
+	d.fillInQuery(qi, humanLabel, humanDesc, devopsgeneric.TableName, sql)
+}
+
+// TopKHosts finds the k hosts with the highest average of a random generic
+// metric in a random window,
+// e.g. in pseudo-SQL:
+//
+// SELECT hostname, avg(metric_N) AS mean_metric_N
+// FROM generic_metrics
+// WHERE time >= '$HOUR_START' AND time < '$HOUR_END'
+// GROUP BY hostname ORDER BY mean_metric_N DESC LIMIT k
+func (d *DevopsGeneric) TopKHosts(qi query.Query, k int) {
+	interval := d.MustRandWindow(devopsgeneric.TopKDuration)
+	metric := d.GetRandomMetric()
+	args := d.newArgs()
+
+	partitionGrouping := "hostname"
+	joinStr := ""
+	if d.UseTags || d.UseJSON {
+		partitionGrouping = "tags_id"
+		joinStr = "JOIN tags ON host_avg.tags_id = tags.id"
+	}
+
+	sql := fmt.Sprintf(`
+        WITH host_avg AS (
+          SELECT %[1]s, avg(%[2]s) AS mean_%[2]s
+          FROM %[3]s
+          WHERE %[2]s IS NOT NULL AND time >= %[4]s AND time < %[5]s
+          GROUP BY %[1]s
+          ORDER BY mean_%[2]s DESC
+          LIMIT %[6]d
+        )
+        SELECT %[7]s, mean_%[2]s
+        FROM host_avg
+        %[8]s
+        ORDER BY mean_%[2]s DESC`,
+		partitionGrouping,
+		metric,
+		devopsgeneric.TableName,
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)),
+		k,
+		d.getHostnameField(),
+		joinStr)
+
+	humanLabel := devopsgeneric.GetTopKHostsLabel("TimescaleDB", k)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devopsgeneric.TableName, sql, args.Values()...)
+}
+
+// MetricDiscovery finds which generic metrics a random host reported in a
+// random window by counting the values of every metric,
+// e.g. in pseudo-SQL:
+//
+// SELECT count(metric_0), ..., count(metric_N)
+// FROM generic_metrics
+// WHERE hostname = '$HOSTNAME'
+// AND time >= '$HOUR_START' AND time < '$HOUR_END'
+func (d *DevopsGeneric) MetricDiscovery(qi query.Query) {
+	interval := d.MustRandWindow(devopsgeneric.MetricDiscoveryDuration)
+	metrics := d.GetAllMetrics()
+	selectClauses := make([]string, len(metrics))
+	for i, m := range metrics {
+		selectClauses[i] = fmt.Sprintf("count(%[1]s) AS %[1]s", m)
+	}
+	args := d.newArgs()
+
+	sql := fmt.Sprintf(`SELECT %s
+        FROM %s
+        WHERE %s AND time >= %s AND time < %s`,
+		strings.Join(selectClauses, ", "),
+		devopsgeneric.TableName,
+		d.devops().getHostWhereString(1, args),
+		args.BindString(interval.Start().Format(goTimeFmt)),
+		args.BindString(interval.End().Format(goTimeFmt)))
+
+	humanLabel := devopsgeneric.GetMetricDiscoveryLabel("TimescaleDB")
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+	d.fillInQuery(qi, humanLabel, humanDesc, devopsgeneric.TableName, sql, args.Values()...)
+}
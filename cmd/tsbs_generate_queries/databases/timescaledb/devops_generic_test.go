@@ -0,0 +1,117 @@
+package timescaledb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func newTestDevopsGeneric(t *testing.T, b *BaseGenerator) *DevopsGeneric {
+	s := time.Unix(0, 0)
+	g, err := b.NewDevopsGeneric(s, s.Add(2*time.Hour), 10, 3)
+	if err != nil {
+		t.Fatalf("Error while creating devops-generic generator: %v", err)
+	}
+	return g.(*DevopsGeneric)
+}
+
+func TestDevopsGenericGroupByTimeSingleMetric(t *testing.T) {
+	expectedHumanLabel := "TimescaleDB 1 generic metric, random    2 hosts, random 1h0m0s by 1m"
+	expectedHumanDesc := "TimescaleDB 1 generic metric, random    2 hosts, random 1h0m0s by 1m: 1970-01-01T00:16:22Z"
+	expectedHypertable := "generic_metrics"
+	expectedSQLQuery := `SELECT time_bucket('60 seconds', time) AS minute,
+        max(metric_0) as max_metric_0
+        FROM generic_metrics
+        WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_3','host_5')) AND time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'
+        GROUP BY minute ORDER BY minute ASC`
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	d := newTestDevopsGeneric(t, &BaseGenerator{UseTags: true, UseTimeBucket: true})
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimeSingleMetric(q, 2, time.Hour)
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedHypertable, expectedSQLQuery)
+}
+
+func TestDevopsGenericLastPointPerHost(t *testing.T) {
+	cases := []struct {
+		desc             string
+		useJSON          bool
+		useTags          bool
+		expectedSQLQuery string
+	}{
+		{
+			desc: "no JSON or tags",
+			expectedSQLQuery: "SELECT DISTINCT ON (hostname) hostname, time, metric_2 FROM generic_metrics " +
+				"WHERE metric_2 IS NOT NULL ORDER BY hostname, time DESC",
+		},
+		{
+			desc:    "use JSON",
+			useJSON: true,
+			expectedSQLQuery: "SELECT DISTINCT ON (t.tagset->>'hostname') t.tagset->>'hostname' AS hostname, b.time, b.metric_2 " +
+				"FROM tags t INNER JOIN LATERAL(SELECT time, metric_2 FROM generic_metrics g WHERE g.tags_id = t.id AND metric_2 IS NOT NULL " +
+				"ORDER BY time DESC LIMIT 1) AS b ON true ORDER BY t.tagset->>'hostname', b.time DESC",
+		},
+		{
+			desc:    "use tags",
+			useTags: true,
+			expectedSQLQuery: "SELECT DISTINCT ON (t.hostname) t.hostname AS hostname, b.time, b.metric_2 " +
+				"FROM tags t INNER JOIN LATERAL(SELECT time, metric_2 FROM generic_metrics g WHERE g.tags_id = t.id AND metric_2 IS NOT NULL " +
+				"ORDER BY time DESC LIMIT 1) AS b ON true ORDER BY t.hostname, b.time DESC",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			d := newTestDevopsGeneric(t, &BaseGenerator{UseJSON: c.useJSON, UseTags: c.useTags})
+
+			q := d.GenerateEmptyQuery()
+			d.LastPointPerHost(q)
+			verifyQuery(t, q, "TimescaleDB last value of a generic metric per host",
+				"TimescaleDB last value of a generic metric per host: metric_2", "generic_metrics", c.expectedSQLQuery)
+		})
+	}
+}
+
+func TestDevopsGenericTopKHosts(t *testing.T) {
+	expectedHumanLabel := "TimescaleDB top 5 hosts by mean of a generic metric, random 1h0m0s"
+	expectedHumanDesc := "TimescaleDB top 5 hosts by mean of a generic metric, random 1h0m0s: 1970-01-01T00:16:22Z"
+	expectedHypertable := "generic_metrics"
+	expectedSQLQuery := `
+        WITH host_avg AS (
+          SELECT tags_id, avg(metric_0) AS mean_metric_0
+          FROM generic_metrics
+          WHERE metric_0 IS NOT NULL AND time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'
+          GROUP BY tags_id
+          ORDER BY mean_metric_0 DESC
+          LIMIT 5
+        )
+        SELECT tags.hostname, mean_metric_0
+        FROM host_avg
+        JOIN tags ON host_avg.tags_id = tags.id
+        ORDER BY mean_metric_0 DESC`
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	d := newTestDevopsGeneric(t, &BaseGenerator{UseTags: true})
+
+	q := d.GenerateEmptyQuery()
+	d.TopKHosts(q, 5)
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedHypertable, expectedSQLQuery)
+}
+
+func TestDevopsGenericMetricDiscovery(t *testing.T) {
+	expectedHumanLabel := "TimescaleDB metrics reported by a random host, random 1h0m0s"
+	expectedHumanDesc := "TimescaleDB metrics reported by a random host, random 1h0m0s: 1970-01-01T00:16:22Z"
+	expectedHypertable := "generic_metrics"
+	expectedSQLQuery := `SELECT count(metric_0) AS metric_0, count(metric_1) AS metric_1, count(metric_2) AS metric_2
+        FROM generic_metrics
+        WHERE hostname IN ('host_9') AND time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'`
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	d := newTestDevopsGeneric(t, &BaseGenerator{})
+
+	q := d.GenerateEmptyQuery()
+	d.MetricDiscovery(q)
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedHypertable, expectedSQLQuery)
+}
@@ -75,7 +75,7 @@ func TestDevopsGetHostWhereWithHostnames(t *testing.T) {
 		}
 		d := dq.(*Devops)
 
-		if got := d.getHostWhereWithHostnames(c.hostnames); got != c.want {
+		if got := d.getHostWhereWithHostnames(c.hostnames, nil); got != c.want {
 			t.Errorf("%s: incorrect output: got %s want %s", c.desc, got, c.want)
 		}
 	}
@@ -109,7 +109,7 @@ func TestDevopsGetHostWhereString(t *testing.T) {
 		}
 		d := dq.(*Devops)
 
-		if got := d.getHostWhereString(c.nHosts); got != c.want {
+		if got := d.getHostWhereString(c.nHosts, nil); got != c.want {
 			t.Errorf("incorrect output for %d hosts: got %s want %s", c.nHosts, got, c.want)
 		}
 	}
@@ -209,6 +209,119 @@ func TestDevopsGroupByTime(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedHypertable, expectedSQLQuery)
 }
 
+func TestDevopsGroupByTimeBucket(t *testing.T) {
+	expectedHumanLabel := "TimescaleDB 1 cpu metric(s), random    1 hosts, random 1s by 5m"
+	expectedHumanDesc := "TimescaleDB 1 cpu metric(s), random    1 hosts, random 1s by 5m: 1970-01-01T00:05:58Z"
+	expectedSQLQuery := `SELECT time_bucket('300 seconds', time) AS bucket,
+        max(usage_user) as max_usage_user
+        FROM cpu
+        WHERE hostname IN ('host_9') AND time >= '1970-01-01 00:05:58.646325 +0000' AND time < '1970-01-01 00:05:59.646325 +0000'
+        GROUP BY bucket ORDER BY bucket ASC`
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	b := BaseGenerator{
+		UseTimeBucket: true,
+	}
+	dq, err := b.NewDevops(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.GroupByTimeBucket(q, 1, 1, time.Second, 5*time.Minute)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, "cpu", expectedSQLQuery)
+}
+
+func TestDevopsOverrides(t *testing.T) {
+	cases := []struct {
+		desc               string
+		fill               func(*Devops, query.Query)
+		expectedHumanLabel string
+		expectedHumanDesc  string
+		expectedSQLQuery   string
+	}{
+		{
+			desc: "double groupby window and bucket",
+			fill: func(d *Devops, q query.Query) {
+				d.GroupByTimeAndPrimaryTagWindow(q, 1, 24*time.Hour, 10*time.Minute)
+			},
+			expectedHumanLabel: "TimescaleDB mean of 1 metrics, all hosts, random 24h0m0s by 10m",
+			expectedHumanDesc:  "TimescaleDB mean of 1 metrics, all hosts, random 24h0m0s by 10m: 1970-01-01T18:16:22Z",
+			expectedSQLQuery: `
+        WITH cpu_avg AS (
+          SELECT time_bucket('600 seconds', time) as bucket, hostname,
+          avg(usage_user) as mean_usage_user
+          FROM cpu
+          WHERE time >= '1970-01-01 18:16:22.646325 +0000' AND time < '1970-01-02 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        )
+        SELECT bucket, hostname, mean_usage_user
+        FROM cpu_avg
+        
+        ORDER BY bucket, hostname`,
+		},
+		{
+			desc: "max all bucket",
+			fill: func(d *Devops, q query.Query) {
+				d.MaxAllCPUBucket(q, 1, 2*time.Hour, 30*time.Minute)
+			},
+			expectedHumanLabel: "TimescaleDB max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m",
+			expectedHumanDesc:  "TimescaleDB max of all CPU metrics, random    1 hosts, random 2h0m0s by 30m: 1970-01-01T20:16:22Z",
+			expectedSQLQuery: `SELECT time_bucket('1800 seconds', time) AS bucket,
+        max(usage_user) as max_usage_user, max(usage_system) as max_usage_system, max(usage_idle) as max_usage_idle, max(usage_nice) as max_usage_nice, max(usage_iowait) as max_usage_iowait, max(usage_irq) as max_usage_irq, max(usage_softirq) as max_usage_softirq, max(usage_steal) as max_usage_steal, max(usage_guest) as max_usage_guest, max(usage_guest_nice) as max_usage_guest_nice
+        FROM cpu
+        WHERE hostname IN ('host_9') AND time >= '1970-01-01 20:16:22.646325 +0000' AND time < '1970-01-01 22:16:22.646325 +0000'
+        GROUP BY bucket ORDER BY bucket`,
+		},
+		{
+			desc: "high cpu window",
+			fill: func(d *Devops, q query.Query) {
+				d.HighCPUForHostsWindow(q, 1, time.Hour)
+			},
+			expectedHumanLabel: "TimescaleDB CPU over threshold, 1 host(s), random 1h0m0s",
+			expectedHumanDesc:  "TimescaleDB CPU over threshold, 1 host(s), random 1h0m0s: 1970-01-02T00:54:10Z",
+			expectedSQLQuery:   "SELECT * FROM cpu WHERE usage_user > 90.0 and time >= '1970-01-02 00:54:10.138978 +0000' AND time < '1970-01-02 01:54:10.138978 +0000' AND hostname IN ('host_5')",
+		},
+		{
+			desc: "groupby orderby limit",
+			fill: func(d *Devops, q query.Query) {
+				d.GroupByOrderByLimitN(q, 10)
+			},
+			expectedHumanLabel: "TimescaleDB max cpu over last 10 min-intervals (random end)",
+			expectedHumanDesc:  "TimescaleDB max cpu over last 10 min-intervals (random end): 1970-01-02T03:16:22Z",
+			expectedSQLQuery: `SELECT time_bucket('60 seconds', time) AS minute, max(usage_user)
+        FROM cpu
+        WHERE time < '1970-01-02 03:16:22.646325 +0000'
+        GROUP BY minute
+        ORDER BY minute DESC
+        LIMIT 10`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{
+				UseTimeBucket: true,
+			}
+			dq, err := b.NewDevops(s, s.Add(2*24*time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			c.fill(d, q)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, "cpu", c.expectedSQLQuery)
+		})
+	}
+}
+
 func TestGroupByOrderByLimit(t *testing.T) {
 	expectedHumanLabel := "TimescaleDB max cpu over last 5 min-intervals (random end)"
 	expectedHumanDesc := "TimescaleDB max cpu over last 5 min-intervals (random end): 1970-01-01T01:16:22Z"
@@ -510,6 +623,292 @@ func TestHighCPUForHosts(t *testing.T) {
 	}
 }
 
+func TestGroupByTimePercentiles(t *testing.T) {
+	cases := []struct {
+		desc             string
+		useTags          bool
+		expectedSQLQuery string
+	}{
+		{
+			desc: "no tags",
+			expectedSQLQuery: `
+        WITH cpu_percentiles AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, hostname,
+          percentile_cont(0.5) WITHIN GROUP (ORDER BY usage_user) AS p50_usage_user, percentile_cont(0.95) WITHIN GROUP (ORDER BY usage_user) AS p95_usage_user, percentile_cont(0.99) WITHIN GROUP (ORDER BY usage_user) AS p99_usage_user
+          FROM cpu
+          WHERE hostname IN ('host_9','host_3') AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        )
+        SELECT hour, hostname, p50_usage_user, p95_usage_user, p99_usage_user
+        FROM cpu_percentiles
+        
+        ORDER BY hour, hostname`,
+		},
+		{
+			desc:    "use tags",
+			useTags: true,
+			expectedSQLQuery: `
+        WITH cpu_percentiles AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, tags_id,
+          percentile_cont(0.5) WITHIN GROUP (ORDER BY usage_user) AS p50_usage_user, percentile_cont(0.95) WITHIN GROUP (ORDER BY usage_user) AS p95_usage_user, percentile_cont(0.99) WITHIN GROUP (ORDER BY usage_user) AS p99_usage_user
+          FROM cpu
+          WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_3')) AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        )
+        SELECT hour, tags.hostname, p50_usage_user, p95_usage_user, p99_usage_user
+        FROM cpu_percentiles
+        JOIN tags ON cpu_percentiles.tags_id = tags.id
+        ORDER BY hour, tags.hostname`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			e := s.Add(24 * time.Hour)
+			b := BaseGenerator{
+				UseTags:       c.useTags,
+				UseTimeBucket: true,
+			}
+			dq, err := b.NewDevops(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			d.GroupByTimePercentiles(q, 2, 1, 12*time.Hour)
+			verifyQuery(t, q, "TimescaleDB p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h",
+				"TimescaleDB p50/p95/p99 of 1 cpu metric(s), random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z",
+				"cpu", c.expectedSQLQuery)
+		})
+	}
+}
+
+func TestGroupByTimeCrossMeasurement(t *testing.T) {
+	cases := []struct {
+		desc             string
+		useTags          bool
+		expectedSQLQuery string
+	}{
+		{
+			desc: "no tags",
+			expectedSQLQuery: `
+        WITH cpu_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, hostname, avg(usage_user) AS mean_usage_user
+          FROM cpu
+          WHERE hostname IN ('host_9','host_3') AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        ), mem_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, hostname, avg(used_percent) AS mean_used_percent
+          FROM mem
+          WHERE hostname IN ('host_9','host_3') AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        ), diskio_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, hostname, avg(reads) AS mean_reads
+          FROM diskio
+          WHERE hostname IN ('host_9','host_3') AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        )
+        SELECT hour, hostname, mean_usage_user, mean_used_percent, mean_reads
+        FROM cpu_avg
+        JOIN mem_avg USING (hour, hostname)
+        JOIN diskio_avg USING (hour, hostname)
+        ORDER BY hour, hostname`,
+		},
+		{
+			desc:    "use tags",
+			useTags: true,
+			expectedSQLQuery: `
+        WITH cpu_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, tags_id, avg(usage_user) AS mean_usage_user
+          FROM cpu
+          WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_3')) AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        ), mem_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, tags_id, avg(used_percent) AS mean_used_percent
+          FROM mem
+          WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_3')) AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        ), diskio_avg AS (
+          SELECT time_bucket('3600 seconds', time) AS hour, tags_id, avg(reads) AS mean_reads
+          FROM diskio
+          WHERE tags_id IN (SELECT id FROM tags WHERE hostname IN ('host_9','host_3')) AND time >= '1970-01-01 06:16:22.646325 +0000' AND time < '1970-01-01 18:16:22.646325 +0000'
+          GROUP BY 1, 2
+        )
+        SELECT hour, tags.hostname, mean_usage_user, mean_used_percent, mean_reads
+        FROM cpu_avg
+        JOIN mem_avg USING (hour, tags_id)
+        JOIN diskio_avg USING (hour, tags_id)
+        JOIN tags ON cpu_avg.tags_id = tags.id
+        ORDER BY hour, tags.hostname`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			e := s.Add(24 * time.Hour)
+			b := BaseGenerator{
+				UseTags:       c.useTags,
+				UseTimeBucket: true,
+			}
+			dq, err := b.NewDevops(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			d.GroupByTimeCrossMeasurement(q, 2, 12*time.Hour)
+			verifyQuery(t, q, "TimescaleDB mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h",
+				"TimescaleDB mean of cpu usage_user, mem used_percent, diskio reads, random    2 hosts, random 12h0m0s by 1h: 1970-01-01T06:16:22Z",
+				"cpu", c.expectedSQLQuery)
+		})
+	}
+}
+
+func TestTopKHosts(t *testing.T) {
+	cases := []struct {
+		desc             string
+		useTags          bool
+		expectedSQLQuery string
+	}{
+		{
+			desc: "no tags",
+			expectedSQLQuery: `
+        WITH host_avg AS (
+          SELECT hostname, avg(usage_user) AS mean_usage_user
+          FROM cpu
+          WHERE time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'
+          GROUP BY 1
+          ORDER BY mean_usage_user DESC
+          LIMIT 10
+        )
+        SELECT hostname, mean_usage_user
+        FROM host_avg
+        
+        ORDER BY mean_usage_user DESC`,
+		},
+		{
+			desc:    "use tags",
+			useTags: true,
+			expectedSQLQuery: `
+        WITH host_avg AS (
+          SELECT tags_id, avg(usage_user) AS mean_usage_user
+          FROM cpu
+          WHERE time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'
+          GROUP BY 1
+          ORDER BY mean_usage_user DESC
+          LIMIT 10
+        )
+        SELECT tags.hostname, mean_usage_user
+        FROM host_avg
+        JOIN tags ON host_avg.tags_id = tags.id
+        ORDER BY mean_usage_user DESC`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			e := s.Add(2 * time.Hour)
+			b := BaseGenerator{
+				UseTags: c.useTags,
+			}
+			dq, err := b.NewDevops(s, e, 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			d.TopKHosts(q, 10)
+			verifyQuery(t, q, "TimescaleDB top 10 hosts by mean usage_user, random 1h0m0s",
+				"TimescaleDB top 10 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:16:22Z",
+				"cpu", c.expectedSQLQuery)
+		})
+	}
+}
+
+func TestTagValuesAndSeriesCount(t *testing.T) {
+	cases := []struct {
+		desc           string
+		useJSON        bool
+		expectedValues string
+		expectedCount  string
+	}{
+		{
+			desc:           "tags",
+			expectedValues: `SELECT DISTINCT hostname FROM tags WHERE region = 'ap-southeast-1' ORDER BY 1`,
+			expectedCount:  `SELECT count(*) AS series FROM tags WHERE region = 'us-east-1'`,
+		},
+		{
+			desc:           "use JSON",
+			useJSON:        true,
+			expectedValues: `SELECT DISTINCT tagset->>'hostname' FROM tags WHERE tagset->>'region' = 'ap-southeast-1' ORDER BY 1`,
+			expectedCount:  `SELECT count(*) AS series FROM tags WHERE tagset->>'region' = 'us-east-1'`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			s := time.Unix(0, 0)
+			b := BaseGenerator{
+				UseJSON: c.useJSON,
+			}
+			dq, err := b.NewDevops(s, s.Add(time.Hour), 10)
+			if err != nil {
+				t.Fatalf("Error while creating devops generator")
+			}
+			d := dq.(*Devops)
+
+			q := d.GenerateEmptyQuery()
+			d.TagValues(q)
+			verifyQuery(t, q, "TimescaleDB hostname tag values, random region",
+				"TimescaleDB hostname tag values, random region: ap-southeast-1", "tags", c.expectedValues)
+
+			q = d.GenerateEmptyQuery()
+			d.SeriesCount(q)
+			verifyQuery(t, q, "TimescaleDB cpu series count, random region",
+				"TimescaleDB cpu series count, random region: us-east-1", "tags", c.expectedCount)
+		})
+	}
+}
+
+func TestDevopsPreparedStatements(t *testing.T) {
+	expectedSQLQuery := `SELECT * FROM cpu WHERE usage_user > 90.0 and time >= $3 AND time < $4 AND tags_id IN (SELECT id FROM tags WHERE hostname IN ($1,$2))`
+	expectedArgs := []string{"host_5", "host_9", "1970-01-01 00:47:30.894865 +0000", "1970-01-01 12:47:30.894865 +0000"}
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(devops.HighCPUDuration).Add(time.Hour)
+	b := BaseGenerator{
+		UseTags:               true,
+		UsePreparedStatements: true,
+	}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.HighCPUForHosts(q, 2)
+	tsq := q.(*query.TimescaleDB)
+
+	if got := string(tsq.SqlQuery); got != expectedSQLQuery {
+		t.Errorf("incorrect SQL template:\ngot\n%s\nwant\n%s", got, expectedSQLQuery)
+	}
+	if got := strings.Join(tsq.SqlArgs, ","); got != strings.Join(expectedArgs, ",") {
+		t.Errorf("incorrect SQL args:\ngot\n%s\nwant\n%s", got, strings.Join(expectedArgs, ","))
+	}
+}
+
 func verifyQuery(t *testing.T, q query.Query, humanLabel, humanDesc, hypertable, sqlQuery string) {
 	tsq, ok := q.(*query.TimescaleDB)
 
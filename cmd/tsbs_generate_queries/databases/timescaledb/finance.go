@@ -0,0 +1,135 @@
+package timescaledb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Finance produces TimescaleDB-specific queries for all the finance query types.
+type Finance struct {
+	*finance.Core
+	*BaseGenerator
+}
+
+// NewFinance makes a Finance object ready to generate Queries.
+func NewFinance(start, end time.Time, scale int, g *BaseGenerator) *Finance {
+	c, err := finance.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Finance{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// getRandomSymbols returns nSymbols random symbols quoted as SQL strings.
+func (f *Finance) getRandomSymbols(nSymbols int) string {
+	symbols, err := f.GetRandomSymbols(nSymbols)
+	panicIfErr(err)
+	return fmt.Sprintf("'%s'", strings.Join(symbols, "','"))
+}
+
+// OHLCBars fetches the open, high, low and close prices and the traded volume
+// per minute of nSymbols symbols.
+func (f *Finance) OHLCBars(qi query.Query, nSymbols int) {
+	symbol := "symbol"
+	symbols := f.getRandomSymbols(nSymbols)
+	interval := f.MustRandWindow(finance.OHLCDuration)
+
+	sql := fmt.Sprintf(`SELECT time_bucket('1 minute', tr.time) AS minute, t.%s,
+		first(tr.price, tr.time) AS open, max(tr.price) AS high, min(tr.price) AS low, last(tr.price, tr.time) AS close, sum(tr.size) AS volume
+		FROM trades tr
+		INNER JOIN tags t ON tr.tags_id = t.id
+		WHERE tr.time >= '%s' AND tr.time < '%s'
+		AND t.%s IN (%s)
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		f.withAlias(symbol),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		f.columnSelect(symbol),
+		symbols)
+
+	humanLabel := "TimescaleDB OHLC bars per minute by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.TradesTableName, sql)
+}
+
+// VWAP fetches the hourly volume-weighted average price of nSymbols symbols.
+func (f *Finance) VWAP(qi query.Query, nSymbols int) {
+	symbol := "symbol"
+	symbols := f.getRandomSymbols(nSymbols)
+	interval := f.MustRandWindow(finance.VWAPDuration)
+
+	sql := fmt.Sprintf(`SELECT time_bucket('1 hour', tr.time) AS hour, t.%s,
+		sum(tr.price * tr.size) / sum(tr.size) AS vwap, sum(tr.size) AS volume
+		FROM trades tr
+		INNER JOIN tags t ON tr.tags_id = t.id
+		WHERE tr.time >= '%s' AND tr.time < '%s'
+		AND t.%s IN (%s)
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		f.withAlias(symbol),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		f.columnSelect(symbol),
+		symbols)
+
+	humanLabel := "TimescaleDB hourly VWAP by specific symbol"
+	humanDesc := fmt.Sprintf("%s: random %4d symbols, %s", humanLabel, nSymbols, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.TradesTableName, sql)
+}
+
+// LastQuotePerSymbol finds the latest quote of all the symbols of an exchange.
+func (f *Finance) LastQuotePerSymbol(qi query.Query) {
+	symbol, exchange := "symbol", "exchange"
+
+	sql := fmt.Sprintf(`SELECT t.%s, q.*
+		FROM tags t INNER JOIN LATERAL
+			(SELECT time, bid_price, bid_size, ask_price, ask_size
+			FROM quotes q
+			WHERE q.tags_id=t.id
+			ORDER BY time DESC LIMIT 1) q ON true
+		WHERE t.%s IS NOT NULL
+		AND t.%s = '%s'`,
+		f.withAlias(symbol),
+		f.columnSelect(symbol),
+		f.columnSelect(exchange),
+		f.GetRandomExchange())
+
+	humanLabel := "TimescaleDB last quote per symbol"
+	humanDesc := humanLabel
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.QuotesTableName, sql)
+}
+
+// SpreadStats computes the minimum, average and maximum bid-ask spread of all
+// the symbols of an exchange in a time window.
+func (f *Finance) SpreadStats(qi query.Query) {
+	symbol, exchange := "symbol", "exchange"
+	interval := f.MustRandWindow(finance.SpreadDuration)
+
+	sql := fmt.Sprintf(`SELECT t.%s,
+		min(q.ask_price - q.bid_price) AS min_spread, avg(q.ask_price - q.bid_price) AS avg_spread, max(q.ask_price - q.bid_price) AS max_spread
+		FROM quotes q
+		INNER JOIN tags t ON q.tags_id = t.id
+		WHERE q.time >= '%s' AND q.time < '%s'
+		AND t.%s = '%s'
+		GROUP BY 1
+		ORDER BY 1`,
+		f.withAlias(symbol),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		f.columnSelect(exchange),
+		f.GetRandomExchange())
+
+	humanLabel := "TimescaleDB spread statistics per symbol"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	f.fillInQuery(qi, humanLabel, humanDesc, finance.QuotesTableName, sql)
+}
@@ -0,0 +1,192 @@
+package timescaledb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestFinanceOHLCBars(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero symbols",
+			input:   0,
+			fail:    true,
+			failMsg: "number of symbols cannot be < 1; got 0",
+		},
+		{
+			desc:    "more symbols than scale",
+			input:   2 * testScale,
+			fail:    true,
+			failMsg: "number of symbols (20) larger than total symbols. See --scale (10)",
+		},
+		{
+			desc:  "one symbol",
+			input: 1,
+
+			expectedHumanLabel: "TimescaleDB OHLC bars per minute by specific symbol",
+			expectedHumanDesc:  "TimescaleDB OHLC bars per minute by specific symbol: random    1 symbols, 1970-01-01T04:54:10Z",
+			expectedHypertable: "trades",
+			expectedSQLQuery: `SELECT time_bucket('1 minute', tr.time) AS minute, t.symbol AS symbol,
+		first(tr.price, tr.time) AS open, max(tr.price) AS high, min(tr.price) AS low, last(tr.price, tr.time) AS close, sum(tr.size) AS volume
+		FROM trades tr
+		INNER JOIN tags t ON tr.tags_id = t.id
+		WHERE tr.time >= '1970-01-01 04:54:10.138978 +0000' AND tr.time < '1970-01-01 05:54:10.138978 +0000'
+		AND t.symbol IN ('symbol_5')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "one symbol use JSON",
+			input:   1,
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB OHLC bars per minute by specific symbol",
+			expectedHumanDesc:  "TimescaleDB OHLC bars per minute by specific symbol: random    1 symbols, 1970-01-01T04:54:10Z",
+			expectedHypertable: "trades",
+			expectedSQLQuery: `SELECT time_bucket('1 minute', tr.time) AS minute, t.tagset->>'symbol' AS symbol,
+		first(tr.price, tr.time) AS open, max(tr.price) AS high, min(tr.price) AS low, last(tr.price, tr.time) AS close, sum(tr.size) AS volume
+		FROM trades tr
+		INNER JOIN tags t ON tr.tags_id = t.id
+		WHERE tr.time >= '1970-01-01 04:54:10.138978 +0000' AND tr.time < '1970-01-01 05:54:10.138978 +0000'
+		AND t.tagset->>'symbol' IN ('symbol_5')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(f *Finance, c testCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.OHLCBars(q, c.input)
+		return q
+	}
+
+	runFinanceTestCases(t, testFunc, cases)
+}
+
+func TestFinanceVWAP(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:  "two symbols",
+			input: 2,
+
+			expectedHumanLabel: "TimescaleDB hourly VWAP by specific symbol",
+			expectedHumanDesc:  "TimescaleDB hourly VWAP by specific symbol: random    2 symbols, 1970-01-01T05:47:30Z",
+			expectedHypertable: "trades",
+			expectedSQLQuery: `SELECT time_bucket('1 hour', tr.time) AS hour, t.symbol AS symbol,
+		sum(tr.price * tr.size) / sum(tr.size) AS vwap, sum(tr.size) AS volume
+		FROM trades tr
+		INNER JOIN tags t ON tr.tags_id = t.id
+		WHERE tr.time >= '1970-01-01 05:47:30.894865 +0000' AND tr.time < '1970-01-01 17:47:30.894865 +0000'
+		AND t.symbol IN ('symbol_5','symbol_9')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(f *Finance, c testCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.VWAP(q, c.input)
+		return q
+	}
+
+	runFinanceTestCases(t, testFunc, cases)
+}
+
+func TestFinanceLastQuotePerSymbol(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB last quote per symbol",
+			expectedHumanDesc:  "TimescaleDB last quote per symbol",
+			expectedHypertable: "quotes",
+			expectedSQLQuery: `SELECT t.symbol AS symbol, q.*
+		FROM tags t INNER JOIN LATERAL
+			(SELECT time, bid_price, bid_size, ask_price, ask_size
+			FROM quotes q
+			WHERE q.tags_id=t.id
+			ORDER BY time DESC LIMIT 1) q ON true
+		WHERE t.symbol IS NOT NULL
+		AND t.exchange = 'TSE'`,
+		},
+	}
+
+	testFunc := func(f *Finance, c testCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.LastQuotePerSymbol(q)
+		return q
+	}
+
+	runFinanceTestCases(t, testFunc, cases)
+}
+
+func TestFinanceSpreadStats(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB spread statistics per symbol",
+			expectedHumanDesc:  "TimescaleDB spread statistics per symbol: 1970-01-01T20:16:22Z",
+			expectedHypertable: "quotes",
+			expectedSQLQuery: `SELECT t.tagset->>'symbol' AS symbol,
+		min(q.ask_price - q.bid_price) AS min_spread, avg(q.ask_price - q.bid_price) AS avg_spread, max(q.ask_price - q.bid_price) AS max_spread
+		FROM quotes q
+		INNER JOIN tags t ON q.tags_id = t.id
+		WHERE q.time >= '1970-01-01 20:16:22.646325 +0000' AND q.time < '1970-01-01 21:16:22.646325 +0000'
+		AND t.tagset->>'exchange' = 'NASDAQ'
+		GROUP BY 1
+		ORDER BY 1`,
+		},
+	}
+
+	testFunc := func(f *Finance, c testCase) query.Query {
+		q := f.GenerateEmptyQuery()
+		f.SpreadStats(q)
+		return q
+	}
+
+	runFinanceTestCases(t, testFunc, cases)
+}
+
+func runFinanceTestCases(t *testing.T, testFunc func(*Finance, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			b.UseJSON = c.useJSON
+			fq, err := b.NewFinance(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating finance generator")
+			}
+			f := fq.(*Finance)
+
+			if c.fail {
+				func() {
+					defer func() {
+						r := recover()
+						if r == nil {
+							t.Fatalf("did not panic when should")
+						}
+
+						if r != c.failMsg {
+							t.Fatalf("incorrect fail message: got %s, want %s", r, c.failMsg)
+						}
+					}()
+
+					testFunc(f, c)
+				}()
+			} else {
+				q := testFunc(f, c)
+
+				verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedHypertable, c.expectedSQLQuery)
+			}
+		})
+	}
+}
@@ -29,18 +29,6 @@ func NewIoT(start, end time.Time, scale int, g *BaseGenerator) *IoT {
 	}
 }
 
-func (i *IoT) columnSelect(column string) string {
-	if i.UseJSON {
-		return fmt.Sprintf("tagset->>'%[1]s'", column)
-	}
-
-	return column
-}
-
-func (i *IoT) withAlias(column string) string {
-	return fmt.Sprintf("%s AS %s", i.columnSelect(column), column)
-}
-
 func (i *IoT) getTrucksWhereWithNames(names []string) string {
 	nameClauses := []string{}
 	if i.UseJSON {
@@ -163,7 +151,7 @@ func (i *IoT) TrucksWithHighLoad(qi query.Query) {
 func (i *IoT) StationaryTrucks(qi query.Query) {
 	name, driver, fleet := "name", "driver", "fleet"
 
-	interval := i.Interval.MustRandWindow(iot.StationaryDuration)
+	interval := i.MustRandWindow(iot.StationaryDuration)
 	sql := fmt.Sprintf(`SELECT t.%s, t.%s
 		FROM tags t 
 		INNER JOIN readings r ON r.tags_id = t.id 
@@ -190,7 +178,7 @@ func (i *IoT) StationaryTrucks(qi query.Query) {
 func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
 	name, driver, fleet := "name", "driver", "fleet"
 
-	interval := i.Interval.MustRandWindow(iot.LongDrivingSessionDuration)
+	interval := i.MustRandWindow(iot.LongDrivingSessionDuration)
 	sql := fmt.Sprintf(`SELECT t.%s, t.%s
 		FROM tags t 
 		INNER JOIN LATERAL 
@@ -224,7 +212,7 @@ func (i *IoT) TrucksWithLongDrivingSessions(qi query.Query) {
 func (i *IoT) TrucksWithLongDailySessions(qi query.Query) {
 	name, driver, fleet := "name", "driver", "fleet"
 
-	interval := i.Interval.MustRandWindow(iot.DailyDrivingDuration)
+	interval := i.MustRandWindow(iot.DailyDrivingDuration)
 	sql := fmt.Sprintf(`SELECT t.%s, t.%s
 		FROM tags t 
 		INNER JOIN LATERAL 
@@ -424,6 +412,33 @@ func (i *IoT) TruckBreakdownFrequency(qi query.Query) {
 	i.fillInQuery(qi, humanLabel, humanDesc, iot.DiagnosticsTableName, sql)
 }
 
+// GapfillByTruck fetches the average velocity per minute of nTrucks trucks,
+// filling the minutes without readings with the last observed value.
+func (i *IoT) GapfillByTruck(qi query.Query, nTrucks int) {
+	name := "name"
+	names, err := i.GetRandomTrucks(nTrucks)
+	panicIfErr(err)
+	interval := i.MustRandWindow(iot.GapfillDuration)
+
+	sql := fmt.Sprintf(`SELECT time_bucket_gapfill('1 minute', r.time) AS minute, t.%s, locf(avg(r.velocity)) AS velocity
+		FROM readings r
+		INNER JOIN tags t ON r.tags_id = t.id
+		WHERE r.time >= '%s' AND r.time < '%s'
+		AND t.%s IN ('%s')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		i.withAlias(name),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		i.columnSelect(name),
+		strings.Join(names, "','"))
+
+	humanLabel := "TimescaleDB gap-filled velocity per minute by specific truck"
+	humanDesc := fmt.Sprintf("%s: random %4d trucks, %s", humanLabel, nTrucks, interval.StartString())
+
+	i.fillInQuery(qi, humanLabel, humanDesc, iot.ReadingsTableName, sql)
+}
+
 // tenMinutePeriods calculates the number of 10 minute periods that can fit in
 // the time duration if we subtract the minutes specified by minutesPerHour value.
 // E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
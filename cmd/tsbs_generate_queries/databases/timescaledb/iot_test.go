@@ -893,6 +893,59 @@ func TestTenMinutePeriods(t *testing.T) {
 
 }
 
+func TestGapfillByTruck(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "zero trucks",
+			input:   0,
+			fail:    true,
+			failMsg: "number of trucks cannot be < 1; got 0",
+		},
+		{
+			desc:  "one truck",
+			input: 1,
+
+			expectedHumanLabel: "TimescaleDB gap-filled velocity per minute by specific truck",
+			expectedHumanDesc:  "TimescaleDB gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T00:54:10Z",
+			expectedHypertable: "readings",
+			expectedSQLQuery: `SELECT time_bucket_gapfill('1 minute', r.time) AS minute, t.name AS name, locf(avg(r.velocity)) AS velocity
+		FROM readings r
+		INNER JOIN tags t ON r.tags_id = t.id
+		WHERE r.time >= '1970-01-01 00:54:10.138978 +0000' AND r.time < '1970-01-01 01:54:10.138978 +0000'
+		AND t.name IN ('truck_5')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "one truck use JSON",
+			input:   1,
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB gap-filled velocity per minute by specific truck",
+			expectedHumanDesc:  "TimescaleDB gap-filled velocity per minute by specific truck: random    1 trucks, 1970-01-01T00:37:12Z",
+			expectedHypertable: "readings",
+			expectedSQLQuery: `SELECT time_bucket_gapfill('1 minute', r.time) AS minute, t.tagset->>'name' AS name, locf(avg(r.velocity)) AS velocity
+		FROM readings r
+		INNER JOIN tags t ON r.tags_id = t.id
+		WHERE r.time >= '1970-01-01 00:37:12.342805 +0000' AND r.time < '1970-01-01 01:37:12.342805 +0000'
+		AND t.tagset->>'name' IN ('truck_3')
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(i *IoT, c testCase) query.Query {
+		q := i.GenerateEmptyQuery()
+		i.GapfillByTruck(q, c.input)
+		return q
+	}
+
+	start := time.Unix(0, 0)
+	end := start.Add(2 * time.Hour)
+
+	runTestCases(t, testFunc, start, end, cases)
+}
+
 func runTestCases(t *testing.T, testFunc func(*IoT, testCase) query.Query, s time.Time, e time.Time, cases []testCase) {
 	rand.Seed(123) // Setting seed for testing purposes.
 
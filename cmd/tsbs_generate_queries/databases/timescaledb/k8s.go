@@ -0,0 +1,104 @@
+package timescaledb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// K8s produces TimescaleDB-specific queries for all the k8s query types.
+type K8s struct {
+	*k8s.Core
+	*BaseGenerator
+}
+
+// NewK8s makes a K8s object ready to generate Queries.
+func NewK8s(start, end time.Time, scale int, g *BaseGenerator) *K8s {
+	c, err := k8s.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &K8s{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// NamespaceCPU fetches the CPU usage of every namespace per minute. The usage
+// is averaged per container first, as there can be several readings of a
+// container in a minute.
+func (k *K8s) NamespaceCPU(qi query.Query) {
+	namespace := "namespace"
+	interval := k.MustRandWindow(k8s.NamespaceCPUDuration)
+
+	sql := fmt.Sprintf(`SELECT c.minute, t.%s, sum(c.cpu_usage) AS cpu_usage
+		FROM (SELECT time_bucket('1 minute', time) AS minute, tags_id, avg(cpu_usage) AS cpu_usage
+			FROM container
+			WHERE time >= '%s' AND time < '%s'
+			GROUP BY 1, 2) c
+		INNER JOIN tags t ON c.tags_id = t.id
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		k.withAlias(namespace),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt))
+
+	humanLabel := "TimescaleDB CPU usage per namespace per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, k8s.ContainerTableName, sql)
+}
+
+// TopPods finds the k pods with the highest average CPU usage, adding up the
+// usage of their containers.
+func (k *K8s) TopPods(qi query.Query, topK int) {
+	namespace, pod := "namespace", "pod"
+	interval := k.MustRandWindow(k8s.TopPodsDuration)
+
+	sql := fmt.Sprintf(`SELECT t.%s, t.%s, sum(c.cpu_usage) AS cpu_usage
+		FROM (SELECT tags_id, avg(cpu_usage) AS cpu_usage
+			FROM container
+			WHERE time >= '%s' AND time < '%s'
+			GROUP BY 1) c
+		INNER JOIN tags t ON c.tags_id = t.id
+		GROUP BY 1, 2
+		ORDER BY 3 DESC
+		LIMIT %d`,
+		k.withAlias(namespace),
+		k.withAlias(pod),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		topK)
+
+	humanLabel := fmt.Sprintf("TimescaleDB top %d pods by CPU usage", topK)
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, k8s.ContainerTableName, sql)
+}
+
+// Restarts finds the containers of a random namespace which restarted, with
+// their number of restarts.
+func (k *K8s) Restarts(qi query.Query) {
+	pod, container, namespace := "pod", "container", "namespace"
+	interval := k.MustRandWindow(k8s.RestartsDuration)
+
+	sql := fmt.Sprintf(`SELECT t.%s, t.%s, max(c.restarts) - min(c.restarts) AS restarts
+		FROM container c
+		INNER JOIN tags t ON c.tags_id = t.id
+		WHERE c.time >= '%s' AND c.time < '%s'
+		AND t.%s = '%s'
+		GROUP BY 1, 2
+		HAVING max(c.restarts) > min(c.restarts)
+		ORDER BY 3 DESC`,
+		k.withAlias(pod),
+		k.withAlias(container),
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		k.columnSelect(namespace),
+		k.GetRandomNamespace())
+
+	humanLabel := "TimescaleDB container restarts in a namespace"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	k.fillInQuery(qi, humanLabel, humanDesc, k8s.ContainerTableName, sql)
+}
@@ -0,0 +1,134 @@
+package timescaledb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestK8sNamespaceCPU(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB CPU usage per namespace per minute",
+			expectedHumanDesc:  "TimescaleDB CPU usage per namespace per minute: 1970-01-01T20:16:22Z",
+			expectedHypertable: "container",
+			expectedSQLQuery: `SELECT c.minute, t.namespace AS namespace, sum(c.cpu_usage) AS cpu_usage
+		FROM (SELECT time_bucket('1 minute', time) AS minute, tags_id, avg(cpu_usage) AS cpu_usage
+			FROM container
+			WHERE time >= '1970-01-01 20:16:22.646325 +0000' AND time < '1970-01-01 21:16:22.646325 +0000'
+			GROUP BY 1, 2) c
+		INNER JOIN tags t ON c.tags_id = t.id
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB CPU usage per namespace per minute",
+			expectedHumanDesc:  "TimescaleDB CPU usage per namespace per minute: 1970-01-01T20:16:22Z",
+			expectedHypertable: "container",
+			expectedSQLQuery: `SELECT c.minute, t.tagset->>'namespace' AS namespace, sum(c.cpu_usage) AS cpu_usage
+		FROM (SELECT time_bucket('1 minute', time) AS minute, tags_id, avg(cpu_usage) AS cpu_usage
+			FROM container
+			WHERE time >= '1970-01-01 20:16:22.646325 +0000' AND time < '1970-01-01 21:16:22.646325 +0000'
+			GROUP BY 1, 2) c
+		INNER JOIN tags t ON c.tags_id = t.id
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(k *K8s, c testCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.NamespaceCPU(q)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func TestK8sTopPods(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:  "top 10",
+			input: 10,
+
+			expectedHumanLabel: "TimescaleDB top 10 pods by CPU usage",
+			expectedHumanDesc:  "TimescaleDB top 10 pods by CPU usage: 1970-01-01T20:16:22Z",
+			expectedHypertable: "container",
+			expectedSQLQuery: `SELECT t.namespace AS namespace, t.pod AS pod, sum(c.cpu_usage) AS cpu_usage
+		FROM (SELECT tags_id, avg(cpu_usage) AS cpu_usage
+			FROM container
+			WHERE time >= '1970-01-01 20:16:22.646325 +0000' AND time < '1970-01-01 21:16:22.646325 +0000'
+			GROUP BY 1) c
+		INNER JOIN tags t ON c.tags_id = t.id
+		GROUP BY 1, 2
+		ORDER BY 3 DESC
+		LIMIT 10`,
+		},
+	}
+
+	testFunc := func(k *K8s, c testCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.TopPods(q, c.input)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func TestK8sRestarts(t *testing.T) {
+	cases := []testCase{
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB container restarts in a namespace",
+			expectedHumanDesc:  "TimescaleDB container restarts in a namespace: 1970-01-01T20:16:22Z",
+			expectedHypertable: "container",
+			expectedSQLQuery: `SELECT t.tagset->>'pod' AS pod, t.tagset->>'container' AS container, max(c.restarts) - min(c.restarts) AS restarts
+		FROM container c
+		INNER JOIN tags t ON c.tags_id = t.id
+		WHERE c.time >= '1970-01-01 20:16:22.646325 +0000' AND c.time < '1970-01-01 21:16:22.646325 +0000'
+		AND t.tagset->>'namespace' = 'kube-system'
+		GROUP BY 1, 2
+		HAVING max(c.restarts) > min(c.restarts)
+		ORDER BY 3 DESC`,
+		},
+	}
+
+	testFunc := func(k *K8s, c testCase) query.Query {
+		q := k.GenerateEmptyQuery()
+		k.Restarts(q)
+		return q
+	}
+
+	runK8sTestCases(t, testFunc, cases)
+}
+
+func runK8sTestCases(t *testing.T, testFunc func(*K8s, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			b.UseJSON = c.useJSON
+			kq, err := b.NewK8s(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating k8s generator")
+			}
+			k := kq.(*K8s)
+
+			q := testFunc(k, c)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedHypertable, c.expectedSQLQuery)
+		})
+	}
+}
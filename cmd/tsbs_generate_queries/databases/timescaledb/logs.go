@@ -0,0 +1,74 @@
+package timescaledb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Logs produces TimescaleDB-specific queries for all the logs query types.
+type Logs struct {
+	*logs.Core
+	*BaseGenerator
+}
+
+// NewLogs makes a Logs object ready to generate Queries.
+func NewLogs(start, end time.Time, scale int, g *BaseGenerator) *Logs {
+	c, err := logs.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &Logs{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// ErrorsPerService counts the error events of every service per minute.
+func (l *Logs) ErrorsPerService(qi query.Query) {
+	service := "service"
+	interval := l.MustRandWindow(logs.ErrorsPerServiceDuration)
+
+	sql := fmt.Sprintf(`SELECT time_bucket('1 minute', l.time) AS minute, t.%s AS %s, count(*) AS errors
+		FROM logs l
+		INNER JOIN tags t ON l.tags_id = t.id
+		WHERE l.time >= '%s' AND l.time < '%s'
+		AND l.severity = '%s'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		l.columnSelect(service), service,
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		logs.ErrorSeverity)
+
+	humanLabel := "TimescaleDB errors per service per minute"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, logs.LogsTableName, sql)
+}
+
+// MessageSearch finds the latest events whose message contains a random
+// search term.
+func (l *Logs) MessageSearch(qi query.Query) {
+	service := "service"
+	interval := l.MustRandWindow(logs.MessageSearchDuration)
+	term := l.GetRandomSearchTerm()
+
+	sql := fmt.Sprintf(`SELECT l.time, t.%s AS %s, l.severity, l.trace_id, l.message
+		FROM logs l
+		INNER JOIN tags t ON l.tags_id = t.id
+		WHERE l.time >= '%s' AND l.time < '%s'
+		AND l.message LIKE '%%%s%%'
+		ORDER BY l.time DESC
+		LIMIT %d`,
+		l.columnSelect(service), service,
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		term,
+		logs.MessageSearchLimit)
+
+	humanLabel := "TimescaleDB latest events with a message containing a term"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	l.fillInQuery(qi, humanLabel, humanDesc, logs.LogsTableName, sql)
+}
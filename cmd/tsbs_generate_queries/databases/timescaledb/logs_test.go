@@ -0,0 +1,100 @@
+package timescaledb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestLogsErrorsPerService(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB errors per service per minute",
+			expectedHumanDesc:  "TimescaleDB errors per service per minute: 1970-01-01T20:16:22Z",
+			expectedHypertable: "logs",
+			expectedSQLQuery: `SELECT time_bucket('1 minute', l.time) AS minute, t.service AS service, count(*) AS errors
+		FROM logs l
+		INNER JOIN tags t ON l.tags_id = t.id
+		WHERE l.time >= '1970-01-01 20:16:22.646325 +0000' AND l.time < '1970-01-01 21:16:22.646325 +0000'
+		AND l.severity = 'ERROR'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB errors per service per minute",
+			expectedHumanDesc:  "TimescaleDB errors per service per minute: 1970-01-01T20:16:22Z",
+			expectedHypertable: "logs",
+			expectedSQLQuery: `SELECT time_bucket('1 minute', l.time) AS minute, t.tagset->>'service' AS service, count(*) AS errors
+		FROM logs l
+		INNER JOIN tags t ON l.tags_id = t.id
+		WHERE l.time >= '1970-01-01 20:16:22.646325 +0000' AND l.time < '1970-01-01 21:16:22.646325 +0000'
+		AND l.severity = 'ERROR'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(l *Logs, c testCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.ErrorsPerService(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func TestLogsMessageSearch(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB latest events with a message containing a term",
+			expectedHumanDesc:  "TimescaleDB latest events with a message containing a term: 1970-01-01T20:16:22Z",
+			expectedHypertable: "logs",
+			expectedSQLQuery: `SELECT l.time, t.service AS service, l.severity, l.trace_id, l.message
+		FROM logs l
+		INNER JOIN tags t ON l.tags_id = t.id
+		WHERE l.time >= '1970-01-01 20:16:22.646325 +0000' AND l.time < '1970-01-01 21:16:22.646325 +0000'
+		AND l.message LIKE '%insufficient funds%'
+		ORDER BY l.time DESC
+		LIMIT 100`,
+		},
+	}
+
+	testFunc := func(l *Logs, c testCase) query.Query {
+		q := l.GenerateEmptyQuery()
+		l.MessageSearch(q)
+		return q
+	}
+
+	runLogsTestCases(t, testFunc, cases)
+}
+
+func runLogsTestCases(t *testing.T, testFunc func(*Logs, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(24 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			b.UseJSON = c.useJSON
+			lq, err := b.NewLogs(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating logs generator")
+			}
+			l := lq.(*Logs)
+
+			q := testFunc(l, c)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedHypertable, c.expectedSQLQuery)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package timescaledb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SmartMeter produces TimescaleDB-specific queries for all the smart-meter
+// query types.
+type SmartMeter struct {
+	*smartmeter.Core
+	*BaseGenerator
+}
+
+// NewSmartMeter makes a SmartMeter object ready to generate Queries.
+func NewSmartMeter(start, end time.Time, scale int, g *BaseGenerator) *SmartMeter {
+	c, err := smartmeter.NewCore(start, end, scale)
+	panicIfErr(err)
+	return &SmartMeter{
+		Core:          c,
+		BaseGenerator: g,
+	}
+}
+
+// DailyConsumption sums the energy consumed in every region per day.
+func (s *SmartMeter) DailyConsumption(qi query.Query) {
+	region := "region"
+	interval := s.GetRandomDays(smartmeter.DailyConsumptionDays)
+
+	sql := fmt.Sprintf(`SELECT time_bucket('1 day', e.time) AS day, t.%s AS %s, sum(e.consumption_kwh) AS consumption_kwh
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '%s' AND e.time < '%s'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		s.columnSelect(region), region,
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt))
+
+	humanLabel := "TimescaleDB daily consumption per region"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, smartmeter.EnergyTableName, sql)
+}
+
+// PeakHourLoad sums the energy consumed in every region per hour during the
+// evening peak hours of a random day. Readings are stamped at the start of
+// the interval they cover, so the 15-minute and hourly readings of an hour
+// add up to its consumption in kWh, which is the average load in kW.
+// On-demand readings may span several hours and are left out.
+func (s *SmartMeter) PeakHourLoad(qi query.Query) {
+	region := "region"
+	interval := s.GetRandomPeakHours()
+
+	sql := fmt.Sprintf(`SELECT time_bucket('1 hour', e.time) AS hour, t.%s AS %s, sum(e.consumption_kwh) AS load_kw
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '%s' AND e.time < '%s' AND t.%s <> '%s'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		s.columnSelect(region), region,
+		interval.Start().Format(goTimeFmt),
+		interval.End().Format(goTimeFmt),
+		s.columnSelect("cadence"), smartmeter.OnDemandCadence)
+
+	humanLabel := "TimescaleDB hourly load per region during peak hours"
+	humanDesc := fmt.Sprintf("%s: %s", humanLabel, interval.StartString())
+
+	s.fillInQuery(qi, humanLabel, humanDesc, smartmeter.EnergyTableName, sql)
+}
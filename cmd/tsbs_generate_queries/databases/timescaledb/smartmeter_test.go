@@ -0,0 +1,111 @@
+package timescaledb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestSmartMeterDailyConsumption(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB daily consumption per region",
+			expectedHumanDesc:  "TimescaleDB daily consumption per region: 1970-01-02T00:00:00Z",
+			expectedHypertable: "energy",
+			expectedSQLQuery: `SELECT time_bucket('1 day', e.time) AS day, t.region AS region, sum(e.consumption_kwh) AS consumption_kwh
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '1970-01-02 00:00:00 +0000' AND e.time < '1970-01-04 00:00:00 +0000'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB daily consumption per region",
+			expectedHumanDesc:  "TimescaleDB daily consumption per region: 1970-01-02T00:00:00Z",
+			expectedHypertable: "energy",
+			expectedSQLQuery: `SELECT time_bucket('1 day', e.time) AS day, t.tagset->>'region' AS region, sum(e.consumption_kwh) AS consumption_kwh
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '1970-01-02 00:00:00 +0000' AND e.time < '1970-01-04 00:00:00 +0000'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c testCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.DailyConsumption(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func TestSmartMeterPeakHourLoad(t *testing.T) {
+	cases := []testCase{
+		{
+			desc: "default",
+
+			expectedHumanLabel: "TimescaleDB hourly load per region during peak hours",
+			expectedHumanDesc:  "TimescaleDB hourly load per region during peak hours: 1970-01-01T17:00:00Z",
+			expectedHypertable: "energy",
+			expectedSQLQuery: `SELECT time_bucket('1 hour', e.time) AS hour, t.region AS region, sum(e.consumption_kwh) AS load_kw
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '1970-01-01 17:00:00 +0000' AND e.time < '1970-01-01 21:00:00 +0000' AND t.cadence <> 'on-demand'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+		{
+			desc:    "use JSON",
+			useJSON: true,
+
+			expectedHumanLabel: "TimescaleDB hourly load per region during peak hours",
+			expectedHumanDesc:  "TimescaleDB hourly load per region during peak hours: 1970-01-01T17:00:00Z",
+			expectedHypertable: "energy",
+			expectedSQLQuery: `SELECT time_bucket('1 hour', e.time) AS hour, t.tagset->>'region' AS region, sum(e.consumption_kwh) AS load_kw
+		FROM energy e
+		INNER JOIN tags t ON e.tags_id = t.id
+		WHERE e.time >= '1970-01-01 17:00:00 +0000' AND e.time < '1970-01-01 21:00:00 +0000' AND t.tagset->>'cadence' <> 'on-demand'
+		GROUP BY 1, 2
+		ORDER BY 2, 1`,
+		},
+	}
+
+	testFunc := func(s *SmartMeter, c testCase) query.Query {
+		q := s.GenerateEmptyQuery()
+		s.PeakHourLoad(q)
+		return q
+	}
+
+	runSmartMeterTestCases(t, testFunc, cases)
+}
+
+func runSmartMeterTestCases(t *testing.T, testFunc func(*SmartMeter, testCase) query.Query, cases []testCase) {
+	s := time.Unix(0, 0)
+	e := s.Add(96 * time.Hour)
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			b := BaseGenerator{}
+			b.UseJSON = c.useJSON
+			sq, err := b.NewSmartMeter(s, e, testScale)
+			if err != nil {
+				t.Fatalf("Error while creating smart-meter generator")
+			}
+			sm := sq.(*SmartMeter)
+
+			q := testFunc(sm, c)
+
+			verifyQuery(t, q, c.expectedHumanLabel, c.expectedHumanDesc, c.expectedHypertable, c.expectedSQLQuery)
+		})
+	}
+}
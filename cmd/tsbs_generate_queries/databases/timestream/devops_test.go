@@ -227,6 +227,35 @@ func TestGroupByOrderByLimit(t *testing.T) {
 	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedTable, expectedSQLQuery)
 }
 
+func TestTopKHosts(t *testing.T) {
+	expectedHumanLabel := "Timestream top 10 hosts by mean usage_user, random 1h0m0s"
+	expectedHumanDesc := "Timestream top 10 hosts by mean usage_user, random 1h0m0s: 1970-01-01T00:16:22Z"
+	expectedTable := "cpu"
+	expectedSQLQuery := `SELECT hostname, avg(measure_value::double) AS mean_usage_user
+        FROM "b"."cpu"
+        WHERE measure_name = 'usage_user' AND time >= '1970-01-01 00:16:22.646325 +0000' AND time < '1970-01-01 01:16:22.646325 +0000'
+        GROUP BY hostname
+        ORDER BY mean_usage_user DESC
+        LIMIT 10`
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	s := time.Unix(0, 0)
+	e := s.Add(2 * time.Hour)
+	b := BaseGenerator{
+		DBName: "b",
+	}
+	dq, err := b.NewDevops(s, e, 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	d := dq.(*Devops)
+
+	q := d.GenerateEmptyQuery()
+	d.TopKHosts(q, 10)
+
+	verifyQuery(t, q, expectedHumanLabel, expectedHumanDesc, expectedTable, expectedSQLQuery)
+}
+
 func TestGroupByTimeAndPrimaryTag(t *testing.T) {
 	cases := []struct {
 		desc               string
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	iutils "github.com/timescale/tsbs/internal/utils"
 	"github.com/timescale/tsbs/pkg/query"
@@ -31,6 +34,42 @@ func (g *BaseGenerator) NewDevops(start, end time.Time, scale int) (utils.QueryG
 	}, nil
 }
 
+// NewIoT creates a new iot use case query generator.
+func (g *BaseGenerator) NewIoT(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := iot.NewCore(start, end, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &IoT{
+		BaseGenerator: g,
+		Core:          core,
+	}, nil
+}
+
+// NewDevopsGeneric creates a new devops-generic use case query generator.
+func (g *BaseGenerator) NewDevopsGeneric(start, end time.Time, scale, maxMetricCount int) (utils.QueryGenerator, error) {
+	core, err := devopsgeneric.NewCore(start, end, scale, maxMetricCount)
+	if err != nil {
+		return nil, err
+	}
+	return &DevopsGeneric{
+		BaseGenerator: g,
+		Core:          core,
+	}, nil
+}
+
+// NewK8s creates a new k8s use case query generator.
+func (g *BaseGenerator) NewK8s(start, end time.Time, scale int) (utils.QueryGenerator, error) {
+	core, err := k8s.NewCore(start, end, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &K8s{
+		BaseGenerator: g,
+		Core:          core,
+	}, nil
+}
+
 type queryInfo struct {
 	// prometheus query
 	query string
@@ -40,24 +79,43 @@ type queryInfo struct {
 	desc string
 	// time range for query executing
 	interval *iutils.TimeInterval
-	// time period to group by in seconds
+	// time period to group by in seconds; an empty step makes an instant
+	// query evaluated at the end of the interval
 	step string
+	// label to list the values of across the series matched by query over
+	// the interval, instead of evaluating query
+	labelName string
 }
 
 // fill Query fills the query struct with data
 func (g *BaseGenerator) fillInQuery(qq query.Query, qi *queryInfo) {
 	q := qq.(*query.HTTP)
 	q.HumanLabel = []byte(qi.label)
-	if qi.interval != nil {
+	if qi.desc != "" {
+		q.HumanDescription = []byte(qi.desc)
+	} else if qi.interval != nil {
 		q.HumanDescription = []byte(fmt.Sprintf("%s: %s", qi.label, qi.interval.StartString()))
 	}
 	q.Method = []byte("GET")
 
 	v := url.Values{}
+	if qi.labelName != "" {
+		v.Set("match[]", qi.query)
+		v.Set("start", strconv.FormatInt(qi.interval.StartUnixNano()/1e9, 10))
+		v.Set("end", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		q.Path = []byte(fmt.Sprintf("/api/v1/label/%s/values?%s", qi.labelName, v.Encode()))
+		q.Body = nil
+		return
+	}
 	v.Set("query", qi.query)
-	v.Set("start", strconv.FormatInt(qi.interval.StartUnixNano()/1e9, 10))
-	v.Set("end", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
-	v.Set("step", qi.step)
-	q.Path = []byte(fmt.Sprintf("/api/v1/query_range?%s", v.Encode()))
+	if qi.step == "" {
+		v.Set("time", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		q.Path = []byte(fmt.Sprintf("/api/v1/query?%s", v.Encode()))
+	} else {
+		v.Set("start", strconv.FormatInt(qi.interval.StartUnixNano()/1e9, 10))
+		v.Set("end", strconv.FormatInt(qi.interval.EndUnixNano()/1e9, 10))
+		v.Set("step", qi.step)
+		q.Path = []byte(fmt.Sprintf("/api/v1/query_range?%s", v.Encode()))
+	}
 	q.Body = nil
 }
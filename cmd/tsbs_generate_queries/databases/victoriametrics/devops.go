@@ -2,6 +2,7 @@ package victoriametrics
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,14 +47,20 @@ func (d *Devops) HighCPUForHosts(qi query.Query, nHosts int) {
 // 	)
 // ) by (__name__)
 func (d *Devops) GroupByTime(qq query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	d.GroupByTimeBucket(qq, nHosts, numMetrics, timeRange, time.Minute)
+}
+
+// GroupByTimeBucket is GroupByTime with time buckets of the given size.
+func (d *Devops) GroupByTimeBucket(qq query.Query, nHosts, numMetrics int, timeRange, bucket time.Duration) {
 	metrics := mustGetCPUMetricsSlice(numMetrics)
 	hosts := d.mustGetRandomHosts(nHosts)
 	selectClause := getSelectClause(metrics, hosts)
+	bucketName := devops.GetBucketName(bucket)
 	qi := &queryInfo{
-		query:    fmt.Sprintf("max(max_over_time(%s[1m])) by (__name__)", selectClause),
-		label:    fmt.Sprintf("VictoriaMetrics %d cpu metric(s), random %4d hosts, random %s by 1m", numMetrics, nHosts, timeRange),
-		interval: d.Interval.MustRandWindow(timeRange),
-		step:     "60",
+		query:    fmt.Sprintf("max(max_over_time(%s[%s])) by (__name__)", selectClause, bucketName),
+		label:    fmt.Sprintf("VictoriaMetrics %d cpu metric(s), random %4d hosts, random %s by %s", numMetrics, nHosts, timeRange, bucketName),
+		interval: d.MustRandWindow(timeRange),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
 	}
 	d.fillInQuery(qq, qi)
 }
@@ -72,13 +79,19 @@ func (d *Devops) GroupByTime(qq query.Query, nHosts, numMetrics int, timeRange t
 // double-groupby-5
 // double-groupby-all
 func (d *Devops) GroupByTimeAndPrimaryTag(qq query.Query, numMetrics int) {
+	d.GroupByTimeAndPrimaryTagWindow(qq, numMetrics, devops.DoubleGroupByDuration, devops.DoubleGroupByBucket)
+}
+
+// GroupByTimeAndPrimaryTagWindow is GroupByTimeAndPrimaryTag over the given
+// window with time buckets of the given size.
+func (d *Devops) GroupByTimeAndPrimaryTagWindow(qq query.Query, numMetrics int, window, bucket time.Duration) {
 	metrics := mustGetCPUMetricsSlice(numMetrics)
 	selectClause := getSelectClause(metrics, nil)
 	qi := &queryInfo{
-		query:    fmt.Sprintf("avg(avg_over_time(%s[1h])) by (__name__, hostname)", selectClause),
-		label:    devops.GetDoubleGroupByLabel("VictoriaMetrics", numMetrics),
-		interval: d.Interval.MustRandWindow(devops.DoubleGroupByDuration),
-		step:     "3600",
+		query:    fmt.Sprintf("avg(avg_over_time(%s[%s])) by (__name__, hostname)", selectClause, devops.GetBucketName(bucket)),
+		label:    devops.GetDoubleGroupByWindowLabel("VictoriaMetrics", numMetrics, window, bucket),
+		interval: d.MustRandWindow(window),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
 	}
 	d.fillInQuery(qq, qi)
 }
@@ -92,17 +105,96 @@ func (d *Devops) GroupByTimeAndPrimaryTag(qq query.Query, numMetrics int) {
 // 	)
 // ) by (__name__)
 func (d *Devops) MaxAllCPU(qq query.Query, nHosts int, duration time.Duration) {
+	d.MaxAllCPUBucket(qq, nHosts, duration, devops.MaxAllBucket)
+}
+
+// MaxAllCPUBucket is MaxAllCPU with time buckets of the given size.
+func (d *Devops) MaxAllCPUBucket(qq query.Query, nHosts int, duration, bucket time.Duration) {
 	hosts := d.mustGetRandomHosts(nHosts)
 	selectClause := getSelectClause(devops.GetAllCPUMetrics(), hosts)
 	qi := &queryInfo{
-		query:    fmt.Sprintf("max(max_over_time(%s[1h])) by (__name__)", selectClause),
-		label:    devops.GetMaxAllLabel("VictoriaMetrics", nHosts),
-		interval: d.Interval.MustRandWindow(duration),
+		query:    fmt.Sprintf("max(max_over_time(%s[%s])) by (__name__)", selectClause, devops.GetBucketName(bucket)),
+		label:    devops.GetMaxAllBucketLabel("VictoriaMetrics", nHosts, duration, bucket),
+		interval: d.MustRandWindow(duration),
+		step:     strconv.FormatInt(int64(bucket.Seconds()), 10),
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// GroupByTimePercentiles selects the p50, p95 and p99 of numMetrics metrics
+// under 'cpu' per host per hour for nHosts hosts,
+// e.g. in MetricsQL:
+//
+// quantiles_over_time("phi", 0.5, 0.95, 0.99, {__name__=~"metric1|metric2...|metricN",hostname=~"hostname1|hostname2...|hostnameN"}[1h]) keep_metric_names
+func (d *Devops) GroupByTimePercentiles(qq query.Query, nHosts, numMetrics int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metrics := mustGetCPUMetricsSlice(numMetrics)
+	hosts := d.mustGetRandomHosts(nHosts)
+	levels := make([]string, len(devops.GetPercentiles()))
+	for i, p := range devops.GetPercentiles() {
+		levels[i] = fmt.Sprintf("%g", p)
+	}
+	qi := &queryInfo{
+		query: fmt.Sprintf("quantiles_over_time('phi', %s, %s[1h]) keep_metric_names",
+			strings.Join(levels, ", "), getSelectClause(metrics, hosts)),
+		label:    devops.GetPercentilesLabel("VictoriaMetrics", numMetrics, nHosts, timeRange),
+		interval: interval,
 		step:     "3600",
 	}
 	d.fillInQuery(qq, qi)
 }
 
+// TopKHosts finds the k hosts with the highest average usage_user in a random
+// window of one hour,
+// e.g. in PromQL:
+//
+// topk(k, avg_over_time(cpu_usage_user[1h]))
+func (d *Devops) TopKHosts(qq query.Query, k int) {
+	interval := d.MustRandWindow(devops.TopKHostsDuration)
+	qi := &queryInfo{
+		query:    fmt.Sprintf("topk(%d, avg_over_time(cpu_usage_user[%s]))", k, getDuration(devops.TopKHostsDuration)),
+		label:    devops.GetTopKHostsLabel("VictoriaMetrics", k),
+		interval: interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// TagValues lists the hostnames of a random region over the whole time range
+// through the label values API,
+// e.g.:
+//
+// /api/v1/label/hostname/values?match[]=cpu_usage_user{region="region1"}
+func (d *Devops) TagValues(qq query.Query) {
+	region := d.GetRandomRegion()
+	label := devops.GetTagValuesLabel("VictoriaMetrics")
+	qi := &queryInfo{
+		query:     fmt.Sprintf("cpu_usage_user{region='%s'}", region),
+		label:     label,
+		desc:      fmt.Sprintf("%s: %s", label, region),
+		interval:  d.Interval,
+		labelName: "hostname",
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// SeriesCount counts the cpu series of a random region over the whole time
+// range, one per host,
+// e.g. in PromQL:
+//
+// count(last_over_time(cpu_usage_user{region="region1"}[range]))
+func (d *Devops) SeriesCount(qq query.Query) {
+	region := d.GetRandomRegion()
+	label := devops.GetSeriesCountLabel("VictoriaMetrics")
+	qi := &queryInfo{
+		query: fmt.Sprintf("count(last_over_time(cpu_usage_user{region='%s'}[%s]))",
+			region, getDuration(d.Interval.Duration())),
+		label:    label,
+		desc:     fmt.Sprintf("%s: %s", label, region),
+		interval: d.Interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
 func getHostClause(hostnames []string) string {
 	if len(hostnames) == 0 {
 		return ""
@@ -0,0 +1,99 @@
+package victoriametrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// DevopsGeneric produces PromQL queries for all the devops-generic query types.
+//
+// Fields are stored as generic_metrics_<field> metrics, e.g.
+// generic_metrics_metric_0, so the queries are valid in Prometheus as well.
+type DevopsGeneric struct {
+	*BaseGenerator
+	*devopsgeneric.Core
+}
+
+// mustGetRandomHosts is the form of GetRandomHosts that cannot error; if it does error,
+// it causes a panic.
+func (d *DevopsGeneric) mustGetRandomHosts(nHosts int) []string {
+	hosts, err := d.GetRandomHosts(nHosts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hosts
+}
+
+// GroupByTimeSingleMetric selects the MAX of a random generic metric per
+// minute for nHosts hosts,
+// e.g. in PromQL:
+//
+// max(max_over_time(generic_metrics_metric_N{hostname=~"hostname1|hostname2...|hostnameN"}[1m]))
+func (d *DevopsGeneric) GroupByTimeSingleMetric(qq query.Query, nHosts int, timeRange time.Duration) {
+	interval := d.MustRandWindow(timeRange)
+	metric := d.GetRandomMetric()
+	hosts := d.mustGetRandomHosts(nHosts)
+	qi := &queryInfo{
+		query:    fmt.Sprintf("max(max_over_time(%s{%s}[1m]))", getGenericMetricName(metric), getHostClause(hosts)),
+		label:    devopsgeneric.GetSingleMetricGroupbyLabel("VictoriaMetrics", nHosts, timeRange),
+		interval: interval,
+		step:     "60",
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// LastPointPerHost finds the last value of a random generic metric for every
+// host at the end of the dataset,
+// e.g. in PromQL:
+//
+// last_over_time(generic_metrics_metric_N[1h])
+func (d *DevopsGeneric) LastPointPerHost(qq query.Query) {
+	qi := &queryInfo{
+		query:    fmt.Sprintf("last_over_time(%s[%s])", getGenericMetricName(d.GetRandomMetric()), lastValueWindow),
+		label:    devopsgeneric.GetLastpointLabel("VictoriaMetrics"),
+		interval: d.Interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// TopKHosts finds the k hosts with the highest average of a random generic
+// metric in a random window,
+// e.g. in PromQL:
+//
+// topk(k, avg_over_time(generic_metrics_metric_N[1h]))
+func (d *DevopsGeneric) TopKHosts(qq query.Query, k int) {
+	interval := d.MustRandWindow(devopsgeneric.TopKDuration)
+	qi := &queryInfo{
+		query: fmt.Sprintf("topk(%d, avg_over_time(%s[%s]))",
+			k, getGenericMetricName(d.GetRandomMetric()), getDuration(devopsgeneric.TopKDuration)),
+		label:    devopsgeneric.GetTopKHostsLabel("VictoriaMetrics", k),
+		interval: interval,
+	}
+	d.fillInQuery(qq, qi)
+}
+
+// MetricDiscovery finds which generic metrics a random host reported in a
+// random window. Range functions drop the metric name, so the raw series are
+// counted per minute instead,
+// e.g. in PromQL:
+//
+// count({__name__=~"generic_metrics_.+",hostname="hostname1"}) by (__name__)
+func (d *DevopsGeneric) MetricDiscovery(qq query.Query) {
+	interval := d.MustRandWindow(devopsgeneric.MetricDiscoveryDuration)
+	hosts := d.mustGetRandomHosts(1)
+	qi := &queryInfo{
+		query: fmt.Sprintf("count({__name__=~'%s_.+', %s}) by (__name__)",
+			devopsgeneric.TableName, getHostClause(hosts)),
+		label:    devopsgeneric.GetMetricDiscoveryLabel("VictoriaMetrics"),
+		interval: interval,
+		step:     "60",
+	}
+	d.fillInQuery(qq, qi)
+}
+
+func getGenericMetricName(metric string) string {
+	return fmt.Sprintf("%s_%s", devopsgeneric.TableName, metric)
+}
@@ -0,0 +1,94 @@
+package victoriametrics
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestDevopsGenericQueries(t *testing.T) {
+	testCases := map[string]struct {
+		fn       func(g *DevopsGeneric, q *query.HTTP)
+		expPath  string
+		expQuery string
+		expStep  string
+		expTime  string
+	}{
+		"GroupByTimeSingleMetric_1": {
+			fn: func(g *DevopsGeneric, q *query.HTTP) {
+				g.GroupByTimeSingleMetric(q, 1, time.Hour)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "max(max_over_time(generic_metrics_metric_0{hostname='host_3'}[1m]))",
+			expStep:  "60",
+		},
+		"GroupByTimeSingleMetric_2": {
+			fn: func(g *DevopsGeneric, q *query.HTTP) {
+				g.GroupByTimeSingleMetric(q, 2, time.Hour)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "max(max_over_time(generic_metrics_metric_0{hostname=~'host_3|host_5'}[1m]))",
+			expStep:  "60",
+		},
+		"LastPointPerHost": {
+			fn: func(g *DevopsGeneric, q *query.HTTP) {
+				g.LastPointPerHost(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "last_over_time(generic_metrics_metric_2[1h])",
+			expTime:  "86400",
+		},
+		"TopKHosts": {
+			fn: func(g *DevopsGeneric, q *query.HTTP) {
+				g.TopKHosts(q, 5)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "topk(5, avg_over_time(generic_metrics_metric_0[3600s]))",
+		},
+		"MetricDiscovery": {
+			fn: func(g *DevopsGeneric, q *query.HTTP) {
+				g.MetricDiscovery(q)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "count({__name__=~'generic_metrics_.+', hostname='host_9'}) by (__name__)",
+			expStep:  "60",
+		},
+	}
+	g := acquireDevopsGenericGenerator(t, time.Hour*24, 10)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			q := g.GenerateEmptyQuery().(*query.HTTP)
+			tc.fn(g, q)
+
+			parts := strings.SplitN(string(q.Path), "?", 2)
+			checkEqual(t, "path", tc.expPath, parts[0])
+			vals, err := url.ParseQuery(parts[1])
+			if err != nil {
+				t.Fatalf("unexpected err while parsing query: %s", err)
+			}
+			checkEqual(t, "query", tc.expQuery, vals.Get("query"))
+			checkEqual(t, "step", tc.expStep, vals.Get("step"))
+			if tc.expTime != "" {
+				checkEqual(t, "time", tc.expTime, vals.Get("time"))
+			}
+			checkEqual(t, "method", http.MethodGet, string(q.Method))
+		})
+	}
+}
+
+func acquireDevopsGenericGenerator(t *testing.T, interval time.Duration, scale int) *DevopsGeneric {
+	b := &BaseGenerator{}
+	s := time.Unix(0, 0)
+	e := s.Add(interval)
+	g, err := b.NewDevopsGeneric(s, e, scale, 3)
+	if err != nil {
+		t.Fatalf("Error while creating devops-generic generator")
+	}
+	return g.(*DevopsGeneric)
+}
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,6 +54,34 @@ func Test_what(t *testing.T) {
 			expQuery: "max(max_over_time({__name__=~'cpu_(usage_user|usage_system|usage_idle|usage_nice|usage_iowait|usage_irq|usage_softirq|usage_steal|usage_guest|usage_guest_nice)', hostname=~'host_5|host_9|host_3|host_1|host_7'}[1h])) by (__name__)",
 			expStep:  "3600",
 		},
+		"GroupByTimeAndPrimaryTagWindow": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimeAndPrimaryTagWindow(q, 1, 2*time.Hour, 10*time.Minute)
+			},
+			expQuery: "avg(avg_over_time(cpu_usage_user{}[10m])) by (__name__, hostname)",
+			expStep:  "600",
+		},
+		"MaxAllCPUBucket": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.MaxAllCPUBucket(q, 5, devops.MaxAllDuration, 30*time.Minute)
+			},
+			expQuery: "max(max_over_time({__name__=~'cpu_(usage_user|usage_system|usage_idle|usage_nice|usage_iowait|usage_irq|usage_softirq|usage_steal|usage_guest|usage_guest_nice)', hostname=~'host_5|host_9|host_3|host_1|host_7'}[30m])) by (__name__)",
+			expStep:  "1800",
+		},
+		"GroupByTimePercentiles_1_1": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimePercentiles(q, 1, 1, 12*time.Hour)
+			},
+			expQuery: "quantiles_over_time('phi', 0.5, 0.95, 0.99, cpu_usage_user{hostname='host_9'}[1h]) keep_metric_names",
+			expStep:  "3600",
+		},
+		"GroupByTimePercentiles_5_8": {
+			fn: func(g *Devops, q *query.HTTP) {
+				g.GroupByTimePercentiles(q, 8, 5, 12*time.Hour)
+			},
+			expQuery: "quantiles_over_time('phi', 0.5, 0.95, 0.99, {__name__=~'cpu_(usage_user|usage_system|usage_idle|usage_nice|usage_iowait)', hostname=~'host_9|host_3|host_5|host_1|host_7|host_2|host_8|host_4'}[1h]) keep_metric_names",
+			expStep:  "3600",
+		},
 		"GroupByOrderByLimit": {
 			fn: func(g *Devops, q *query.HTTP) {
 				g.GroupByOrderByLimit(q)
@@ -113,6 +142,75 @@ func Test_what(t *testing.T) {
 	}
 }
 
+func TestTopKHosts(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.TopKHosts(q, 10)
+
+	parts := strings.SplitN(string(q.Path), "?", 2)
+	checkEqual(t, "path", "/api/v1/query", parts[0])
+	vals, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatalf("unexpected err while parsing query: %s", err)
+	}
+	checkEqual(t, "query", "topk(10, avg_over_time(cpu_usage_user[3600s]))", vals.Get("query"))
+	checkEqual(t, "time", "4582", vals.Get("time"))
+	checkEqual(t, "label", "VictoriaMetrics top 10 hosts by mean usage_user, random 1h0m0s", string(q.HumanLabel))
+}
+
+func TestGroupByTimeBucket(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.GroupByTimeBucket(q, 1, 1, time.Hour, 5*time.Minute)
+
+	parts := strings.SplitN(string(q.Path), "?", 2)
+	checkEqual(t, "path", "/api/v1/query_range", parts[0])
+	vals, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatalf("unexpected err while parsing query: %s", err)
+	}
+	checkEqual(t, "query", "max(max_over_time(cpu_usage_user{hostname='host_5'}[5m])) by (__name__)", vals.Get("query"))
+	checkEqual(t, "step", "300", vals.Get("step"))
+	checkEqual(t, "label", "VictoriaMetrics 1 cpu metric(s), random    1 hosts, random 1h0m0s by 5m", string(q.HumanLabel))
+}
+
+func TestTagValues(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.TagValues(q)
+
+	parts := strings.SplitN(string(q.Path), "?", 2)
+	checkEqual(t, "path", "/api/v1/label/hostname/values", parts[0])
+	vals, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatalf("unexpected err while parsing query: %s", err)
+	}
+	checkEqual(t, "match", "cpu_usage_user{region='ap-southeast-1'}", vals.Get("match[]"))
+	checkEqual(t, "start", "0", vals.Get("start"))
+	checkEqual(t, "end", "7200", vals.Get("end"))
+	checkEqual(t, "desc", "VictoriaMetrics hostname tag values, random region: ap-southeast-1", string(q.HumanDescription))
+}
+
+func TestSeriesCount(t *testing.T) {
+	g := acquireGenerator(t, 2*time.Hour, 10)
+	rand.Seed(123) // Setting seed for testing purposes.
+	q := g.GenerateEmptyQuery().(*query.HTTP)
+	g.SeriesCount(q)
+
+	parts := strings.SplitN(string(q.Path), "?", 2)
+	checkEqual(t, "path", "/api/v1/query", parts[0])
+	vals, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatalf("unexpected err while parsing query: %s", err)
+	}
+	checkEqual(t, "query", "count(last_over_time(cpu_usage_user{region='ap-southeast-1'}[7200s]))", vals.Get("query"))
+	checkEqual(t, "time", "7200", vals.Get("time"))
+	checkEqual(t, "desc", "VictoriaMetrics cpu series count, random region: ap-southeast-1", string(q.HumanDescription))
+}
+
 func checkEqual(t *testing.T, name, a, b string) {
 	if a != b {
 		t.Fatalf("values for %q are not equal \na: %q \nb: %q", name, a, b)
@@ -0,0 +1,230 @@
+package victoriametrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// lastValueWindow is how far back the last-value queries look for the latest
+// reading of a truck.
+const lastValueWindow = "1h"
+
+// IoT produces MetricsQL queries for all the iot query types.
+//
+// Fields are stored as <measurement>_<field> metrics, e.g. readings_velocity,
+// labeled with the string truck tags. The numeric truck tags, such as
+// load_capacity, are written as fields by the line protocol serializer, so
+// they are metrics too, e.g. diagnostics_load_capacity, joined to the other
+// series of a truck on its name.
+type IoT struct {
+	*BaseGenerator
+	*iot.Core
+}
+
+// mustGetRandomTrucks is the form of GetRandomTrucks that cannot error; if it
+// does error, it causes a panic.
+func (i *IoT) mustGetRandomTrucks(nTrucks int) []string {
+	trucks, err := i.GetRandomTrucks(nTrucks)
+	if err != nil {
+		panic(err.Error())
+	}
+	return trucks
+}
+
+// LastLocByTruck finds the truck location for nTrucks,
+// e.g. in MetricsQL:
+//
+// last_over_time({__name__=~"readings_(latitude|longitude)",name=~"truck1|truck2...|truckN"}[1h])
+func (i *IoT) LastLocByTruck(qq query.Query, nTrucks int) {
+	trucks := i.mustGetRandomTrucks(nTrucks)
+	qi := &queryInfo{
+		query:    fmt.Sprintf("last_over_time(%s[%s])", getLocationSelector(getTrucksClause(trucks)), lastValueWindow),
+		label:    fmt.Sprintf("VictoriaMetrics last location by specific truck: random %4d trucks", nTrucks),
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// LastLocPerTruck finds all the truck locations of a random fleet.
+func (i *IoT) LastLocPerTruck(qq query.Query) {
+	qi := &queryInfo{
+		query:    fmt.Sprintf("last_over_time(%s[%s])", getLocationSelector(getFleetClause(i.GetRandomFleet())), lastValueWindow),
+		label:    "VictoriaMetrics last location per truck",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// TrucksWithLowFuel finds all trucks of a random fleet with low fuel (less than 10%).
+func (i *IoT) TrucksWithLowFuel(qq query.Query) {
+	qi := &queryInfo{
+		query: fmt.Sprintf("last_over_time(diagnostics_fuel_state{%s}[%s]) < 0.1",
+			getFleetClause(i.GetRandomFleet()), lastValueWindow),
+		label:    "VictoriaMetrics trucks with low fuel: under 10 percent",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// TrucksWithHighLoad finds all trucks of a random fleet that have load over 90%.
+func (i *IoT) TrucksWithHighLoad(qq query.Query) {
+	fleet := getFleetClause(i.GetRandomFleet())
+	qi := &queryInfo{
+		query: fmt.Sprintf("last_over_time(diagnostics_current_load{%s}[%s]) / on(name) group_left() "+
+			"last_over_time(diagnostics_load_capacity{%s}[%s]) > 0.9",
+			fleet, lastValueWindow, fleet, lastValueWindow),
+		label:    "VictoriaMetrics trucks with high load: over 90 percent",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// StationaryTrucks finds all trucks of a random fleet that have low average
+// velocity in a random 10 minute window.
+func (i *IoT) StationaryTrucks(qq query.Query) {
+	interval := i.MustRandWindow(iot.StationaryDuration)
+	qi := &queryInfo{
+		query: fmt.Sprintf("avg_over_time(readings_velocity{%s}[%s]) < 1",
+			getFleetClause(i.GetRandomFleet()), getDuration(iot.StationaryDuration)),
+		label:    "VictoriaMetrics stationary trucks: with low avg velocity in last 10 minutes",
+		interval: interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// TrucksWithLongDrivingSessions finds all trucks of a random fleet that have
+// not stopped at least 20 mins in the last 4 hours.
+func (i *IoT) TrucksWithLongDrivingSessions(qq query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 5 mins per hour.
+	qi := &queryInfo{
+		query:    getDrivingPeriodsQuery(i.GetRandomFleet(), iot.LongDrivingSessionDuration, tenMinutePeriods(5, iot.LongDrivingSessionDuration)),
+		label:    "VictoriaMetrics trucks with longer driving sessions: stopped less than 20 mins in 4 hour period",
+		interval: i.MustRandWindow(iot.LongDrivingSessionDuration),
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// TrucksWithLongDailySessions finds all trucks of a random fleet that have
+// driven more than 10 hours in the last 24 hours.
+func (i *IoT) TrucksWithLongDailySessions(qq query.Query) {
+	// Calculate number of 10 min intervals that is the max driving duration for the session if we rest 35 mins per hour.
+	qi := &queryInfo{
+		query:    getDrivingPeriodsQuery(i.GetRandomFleet(), iot.DailyDrivingDuration, tenMinutePeriods(35, iot.DailyDrivingDuration)),
+		label:    "VictoriaMetrics trucks with longer daily sessions: drove more than 10 hours in the last 24 hours",
+		interval: i.MustRandWindow(iot.DailyDrivingDuration),
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// AvgVsProjectedFuelConsumption calculates average and projected fuel
+// consumption per fleet while the trucks are moving.
+func (i *IoT) AvgVsProjectedFuelConsumption(qq query.Query) {
+	window := getDuration(i.Interval.Duration())
+	qi := &queryInfo{
+		query: fmt.Sprintf("label_set(avg(avg_over_time((readings_fuel_consumption{name!=''} if readings_velocity > 1)[%s:])) by (fleet), 'stat', 'avg') or "+
+			"label_set(avg(avg_over_time((readings_nominal_fuel_consumption{name!=''} if readings_velocity > 1)[%s:])) by (fleet), 'stat', 'projected')",
+			window, window),
+		label:    "VictoriaMetrics average vs projected fuel consumption per fleet",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// AvgDailyDrivingDuration finds the average driving duration per driver,
+// counting the hours made of 10 minute periods with moving trucks.
+func (i *IoT) AvgDailyDrivingDuration(qq query.Query) {
+	qi := &queryInfo{
+		query: fmt.Sprintf("avg(avg_over_time((count_over_time(%s[1d:10m]) / 6)[%s:1d])) by (fleet, name, driver)",
+			getDrivingSelector(""), getDuration(i.Interval.Duration())),
+		label:    "VictoriaMetrics average driver driving duration per day",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// AvgDailyDrivingSession is not supported: MetricsQL cannot delimit the
+// driving sessions of a truck.
+func (i *IoT) AvgDailyDrivingSession(qq query.Query) {
+	panic("AvgDailyDrivingSession not supported in MetricsQL")
+}
+
+// AvgLoad finds the average load per truck model per fleet.
+func (i *IoT) AvgLoad(qq query.Query) {
+	window := getDuration(i.Interval.Duration())
+	qi := &queryInfo{
+		query: fmt.Sprintf("avg(avg_over_time(diagnostics_current_load{name!=''}[%s]) / on(name) group_left() "+
+			"last_over_time(diagnostics_load_capacity{name!=''}[%s])) by (fleet, model)",
+			window, window),
+		label:    "VictoriaMetrics average load per truck model per fleet",
+		interval: i.Interval,
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// DailyTruckActivity returns the share of the day trucks have been active
+// (not out-of-commission) per fleet per model, counting the 10 minute periods
+// with a low average status.
+func (i *IoT) DailyTruckActivity(qq query.Query) {
+	qi := &queryInfo{
+		query:    "avg(count_over_time((avg_over_time(diagnostics_status{name!=''}[10m]) < 1)[1d:10m]) / 144) by (fleet, model)",
+		label:    "VictoriaMetrics daily truck activity per fleet per model",
+		interval: i.Interval,
+		step:     "86400",
+	}
+	i.fillInQuery(qq, qi)
+}
+
+// TruckBreakdownFrequency is not supported: MetricsQL cannot count the
+// transitions of a truck into the broken down state.
+func (i *IoT) TruckBreakdownFrequency(qq query.Query) {
+	panic("TruckBreakdownFrequency not supported in MetricsQL")
+}
+
+func getTrucksClause(trucks []string) string {
+	if len(trucks) == 1 {
+		return fmt.Sprintf("name='%s'", trucks[0])
+	}
+	return fmt.Sprintf("name=~'%s'", strings.Join(trucks, "|"))
+}
+
+func getFleetClause(fleet string) string {
+	return fmt.Sprintf("fleet='%s', name!=''", fleet)
+}
+
+func getLocationSelector(trucksClause string) string {
+	return fmt.Sprintf("{__name__=~'readings_(latitude|longitude)', %s}", trucksClause)
+}
+
+// getDrivingSelector selects the 10 minute periods during which the trucks
+// matching fleetClause were moving.
+func getDrivingSelector(fleetClause string) string {
+	if fleetClause == "" {
+		fleetClause = "name!=''"
+	}
+	return fmt.Sprintf("(avg_over_time(readings_velocity{%s}[10m]) > 1)", fleetClause)
+}
+
+// getDrivingPeriodsQuery selects the trucks of fleet which were moving in more
+// than periods 10 minute periods of the last duration.
+func getDrivingPeriodsQuery(fleet string, duration time.Duration, periods int) string {
+	return fmt.Sprintf("count_over_time(%s[%s:10m]) > %d",
+		getDrivingSelector(getFleetClause(fleet)), getDuration(duration), periods)
+}
+
+// getDuration formats d as a MetricsQL duration in seconds.
+func getDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d.Seconds()))
+}
+
+// tenMinutePeriods calculates the number of 10 minute periods that can fit in
+// the time duration if we subtract the minutes specified by minutesPerHour value.
+// E.g.: 4 hours - 5 minutes per hour = 3 hours and 40 minutes = 22 ten minute periods
+func tenMinutePeriods(minutesPerHour float64, duration time.Duration) int {
+	durationMinutes := duration.Minutes()
+	leftover := minutesPerHour * duration.Hours()
+	return int((durationMinutes - leftover) / 10)
+}
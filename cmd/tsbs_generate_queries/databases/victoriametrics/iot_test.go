@@ -0,0 +1,239 @@
+package victoriametrics
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	iotdata "github.com/timescale/tsbs/pkg/data/usecases/iot"
+	"github.com/timescale/tsbs/pkg/query"
+	"github.com/timescale/tsbs/pkg/targets/influx"
+)
+
+func TestIoTQueries(t *testing.T) {
+	testCases := map[string]struct {
+		fn        func(g *IoT, q *query.HTTP)
+		expPath   string
+		expQuery  string
+		expStep   string
+		expTime   string
+		expToFail bool
+	}{
+		"LastLocByTruck_1": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.LastLocByTruck(q, 1)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "last_over_time({__name__=~'readings_(latitude|longitude)', name='truck_5'}[1h])",
+			expTime:  "86400",
+		},
+		"LastLocByTruck_2": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.LastLocByTruck(q, 2)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "last_over_time({__name__=~'readings_(latitude|longitude)', name=~'truck_5|truck_9'}[1h])",
+			expTime:  "86400",
+		},
+		"TrucksWithLowFuel": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.TrucksWithLowFuel(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "last_over_time(diagnostics_fuel_state{fleet='South', name!=''}[1h]) < 0.1",
+			expTime:  "86400",
+		},
+		"StationaryTrucks": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.StationaryTrucks(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "avg_over_time(readings_velocity{fleet='West', name!=''}[600s]) < 1",
+		},
+		"TrucksWithLongDrivingSessions": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.TrucksWithLongDrivingSessions(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "count_over_time((avg_over_time(readings_velocity{fleet='South', name!=''}[10m]) > 1)[14400s:10m]) > 22",
+		},
+		"DailyTruckActivity": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.DailyTruckActivity(q)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "avg(count_over_time((avg_over_time(diagnostics_status{name!=''}[10m]) < 1)[1d:10m]) / 144) by (fleet, model)",
+			expStep:  "86400",
+		},
+		"AvgDailyDrivingSession": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.AvgDailyDrivingSession(q)
+			},
+			expToFail: true,
+		},
+		"TruckBreakdownFrequency": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.TruckBreakdownFrequency(q)
+			},
+			expToFail: true,
+		},
+		"LastLocByTruck_too_many_trucks": {
+			fn: func(g *IoT, q *query.HTTP) {
+				g.LastLocByTruck(q, 20)
+			},
+			expToFail: true,
+		},
+	}
+	g := acquireIoTGenerator(t, time.Hour*24, 10)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			q := g.GenerateEmptyQuery().(*query.HTTP)
+			if tc.expToFail {
+				func() {
+					defer func() {
+						if recover() == nil {
+							t.Errorf("expected to panic")
+						}
+					}()
+					tc.fn(g, q)
+				}()
+				return
+			}
+
+			tc.fn(g, q)
+			parts := strings.SplitN(string(q.Path), "?", 2)
+			checkEqual(t, "path", tc.expPath, parts[0])
+			vals, err := url.ParseQuery(parts[1])
+			if err != nil {
+				t.Fatalf("unexpected err while parsing query: %s", err)
+			}
+			checkEqual(t, "query", tc.expQuery, vals.Get("query"))
+			checkEqual(t, "step", tc.expStep, vals.Get("step"))
+			if tc.expTime != "" {
+				checkEqual(t, "time", tc.expTime, vals.Get("time"))
+			}
+			checkEqual(t, "method", http.MethodGet, string(q.Method))
+		})
+	}
+}
+
+// TestIoTSeriesNames checks the queries only read metrics and labels that
+// loading the iot data through the line protocol serializer produces.
+func TestIoTSeriesNames(t *testing.T) {
+	metrics, labels := loadedIoTSeries(t)
+	metricRe := regexp.MustCompile(`\b(readings|diagnostics)_[a-z_]+`)
+	labelRe := regexp.MustCompile(`(?:by|on) ?\(([a-z_, ]*)\)|([a-z_]+) ?(?:=|!=|=~)`)
+
+	g := acquireIoTGenerator(t, time.Hour*48, 10)
+	fills := map[string]func(query.Query){
+		"LastLocByTruck":                func(q query.Query) { g.LastLocByTruck(q, 1) },
+		"LastLocPerTruck":               g.LastLocPerTruck,
+		"TrucksWithLowFuel":             g.TrucksWithLowFuel,
+		"TrucksWithHighLoad":            g.TrucksWithHighLoad,
+		"StationaryTrucks":              g.StationaryTrucks,
+		"TrucksWithLongDrivingSessions": g.TrucksWithLongDrivingSessions,
+		"TrucksWithLongDailySessions":   g.TrucksWithLongDailySessions,
+		"AvgVsProjectedFuelConsumption": g.AvgVsProjectedFuelConsumption,
+		"AvgDailyDrivingDuration":       g.AvgDailyDrivingDuration,
+		"AvgLoad":                       g.AvgLoad,
+		"DailyTruckActivity":            g.DailyTruckActivity,
+	}
+	for name, fill := range fills {
+		q := g.GenerateEmptyQuery().(*query.HTTP)
+		fill(q)
+		vals, err := url.ParseQuery(strings.SplitN(string(q.Path), "?", 2)[1])
+		if err != nil {
+			t.Fatalf("%s: unexpected err while parsing query: %s", name, err)
+		}
+		promQL := vals.Get("query")
+		if strings.Contains(promQL, "label_value") {
+			t.Errorf("%s: query reads a label as a value: %s", name, promQL)
+		}
+		for _, metric := range metricRe.FindAllString(promQL, -1) {
+			if !metrics[metric] {
+				t.Errorf("%s: query reads metric %s, which is not loaded", name, metric)
+			}
+		}
+		for _, m := range labelRe.FindAllStringSubmatch(promQL, -1) {
+			for _, label := range strings.Split(m[1]+","+m[2], ",") {
+				label = strings.TrimSpace(label)
+				if label != "" && label != "__name__" && !labels[label] {
+					t.Errorf("%s: query reads label %s, which is not loaded", name, label)
+				}
+			}
+		}
+	}
+}
+
+// loadedIoTSeries returns the metric and label names VictoriaMetrics stores
+// for the iot data serialized in line protocol: a <measurement>_<field>
+// metric per field, labeled with the tags.
+func loadedIoTSeries(t *testing.T) (metrics, labels map[string]bool) {
+	sc := &iotdata.SimulatorConfig{
+		Start:                time.Unix(0, 0),
+		End:                  time.Unix(0, 0).Add(time.Hour),
+		InitGeneratorScale:   10,
+		GeneratorScale:       10,
+		GeneratorConstructor: iotdata.NewTruck,
+	}
+	sim := sc.NewSimulator(time.Minute, 0)
+	serializer := &influx.Serializer{}
+	buf := &bytes.Buffer{}
+	p := data.NewPoint()
+	for !sim.Finished() {
+		if sim.Next(p) {
+			if err := serializer.Serialize(p, buf); err != nil {
+				t.Fatalf("unexpected error serializing point: %v", err)
+			}
+		}
+		p.Reset()
+	}
+
+	metrics, labels = map[string]bool{}, map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		parts := strings.Split(line, " ")
+		series := strings.Split(parts[0], ",")
+		for _, tag := range series[1:] {
+			labels[strings.SplitN(tag, "=", 2)[0]] = true
+		}
+		for _, field := range strings.Split(parts[1], ",") {
+			metrics[series[0]+"_"+strings.SplitN(field, "=", 2)[0]] = true
+		}
+	}
+	for _, metric := range []string{"diagnostics_load_capacity", "readings_nominal_fuel_consumption"} {
+		if !metrics[metric] {
+			t.Fatalf("numeric truck tag not loaded as metric %s", metric)
+		}
+	}
+	if labels["load_capacity"] {
+		t.Fatalf("numeric truck tag load_capacity loaded as a label")
+	}
+	return metrics, labels
+}
+
+func TestTenMinutePeriods(t *testing.T) {
+	if got := tenMinutePeriods(5, 4*time.Hour); got != 22 {
+		t.Errorf("incorrect periods for 4 hours: got %d want 22", got)
+	}
+	if got := tenMinutePeriods(35, 24*time.Hour); got != 60 {
+		t.Errorf("incorrect periods for 24 hours: got %d want 60", got)
+	}
+}
+
+func acquireIoTGenerator(t *testing.T, interval time.Duration, scale int) *IoT {
+	b := &BaseGenerator{}
+	s := time.Unix(0, 0)
+	e := s.Add(interval)
+	g, err := b.NewIoT(s, e, scale)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+	return g.(*IoT)
+}
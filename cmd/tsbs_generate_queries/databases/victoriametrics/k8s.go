@@ -0,0 +1,62 @@
+package victoriametrics
+
+import (
+	"fmt"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// K8s produces MetricsQL queries for all the k8s query types.
+//
+// Fields are stored as container_<field> metrics, e.g. container_cpu_usage,
+// labeled with the pod, container, namespace, deployment and node.
+type K8s struct {
+	*BaseGenerator
+	*k8s.Core
+}
+
+// NamespaceCPU selects the CPU usage of every namespace per minute,
+// e.g. in MetricsQL:
+//
+// sum(avg_over_time(container_cpu_usage[1m])) by (namespace)
+func (k *K8s) NamespaceCPU(qq query.Query) {
+	qi := &queryInfo{
+		query:    "sum(avg_over_time(container_cpu_usage[1m])) by (namespace)",
+		label:    "VictoriaMetrics CPU usage per namespace per minute",
+		interval: k.MustRandWindow(k8s.NamespaceCPUDuration),
+		step:     "60",
+	}
+	k.fillInQuery(qq, qi)
+}
+
+// TopPods finds the k pods with the highest average CPU usage in a random
+// window of one hour,
+// e.g. in MetricsQL:
+//
+// topk(k, sum(avg_over_time(container_cpu_usage[1h])) by (namespace, pod))
+func (k *K8s) TopPods(qq query.Query, topK int) {
+	qi := &queryInfo{
+		query: fmt.Sprintf("topk(%d, sum(avg_over_time(container_cpu_usage[%s])) by (namespace, pod))",
+			topK, getDuration(k8s.TopPodsDuration)),
+		label:    fmt.Sprintf("VictoriaMetrics top %d pods by CPU usage", topK),
+		interval: k.MustRandWindow(k8s.TopPodsDuration),
+	}
+	k.fillInQuery(qq, qi)
+}
+
+// Restarts finds the containers of a random namespace which restarted in a
+// random window of one hour,
+// e.g. in MetricsQL:
+//
+// increase(container_restarts{namespace='namespace1'}[1h]) > 0
+func (k *K8s) Restarts(qq query.Query) {
+	interval := k.MustRandWindow(k8s.RestartsDuration)
+	qi := &queryInfo{
+		query: fmt.Sprintf("increase(container_restarts{namespace='%s'}[%s]) > 0",
+			k.GetRandomNamespace(), getDuration(k8s.RestartsDuration)),
+		label:    "VictoriaMetrics container restarts in a namespace",
+		interval: interval,
+	}
+	k.fillInQuery(qq, qi)
+}
@@ -0,0 +1,84 @@
+package victoriametrics
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestK8sQueries(t *testing.T) {
+	testCases := map[string]struct {
+		fn       func(g *K8s, q *query.HTTP)
+		expPath  string
+		expQuery string
+		expStep  string
+		expTime  string
+		expLabel string
+	}{
+		"NamespaceCPU": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.NamespaceCPU(q)
+			},
+			expPath:  "/api/v1/query_range",
+			expQuery: "sum(avg_over_time(container_cpu_usage[1m])) by (namespace)",
+			expStep:  "60",
+			expLabel: "VictoriaMetrics CPU usage per namespace per minute",
+		},
+		"TopPods": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.TopPods(q, 10)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "topk(10, sum(avg_over_time(container_cpu_usage[3600s])) by (namespace, pod))",
+			expTime:  "76582",
+			expLabel: "VictoriaMetrics top 10 pods by CPU usage",
+		},
+		"Restarts": {
+			fn: func(g *K8s, q *query.HTTP) {
+				g.Restarts(q)
+			},
+			expPath:  "/api/v1/query",
+			expQuery: "increase(container_restarts{namespace='kube-system'}[3600s]) > 0",
+			expTime:  "76582",
+			expLabel: "VictoriaMetrics container restarts in a namespace",
+		},
+	}
+	g := acquireK8sGenerator(t, 24*time.Hour, 10)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rand.Seed(123) // Setting seed for testing purposes.
+			q := g.GenerateEmptyQuery().(*query.HTTP)
+			tc.fn(g, q)
+
+			parts := strings.SplitN(string(q.Path), "?", 2)
+			checkEqual(t, "path", tc.expPath, parts[0])
+			vals, err := url.ParseQuery(parts[1])
+			if err != nil {
+				t.Fatalf("unexpected err while parsing query: %s", err)
+			}
+			checkEqual(t, "query", tc.expQuery, vals.Get("query"))
+			checkEqual(t, "step", tc.expStep, vals.Get("step"))
+			if tc.expTime != "" {
+				checkEqual(t, "time", tc.expTime, vals.Get("time"))
+			}
+			checkEqual(t, "label", tc.expLabel, string(q.HumanLabel))
+			checkEqual(t, "method", http.MethodGet, string(q.Method))
+		})
+	}
+}
+
+func acquireK8sGenerator(t *testing.T, interval time.Duration, scale int) *K8s {
+	b := &BaseGenerator{}
+	s := time.Unix(0, 0)
+	e := s.Add(interval)
+	g, err := b.NewK8s(s, e, scale)
+	if err != nil {
+		t.Fatalf("Error while creating k8s generator")
+	}
+	return g.(*K8s)
+}
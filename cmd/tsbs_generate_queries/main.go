@@ -11,7 +11,12 @@ import (
 	"github.com/blagojts/viper"
 	"github.com/spf13/pflag"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/internal/inputs"
 	internalUtils "github.com/timescale/tsbs/internal/utils"
@@ -19,22 +24,39 @@ import (
 
 var useCaseMatrix = map[string]map[string]utils.QueryFillerMaker{
 	"devops": {
-		devops.LabelSingleGroupby + "-1-1-1":  devops.NewSingleGroupby(1, 1, 1),
-		devops.LabelSingleGroupby + "-1-1-12": devops.NewSingleGroupby(1, 1, 12),
-		devops.LabelSingleGroupby + "-1-8-1":  devops.NewSingleGroupby(1, 8, 1),
-		devops.LabelSingleGroupby + "-5-1-1":  devops.NewSingleGroupby(5, 1, 1),
-		devops.LabelSingleGroupby + "-5-1-12": devops.NewSingleGroupby(5, 1, 12),
-		devops.LabelSingleGroupby + "-5-8-1":  devops.NewSingleGroupby(5, 8, 1),
-		devops.LabelMaxAll + "-1":             devops.NewMaxAllCPU(1, devops.MaxAllDuration),
-		devops.LabelMaxAll + "-8":             devops.NewMaxAllCPU(8, devops.MaxAllDuration),
-		devops.LabelMaxAll + "-32-24":         devops.NewMaxAllCPU(32, 24*time.Hour),
-		devops.LabelDoubleGroupby + "-1":      devops.NewGroupBy(1),
-		devops.LabelDoubleGroupby + "-5":      devops.NewGroupBy(5),
-		devops.LabelDoubleGroupby + "-all":    devops.NewGroupBy(devops.GetCPUMetricsLen()),
-		devops.LabelGroupbyOrderbyLimit:       devops.NewGroupByOrderByLimit,
-		devops.LabelHighCPU + "-all":          devops.NewHighCPU(0),
-		devops.LabelHighCPU + "-1":            devops.NewHighCPU(1),
-		devops.LabelLastpoint:                 devops.NewLastPointPerHost,
+		devops.LabelSingleGroupby + "-1-1-1":   devops.NewSingleGroupby(1, 1, 1),
+		devops.LabelSingleGroupby + "-1-1-12":  devops.NewSingleGroupby(1, 1, 12),
+		devops.LabelSingleGroupby + "-1-8-1":   devops.NewSingleGroupby(1, 8, 1),
+		devops.LabelSingleGroupby + "-5-1-1":   devops.NewSingleGroupby(5, 1, 1),
+		devops.LabelSingleGroupby + "-5-1-12":  devops.NewSingleGroupby(5, 1, 12),
+		devops.LabelSingleGroupby + "-5-8-1":   devops.NewSingleGroupby(5, 8, 1),
+		devops.LabelMaxAll + "-1":              devops.NewMaxAllCPU(1, devops.MaxAllDuration),
+		devops.LabelMaxAll + "-8":              devops.NewMaxAllCPU(8, devops.MaxAllDuration),
+		devops.LabelMaxAll + "-32-24":          devops.NewMaxAllCPU(32, 24*time.Hour),
+		devops.LabelDoubleGroupby + "-1":       devops.NewGroupBy(1),
+		devops.LabelDoubleGroupby + "-5":       devops.NewGroupBy(5),
+		devops.LabelDoubleGroupby + "-all":     devops.NewGroupBy(devops.GetCPUMetricsLen()),
+		devops.LabelGroupbyOrderbyLimit:        devops.NewGroupByOrderByLimit,
+		devops.LabelHighCPU + "-all":           devops.NewHighCPU(0),
+		devops.LabelHighCPU + "-1":             devops.NewHighCPU(1),
+		devops.LabelLastpoint:                  devops.NewLastPointPerHost,
+		devops.LabelPercentiles + "-1-1-12":    devops.NewPercentiles(1, 1, 12),
+		devops.LabelPercentiles + "-1-8-12":    devops.NewPercentiles(1, 8, 12),
+		devops.LabelPercentiles + "-5-8-12":    devops.NewPercentiles(5, 8, 12),
+		devops.LabelTopKHosts + "-10":          devops.NewTopKHosts(10),
+		devops.LabelCrossMeasurement + "-1-12": devops.NewCrossMeasurement(1, 12),
+		devops.LabelCrossMeasurement + "-8-1":  devops.NewCrossMeasurement(8, 1),
+		devops.LabelTagValues:                  devops.NewTagValues,
+		devops.LabelSeriesCount:                devops.NewSeriesCount,
+	},
+	"devops-generic": {
+		devopsgeneric.LabelSingleMetricGroupby + "-1-1":  devopsgeneric.NewSingleMetricGroupby(1, 1),
+		devopsgeneric.LabelSingleMetricGroupby + "-1-12": devopsgeneric.NewSingleMetricGroupby(1, 12),
+		devopsgeneric.LabelSingleMetricGroupby + "-8-1":  devopsgeneric.NewSingleMetricGroupby(8, 1),
+		devopsgeneric.LabelLastpoint:                     devopsgeneric.NewLastPointPerHost,
+		devopsgeneric.LabelTopKHosts + "-5":              devopsgeneric.NewTopKHosts(5),
+		devopsgeneric.LabelTopKHosts + "-10":             devopsgeneric.NewTopKHosts(10),
+		devopsgeneric.LabelMetricDiscovery:               devopsgeneric.NewMetricDiscovery,
 	},
 	"iot": {
 		iot.LabelLastLoc:                       iot.NewLastLocPerTruck,
@@ -50,6 +72,29 @@ var useCaseMatrix = map[string]map[string]utils.QueryFillerMaker{
 		iot.LabelAvgLoad:                       iot.NewAvgLoad,
 		iot.LabelDailyActivity:                 iot.NewDailyTruckActivity,
 		iot.LabelBreakdownFrequency:            iot.NewTruckBreakdownFrequency,
+		iot.LabelGapfill + "-1":                iot.NewGapfill(1),
+		iot.LabelGapfill + "-10":               iot.NewGapfill(10),
+	},
+	"finance": {
+		finance.LabelOHLC + "-1":  finance.NewOHLC(1),
+		finance.LabelOHLC + "-10": finance.NewOHLC(10),
+		finance.LabelVWAP + "-1":  finance.NewVWAP(1),
+		finance.LabelVWAP + "-10": finance.NewVWAP(10),
+		finance.LabelLastQuote:    finance.NewLastQuotePerSymbol,
+		finance.LabelSpreadStats:  finance.NewSpreadStats,
+	},
+	"k8s": {
+		k8s.LabelNamespaceCPU:    k8s.NewNamespaceCPU,
+		k8s.LabelTopPods + "-10": k8s.NewTopPods(10),
+		k8s.LabelRestarts:        k8s.NewRestarts,
+	},
+	"logs": {
+		logs.LabelErrorsPerService: logs.NewErrorsPerService,
+		logs.LabelMessageSearch:    logs.NewMessageSearch,
+	},
+	"smart-meter": {
+		smartmeter.LabelDailyConsumption: smartmeter.NewDailyConsumption,
+		smartmeter.LabelPeakHourLoad:     smartmeter.NewPeakHourLoad,
 	},
 }
 
@@ -89,6 +134,18 @@ func init() {
 	if err := viper.Unmarshal(&conf); err != nil {
 		panic(fmt.Errorf("unable to decode config: %s", err))
 	}
+
+	if conf.QueryMatrixFile != "" {
+		if err := loadQueryMatrix(conf.QueryMatrixFile, useCaseMatrix); err != nil {
+			panic(fmt.Errorf("unable to load query matrix: %s", err))
+		}
+	}
+
+	if conf.QueryTemplatesFile != "" {
+		if err := loadQueryTemplates(conf.QueryTemplatesFile, useCaseMatrix); err != nil {
+			panic(fmt.Errorf("unable to load query templates: %s", err))
+		}
+	}
 }
 
 func main() {
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	errUnsupportedMatrixUseCaseFmt = "query type %q: use case %q does not support custom query types"
+	errEmptyMatrixQueryTypeName    = "query type name cannot be empty"
+	errDuplicateMatrixQueryTypeFmt = "query type %q is already defined for use case %q"
+)
+
+// queryMatrix is the layout of a YAML file that defines additional query
+// types, e.g.:
+//
+// query-types:
+//   - name: single-groupby-5-8-6-5m
+//     use-case: devops
+//     kind: single-groupby
+//     metrics: 5
+//     hosts: 8
+//     window: 6h
+//     bucket: 5m
+type queryMatrix struct {
+	QueryTypes []queryMatrixEntry `yaml:"query-types"`
+}
+
+type queryMatrixEntry struct {
+	Name             string `yaml:"name"`
+	UseCase          string `yaml:"use-case"`
+	devops.QuerySpec `yaml:",inline"`
+}
+
+// loadQueryMatrix reads the query types from the YAML file at path and adds
+// them to the use case matrix.
+func loadQueryMatrix(path string, matrix map[string]map[string]utils.QueryFillerMaker) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read query matrix file: %v", err)
+	}
+	return addQueryMatrix(b, matrix)
+}
+
+// addQueryMatrix parses the query types in the YAML document b and adds them
+// to the use case matrix. Either all query types are added or none.
+func addQueryMatrix(b []byte, matrix map[string]map[string]utils.QueryFillerMaker) error {
+	qm := queryMatrix{}
+	if err := yaml.UnmarshalStrict(b, &qm); err != nil {
+		return fmt.Errorf("cannot parse query matrix: %v", err)
+	}
+
+	added := make([]addedQueryType, 0, len(qm.QueryTypes))
+	for _, qt := range qm.QueryTypes {
+		// only devops query types can be described by a devops.QuerySpec;
+		// cpu-only shares them, see init()
+		if qt.UseCase != "devops" && qt.UseCase != "cpu-only" {
+			return fmt.Errorf(errUnsupportedMatrixUseCaseFmt, qt.Name, qt.UseCase)
+		}
+		maker, err := qt.QueryFillerMaker()
+		if err != nil {
+			return fmt.Errorf("query type %q: %v", qt.Name, err)
+		}
+		added = append(added, addedQueryType{name: qt.Name, useCase: qt.UseCase, maker: maker})
+	}
+	return addQueryTypes(added, matrix)
+}
+
+// addedQueryType is a query type defined outside of the Go code
+type addedQueryType struct {
+	name    string
+	useCase string
+	maker   utils.QueryFillerMaker
+}
+
+// addQueryTypes adds the query types to the use case matrix, refusing names
+// that are empty or already taken. Either all query types are added or none.
+func addQueryTypes(added []addedQueryType, matrix map[string]map[string]utils.QueryFillerMaker) error {
+	for i, qt := range added {
+		if qt.name == "" {
+			return fmt.Errorf(errEmptyMatrixQueryTypeName)
+		}
+		if _, ok := matrix[qt.useCase][qt.name]; ok {
+			return fmt.Errorf(errDuplicateMatrixQueryTypeFmt, qt.name, qt.useCase)
+		}
+		// use cases may share their query types, e.g. devops and cpu-only,
+		// so names must be unique across use cases
+		for _, prev := range added[:i] {
+			if prev.name == qt.name {
+				return fmt.Errorf(errDuplicateMatrixQueryTypeFmt, qt.name, qt.useCase)
+			}
+		}
+	}
+
+	for _, qt := range added {
+		matrix[qt.useCase][qt.name] = qt.maker
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+)
+
+func newTestMatrix() map[string]map[string]utils.QueryFillerMaker {
+	queryTypes := map[string]utils.QueryFillerMaker{
+		devops.LabelLastpoint: devops.NewLastPointPerHost,
+	}
+	return map[string]map[string]utils.QueryFillerMaker{
+		"devops":   queryTypes,
+		"cpu-only": queryTypes,
+		"iot":      {},
+	}
+}
+
+func TestAddQueryMatrix(t *testing.T) {
+	doc := `
+query-types:
+  - name: single-groupby-5-8-6-5m
+    use-case: devops
+    kind: single-groupby
+    metrics: 5
+    hosts: 8
+    window: 6h
+    bucket: 5m
+  - name: top-k-hosts-3
+    use-case: cpu-only
+    kind: top-k-hosts
+    limit: 3
+`
+	matrix := newTestMatrix()
+	if err := addQueryMatrix([]byte(doc), matrix); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"single-groupby-5-8-6-5m", "top-k-hosts-3", devops.LabelLastpoint} {
+		if _, ok := matrix["devops"][name]; !ok {
+			t.Errorf("query type %s missing for devops", name)
+		}
+		if _, ok := matrix["cpu-only"][name]; !ok {
+			t.Errorf("query type %s missing for cpu-only", name)
+		}
+	}
+}
+
+func TestAddQueryMatrixErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		doc  string
+		want string
+	}{
+		{
+			desc: "empty name",
+			doc:  "query-types:\n  - use-case: devops\n    kind: lastpoint\n",
+			want: errEmptyMatrixQueryTypeName,
+		},
+		{
+			desc: "unsupported use case",
+			doc:  "query-types:\n  - name: foo\n    use-case: iot\n    kind: lastpoint\n",
+			want: fmt.Sprintf(errUnsupportedMatrixUseCaseFmt, "foo", "iot"),
+		},
+		{
+			desc: "built-in name",
+			doc:  "query-types:\n  - name: lastpoint\n    use-case: devops\n    kind: lastpoint\n",
+			want: fmt.Sprintf(errDuplicateMatrixQueryTypeFmt, "lastpoint", "devops"),
+		},
+		{
+			desc: "duplicate name",
+			doc: "query-types:\n  - name: foo\n    use-case: devops\n    kind: lastpoint\n" +
+				"  - name: foo\n    use-case: cpu-only\n    kind: lastpoint\n",
+			want: fmt.Sprintf(errDuplicateMatrixQueryTypeFmt, "foo", "cpu-only"),
+		},
+		{
+			desc: "invalid spec",
+			doc:  "query-types:\n  - name: foo\n    use-case: devops\n    kind: lastpoint\n    hosts: 1\n",
+			want: `query type "foo": query kind "lastpoint" does not take hosts`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			matrix := newTestMatrix()
+			err := addQueryMatrix([]byte(c.doc), matrix)
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if got := err.Error(); got != c.want {
+				t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, c.want)
+			}
+			if got := len(matrix["devops"]); got != 1 {
+				t.Errorf("matrix was modified: got %d query types want 1", got)
+			}
+		})
+	}
+
+	if err := addQueryMatrix([]byte("query-types:\n  - name: foo\n    windows: 1h\n"), newTestMatrix()); err == nil {
+		t.Errorf("expected error for unknown field, got none")
+	}
+}
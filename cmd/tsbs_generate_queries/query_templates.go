@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/iot"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/querytemplate"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const errUnknownTemplateUseCaseFmt = "query template %q: unknown use case %q"
+
+// defaultMeasurements are the measurements the query templates of each use
+// case read unless they set their own.
+var defaultMeasurements = map[string]string{
+	"devops":         devops.TableName,
+	"cpu-only":       devops.TableName,
+	"devops-generic": devopsgeneric.TableName,
+	"iot":            iot.ReadingsTableName,
+	"finance":        finance.TradesTableName,
+	"k8s":            k8s.ContainerTableName,
+	"logs":           logs.LogsTableName,
+	"smart-meter":    smartmeter.EnergyTableName,
+}
+
+// queryTemplates is the layout of a YAML file that defines query types by
+// templates, e.g.:
+//
+// query-templates:
+//   - name: my-max-usage
+//     use-case: devops
+//     hosts: 2
+//     metrics: 1
+//     window: 1h
+//     measurement: cpu
+//     query: SELECT max({{join .Metrics ", "}}) FROM cpu WHERE hostname IN ({{.Hosts}})
+type queryTemplates struct {
+	Templates []queryTemplateEntry `yaml:"query-templates"`
+}
+
+type queryTemplateEntry struct {
+	Name               string `yaml:"name"`
+	UseCase            string `yaml:"use-case"`
+	querytemplate.Spec `yaml:",inline"`
+}
+
+// loadQueryTemplates reads the query templates from the YAML file at path and
+// adds them to the use case matrix.
+func loadQueryTemplates(path string, matrix map[string]map[string]utils.QueryFillerMaker) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read query templates file: %v", err)
+	}
+	return addQueryTemplates(b, matrix)
+}
+
+// addQueryTemplates parses the query templates in the YAML document b and adds
+// them to the use case matrix. Either all query templates are added or none.
+func addQueryTemplates(b []byte, matrix map[string]map[string]utils.QueryFillerMaker) error {
+	qt := queryTemplates{}
+	if err := yaml.UnmarshalStrict(b, &qt); err != nil {
+		return fmt.Errorf("cannot parse query templates: %v", err)
+	}
+
+	added := make([]addedQueryType, 0, len(qt.Templates))
+	for _, t := range qt.Templates {
+		if _, ok := matrix[t.UseCase]; !ok {
+			return fmt.Errorf(errUnknownTemplateUseCaseFmt, t.Name, t.UseCase)
+		}
+		if t.Measurement == "" {
+			t.Measurement = defaultMeasurements[t.UseCase]
+		}
+		maker, err := querytemplate.New(t.Name, t.Spec)
+		if err != nil {
+			return fmt.Errorf("query template %q: %v", t.Name, err)
+		}
+		added = append(added, addedQueryType{name: t.Name, useCase: t.UseCase, maker: maker})
+	}
+	return addQueryTypes(added, matrix)
+}
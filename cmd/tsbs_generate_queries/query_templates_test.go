@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/timescaledb"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestAddQueryTemplates(t *testing.T) {
+	doc := `
+query-templates:
+  - name: my-max-usage
+    use-case: devops
+    hosts: 2
+    metrics: 1
+    window: 1h
+    query: |
+      SELECT max({{join .Metrics ", "}}) FROM cpu
+      WHERE hostname IN ({{.Hosts}}) AND time >= '{{.Start}}' AND time < '{{.End}}'
+  - name: my-fleet-readings
+    use-case: iot
+    window: 30m
+    query: "/query?q={{urlquery \"SELECT * FROM readings WHERE fleet = '\" .Fleet \"'\"}}"
+`
+	matrix := newTestMatrix()
+	if err := addQueryTemplates([]byte(doc), matrix); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := matrix["cpu-only"]["my-max-usage"]; !ok {
+		t.Errorf("query template my-max-usage missing for cpu-only")
+	}
+	if _, ok := matrix["iot"]["my-fleet-readings"]; !ok {
+		t.Errorf("query template my-fleet-readings missing for iot")
+	}
+
+	// the template does not set its measurement, so it reads the cpu table
+	b := &timescaledb.BaseGenerator{}
+	gen, err := b.NewDevops(time.Unix(0, 0), time.Unix(0, 0).Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+	q := matrix["devops"]["my-max-usage"](gen).Fill(gen.GenerateEmptyQuery()).(*query.TimescaleDB)
+	if got := string(q.Hypertable); got != "cpu" {
+		t.Errorf("incorrect hypertable: got %s want cpu", got)
+	}
+}
+
+func TestAddQueryTemplatesErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		doc  string
+		want string
+	}{
+		{
+			desc: "unknown use case",
+			doc:  "query-templates:\n  - name: foo\n    use-case: bar\n    window: 1h\n    query: SELECT 1\n",
+			want: fmt.Sprintf(errUnknownTemplateUseCaseFmt, "foo", "bar"),
+		},
+		{
+			desc: "built-in name",
+			doc:  "query-templates:\n  - name: lastpoint\n    use-case: devops\n    window: 1h\n    query: SELECT 1\n",
+			want: fmt.Sprintf(errDuplicateMatrixQueryTypeFmt, "lastpoint", "devops"),
+		},
+		{
+			desc: "invalid template",
+			doc:  "query-templates:\n  - name: foo\n    use-case: iot\n    query: SELECT 1\n",
+			want: `query template "foo": query template requires a window`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			matrix := newTestMatrix()
+			err := addQueryTemplates([]byte(c.doc), matrix)
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if got := err.Error(); got != c.want {
+				t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, c.want)
+			}
+			if got := len(matrix["devops"]) + len(matrix["iot"]); got != 1 {
+				t.Errorf("matrix was modified: got %d query types want 1", got)
+			}
+		})
+	}
+}
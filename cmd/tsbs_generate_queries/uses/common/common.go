@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"time"
@@ -11,9 +12,41 @@ import (
 )
 
 const (
-	errMoreItemsThanScale = "cannot get random permutation with more items than scale"
+	errMoreItemsThanScale        = "cannot get random permutation with more items than scale"
+	errUnknownWindowPlacementFmt = "unknown window placement '%s'"
+
+	// WindowPlacementUniform places query windows uniformly across the time range
+	WindowPlacementUniform = "uniform"
+	// WindowPlacementLatest anchors query windows at the end of the time range
+	WindowPlacementLatest = "latest"
+	// WindowPlacementExponential places query windows back from the end of the
+	// time range by an exponentially distributed offset averaging one window
+	WindowPlacementExponential = "exponential"
+	// WindowPlacementZipf places query windows on window-sized slots back from
+	// the end of the time range, picking the slots by a Zipf distribution
+	WindowPlacementZipf = "zipf"
+
+	// zipfExponent is the exponent of the Zipf distribution of the slots
+	zipfExponent = 1.5
 )
 
+var windowPlacements = []string{
+	WindowPlacementUniform,
+	WindowPlacementLatest,
+	WindowPlacementExponential,
+	WindowPlacementZipf,
+}
+
+// WindowPlacements returns the ways query windows can be placed in the time range
+func WindowPlacements() []string {
+	return windowPlacements
+}
+
+// WindowPlacer is a query generator whose window placement can be changed
+type WindowPlacer interface {
+	SetWindowPlacement(string) error
+}
+
 // Core is the common component of all generators for all systems
 type Core struct {
 	// Interval is the entire time range of the dataset
@@ -21,6 +54,9 @@ type Core struct {
 
 	// Scale is the cardinality of the dataset in terms of devices/hosts
 	Scale int
+
+	// WindowPlacement is how MustRandWindow places query windows in Interval
+	WindowPlacement string
 }
 
 // NewCore returns a new Core for the given time range and cardinality
@@ -30,12 +66,76 @@ func NewCore(start, end time.Time, scale int) (*Core, error) {
 		return nil, err
 	}
 
-	return &Core{Interval: ti, Scale: scale}, nil
+	return &Core{Interval: ti, Scale: scale, WindowPlacement: WindowPlacementUniform}, nil
 }
 
+// SetWindowPlacement sets how MustRandWindow places query windows in the time range
+func (c *Core) SetWindowPlacement(placement string) error {
+	for _, p := range windowPlacements {
+		if p == placement {
+			c.WindowPlacement = placement
+			return nil
+		}
+	}
+	return fmt.Errorf(errUnknownWindowPlacementFmt, placement)
+}
+
+// MustRandWindow returns a random window of the given duration in the time
+// range of the dataset, placed according to the WindowPlacement of the Core.
+// It panics if the window does not fit in the time range.
+func (c *Core) MustRandWindow(window time.Duration) *internalutils.TimeInterval {
+	span := c.Interval.Duration() - window
+	if c.WindowPlacement == WindowPlacementUniform || span <= 0 {
+		// Oversized windows are reported by the time range itself
+		return c.Interval.MustRandWindow(window)
+	}
+
+	var offset time.Duration
+	switch c.WindowPlacement {
+	case WindowPlacementLatest:
+		offset = 0
+	case WindowPlacementExponential:
+		// The offset is drawn from the exponential distribution truncated to
+		// the time range, by inverting its CDF, so that short time ranges
+		// neither pile windows up at the start nor wrap them around
+		w := float64(window)
+		offset = time.Duration(-w * math.Log1p(rand.Float64()*math.Expm1(-float64(span)/w)))
+	case WindowPlacementZipf:
+		slots := uint64(1)
+		if window > 0 {
+			slots += uint64(span / window)
+		}
+		zipf := rand.NewZipf(rand.New(globalSource{}), zipfExponent, 1, slots-1)
+		offset = time.Duration(zipf.Uint64()) * window
+	default:
+		panic(fmt.Sprintf(errUnknownWindowPlacementFmt, c.WindowPlacement))
+	}
+
+	start := c.Interval.End().Add(-window - offset)
+	res, err := internalutils.NewTimeInterval(start, start.Add(window))
+	if err != nil {
+		panic(err.Error())
+	}
+	return res
+}
+
+// globalSource is a rand.Source drawing from the global source of math/rand,
+// so that the seed of the generation applies to it.
+type globalSource struct{}
+
+func (globalSource) Int63() int64 { return rand.Int63() }
+
+func (globalSource) Seed(int64) {}
+
 // PanicUnimplementedQuery generates a panic for the provided query generator.
 func PanicUnimplementedQuery(dg utils.QueryGenerator) {
-	panic(fmt.Sprintf("database (%v) does not implement query", reflect.TypeOf(dg)))
+	panic(UnimplementedQueryError(dg).Error())
+}
+
+// UnimplementedQueryError returns the error for a query the provided query
+// generator does not implement, for query fillers to report it beforehand.
+func UnimplementedQueryError(dg utils.QueryGenerator) error {
+	return fmt.Errorf("database (%v) does not implement query", reflect.TypeOf(dg))
 }
 
 // GetRandomSubsetPerm returns a subset of numItems of a permutation of numbers from 0 to totalNumbers,
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"math/rand"
 	"sort"
 	"testing"
 	"time"
@@ -75,3 +76,127 @@ func TestGetRandomSubsetPermError(t *testing.T) {
 		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, errMoreItemsThanScale)
 	}
 }
+
+func TestCoreSetWindowPlacement(t *testing.T) {
+	c, err := NewCore(time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.WindowPlacement; got != WindowPlacementUniform {
+		t.Errorf("NewCore does not have uniform window placement: got %s", got)
+	}
+	for _, p := range WindowPlacements() {
+		if err := c.SetWindowPlacement(p); err != nil {
+			t.Errorf("unexpected error for placement %s: %v", p, err)
+		}
+		if got := c.WindowPlacement; got != p {
+			t.Errorf("incorrect window placement: got %s want %s", got, p)
+		}
+	}
+	want := "unknown window placement 'foo'"
+	if err := c.SetWindowPlacement("foo"); err == nil || err.Error() != want {
+		t.Errorf("unexpected error for unknown placement: got %v want %s", err, want)
+	}
+}
+
+func TestCoreMustRandWindow(t *testing.T) {
+	start := time.Unix(0, 0)
+	end := start.Add(24 * time.Hour)
+	window := time.Hour
+
+	for _, p := range WindowPlacements() {
+		t.Run(p, func(t *testing.T) {
+			c, err := NewCore(start, end, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := c.SetWindowPlacement(p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rand.Seed(123) // Setting seed for testing purposes.
+			latest := 0
+			for i := 0; i < 1000; i++ {
+				x := c.MustRandWindow(window)
+				if got := x.Duration(); got != window {
+					t.Fatalf("incorrect window duration: got %v want %v", got, window)
+				}
+				if x.Start().Before(start) || x.End().After(end) {
+					t.Fatalf("window out of range: %s - %s", x.StartString(), x.EndString())
+				}
+				if end.Sub(x.End()) < 2*window {
+					latest++
+				}
+			}
+
+			switch p {
+			case WindowPlacementUniform:
+				if latest > 200 {
+					t.Errorf("too many recent windows for uniform placement: %d", latest)
+				}
+			case WindowPlacementLatest:
+				if latest != 1000 {
+					t.Errorf("windows not anchored at the end for latest placement: %d", latest)
+				}
+			default:
+				if latest < 500 {
+					t.Errorf("too few recent windows for %s placement: %d", p, latest)
+				}
+			}
+		})
+	}
+}
+
+func TestCoreMustRandWindowExponentialTruncated(t *testing.T) {
+	start := time.Unix(0, 0)
+	window := time.Hour
+	// the time range leaves half a window to place the windows in, so most
+	// offsets of an untruncated exponential would fall past it
+	span := window / 2
+	c, err := NewCore(start, start.Add(window+span), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.SetWindowPlacement(WindowPlacementExponential); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rand.Seed(123) // Setting seed for testing purposes.
+	oldest, older := 0, 0
+	for i := 0; i < 1000; i++ {
+		x := c.MustRandWindow(window)
+		if x.Start().Before(start) {
+			t.Fatalf("window out of range: %s - %s", x.StartString(), x.EndString())
+		}
+		if x.Start().Equal(start) {
+			oldest++
+		}
+		if x.Start().Before(start.Add(span / 2)) {
+			older++
+		}
+	}
+	if oldest > 5 {
+		t.Errorf("windows piled up at the start: got %d", oldest)
+	}
+	// P(offset > span/2 | offset <= span) = (e^-0.25 - e^-0.5) / (1 - e^-0.5),
+	// about 438 of 1000 windows
+	if older < 390 || older > 490 {
+		t.Errorf("incorrect number of windows in the older half: got %d want about 438", older)
+	}
+}
+
+func TestCoreMustRandWindowTooLarge(t *testing.T) {
+	c, err := NewCore(time.Unix(0, 0), time.Unix(0, 0).Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.SetWindowPlacement(WindowPlacementLatest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("did not panic for window larger than time range")
+		}
+	}()
+	c.MustRandWindow(2 * time.Hour)
+}
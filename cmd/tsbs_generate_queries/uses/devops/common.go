@@ -2,9 +2,12 @@ package devops
 
 import (
 	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/devops"
 	"github.com/timescale/tsbs/pkg/query"
 )
 
@@ -21,8 +24,16 @@ const (
 	DoubleGroupByDuration = 12 * time.Hour
 	// HighCPUDuration is the how big the time range for HighCPU query is
 	HighCPUDuration = 12 * time.Hour
+	// DoubleGroupByBucket is the how big the time buckets of DoubleGroupBy query are
+	DoubleGroupByBucket = time.Hour
 	// MaxAllDuration is the how big the time range for MaxAll query is
 	MaxAllDuration = 8 * time.Hour
+	// MaxAllBucket is the how big the time buckets of MaxAll query are
+	MaxAllBucket = time.Hour
+	// GroupByOrderByLimitRows is the how many rows GroupByOrderByLimit query returns
+	GroupByOrderByLimitRows = 5
+	// TopKHostsDuration is the how big the time range for TopKHosts query is
+	TopKHostsDuration = time.Hour
 
 	// LabelSingleGroupby is the label prefix for queries of the single groupby variety
 	LabelSingleGroupby = "single-groupby"
@@ -36,6 +47,16 @@ const (
 	LabelGroupbyOrderbyLimit = "groupby-orderby-limit"
 	// LabelHighCPU is the prefix for queries of the high-CPU variety
 	LabelHighCPU = "high-cpu"
+	// LabelPercentiles is the prefix for queries of the percentiles variety
+	LabelPercentiles = "percentiles"
+	// LabelTopKHosts is the prefix for queries of the top-k hosts variety
+	LabelTopKHosts = "top-k-hosts"
+	// LabelCrossMeasurement is the prefix for queries of the cross-measurement variety
+	LabelCrossMeasurement = "cross-measurement"
+	// LabelTagValues is the label for the tag values query
+	LabelTagValues = "tag-values"
+	// LabelSeriesCount is the label for the series count query
+	LabelSeriesCount = "series-count"
 )
 
 // Core is the common component of all generators for all systems
@@ -55,6 +76,12 @@ func (d *Core) GetRandomHosts(nHosts int) ([]string, error) {
 	return getRandomHosts(nHosts, d.Scale)
 }
 
+// GetRandomRegion returns one of the region choices of the hosts by random
+func (d *Core) GetRandomRegion() string {
+	regions := devops.RegionNames()
+	return regions[rand.Intn(len(regions))]
+}
+
 // cpuMetrics is the list of metric names for CPU
 var cpuMetrics = []string{
 	"usage_user",
@@ -80,6 +107,38 @@ func GetCPUMetricsSlice(numMetrics int) ([]string, error) {
 	return cpuMetrics[:numMetrics], nil
 }
 
+// percentiles is the list of percentiles computed by percentiles queries, as fractions
+var percentiles = []float64{0.5, 0.95, 0.99}
+
+// GetPercentiles returns the percentiles computed by percentiles queries, as fractions
+func GetPercentiles() []float64 {
+	return percentiles
+}
+
+// GetPercentileName returns the short name of a percentile, e.g. p95 for 0.95
+func GetPercentileName(p float64) string {
+	return fmt.Sprintf("p%g", p*100)
+}
+
+// MeasurementMetric is a metric of a given devops measurement
+type MeasurementMetric struct {
+	Measurement string
+	Metric      string
+}
+
+// crossMeasurementMetrics is the list of metrics correlated per host by
+// cross-measurement queries, the first one being from the cpu table
+var crossMeasurementMetrics = []MeasurementMetric{
+	{Measurement: TableName, Metric: "usage_user"},
+	{Measurement: "mem", Metric: "used_percent"},
+	{Measurement: "diskio", Metric: "reads"},
+}
+
+// GetCrossMeasurementMetrics returns the metrics correlated by cross-measurement queries
+func GetCrossMeasurementMetrics() []MeasurementMetric {
+	return crossMeasurementMetrics
+}
+
 // GetAllCPUMetrics returns all the metrics for CPU
 func GetAllCPUMetrics() []string {
 	return cpuMetrics
@@ -95,11 +154,23 @@ type SingleGroupbyFiller interface {
 	GroupByTime(query.Query, int, int, time.Duration)
 }
 
+// SingleGroupbyBucketFiller is a type that can fill in a single groupby query
+// with time buckets of any size
+type SingleGroupbyBucketFiller interface {
+	GroupByTimeBucket(query.Query, int, int, time.Duration, time.Duration)
+}
+
 // DoubleGroupbyFiller is a type that can fill in a double groupby query
 type DoubleGroupbyFiller interface {
 	GroupByTimeAndPrimaryTag(query.Query, int)
 }
 
+// DoubleGroupbyWindowFiller is a type that can fill in a double groupby query
+// over a window of any length with time buckets of any size
+type DoubleGroupbyWindowFiller interface {
+	GroupByTimeAndPrimaryTagWindow(query.Query, int, time.Duration, time.Duration)
+}
+
 // LastPointFiller is a type that can fill in a last point query
 type LastPointFiller interface {
 	LastPointPerHost(query.Query)
@@ -110,19 +181,81 @@ type MaxAllFiller interface {
 	MaxAllCPU(query.Query, int, time.Duration)
 }
 
+// MaxAllBucketFiller is a type that can fill in a max all CPU metrics query
+// with time buckets of any size
+type MaxAllBucketFiller interface {
+	MaxAllCPUBucket(query.Query, int, time.Duration, time.Duration)
+}
+
 // GroupbyOrderbyLimitFiller is a type that can fill in a groupby-orderby-limit query
 type GroupbyOrderbyLimitFiller interface {
 	GroupByOrderByLimit(query.Query)
 }
 
+// GroupbyOrderbyLimitNFiller is a type that can fill in a groupby-orderby-limit
+// query with any limit
+type GroupbyOrderbyLimitNFiller interface {
+	GroupByOrderByLimitN(query.Query, int)
+}
+
 // HighCPUFiller is a type that can fill in a high-cpu query
 type HighCPUFiller interface {
 	HighCPUForHosts(query.Query, int)
 }
 
+// HighCPUWindowFiller is a type that can fill in a high-cpu query over a
+// window of any length
+type HighCPUWindowFiller interface {
+	HighCPUForHostsWindow(query.Query, int, time.Duration)
+}
+
+// PercentilesFiller is a type that can fill in a percentiles query
+type PercentilesFiller interface {
+	GroupByTimePercentiles(query.Query, int, int, time.Duration)
+}
+
+// CrossMeasurementFiller is a type that can fill in a cross-measurement query
+type CrossMeasurementFiller interface {
+	GroupByTimeCrossMeasurement(query.Query, int, time.Duration)
+}
+
+// TopKHostsFiller is a type that can fill in a top-k hosts query
+type TopKHostsFiller interface {
+	TopKHosts(query.Query, int)
+}
+
+// TagValuesFiller is a type that can fill in a tag values query
+type TagValuesFiller interface {
+	TagValues(query.Query)
+}
+
+// SeriesCountFiller is a type that can fill in a series count query
+type SeriesCountFiller interface {
+	SeriesCount(query.Query)
+}
+
+// GetBucketName returns the short name of a time bucket, e.g. 1m for a minute,
+// which is also a valid InfluxQL, PromQL and QuestDB duration
+func GetBucketName(bucket time.Duration) string {
+	switch {
+	case bucket%time.Hour == 0:
+		return fmt.Sprintf("%dh", bucket/time.Hour)
+	case bucket%time.Minute == 0:
+		return fmt.Sprintf("%dm", bucket/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", bucket/time.Second)
+	}
+}
+
 // GetDoubleGroupByLabel returns the Query human-readable label for DoubleGroupBy queries
 func GetDoubleGroupByLabel(dbName string, numMetrics int) string {
-	return fmt.Sprintf("%s mean of %d metrics, all hosts, random %s by 1h", dbName, numMetrics, DoubleGroupByDuration)
+	return GetDoubleGroupByWindowLabel(dbName, numMetrics, DoubleGroupByDuration, DoubleGroupByBucket)
+}
+
+// GetDoubleGroupByWindowLabel returns the Query human-readable label for
+// DoubleGroupBy queries over any window with time buckets of any size
+func GetDoubleGroupByWindowLabel(dbName string, numMetrics int, window, bucket time.Duration) string {
+	return fmt.Sprintf("%s mean of %d metrics, all hosts, random %s by %s", dbName, numMetrics, window, GetBucketName(bucket))
 }
 
 // GetHighCPULabel returns the Query human-readable label for HighCPU queries
@@ -138,9 +271,66 @@ func GetHighCPULabel(dbName string, nHosts int) (string, error) {
 	return label, nil
 }
 
+// GetHighCPUWindowLabel returns the Query human-readable label for HighCPU
+// queries over any window, which is left out of the label of the default one
+func GetHighCPUWindowLabel(dbName string, nHosts int, window time.Duration) (string, error) {
+	label, err := GetHighCPULabel(dbName, nHosts)
+	if err != nil || window == HighCPUDuration {
+		return label, err
+	}
+	return fmt.Sprintf("%s, random %s", label, window), nil
+}
+
 // GetMaxAllLabel returns the Query human-readable label for MaxAllCPU queries
 func GetMaxAllLabel(dbName string, nHosts int) string {
-	return fmt.Sprintf("%s max of all CPU metrics, random %4d hosts, random %s by 1h", dbName, nHosts, MaxAllDuration)
+	return GetMaxAllBucketLabel(dbName, nHosts, MaxAllDuration, MaxAllBucket)
+}
+
+// GetMaxAllBucketLabel returns the Query human-readable label for MaxAllCPU
+// queries over any window with time buckets of any size
+func GetMaxAllBucketLabel(dbName string, nHosts int, window, bucket time.Duration) string {
+	return fmt.Sprintf("%s max of all CPU metrics, random %4d hosts, random %s by %s", dbName, nHosts, window, GetBucketName(bucket))
+}
+
+// GetGroupByOrderByLimitLabel returns the Query human-readable label for
+// GroupByOrderByLimit queries
+func GetGroupByOrderByLimitLabel(dbName string, limit int) string {
+	return fmt.Sprintf("%s max cpu over last %d min-intervals (random end)", dbName, limit)
+}
+
+// GetPercentilesLabel returns the Query human-readable label for Percentiles queries
+func GetPercentilesLabel(dbName string, numMetrics, nHosts int, timeRange time.Duration) string {
+	names := make([]string, len(percentiles))
+	for i, p := range percentiles {
+		names[i] = GetPercentileName(p)
+	}
+	return fmt.Sprintf("%s %s of %d cpu metric(s), random %4d hosts, random %s by 1h",
+		dbName, strings.Join(names, "/"), numMetrics, nHosts, timeRange)
+}
+
+// GetTopKHostsLabel returns the Query human-readable label for TopKHosts queries
+func GetTopKHostsLabel(dbName string, k int) string {
+	return fmt.Sprintf("%s top %d hosts by mean usage_user, random %s", dbName, k, TopKHostsDuration)
+}
+
+// GetCrossMeasurementLabel returns the Query human-readable label for CrossMeasurement queries
+func GetCrossMeasurementLabel(dbName string, nHosts int, timeRange time.Duration) string {
+	names := make([]string, len(crossMeasurementMetrics))
+	for i, m := range crossMeasurementMetrics {
+		names[i] = m.Measurement + " " + m.Metric
+	}
+	return fmt.Sprintf("%s mean of %s, random %4d hosts, random %s by 1h",
+		dbName, strings.Join(names, ", "), nHosts, timeRange)
+}
+
+// GetTagValuesLabel returns the Query human-readable label for TagValues queries
+func GetTagValuesLabel(dbName string) string {
+	return fmt.Sprintf("%s hostname tag values, random region", dbName)
+}
+
+// GetSeriesCountLabel returns the Query human-readable label for SeriesCount queries
+func GetSeriesCountLabel(dbName string) string {
+	return fmt.Sprintf("%s cpu series count, random region", dbName)
 }
 
 // getRandomHosts returns a subset of numHosts hostnames of a permutation of hostnames,
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/data/usecases/devops"
 )
 
 func TestNewCore(t *testing.T) {
@@ -268,3 +269,77 @@ func TestGetMaxAllLabel(t *testing.T) {
 		t.Errorf("incorrect output: got %s want %s", got, want)
 	}
 }
+
+func TestGetPercentileName(t *testing.T) {
+	cases := map[float64]string{
+		0.5:   "p50",
+		0.95:  "p95",
+		0.99:  "p99",
+		0.999: "p99.9",
+	}
+	for p, want := range cases {
+		if got := GetPercentileName(p); got != want {
+			t.Errorf("incorrect output for %v: got %s want %s", p, got, want)
+		}
+	}
+}
+
+func TestGetPercentilesLabel(t *testing.T) {
+	want := "Foo p50/p95/p99 of 5 cpu metric(s), random    8 hosts, random 12h0m0s by 1h"
+	got := GetPercentilesLabel("Foo", 5, 8, 12*time.Hour)
+	if got != want {
+		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetTopKHostsLabel(t *testing.T) {
+	want := "Foo top 10 hosts by mean usage_user, random 1h0m0s"
+	got := GetTopKHostsLabel("Foo", 10)
+	if got != want {
+		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetCrossMeasurementLabel(t *testing.T) {
+	want := "Foo mean of cpu usage_user, mem used_percent, diskio reads, random    8 hosts, random 1h0m0s by 1h"
+	got := GetCrossMeasurementLabel("Foo", 8, time.Hour)
+	if got != want {
+		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetTagValuesLabel(t *testing.T) {
+	want := "Foo hostname tag values, random region"
+	if got := GetTagValuesLabel("Foo"); got != want {
+		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetSeriesCountLabel(t *testing.T) {
+	want := "Foo cpu series count, random region"
+	if got := GetSeriesCountLabel("Foo"); got != want {
+		t.Errorf("incorrect output:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCoreGetRandomRegion(t *testing.T) {
+	c, err := NewCore(time.Now(), time.Now(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error for NewCore: %v", err)
+	}
+
+	regions := devops.RegionNames()
+	for i := 0; i < 100; i++ {
+		region := c.GetRandomRegion()
+		found := false
+		for _, r := range regions {
+			if r == region {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("random region %s is not a host region", region)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package devops
+
+import (
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// CrossMeasurement contains info for filling in cross-measurement queries
+type CrossMeasurement struct {
+	core  utils.QueryGenerator
+	hosts int
+	hours int
+}
+
+// NewCrossMeasurement produces a new function that produces a new CrossMeasurement
+func NewCrossMeasurement(hosts, hours int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &CrossMeasurement{
+			core:  core,
+			hosts: hosts,
+			hours: hours,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details
+func (d *CrossMeasurement) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(CrossMeasurementFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.GroupByTimeCrossMeasurement(q, d.hosts, time.Duration(int64(d.hours)*int64(time.Hour)))
+	return q
+}
@@ -1,6 +1,8 @@
 package devops
 
 import (
+	"time"
+
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
@@ -10,20 +12,38 @@ import (
 type Groupby struct {
 	core       utils.QueryGenerator
 	numMetrics int
+	window     time.Duration
+	bucket     time.Duration
 }
 
 // NewGroupBy produces a function that produces a new Groupby for the given parameters
 func NewGroupBy(numMetrics int) utils.QueryFillerMaker {
+	return NewGroupByWindow(numMetrics, DoubleGroupByDuration, DoubleGroupByBucket)
+}
+
+// NewGroupByWindow produces a function that produces a new Groupby over a
+// window of any length with time buckets of any size
+func NewGroupByWindow(numMetrics int, window, bucket time.Duration) utils.QueryFillerMaker {
 	return func(core utils.QueryGenerator) utils.QueryFiller {
 		return &Groupby{
 			core:       core,
 			numMetrics: numMetrics,
+			window:     window,
+			bucket:     bucket,
 		}
 	}
 }
 
 // Fill fills in the query.Query with query details
 func (d *Groupby) Fill(q query.Query) query.Query {
+	if !d.isDefault() {
+		fc, ok := d.core.(DoubleGroupbyWindowFiller)
+		if !ok {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		fc.GroupByTimeAndPrimaryTagWindow(q, d.numMetrics, d.window, d.bucket)
+		return q
+	}
 	fc, ok := d.core.(DoubleGroupbyFiller)
 	if !ok {
 		common.PanicUnimplementedQuery(d.core)
@@ -31,3 +51,16 @@ func (d *Groupby) Fill(q query.Query) query.Query {
 	fc.GroupByTimeAndPrimaryTag(q, d.numMetrics)
 	return q
 }
+
+// Validate checks that the database implements the query with a window or
+// bucket other than the default ones
+func (d *Groupby) Validate() error {
+	if _, ok := d.core.(DoubleGroupbyWindowFiller); !ok && !d.isDefault() {
+		return common.UnimplementedQueryError(d.core)
+	}
+	return nil
+}
+
+func (d *Groupby) isDefault() bool {
+	return d.window == DoubleGroupByDuration && d.bucket == DoubleGroupByBucket
+}
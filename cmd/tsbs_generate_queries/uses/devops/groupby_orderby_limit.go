@@ -8,16 +8,33 @@ import (
 
 // GroupByOrderByLimit produces a filler for queries in the devops groupby-orderby-limit case.
 type GroupByOrderByLimit struct {
-	core utils.QueryGenerator
+	core  utils.QueryGenerator
+	limit int
 }
 
 // NewGroupByOrderByLimit returns a new GroupByOrderByLimit for given paremeters
 func NewGroupByOrderByLimit(core utils.QueryGenerator) utils.QueryFiller {
-	return &GroupByOrderByLimit{core}
+	return &GroupByOrderByLimit{core: core, limit: GroupByOrderByLimitRows}
+}
+
+// NewGroupByOrderByLimitN produces a new function that produces a new
+// GroupByOrderByLimit with any limit
+func NewGroupByOrderByLimitN(limit int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &GroupByOrderByLimit{core: core, limit: limit}
+	}
 }
 
 // Fill fills in the query.Query with query details
 func (d *GroupByOrderByLimit) Fill(q query.Query) query.Query {
+	if d.limit != GroupByOrderByLimitRows {
+		fc, ok := d.core.(GroupbyOrderbyLimitNFiller)
+		if !ok {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		fc.GroupByOrderByLimitN(q, d.limit)
+		return q
+	}
 	fc, ok := d.core.(GroupbyOrderbyLimitFiller)
 	if !ok {
 		common.PanicUnimplementedQuery(d.core)
@@ -25,3 +42,12 @@ func (d *GroupByOrderByLimit) Fill(q query.Query) query.Query {
 	fc.GroupByOrderByLimit(q)
 	return q
 }
+
+// Validate checks that the database implements the query with a limit other
+// than the default one
+func (d *GroupByOrderByLimit) Validate() error {
+	if _, ok := d.core.(GroupbyOrderbyLimitNFiller); !ok && d.limit != GroupByOrderByLimitRows {
+		return common.UnimplementedQueryError(d.core)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package devops
 
 import (
+	"time"
+
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	"github.com/timescale/tsbs/pkg/query"
@@ -8,22 +10,38 @@ import (
 
 // HighCPU produces a QueryFiller for the devops high-cpu cases
 type HighCPU struct {
-	core  utils.QueryGenerator
-	hosts int
+	core   utils.QueryGenerator
+	hosts  int
+	window time.Duration
 }
 
 // NewHighCPU produces a new function that produces a new HighCPU
 func NewHighCPU(hosts int) utils.QueryFillerMaker {
+	return NewHighCPUWindow(hosts, HighCPUDuration)
+}
+
+// NewHighCPUWindow produces a new function that produces a new HighCPU over
+// a window of any length
+func NewHighCPUWindow(hosts int, window time.Duration) utils.QueryFillerMaker {
 	return func(core utils.QueryGenerator) utils.QueryFiller {
 		return &HighCPU{
-			core:  core,
-			hosts: hosts,
+			core:   core,
+			hosts:  hosts,
+			window: window,
 		}
 	}
 }
 
 // Fill fills in the query.Query with query details
 func (d *HighCPU) Fill(q query.Query) query.Query {
+	if d.window != HighCPUDuration {
+		fc, ok := d.core.(HighCPUWindowFiller)
+		if !ok {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		fc.HighCPUForHostsWindow(q, d.hosts, d.window)
+		return q
+	}
 	fc, ok := d.core.(HighCPUFiller)
 	if !ok {
 		common.PanicUnimplementedQuery(d.core)
@@ -31,3 +49,12 @@ func (d *HighCPU) Fill(q query.Query) query.Query {
 	fc.HighCPUForHosts(q, d.hosts)
 	return q
 }
+
+// Validate checks that the database implements the query with a window other
+// than the default one
+func (d *HighCPU) Validate() error {
+	if _, ok := d.core.(HighCPUWindowFiller); !ok && d.window != HighCPUDuration {
+		return common.UnimplementedQueryError(d.core)
+	}
+	return nil
+}
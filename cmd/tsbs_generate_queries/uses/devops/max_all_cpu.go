@@ -13,21 +13,37 @@ type MaxAllCPU struct {
 	core     utils.QueryGenerator
 	hosts    int
 	duration time.Duration
+	bucket   time.Duration
 }
 
 // NewMaxAllCPU produces a new function that produces a new AllMaxCPU
 func NewMaxAllCPU(hosts int, duration time.Duration) utils.QueryFillerMaker {
+	return NewMaxAllCPUBucket(hosts, duration, MaxAllBucket)
+}
+
+// NewMaxAllCPUBucket produces a new function that produces a new AllMaxCPU
+// with time buckets of any size
+func NewMaxAllCPUBucket(hosts int, duration, bucket time.Duration) utils.QueryFillerMaker {
 	return func(core utils.QueryGenerator) utils.QueryFiller {
 		return &MaxAllCPU{
 			core:     core,
 			hosts:    hosts,
 			duration: duration,
+			bucket:   bucket,
 		}
 	}
 }
 
 // Fill fills in the query.Query with query details
 func (d *MaxAllCPU) Fill(q query.Query) query.Query {
+	if d.bucket != MaxAllBucket {
+		fc, ok := d.core.(MaxAllBucketFiller)
+		if !ok {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		fc.MaxAllCPUBucket(q, d.hosts, d.duration, d.bucket)
+		return q
+	}
 	fc, ok := d.core.(MaxAllFiller)
 	if !ok {
 		common.PanicUnimplementedQuery(d.core)
@@ -35,3 +51,12 @@ func (d *MaxAllCPU) Fill(q query.Query) query.Query {
 	fc.MaxAllCPU(q, d.hosts, d.duration)
 	return q
 }
+
+// Validate checks that the database implements the query with a bucket other
+// than the default one
+func (d *MaxAllCPU) Validate() error {
+	if _, ok := d.core.(MaxAllBucketFiller); !ok && d.bucket != MaxAllBucket {
+		return common.UnimplementedQueryError(d.core)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package devops
+
+import (
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Percentiles contains info for filling in percentiles queries
+type Percentiles struct {
+	core    utils.QueryGenerator
+	metrics int
+	hosts   int
+	hours   int
+}
+
+// NewPercentiles produces a new function that produces a new Percentiles
+func NewPercentiles(metrics, hosts, hours int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &Percentiles{
+			core:    core,
+			metrics: metrics,
+			hosts:   hosts,
+			hours:   hours,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details
+func (d *Percentiles) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(PercentilesFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.GroupByTimePercentiles(q, d.hosts, d.metrics, time.Duration(int64(d.hours)*int64(time.Hour)))
+	return q
+}
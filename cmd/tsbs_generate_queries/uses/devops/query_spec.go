@@ -0,0 +1,196 @@
+package devops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+)
+
+const (
+	errUnknownKindFmt       = "unknown query kind %q"
+	errUnsupportedFieldFmt  = "query kind %q does not take %s"
+	errMissingFieldFmt      = "query kind %q requires %s"
+	errNegativeFieldsFmt    = "query kind %q cannot have negative parameters"
+	errTooManyMetricsFmt    = "query kind %q asks for %d metrics, at most %d are available"
+	errWholeHoursFmt        = "query kind %q requires a window of whole hours, got %s"
+	errBucketTooSmallFmt    = "bucket must be at least 1s, got %s"
+	errBucketWholeSecondFmt = "bucket must be a whole number of seconds, got %s"
+	errBucketExceedsWindow  = "bucket %s cannot be larger than window %s"
+)
+
+// QuerySpec describes a devops query type by the kind of its filler and its
+// parameters, so that query types can be defined outside of the Go code.
+// The kind is one of the devops labels, e.g. single-groupby or cpu-max-all.
+// A zero field means the parameter is not set, in which case the query kind
+// uses its default, e.g. a 12h window and 1h buckets for double-groupby.
+type QuerySpec struct {
+	Kind    string        `yaml:"kind"`
+	Metrics int           `yaml:"metrics"`
+	Hosts   int           `yaml:"hosts"`
+	Window  time.Duration `yaml:"window"`
+	Bucket  time.Duration `yaml:"bucket"`
+	Limit   int           `yaml:"limit"`
+}
+
+// querySpecFields lists which parameters each query kind takes
+var querySpecFields = map[string][]string{
+	LabelSingleGroupby:       {"metrics", "hosts", "window", "bucket"},
+	LabelMaxAll:              {"hosts", "window", "bucket"},
+	LabelDoubleGroupby:       {"metrics", "window", "bucket"},
+	LabelHighCPU:             {"hosts", "window"},
+	LabelPercentiles:         {"metrics", "hosts", "window"},
+	LabelTopKHosts:           {"limit"},
+	LabelCrossMeasurement:    {"hosts", "window"},
+	LabelLastpoint:           {},
+	LabelGroupbyOrderbyLimit: {"limit"},
+	LabelTagValues:           {},
+	LabelSeriesCount:         {},
+}
+
+// QueryFillerMaker validates the QuerySpec and returns the
+// utils.QueryFillerMaker for it.
+func (s *QuerySpec) QueryFillerMaker() (utils.QueryFillerMaker, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	switch s.Kind {
+	case LabelSingleGroupby:
+		return NewSingleGroupbyBucket(s.Metrics, s.Hosts, s.Window, orDefault(s.Bucket, time.Minute)), nil
+	case LabelMaxAll:
+		return NewMaxAllCPUBucket(s.Hosts, s.Window, orDefault(s.Bucket, MaxAllBucket)), nil
+	case LabelDoubleGroupby:
+		return NewGroupByWindow(s.Metrics, orDefault(s.Window, DoubleGroupByDuration), orDefault(s.Bucket, DoubleGroupByBucket)), nil
+	case LabelHighCPU:
+		return NewHighCPUWindow(s.Hosts, orDefault(s.Window, HighCPUDuration)), nil
+	case LabelPercentiles:
+		return NewPercentiles(s.Metrics, s.Hosts, int(s.Window/time.Hour)), nil
+	case LabelTopKHosts:
+		return NewTopKHosts(s.Limit), nil
+	case LabelCrossMeasurement:
+		return NewCrossMeasurement(s.Hosts, int(s.Window/time.Hour)), nil
+	case LabelLastpoint:
+		return NewLastPointPerHost, nil
+	case LabelGroupbyOrderbyLimit:
+		if s.Limit == 0 {
+			return NewGroupByOrderByLimit, nil
+		}
+		return NewGroupByOrderByLimitN(s.Limit), nil
+	case LabelTagValues:
+		return NewTagValues, nil
+	case LabelSeriesCount:
+		return NewSeriesCount, nil
+	}
+	return nil, fmt.Errorf(errUnknownKindFmt, s.Kind)
+}
+
+func (s *QuerySpec) validate() error {
+	fields, ok := querySpecFields[s.Kind]
+	if !ok {
+		return fmt.Errorf(errUnknownKindFmt, s.Kind)
+	}
+	takes := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		takes[f] = true
+	}
+
+	set := map[string]bool{
+		"metrics": s.Metrics != 0,
+		"hosts":   s.Hosts != 0,
+		"window":  s.Window != 0,
+		"bucket":  s.Bucket != 0,
+		"limit":   s.Limit != 0,
+	}
+	for _, f := range []string{"metrics", "hosts", "window", "bucket", "limit"} {
+		if set[f] && !takes[f] {
+			return fmt.Errorf(errUnsupportedFieldFmt, s.Kind, f)
+		}
+	}
+
+	if s.Metrics < 0 || s.Hosts < 0 || s.Window < 0 || s.Bucket < 0 || s.Limit < 0 {
+		return fmt.Errorf(errNegativeFieldsFmt, s.Kind)
+	}
+	if s.Metrics > GetCPUMetricsLen() {
+		return fmt.Errorf(errTooManyMetricsFmt, s.Kind, s.Metrics, GetCPUMetricsLen())
+	}
+
+	switch s.Kind {
+	case LabelSingleGroupby:
+		if err := s.require("metrics", "hosts", "window"); err != nil {
+			return err
+		}
+		return s.validateBucket(s.Window)
+	case LabelMaxAll:
+		if err := s.require("hosts", "window"); err != nil {
+			return err
+		}
+		return s.validateBucket(s.Window)
+	case LabelDoubleGroupby:
+		if err := s.require("metrics"); err != nil {
+			return err
+		}
+		return s.validateBucket(orDefault(s.Window, DoubleGroupByDuration))
+	case LabelTopKHosts:
+		return s.require("limit")
+	case LabelPercentiles, LabelCrossMeasurement:
+		if err := s.require("hosts", "window"); err != nil {
+			return err
+		}
+		if s.Kind == LabelPercentiles {
+			if err := s.require("metrics"); err != nil {
+				return err
+			}
+		}
+		if s.Window%time.Hour != 0 {
+			return fmt.Errorf(errWholeHoursFmt, s.Kind, s.Window)
+		}
+	}
+	return nil
+}
+
+func (s *QuerySpec) require(fields ...string) error {
+	for _, f := range fields {
+		var missing bool
+		switch f {
+		case "metrics":
+			missing = s.Metrics == 0
+		case "hosts":
+			missing = s.Hosts == 0
+		case "window":
+			missing = s.Window == 0
+		case "limit":
+			missing = s.Limit == 0
+		}
+		if missing {
+			return fmt.Errorf(errMissingFieldFmt, s.Kind, f)
+		}
+	}
+	return nil
+}
+
+// validateBucket checks the bucket against the window it divides, which is
+// the default window of the query kind if none is set
+func (s *QuerySpec) validateBucket(window time.Duration) error {
+	if s.Bucket == 0 {
+		return nil
+	}
+	if s.Bucket < time.Second {
+		return fmt.Errorf(errBucketTooSmallFmt, s.Bucket)
+	}
+	if s.Bucket%time.Second != 0 {
+		return fmt.Errorf(errBucketWholeSecondFmt, s.Bucket)
+	}
+	if s.Bucket > window {
+		return fmt.Errorf(errBucketExceedsWindow, s.Bucket, window)
+	}
+	return nil
+}
+
+// orDefault returns d if the duration v is not set
+func orDefault(v, d time.Duration) time.Duration {
+	if v == 0 {
+		return d
+	}
+	return v
+}
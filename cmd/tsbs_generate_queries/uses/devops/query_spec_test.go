@@ -0,0 +1,343 @@
+package devops
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestQuerySpecQueryFillerMaker(t *testing.T) {
+	cases := []struct {
+		desc string
+		spec QuerySpec
+		want interface{}
+	}{
+		{
+			desc: "single groupby with default bucket",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 2, Hosts: 4, Window: 3 * time.Hour},
+			want: &SingleGroupby{metrics: 2, hosts: 4, window: 3 * time.Hour, bucket: time.Minute},
+		},
+		{
+			desc: "single groupby with bucket",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Hosts: 1, Window: 30 * time.Minute, Bucket: 10 * time.Second},
+			want: &SingleGroupby{metrics: 1, hosts: 1, window: 30 * time.Minute, bucket: 10 * time.Second},
+		},
+		{
+			desc: "max all",
+			spec: QuerySpec{Kind: LabelMaxAll, Hosts: 16, Window: 48 * time.Hour},
+			want: &MaxAllCPU{hosts: 16, duration: 48 * time.Hour, bucket: time.Hour},
+		},
+		{
+			desc: "max all with bucket",
+			spec: QuerySpec{Kind: LabelMaxAll, Hosts: 1, Window: 2 * time.Hour, Bucket: 15 * time.Minute},
+			want: &MaxAllCPU{hosts: 1, duration: 2 * time.Hour, bucket: 15 * time.Minute},
+		},
+		{
+			desc: "double groupby",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 3},
+			want: &Groupby{numMetrics: 3, window: 12 * time.Hour, bucket: time.Hour},
+		},
+		{
+			desc: "double groupby with window",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 3, Window: 24 * time.Hour},
+			want: &Groupby{numMetrics: 3, window: 24 * time.Hour, bucket: time.Hour},
+		},
+		{
+			desc: "double groupby with bucket",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 1, Bucket: 10 * time.Minute},
+			want: &Groupby{numMetrics: 1, window: 12 * time.Hour, bucket: 10 * time.Minute},
+		},
+		{
+			desc: "high cpu for all hosts",
+			spec: QuerySpec{Kind: LabelHighCPU},
+			want: &HighCPU{hosts: 0, window: 12 * time.Hour},
+		},
+		{
+			desc: "high cpu with window",
+			spec: QuerySpec{Kind: LabelHighCPU, Hosts: 1, Window: time.Hour},
+			want: &HighCPU{hosts: 1, window: time.Hour},
+		},
+		{
+			desc: "percentiles",
+			spec: QuerySpec{Kind: LabelPercentiles, Metrics: 1, Hosts: 2, Window: 6 * time.Hour},
+			want: &Percentiles{metrics: 1, hosts: 2, hours: 6},
+		},
+		{
+			desc: "top k hosts",
+			spec: QuerySpec{Kind: LabelTopKHosts, Limit: 3},
+			want: &TopKHosts{k: 3},
+		},
+		{
+			desc: "cross measurement",
+			spec: QuerySpec{Kind: LabelCrossMeasurement, Hosts: 2, Window: 2 * time.Hour},
+			want: &CrossMeasurement{hosts: 2, hours: 2},
+		},
+		{
+			desc: "lastpoint",
+			spec: QuerySpec{Kind: LabelLastpoint},
+			want: &LastPointPerHost{},
+		},
+		{
+			desc: "groupby orderby limit",
+			spec: QuerySpec{Kind: LabelGroupbyOrderbyLimit},
+			want: &GroupByOrderByLimit{limit: 5},
+		},
+		{
+			desc: "groupby orderby limit with limit",
+			spec: QuerySpec{Kind: LabelGroupbyOrderbyLimit, Limit: 20},
+			want: &GroupByOrderByLimit{limit: 20},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			m, err := c.spec.QueryFillerMaker()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := m(nil); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("incorrect filler:\ngot\n%+v\nwant\n%+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestQuerySpecQueryFillerMakerErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		spec QuerySpec
+		want string
+	}{
+		{
+			desc: "unknown kind",
+			spec: QuerySpec{Kind: "foo"},
+			want: fmt.Sprintf(errUnknownKindFmt, "foo"),
+		},
+		{
+			desc: "unsupported field",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 1, Hosts: 1},
+			want: fmt.Sprintf(errUnsupportedFieldFmt, LabelDoubleGroupby, "hosts"),
+		},
+		{
+			desc: "missing field",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Window: time.Hour},
+			want: fmt.Sprintf(errMissingFieldFmt, LabelSingleGroupby, "hosts"),
+		},
+		{
+			desc: "negative field",
+			spec: QuerySpec{Kind: LabelTopKHosts, Limit: -1},
+			want: fmt.Sprintf(errNegativeFieldsFmt, LabelTopKHosts),
+		},
+		{
+			desc: "too many metrics",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 11},
+			want: fmt.Sprintf(errTooManyMetricsFmt, LabelDoubleGroupby, 11, GetCPUMetricsLen()),
+		},
+		{
+			desc: "window not in whole hours",
+			spec: QuerySpec{Kind: LabelPercentiles, Metrics: 1, Hosts: 1, Window: 90 * time.Minute},
+			want: fmt.Sprintf(errWholeHoursFmt, LabelPercentiles, 90*time.Minute),
+		},
+		{
+			desc: "bucket too small",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Hosts: 1, Window: time.Hour, Bucket: time.Millisecond},
+			want: fmt.Sprintf(errBucketTooSmallFmt, time.Millisecond),
+		},
+		{
+			desc: "bucket not in whole seconds",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Hosts: 1, Window: time.Hour, Bucket: 1500 * time.Millisecond},
+			want: fmt.Sprintf(errBucketWholeSecondFmt, 1500*time.Millisecond),
+		},
+		{
+			desc: "bucket larger than window",
+			spec: QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Hosts: 1, Window: time.Hour, Bucket: 2 * time.Hour},
+			want: fmt.Sprintf(errBucketExceedsWindow, 2*time.Hour, time.Hour),
+		},
+		{
+			desc: "bucket larger than default window",
+			spec: QuerySpec{Kind: LabelDoubleGroupby, Metrics: 1, Bucket: 24 * time.Hour},
+			want: fmt.Sprintf(errBucketExceedsWindow, 24*time.Hour, 12*time.Hour),
+		},
+		{
+			desc: "max all bucket not in whole seconds",
+			spec: QuerySpec{Kind: LabelMaxAll, Hosts: 1, Window: time.Hour, Bucket: 1500 * time.Millisecond},
+			want: fmt.Sprintf(errBucketWholeSecondFmt, 1500*time.Millisecond),
+		},
+		{
+			desc: "high cpu with bucket",
+			spec: QuerySpec{Kind: LabelHighCPU, Bucket: time.Minute},
+			want: fmt.Sprintf(errUnsupportedFieldFmt, LabelHighCPU, "bucket"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := c.spec.QueryFillerMaker()
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if got := err.Error(); got != c.want {
+				t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, c.want)
+			}
+		})
+	}
+}
+
+// testDefaultsGenerator fills in devops queries only with their default
+// parameters and records the calls
+type testDefaultsGenerator struct {
+	calls []string
+}
+
+func (g *testDefaultsGenerator) GenerateEmptyQuery() query.Query {
+	return query.NewHTTP()
+}
+
+func (g *testDefaultsGenerator) GroupByTime(_ query.Query, nHosts, numMetrics int, window time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("GroupByTime ", nHosts, numMetrics, window))
+}
+
+func (g *testDefaultsGenerator) GroupByTimeAndPrimaryTag(_ query.Query, numMetrics int) {
+	g.calls = append(g.calls, fmt.Sprint("GroupByTimeAndPrimaryTag ", numMetrics))
+}
+
+func (g *testDefaultsGenerator) MaxAllCPU(_ query.Query, nHosts int, window time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("MaxAllCPU ", nHosts, window))
+}
+
+func (g *testDefaultsGenerator) HighCPUForHosts(_ query.Query, nHosts int) {
+	g.calls = append(g.calls, fmt.Sprint("HighCPUForHosts ", nHosts))
+}
+
+func (g *testDefaultsGenerator) GroupByOrderByLimit(_ query.Query) {
+	g.calls = append(g.calls, "GroupByOrderByLimit")
+}
+
+// testOverridesGenerator also fills in devops queries with other parameters
+type testOverridesGenerator struct {
+	testDefaultsGenerator
+}
+
+func (g *testOverridesGenerator) GroupByTimeBucket(_ query.Query, nHosts, numMetrics int, window, bucket time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("GroupByTimeBucket ", nHosts, numMetrics, window, bucket))
+}
+
+func (g *testOverridesGenerator) GroupByTimeAndPrimaryTagWindow(_ query.Query, numMetrics int, window, bucket time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("GroupByTimeAndPrimaryTagWindow ", numMetrics, window, bucket))
+}
+
+func (g *testOverridesGenerator) MaxAllCPUBucket(_ query.Query, nHosts int, window, bucket time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("MaxAllCPUBucket ", nHosts, window, bucket))
+}
+
+func (g *testOverridesGenerator) HighCPUForHostsWindow(_ query.Query, nHosts int, window time.Duration) {
+	g.calls = append(g.calls, fmt.Sprint("HighCPUForHostsWindow ", nHosts, window))
+}
+
+func (g *testOverridesGenerator) GroupByOrderByLimitN(_ query.Query, limit int) {
+	g.calls = append(g.calls, fmt.Sprint("GroupByOrderByLimitN ", limit))
+}
+
+func TestQuerySpecOverrides(t *testing.T) {
+	cases := []struct {
+		desc         string
+		spec         QuerySpec
+		wantDefaults string
+		wantOverride string
+	}{
+		{
+			desc:         "single groupby bucket",
+			spec:         QuerySpec{Kind: LabelSingleGroupby, Metrics: 1, Hosts: 2, Window: time.Hour, Bucket: 10 * time.Second},
+			wantOverride: "GroupByTimeBucket 2 1 1h0m0s 10s",
+		},
+		{
+			desc:         "double groupby default",
+			spec:         QuerySpec{Kind: LabelDoubleGroupby, Metrics: 2},
+			wantDefaults: "GroupByTimeAndPrimaryTag 2",
+			wantOverride: "GroupByTimeAndPrimaryTag 2",
+		},
+		{
+			desc:         "double groupby window",
+			spec:         QuerySpec{Kind: LabelDoubleGroupby, Metrics: 2, Window: 24 * time.Hour},
+			wantOverride: "GroupByTimeAndPrimaryTagWindow 2 24h0m0s 1h0m0s",
+		},
+		{
+			desc:         "double groupby bucket",
+			spec:         QuerySpec{Kind: LabelDoubleGroupby, Metrics: 2, Bucket: 5 * time.Minute},
+			wantOverride: "GroupByTimeAndPrimaryTagWindow 2 12h0m0s 5m0s",
+		},
+		{
+			desc:         "max all default",
+			spec:         QuerySpec{Kind: LabelMaxAll, Hosts: 8, Window: 4 * time.Hour},
+			wantDefaults: "MaxAllCPU 8 4h0m0s",
+			wantOverride: "MaxAllCPU 8 4h0m0s",
+		},
+		{
+			desc:         "max all bucket",
+			spec:         QuerySpec{Kind: LabelMaxAll, Hosts: 8, Window: 4 * time.Hour, Bucket: 30 * time.Minute},
+			wantOverride: "MaxAllCPUBucket 8 4h0m0s 30m0s",
+		},
+		{
+			desc:         "high cpu default",
+			spec:         QuerySpec{Kind: LabelHighCPU, Hosts: 1},
+			wantDefaults: "HighCPUForHosts 1",
+			wantOverride: "HighCPUForHosts 1",
+		},
+		{
+			desc:         "high cpu window",
+			spec:         QuerySpec{Kind: LabelHighCPU, Hosts: 1, Window: 2 * time.Hour},
+			wantOverride: "HighCPUForHostsWindow 1 2h0m0s",
+		},
+		{
+			desc:         "groupby orderby limit default",
+			spec:         QuerySpec{Kind: LabelGroupbyOrderbyLimit},
+			wantDefaults: "GroupByOrderByLimit",
+			wantOverride: "GroupByOrderByLimit",
+		},
+		{
+			desc:         "groupby orderby limit limit",
+			spec:         QuerySpec{Kind: LabelGroupbyOrderbyLimit, Limit: 10},
+			wantOverride: "GroupByOrderByLimitN 10",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			m, err := c.spec.QueryFillerMaker()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			g := &testOverridesGenerator{}
+			f := m(g)
+			if err := f.(utils.QueryFillerValidator).Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			f.Fill(g.GenerateEmptyQuery())
+			if len(g.calls) != 1 || g.calls[0] != c.wantOverride {
+				t.Errorf("incorrect calls: got %q want %q", g.calls, c.wantOverride)
+			}
+
+			d := &testDefaultsGenerator{}
+			f = m(d)
+			err = f.(utils.QueryFillerValidator).Validate()
+			if c.wantDefaults == "" {
+				if err == nil {
+					t.Errorf("unexpected lack of error for a generator without the override")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			f.Fill(d.GenerateEmptyQuery())
+			if len(d.calls) != 1 || d.calls[0] != c.wantDefaults {
+				t.Errorf("incorrect calls: got %q want %q", d.calls, c.wantDefaults)
+			}
+		})
+	}
+}
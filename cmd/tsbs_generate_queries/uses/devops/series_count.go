@@ -0,0 +1,27 @@
+package devops
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SeriesCount returns QueryFiller for the devops series count case
+type SeriesCount struct {
+	core utils.QueryGenerator
+}
+
+// NewSeriesCount returns a new SeriesCount for given paremeters
+func NewSeriesCount(core utils.QueryGenerator) utils.QueryFiller {
+	return &SeriesCount{core}
+}
+
+// Fill fills in the query.Query with query details
+func (d *SeriesCount) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(SeriesCountFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.SeriesCount(q)
+	return q
+}
@@ -13,27 +13,52 @@ type SingleGroupby struct {
 	core    utils.QueryGenerator
 	metrics int
 	hosts   int
-	hours   int
+	window  time.Duration
+	bucket  time.Duration
 }
 
 // NewSingleGroupby produces a new function that produces a new SingleGroupby
 func NewSingleGroupby(metrics, hosts, hours int) utils.QueryFillerMaker {
+	return NewSingleGroupbyBucket(metrics, hosts, time.Duration(int64(hours)*int64(time.Hour)), time.Minute)
+}
+
+// NewSingleGroupbyBucket produces a new function that produces a new
+// SingleGroupby over a window of any length with time buckets of any size
+func NewSingleGroupbyBucket(metrics, hosts int, window, bucket time.Duration) utils.QueryFillerMaker {
 	return func(core utils.QueryGenerator) utils.QueryFiller {
 		return &SingleGroupby{
 			core:    core,
 			metrics: metrics,
 			hosts:   hosts,
-			hours:   hours,
+			window:  window,
+			bucket:  bucket,
 		}
 	}
 }
 
 // Fill fills in the query.Query with query details
 func (d *SingleGroupby) Fill(q query.Query) query.Query {
+	if d.bucket != time.Minute {
+		fc, ok := d.core.(SingleGroupbyBucketFiller)
+		if !ok {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		fc.GroupByTimeBucket(q, d.hosts, d.metrics, d.window, d.bucket)
+		return q
+	}
 	fc, ok := d.core.(SingleGroupbyFiller)
 	if !ok {
 		common.PanicUnimplementedQuery(d.core)
 	}
-	fc.GroupByTime(q, d.hosts, d.metrics, time.Duration(int64(d.hours)*int64(time.Hour)))
+	fc.GroupByTime(q, d.hosts, d.metrics, d.window)
 	return q
 }
+
+// Validate checks that the database implements the query with a bucket other
+// than the default one
+func (d *SingleGroupby) Validate() error {
+	if _, ok := d.core.(SingleGroupbyBucketFiller); !ok && d.bucket != time.Minute {
+		return common.UnimplementedQueryError(d.core)
+	}
+	return nil
+}
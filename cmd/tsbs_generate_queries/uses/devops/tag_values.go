@@ -0,0 +1,27 @@
+package devops
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// TagValues returns QueryFiller for the devops tag values case
+type TagValues struct {
+	core utils.QueryGenerator
+}
+
+// NewTagValues returns a new TagValues for given paremeters
+func NewTagValues(core utils.QueryGenerator) utils.QueryFiller {
+	return &TagValues{core}
+}
+
+// Fill fills in the query.Query with query details
+func (d *TagValues) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(TagValuesFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.TagValues(q)
+	return q
+}
@@ -0,0 +1,111 @@
+package devopsgeneric
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	errMaxMetricCount = "max metric count cannot be < 1; got %d"
+
+	// TableName is the name of the table where the time series data is stored for devops-generic use case.
+	TableName = "generic_metrics"
+
+	// TopKDuration is the how big the time range for TopKHosts query is
+	TopKDuration = time.Hour
+	// MetricDiscoveryDuration is the how big the time range for MetricDiscovery query is
+	MetricDiscoveryDuration = time.Hour
+
+	// LabelSingleMetricGroupby is the label prefix for queries of the single metric groupby variety
+	LabelSingleMetricGroupby = "single-metric-groupby"
+	// LabelLastpoint is the label for the lastpoint query
+	LabelLastpoint = "lastpoint"
+	// LabelTopKHosts is the label prefix for queries of the top-k hosts variety
+	LabelTopKHosts = "top-k-hosts"
+	// LabelMetricDiscovery is the label for the metric discovery query
+	LabelMetricDiscovery = "metric-discovery"
+)
+
+// Core is the common component of all generators for all systems
+type Core struct {
+	*devops.Core
+
+	// MaxMetricCount is the number of metric_N fields a host can have at most
+	MaxMetricCount int
+}
+
+// NewCore returns a new Core for the given time range, cardinality and
+// maximum number of metrics per host
+func NewCore(start, end time.Time, scale, maxMetricCount int) (*Core, error) {
+	if maxMetricCount < 1 {
+		return nil, fmt.Errorf(errMaxMetricCount, maxMetricCount)
+	}
+	c, err := devops.NewCore(start, end, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &Core{Core: c, MaxMetricCount: maxMetricCount}, nil
+}
+
+// GetRandomMetric returns the name of a random generic metric field
+func (c *Core) GetRandomMetric() string {
+	return GetMetricName(rand.Intn(c.MaxMetricCount))
+}
+
+// GetAllMetrics returns the names of all the generic metric fields
+func (c *Core) GetAllMetrics() []string {
+	metrics := make([]string, c.MaxMetricCount)
+	for i := range metrics {
+		metrics[i] = GetMetricName(i)
+	}
+	return metrics
+}
+
+// GetMetricName returns the name of the i-th generic metric field
+func GetMetricName(i int) string {
+	return fmt.Sprintf("metric_%d", i)
+}
+
+// SingleMetricGroupbyFiller is a type that can fill in a single metric groupby query
+type SingleMetricGroupbyFiller interface {
+	GroupByTimeSingleMetric(query.Query, int, time.Duration)
+}
+
+// LastPointFiller is a type that can fill in a last point query
+type LastPointFiller interface {
+	LastPointPerHost(query.Query)
+}
+
+// TopKHostsFiller is a type that can fill in a top-k hosts query
+type TopKHostsFiller interface {
+	TopKHosts(query.Query, int)
+}
+
+// MetricDiscoveryFiller is a type that can fill in a metric discovery query
+type MetricDiscoveryFiller interface {
+	MetricDiscovery(query.Query)
+}
+
+// GetSingleMetricGroupbyLabel returns the Query human-readable label for SingleMetricGroupby queries
+func GetSingleMetricGroupbyLabel(dbName string, nHosts int, timeRange time.Duration) string {
+	return fmt.Sprintf("%s 1 generic metric, random %4d hosts, random %s by 1m", dbName, nHosts, timeRange)
+}
+
+// GetLastpointLabel returns the Query human-readable label for LastPoint queries
+func GetLastpointLabel(dbName string) string {
+	return fmt.Sprintf("%s last value of a generic metric per host", dbName)
+}
+
+// GetTopKHostsLabel returns the Query human-readable label for TopKHosts queries
+func GetTopKHostsLabel(dbName string, k int) string {
+	return fmt.Sprintf("%s top %d hosts by mean of a generic metric, random %s", dbName, k, TopKDuration)
+}
+
+// GetMetricDiscoveryLabel returns the Query human-readable label for MetricDiscovery queries
+func GetMetricDiscoveryLabel(dbName string) string {
+	return fmt.Sprintf("%s metrics reported by a random host, random %s", dbName, MetricDiscoveryDuration)
+}
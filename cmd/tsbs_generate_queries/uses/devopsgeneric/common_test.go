@@ -0,0 +1,70 @@
+package devopsgeneric
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestNewCore(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Scale; got != 10 {
+		t.Errorf("NewCore does not have right scale: got %d want %d", got, 10)
+	}
+	if got := c.MaxMetricCount; got != 20 {
+		t.Errorf("NewCore does not have right max metric count: got %d want %d", got, 20)
+	}
+}
+
+func TestNewCoreBadMaxMetricCount(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	_, err := NewCore(s, e, 10, 0)
+	if err == nil {
+		t.Fatal("unexpected lack of error")
+	}
+	if got, want := err.Error(), fmt.Sprintf(errMaxMetricCount, 0); got != want {
+		t.Errorf("NewCore did not error correctly:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCoreGetRandomMetric(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	valid := map[string]bool{"metric_0": true, "metric_1": true, "metric_2": true}
+	rand.Seed(123)
+	for i := 0; i < 100; i++ {
+		if got := c.GetRandomMetric(); !valid[got] {
+			t.Fatalf("random metric out of range: got %s", got)
+		}
+	}
+}
+
+func TestCoreGetAllMetrics(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := c.GetAllMetrics()
+	want := []string{"metric_0", "metric_1", "metric_2"}
+	if len(got) != len(want) {
+		t.Fatalf("incorrect number of metrics: got %d want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("incorrect metric %d: got %s want %s", i, got[i], want[i])
+		}
+	}
+}
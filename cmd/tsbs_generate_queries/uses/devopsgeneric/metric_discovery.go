@@ -0,0 +1,27 @@
+package devopsgeneric
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// MetricDiscovery returns QueryFiller for the devops-generic metric discovery case
+type MetricDiscovery struct {
+	core utils.QueryGenerator
+}
+
+// NewMetricDiscovery returns a new MetricDiscovery for given paremeters
+func NewMetricDiscovery(core utils.QueryGenerator) utils.QueryFiller {
+	return &MetricDiscovery{core}
+}
+
+// Fill fills in the query.Query with query details
+func (d *MetricDiscovery) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(MetricDiscoveryFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.MetricDiscovery(q)
+	return q
+}
@@ -0,0 +1,37 @@
+package devopsgeneric
+
+import (
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SingleMetricGroupby contains info for filling in single metric groupby queries
+type SingleMetricGroupby struct {
+	core  utils.QueryGenerator
+	hosts int
+	hours int
+}
+
+// NewSingleMetricGroupby produces a new function that produces a new SingleMetricGroupby
+func NewSingleMetricGroupby(hosts, hours int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &SingleMetricGroupby{
+			core:  core,
+			hosts: hosts,
+			hours: hours,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details
+func (d *SingleMetricGroupby) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(SingleMetricGroupbyFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.GroupByTimeSingleMetric(q, d.hosts, time.Duration(int64(d.hours)*int64(time.Hour)))
+	return q
+}
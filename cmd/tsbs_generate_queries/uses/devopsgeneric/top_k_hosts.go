@@ -0,0 +1,33 @@
+package devopsgeneric
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// TopKHosts contains info for filling in top-k hosts queries
+type TopKHosts struct {
+	core utils.QueryGenerator
+	k    int
+}
+
+// NewTopKHosts produces a new function that produces a new TopKHosts
+func NewTopKHosts(k int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &TopKHosts{
+			core: core,
+			k:    k,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details
+func (d *TopKHosts) Fill(q query.Query) query.Query {
+	fc, ok := d.core.(TopKHostsFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	fc.TopKHosts(q, d.k)
+	return q
+}
@@ -0,0 +1,102 @@
+package finance
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/finance"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	// TradesTableName is the name of the table where all the trades
+	// time series data is stored.
+	TradesTableName = "trades"
+	// QuotesTableName is the name of the table where all the quotes
+	// time series data is stored.
+	QuotesTableName = "quotes"
+
+	// OHLCDuration is the time duration covered by the one minute OHLC bars.
+	OHLCDuration = time.Hour
+	// VWAPDuration is the time duration covered by the hourly VWAP.
+	VWAPDuration = 12 * time.Hour
+	// SpreadDuration is the time duration to evaluate the spread statistics.
+	SpreadDuration = time.Hour
+
+	// LabelOHLC is the label prefix for the OHLC bars query.
+	LabelOHLC = "ohlc"
+	// LabelVWAP is the label prefix for the volume-weighted average price query.
+	LabelVWAP = "vwap"
+	// LabelLastQuote is the label for the last quote per symbol query.
+	LabelLastQuote = "last-quote"
+	// LabelSpreadStats is the label for the spread statistics query.
+	LabelSpreadStats = "spread-stats"
+)
+
+// Core is the common component of all generators for all systems.
+type Core struct {
+	*common.Core
+}
+
+// GetRandomExchange returns one of the exchange choices by random.
+func (c Core) GetRandomExchange() string {
+	return finance.ExchangeChoices[rand.Intn(len(finance.ExchangeChoices))]
+}
+
+// NewCore returns a new Core for the given time range and cardinality
+func NewCore(start, end time.Time, scale int) (*Core, error) {
+	c, err := common.NewCore(start, end, scale)
+	return &Core{Core: c}, err
+}
+
+// GetRandomSymbols returns a random set of nSymbols from a given Core
+func (c *Core) GetRandomSymbols(nSymbols int) ([]string, error) {
+	return getRandomSymbols(nSymbols, c.Scale)
+}
+
+// getRandomSymbols returns a subset of numSymbols names of a permutation of
+// symbol names, numbered from 0 to totalSymbols.
+// Ex.: symbol_12, symbol_7, symbol_25 for numSymbols=3 and totalSymbols=30
+func getRandomSymbols(numSymbols int, totalSymbols int) ([]string, error) {
+	if numSymbols < 1 {
+		return nil, fmt.Errorf("number of symbols cannot be < 1; got %d", numSymbols)
+	}
+	if numSymbols > totalSymbols {
+		return nil, fmt.Errorf("number of symbols (%d) larger than total symbols. See --scale (%d)", numSymbols, totalSymbols)
+	}
+
+	randomNumbers, err := common.GetRandomSubsetPerm(numSymbols, totalSymbols)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := []string{}
+	for _, n := range randomNumbers {
+		symbols = append(symbols, fmt.Sprintf("symbol_%d", n))
+	}
+
+	return symbols, nil
+}
+
+// OHLCFiller is a type that can fill in an OHLC bars query for a number of symbols.
+type OHLCFiller interface {
+	OHLCBars(query.Query, int)
+}
+
+// VWAPFiller is a type that can fill in a volume-weighted average price query
+// for a number of symbols.
+type VWAPFiller interface {
+	VWAP(query.Query, int)
+}
+
+// LastQuoteFiller is a type that can fill in a last quote per symbol query.
+type LastQuoteFiller interface {
+	LastQuotePerSymbol(query.Query)
+}
+
+// SpreadStatsFiller is a type that can fill in a spread statistics query.
+type SpreadStatsFiller interface {
+	SpreadStats(query.Query)
+}
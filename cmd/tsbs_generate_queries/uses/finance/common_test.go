@@ -0,0 +1,71 @@
+package finance
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/finance"
+)
+
+func TestNewCore(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Scale; got != 10 {
+		t.Errorf("NewCore does not have right scale: got %d want %d", got, 10)
+	}
+}
+
+func TestGetRandomSymbols(t *testing.T) {
+	rand.Seed(123)
+	s := time.Now()
+	c, err := NewCore(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := c.GetRandomSymbols(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"symbol_5", "symbol_9"}
+	if len(got) != len(want) {
+		t.Fatalf("incorrect number of symbols: got %d want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("incorrect symbol %d: got %s want %s", i, got[i], want[i])
+		}
+	}
+
+	errCases := map[int]string{
+		0:  "number of symbols cannot be < 1; got 0",
+		11: "number of symbols (11) larger than total symbols. See --scale (10)",
+	}
+	for n, wantErr := range errCases {
+		if _, err := c.GetRandomSymbols(n); err == nil || err.Error() != wantErr {
+			t.Errorf("incorrect error for %d symbols: got %v want %s", n, err, wantErr)
+		}
+	}
+}
+
+func TestGetRandomExchange(t *testing.T) {
+	s := time.Now()
+	c, err := NewCore(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	valid := map[string]bool{}
+	for _, e := range finance.ExchangeChoices {
+		valid[e] = true
+	}
+	for i := 0; i < 100; i++ {
+		if got := c.GetRandomExchange(); !valid[got] {
+			t.Fatalf("random exchange not a valid choice: got %s", got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package finance
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// OHLC contains info for filling in one minute OHLC bars queries.
+type OHLC struct {
+	core     utils.QueryGenerator
+	nSymbols int
+}
+
+// NewOHLC produces a new function that produces a new OHLC bars query filler.
+func NewOHLC(nSymbols int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &OHLC{
+			core:     core,
+			nSymbols: nSymbols,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *OHLC) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(OHLCFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.OHLCBars(q, i.nSymbols)
+	return q
+}
@@ -0,0 +1,29 @@
+package finance
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// SpreadStats contains info for filling in spread statistics queries.
+type SpreadStats struct {
+	core utils.QueryGenerator
+}
+
+// NewSpreadStats creates a new spread statistics query filler.
+func NewSpreadStats(core utils.QueryGenerator) utils.QueryFiller {
+	return &SpreadStats{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *SpreadStats) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(SpreadStatsFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.SpreadStats(q)
+	return q
+}
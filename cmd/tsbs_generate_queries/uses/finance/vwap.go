@@ -0,0 +1,33 @@
+package finance
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// VWAP contains info for filling in hourly volume-weighted average price queries.
+type VWAP struct {
+	core     utils.QueryGenerator
+	nSymbols int
+}
+
+// NewVWAP produces a new function that produces a new VWAP query filler.
+func NewVWAP(nSymbols int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &VWAP{
+			core:     core,
+			nSymbols: nSymbols,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *VWAP) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(VWAPFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.VWAP(q, i.nSymbols)
+	return q
+}
@@ -25,6 +25,8 @@ const (
 	LongDrivingSessionDuration = 4 * time.Hour
 	// DailyDrivingDuration is time duration of one day of driving.
 	DailyDrivingDuration = 24 * time.Hour
+	// GapfillDuration is the time duration of the gap-filled velocity series.
+	GapfillDuration = time.Hour
 
 	// LabelLastLoc is the label for the last location query.
 	LabelLastLoc = "last-loc"
@@ -52,6 +54,8 @@ const (
 	LabelDailyActivity = "daily-activity"
 	// LabelBreakdownFrequency is the label for the breakdown frequency query.
 	LabelBreakdownFrequency = "breakdown-frequency"
+	// LabelGapfill is the label prefix for the gap-filled velocity query.
+	LabelGapfill = "gapfill"
 )
 
 // Core is the common component of all generators for all systems.
@@ -164,3 +168,8 @@ type DailyTruckActivityFiller interface {
 type TruckBreakdownFrequencyFiller interface {
 	TruckBreakdownFrequency(query.Query)
 }
+
+// GapfillFiller is a type that can fill in a gap-filled velocity query for a number of trucks.
+type GapfillFiller interface {
+	GapfillByTruck(query.Query, int)
+}
@@ -0,0 +1,33 @@
+package iot
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Gapfill contains info for filling in gap-filled velocity queries.
+type Gapfill struct {
+	core    utils.QueryGenerator
+	nTrucks int
+}
+
+// NewGapfill produces a new function that produces a new gap-filled velocity query filler.
+func NewGapfill(nTrucks int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &Gapfill{
+			core:    core,
+			nTrucks: nTrucks,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *Gapfill) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(GapfillFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.GapfillByTruck(q, i.nTrucks)
+	return q
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/k8s"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	// ContainerTableName is the name of the table where all the container
+	// time series data is stored.
+	ContainerTableName = "container"
+
+	// NamespaceCPUDuration is the time duration covered by the per minute
+	// namespace CPU usage.
+	NamespaceCPUDuration = time.Hour
+	// TopPodsDuration is the time duration to rank the pods by CPU usage.
+	TopPodsDuration = time.Hour
+	// RestartsDuration is the time duration to look for container restarts.
+	RestartsDuration = time.Hour
+
+	// LabelNamespaceCPU is the label for the per namespace CPU usage query.
+	LabelNamespaceCPU = "namespace-cpu"
+	// LabelTopPods is the label prefix for the top pods by CPU usage query.
+	LabelTopPods = "top-pods"
+	// LabelRestarts is the label for the container restarts query.
+	LabelRestarts = "restarts"
+)
+
+// Core is the common component of all generators for all systems.
+type Core struct {
+	*common.Core
+}
+
+// GetRandomNamespace returns one of the namespace choices by random.
+func (c Core) GetRandomNamespace() string {
+	return k8s.NamespaceChoices[rand.Intn(len(k8s.NamespaceChoices))]
+}
+
+// NewCore returns a new Core for the given time range and cardinality
+func NewCore(start, end time.Time, scale int) (*Core, error) {
+	c, err := common.NewCore(start, end, scale)
+	return &Core{Core: c}, err
+}
+
+// NamespaceCPUFiller is a type that can fill in a per namespace CPU usage query.
+type NamespaceCPUFiller interface {
+	NamespaceCPU(query.Query)
+}
+
+// TopPodsFiller is a type that can fill in a query for the k pods with the
+// highest CPU usage.
+type TopPodsFiller interface {
+	TopPods(query.Query, int)
+}
+
+// RestartsFiller is a type that can fill in a container restarts query.
+type RestartsFiller interface {
+	Restarts(query.Query)
+}
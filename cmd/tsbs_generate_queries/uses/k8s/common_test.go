@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/k8s"
+)
+
+func TestNewCore(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Scale; got != 10 {
+		t.Errorf("NewCore does not have right scale: got %d want %d", got, 10)
+	}
+}
+
+func TestGetRandomNamespace(t *testing.T) {
+	rand.Seed(123)
+	s := time.Now()
+	c, err := NewCore(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := c.GetRandomNamespace()
+	found := false
+	for _, ns := range k8s.NamespaceChoices {
+		if ns == got {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("unknown namespace: %s", got)
+	}
+}
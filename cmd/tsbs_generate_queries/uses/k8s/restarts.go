@@ -0,0 +1,29 @@
+package k8s
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Restarts contains info for filling in container restarts queries.
+type Restarts struct {
+	core utils.QueryGenerator
+}
+
+// NewRestarts creates a new container restarts query filler.
+func NewRestarts(core utils.QueryGenerator) utils.QueryFiller {
+	return &Restarts{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *Restarts) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(RestartsFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.Restarts(q)
+	return q
+}
@@ -0,0 +1,33 @@
+package k8s
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// TopPods contains info for filling in top pods by CPU usage queries.
+type TopPods struct {
+	core utils.QueryGenerator
+	k    int
+}
+
+// NewTopPods produces a new function that produces a new top pods query filler.
+func NewTopPods(k int) utils.QueryFillerMaker {
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &TopPods{
+			core: core,
+			k:    k,
+		}
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *TopPods) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(TopPodsFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.TopPods(q, i.k)
+	return q
+}
@@ -0,0 +1,61 @@
+package logs
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/logs"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	// LogsTableName is the name of the table where all the log events are
+	// stored.
+	LogsTableName = "logs"
+
+	// ErrorsPerServiceDuration is the time duration covered by the per minute
+	// error counts.
+	ErrorsPerServiceDuration = time.Hour
+	// MessageSearchDuration is the time duration searched for a message.
+	MessageSearchDuration = time.Hour
+	// MessageSearchLimit is the largest number of events returned by a
+	// message search.
+	MessageSearchLimit = 100
+
+	// ErrorSeverity is the severity of the events counted as errors.
+	ErrorSeverity = logs.SeverityError
+
+	// LabelErrorsPerService is the label for the per service error count query.
+	LabelErrorsPerService = "errors-per-service"
+	// LabelMessageSearch is the label for the message substring search query.
+	LabelMessageSearch = "message-search"
+)
+
+// Core is the common component of all generators for all systems.
+type Core struct {
+	*common.Core
+}
+
+// GetRandomSearchTerm returns one of the message search terms by random.
+func (c Core) GetRandomSearchTerm() string {
+	return logs.SearchTerms[rand.Intn(len(logs.SearchTerms))]
+}
+
+// NewCore returns a new Core for the given time range and cardinality
+func NewCore(start, end time.Time, scale int) (*Core, error) {
+	c, err := common.NewCore(start, end, scale)
+	return &Core{Core: c}, err
+}
+
+// ErrorsPerServiceFiller is a type that can fill in a per service per minute
+// error count query.
+type ErrorsPerServiceFiller interface {
+	ErrorsPerService(query.Query)
+}
+
+// MessageSearchFiller is a type that can fill in a query for the latest
+// events whose message contains a search term.
+type MessageSearchFiller interface {
+	MessageSearch(query.Query)
+}
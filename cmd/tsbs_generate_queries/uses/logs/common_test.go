@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/logs"
+)
+
+func TestNewCore(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Scale; got != 10 {
+		t.Errorf("NewCore does not have right scale: got %d want %d", got, 10)
+	}
+}
+
+func TestGetRandomSearchTerm(t *testing.T) {
+	rand.Seed(123)
+	s := time.Now()
+	c, err := NewCore(s, s.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := c.GetRandomSearchTerm()
+	found := false
+	for _, term := range logs.SearchTerms {
+		if term == got {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("unknown search term: %s", got)
+	}
+}
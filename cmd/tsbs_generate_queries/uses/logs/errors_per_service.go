@@ -0,0 +1,29 @@
+package logs
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// ErrorsPerService contains info for filling in per service error count queries.
+type ErrorsPerService struct {
+	core utils.QueryGenerator
+}
+
+// NewErrorsPerService creates a new per service error count query filler.
+func NewErrorsPerService(core utils.QueryGenerator) utils.QueryFiller {
+	return &ErrorsPerService{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *ErrorsPerService) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(ErrorsPerServiceFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.ErrorsPerService(q)
+	return q
+}
@@ -0,0 +1,29 @@
+package logs
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// MessageSearch contains info for filling in message search queries.
+type MessageSearch struct {
+	core utils.QueryGenerator
+}
+
+// NewMessageSearch creates a new message search query filler.
+func NewMessageSearch(core utils.QueryGenerator) utils.QueryFiller {
+	return &MessageSearch{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *MessageSearch) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(MessageSearchFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.MessageSearch(q)
+	return q
+}
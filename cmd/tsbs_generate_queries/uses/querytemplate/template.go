@@ -0,0 +1,273 @@
+// Package querytemplate fills queries from user-supplied templates, so queries
+// of schemas and databases TSBS does not know can be generated with the same
+// randomization as the built-in query types.
+package querytemplate
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	internalutils "github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	errEmptyQuery         = "query template cannot be empty"
+	errNoWindow           = "query template requires a window"
+	errNegativeFmt        = "%s cannot be negative"
+	errUnsupportedTypeFmt = "query type %v does not support query templates"
+
+	// LabelTemplate is the prefix of the labels of template queries
+	LabelTemplate = "Template"
+)
+
+// Spec describes a query template and the random values it is filled with.
+type Spec struct {
+	// Hosts is the number of random hosts, or trucks for IoT and symbols for
+	// finance, in {{.Hosts}}
+	Hosts int `yaml:"hosts"`
+	// Metrics is the number of random cpu metrics in {{.Metrics}}
+	Metrics int `yaml:"metrics"`
+	// Window is the length of the random time window from {{.Start}} to {{.End}}
+	Window time.Duration `yaml:"window"`
+	// Measurement is the table the query reads, which SQL queries carry as
+	// their hypertable or table like the built-in query types
+	Measurement string `yaml:"measurement"`
+	// Query is the SQL query, or the path for HTTP queries
+	Query string `yaml:"query"`
+	// Body is the body of HTTP queries
+	Body string `yaml:"body"`
+	// Method is the method of HTTP queries, GET by default
+	Method string `yaml:"method"`
+}
+
+// List is a list of values in a template. It prints as a comma-separated list
+// of quoted values, e.g. 'host_1', 'host_2', ready for a SQL IN clause.
+type List []string
+
+func (l List) String() string {
+	quoted := make([]string, len(l))
+	for i, s := range l {
+		quoted[i] = "'" + s + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Time is a timestamp in a template. It prints in RFC3339 and has all the
+// methods of time.Time, e.g. {{.Start.Unix}} or {{.Start.Format "2006-01-02"}}.
+type Time struct {
+	time.Time
+}
+
+func (t Time) String() string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Data is what a template is executed with.
+type Data struct {
+	Hosts   List
+	Metrics List
+	Start   Time
+	End     Time
+	Fleet   string
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+}
+
+type windowGenerator interface {
+	MustRandWindow(time.Duration) *internalutils.TimeInterval
+}
+
+type hostsGenerator interface {
+	GetRandomHosts(int) ([]string, error)
+}
+
+type trucksGenerator interface {
+	GetRandomTrucks(int) ([]string, error)
+	GetRandomFleet() string
+}
+
+type symbolsGenerator interface {
+	GetRandomSymbols(int) ([]string, error)
+}
+
+// Template contains info for filling in a query from a template
+type Template struct {
+	core  utils.QueryGenerator
+	name  string
+	spec  Spec
+	query *template.Template
+	body  *template.Template
+}
+
+// New parses the templates of spec and produces a new function that produces
+// a new Template.
+func New(name string, spec Spec) (utils.QueryFillerMaker, error) {
+	if spec.Query == "" {
+		return nil, fmt.Errorf(errEmptyQuery)
+	}
+	if spec.Window <= 0 {
+		return nil, fmt.Errorf(errNoWindow)
+	}
+	if spec.Hosts < 0 {
+		return nil, fmt.Errorf(errNegativeFmt, "hosts")
+	}
+	if spec.Metrics < 0 {
+		return nil, fmt.Errorf(errNegativeFmt, "metrics")
+	}
+	if spec.Metrics > 0 {
+		if _, err := devops.GetCPUMetricsSlice(spec.Metrics); err != nil {
+			return nil, err
+		}
+	}
+	if spec.Method == "" {
+		spec.Method = "GET"
+	}
+
+	q, err := parse(name, spec.Query)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parse(name+"-body", spec.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(core utils.QueryGenerator) utils.QueryFiller {
+		return &Template{
+			core:  core,
+			name:  name,
+			spec:  spec,
+			query: q,
+			body:  b,
+		}
+	}, nil
+}
+
+// parse parses the template text and executes it once with placeholder data,
+// so references to unknown fields are reported before generating queries.
+func parse(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Execute(&bytes.Buffer{}, &Data{}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Validate returns an error if the queries of the generator are of a type
+// templates can not fill in, or if the generator can not pick the random
+// window or hosts of the template.
+func (d *Template) Validate() error {
+	if _, ok := d.core.(windowGenerator); !ok {
+		return common.UnimplementedQueryError(d.core)
+	}
+	if d.spec.Hosts > 0 {
+		switch d.core.(type) {
+		case trucksGenerator, symbolsGenerator, hostsGenerator:
+		default:
+			return common.UnimplementedQueryError(d.core)
+		}
+	}
+
+	q := d.core.GenerateEmptyQuery()
+	defer q.Release()
+	switch q.(type) {
+	case *query.TimescaleDB, *query.ClickHouse, *query.CrateDB, *query.Timestream, *query.HTTP:
+		return nil
+	default:
+		return fmt.Errorf(errUnsupportedTypeFmt, reflect.TypeOf(q))
+	}
+}
+
+// Fill fills in the query.Query with query details
+func (d *Template) Fill(q query.Query) query.Query {
+	wg, ok := d.core.(windowGenerator)
+	if !ok {
+		common.PanicUnimplementedQuery(d.core)
+	}
+	interval := wg.MustRandWindow(d.spec.Window)
+	data := &Data{
+		Start: Time{interval.Start()},
+		End:   Time{interval.End()},
+	}
+
+	tg, isIoT := d.core.(trucksGenerator)
+	if d.spec.Hosts > 0 {
+		var hosts []string
+		var err error
+		if isIoT {
+			hosts, err = tg.GetRandomTrucks(d.spec.Hosts)
+		} else if sg, ok := d.core.(symbolsGenerator); ok {
+			hosts, err = sg.GetRandomSymbols(d.spec.Hosts)
+		} else if hg, ok := d.core.(hostsGenerator); ok {
+			hosts, err = hg.GetRandomHosts(d.spec.Hosts)
+		} else {
+			common.PanicUnimplementedQuery(d.core)
+		}
+		if err != nil {
+			panic(err.Error())
+		}
+		data.Hosts = hosts
+	}
+	if d.spec.Metrics > 0 {
+		metrics, err := devops.GetCPUMetricsSlice(d.spec.Metrics)
+		if err != nil {
+			panic(err.Error())
+		}
+		data.Metrics = metrics
+	}
+	if isIoT {
+		data.Fleet = tg.GetRandomFleet()
+	}
+
+	label := fmt.Sprintf("%s %s", LabelTemplate, d.name)
+	desc := fmt.Sprintf("%s: %s", label, data.Start)
+	text := d.execute(d.query, data)
+
+	labelBytes, descBytes, textBytes := []byte(label), []byte(desc), []byte(text)
+	tableBytes := []byte(d.spec.Measurement)
+	switch tq := q.(type) {
+	case *query.TimescaleDB:
+		tq.HumanLabel, tq.HumanDescription, tq.SqlQuery = labelBytes, descBytes, textBytes
+		tq.Hypertable = tableBytes
+	case *query.ClickHouse:
+		tq.HumanLabel, tq.HumanDescription, tq.SqlQuery = labelBytes, descBytes, textBytes
+		tq.Table = tableBytes
+	case *query.CrateDB:
+		tq.HumanLabel, tq.HumanDescription, tq.SqlQuery = labelBytes, descBytes, textBytes
+		tq.Table = tableBytes
+	case *query.Timestream:
+		tq.HumanLabel, tq.HumanDescription, tq.SqlQuery = labelBytes, descBytes, textBytes
+		tq.Table = tableBytes
+	case *query.HTTP:
+		tq.HumanLabel, tq.HumanDescription = labelBytes, descBytes
+		tq.Method = []byte(d.spec.Method)
+		tq.Path = textBytes
+		tq.Body = []byte(d.execute(d.body, data))
+		tq.StartTimestamp = interval.StartUnixNano()
+		tq.EndTimestamp = interval.EndUnixNano()
+	default:
+		panic(fmt.Sprintf(errUnsupportedTypeFmt, reflect.TypeOf(q)))
+	}
+	return q
+}
+
+func (d *Template) execute(t *template.Template, data *Data) string {
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		panic(err.Error())
+	}
+	return buf.String()
+}
@@ -0,0 +1,246 @@
+package querytemplate
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/clickhouse"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/influx"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/mongo"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/timescaledb"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestNewErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		spec Spec
+		want string
+	}{
+		{
+			desc: "empty query",
+			spec: Spec{Window: time.Hour},
+			want: errEmptyQuery,
+		},
+		{
+			desc: "no window",
+			spec: Spec{Query: "SELECT 1"},
+			want: errNoWindow,
+		},
+		{
+			desc: "negative hosts",
+			spec: Spec{Query: "SELECT 1", Window: time.Hour, Hosts: -1},
+			want: "hosts cannot be negative",
+		},
+		{
+			desc: "too many metrics",
+			spec: Spec{Query: "SELECT 1", Window: time.Hour, Metrics: 100},
+			want: "too many metrics asked for",
+		},
+		{
+			desc: "bad syntax",
+			spec: Spec{Query: "SELECT {{.Hosts", Window: time.Hour},
+			want: `template: foo:1: unclosed action`,
+		},
+		{
+			desc: "unknown placeholder",
+			spec: Spec{Query: "SELECT {{.Trucks}}", Window: time.Hour},
+			want: `template: foo:1:9: executing "foo" at <.Trucks>: can't evaluate field Trucks in type *querytemplate.Data`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := New("foo", c.spec)
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if got := err.Error(); got != c.want {
+				t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, c.want)
+			}
+		})
+	}
+}
+
+func fill(t *testing.T, spec Spec, gen utils.QueryGenerator) query.Query {
+	maker, err := New("foo", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rand.Seed(123) // Setting seed for testing purposes.
+	return maker(gen).Fill(gen.GenerateEmptyQuery())
+}
+
+func TestFillSQL(t *testing.T) {
+	s := time.Unix(0, 0)
+	b := &timescaledb.BaseGenerator{}
+	gen, err := b.NewDevops(s, s.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating devops generator")
+	}
+
+	spec := Spec{
+		Hosts:       2,
+		Metrics:     2,
+		Window:      time.Hour,
+		Measurement: "cpu",
+		Query: "SELECT {{join .Metrics \", \"}} FROM cpu WHERE hostname IN ({{.Hosts}}) " +
+			"AND time >= '{{.Start}}' AND time < '{{.End}}' AND {{.End.Unix}} > 0",
+	}
+	q := fill(t, spec, gen).(*query.TimescaleDB)
+
+	wantSQL := "SELECT usage_user, usage_system FROM cpu WHERE hostname IN ('host_9', 'host_3') " +
+		"AND time >= '1970-01-01T00:16:22Z' AND time < '1970-01-01T01:16:22Z' AND 4582 > 0"
+	if got := string(q.SqlQuery); got != wantSQL {
+		t.Errorf("incorrect query:\ngot\n%s\nwant\n%s", got, wantSQL)
+	}
+	if got := string(q.Hypertable); got != "cpu" {
+		t.Errorf("incorrect hypertable: got %s", got)
+	}
+	if got := string(q.HumanLabel); got != "Template foo" {
+		t.Errorf("incorrect label: got %s", got)
+	}
+	if got := string(q.HumanDescription); got != "Template foo: 1970-01-01T00:16:22Z" {
+		t.Errorf("incorrect description: got %s", got)
+	}
+}
+
+func TestFillHTTP(t *testing.T) {
+	s := time.Unix(0, 0)
+	b := &influx.BaseGenerator{}
+	gen, err := b.NewIoT(s, s.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+
+	spec := Spec{
+		Hosts:  1,
+		Window: time.Hour,
+		Query:  `/query?q={{urlquery "SELECT * FROM readings WHERE name = " .Hosts " AND fleet = '" .Fleet "'"}}`,
+		Body:   "{{.Start.UnixNano}}",
+		Method: "POST",
+	}
+	q := fill(t, spec, gen).(*query.HTTP)
+
+	wantPath := "/query?q=SELECT+%2A+FROM+readings+WHERE+name+%3D+%27truck_9%27+AND+fleet+%3D+%27South%27"
+	if got := string(q.Path); got != wantPath {
+		t.Errorf("incorrect path:\ngot\n%s\nwant\n%s", got, wantPath)
+	}
+	if got := string(q.Method); got != "POST" {
+		t.Errorf("incorrect method: got %s", got)
+	}
+	if got, want := string(q.Body), "982646325489"; got != want {
+		t.Errorf("incorrect body: got %s want %s", got, want)
+	}
+	if got, want := q.EndTimestamp-q.StartTimestamp, time.Hour.Nanoseconds(); got != want {
+		t.Errorf("incorrect window: got %d want %d", got, want)
+	}
+}
+
+func TestFillFinance(t *testing.T) {
+	s := time.Unix(0, 0)
+	b := &timescaledb.BaseGenerator{}
+	gen, err := b.NewFinance(s, s.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating finance generator")
+	}
+
+	spec := Spec{
+		Hosts:  2,
+		Window: time.Hour,
+		Query:  "SELECT * FROM trades WHERE symbol IN ({{.Hosts}})",
+	}
+	q := fill(t, spec, gen).(*query.TimescaleDB)
+
+	want := "SELECT * FROM trades WHERE symbol IN ('symbol_9', 'symbol_3')"
+	if got := string(q.SqlQuery); got != want {
+		t.Errorf("incorrect query:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFillTable(t *testing.T) {
+	s := time.Unix(0, 0)
+	b := &clickhouse.BaseGenerator{}
+	gen, err := b.NewIoT(s, s.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Error while creating iot generator")
+	}
+
+	spec := Spec{
+		Window:      time.Hour,
+		Measurement: "diagnostics",
+		Query:       "SELECT max(current_load) FROM diagnostics",
+	}
+	q := fill(t, spec, gen).(*query.ClickHouse)
+
+	if got := string(q.Table); got != "diagnostics" {
+		t.Errorf("incorrect table: got %s", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := time.Unix(0, 0)
+	e := s.Add(2 * time.Hour)
+	newGen := func(gen utils.QueryGenerator, err error) utils.QueryGenerator {
+		if err != nil {
+			t.Fatalf("Error while creating generator: %v", err)
+		}
+		return gen
+	}
+	hosts := Spec{Hosts: 1, Window: time.Hour, Query: "SELECT {{.Hosts}}"}
+	cases := []struct {
+		desc string
+		gen  utils.QueryGenerator
+		spec Spec
+		want string
+	}{
+		{
+			desc: "sql",
+			gen:  newGen((&timescaledb.BaseGenerator{}).NewDevops(s, e, 10)),
+			spec: hosts,
+		},
+		{
+			desc: "http",
+			gen:  newGen((&influx.BaseGenerator{}).NewDevops(s, e, 10)),
+			spec: hosts,
+		},
+		{
+			desc: "unsupported query type",
+			gen:  newGen((&mongo.BaseGenerator{}).NewDevops(s, e, 10)),
+			spec: hosts,
+			want: "query type *query.Mongo does not support query templates",
+		},
+		{
+			desc: "no hosts",
+			gen:  newGen((&timescaledb.BaseGenerator{}).NewSmartMeter(s, e, 10)),
+			spec: hosts,
+			want: "database (*timescaledb.SmartMeter) does not implement query",
+		},
+		{
+			desc: "no hosts asked for",
+			gen:  newGen((&timescaledb.BaseGenerator{}).NewSmartMeter(s, e, 10)),
+			spec: Spec{Window: time.Hour, Query: "SELECT 1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			maker, err := New("foo", c.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = maker(c.gen).(utils.QueryFillerValidator).Validate()
+			if c.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error, got none")
+			} else if got := err.Error(); got != c.want {
+				t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package smartmeter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	internalutils "github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const (
+	// EnergyTableName is the name of the table where all the meter readings
+	// are stored.
+	EnergyTableName = "energy"
+
+	// DailyConsumptionDays is the number of whole days covered by the daily
+	// consumption query.
+	DailyConsumptionDays = 2
+	// PeakHoursStart is the time of day the evening peak hours start at.
+	PeakHoursStart = 17 * time.Hour
+	// PeakHoursDuration is the time duration of the evening peak hours.
+	PeakHoursDuration = 4 * time.Hour
+	// OnDemandCadence is the cadence tag of the meters read at random times,
+	// whose readings may cover several hours and are left out of the peak
+	// hour load.
+	OnDemandCadence = "on-demand"
+
+	// LabelDailyConsumption is the label for the daily consumption per region
+	// query.
+	LabelDailyConsumption = "daily-consumption"
+	// LabelPeakHourLoad is the label for the peak hours load per region query.
+	LabelPeakHourLoad = "peak-hour-load"
+
+	day = 24 * time.Hour
+
+	errNoRandomDaysFmt      = "time range does not contain %d whole days, from midnight to midnight"
+	errNoRandomPeakHoursFmt = "time range does not contain the peak hours of any day, from %v to %v"
+)
+
+// Core is the common component of all generators for all systems.
+type Core struct {
+	*common.Core
+}
+
+// NewCore returns a new Core for the given time range and cardinality
+func NewCore(start, end time.Time, scale int) (*Core, error) {
+	c, err := common.NewCore(start, end, scale)
+	return &Core{Core: c}, err
+}
+
+// GetRandomDays returns the given number of whole days, from midnight to
+// midnight, at a random place of the time range. It panics if the time range
+// does not contain them, which ValidateRandomDays reports beforehand.
+func (c Core) GetRandomDays(days int) *internalutils.TimeInterval {
+	return c.mustRandDaily(0, time.Duration(days)*day)
+}
+
+// ValidateRandomDays returns an error if the time range does not contain the
+// given number of whole days.
+func (c Core) ValidateRandomDays(days int) error {
+	if _, n := c.dailyWindows(0, time.Duration(days)*day); n == 0 {
+		return fmt.Errorf(errNoRandomDaysFmt, days)
+	}
+	return nil
+}
+
+// GetRandomPeakHours returns the evening peak hours of a random day of the
+// time range. It panics if the time range does not contain the peak hours of
+// any day, which ValidateRandomPeakHours reports beforehand.
+func (c Core) GetRandomPeakHours() *internalutils.TimeInterval {
+	return c.mustRandDaily(PeakHoursStart, PeakHoursDuration)
+}
+
+// ValidateRandomPeakHours returns an error if the time range does not
+// contain the peak hours of any day.
+func (c Core) ValidateRandomPeakHours() error {
+	if _, n := c.dailyWindows(PeakHoursStart, PeakHoursDuration); n == 0 {
+		return fmt.Errorf(errNoRandomPeakHoursFmt, PeakHoursStart, PeakHoursStart+PeakHoursDuration)
+	}
+	return nil
+}
+
+// dailyWindows returns the earliest start of a window of the given duration
+// starting at the given time of day, and the number of days on which such a
+// window fits in the time range.
+func (c Core) dailyWindows(timeOfDay, window time.Duration) (time.Time, int64) {
+	first := c.Interval.Start().Truncate(day).Add(timeOfDay)
+	if first.Before(c.Interval.Start()) {
+		first = first.Add(day)
+	}
+	last := c.Interval.End().Add(-window)
+	if last.Before(first) {
+		return first, 0
+	}
+	return first, int64(last.Sub(first)/day) + 1
+}
+
+// mustRandDaily returns a window of the given duration starting at the given
+// time of a random day, among the days on which it fits in the time range.
+// It panics if there are none.
+func (c Core) mustRandDaily(timeOfDay, window time.Duration) *internalutils.TimeInterval {
+	first, days := c.dailyWindows(timeOfDay, window)
+	if days == 0 {
+		panic(fmt.Sprintf("no %v window starting at %v of a day fits in the time range", window, timeOfDay))
+	}
+	// Place a day long window as configured over the day before the first
+	// start and the days of all the starts, then move it forward to the next
+	// start. Any day long window contains exactly one of the starts, so
+	// every start is equally likely with the uniform placement, and the
+	// latest is favored as much as the window placements favor recent data.
+	slots := *c.Core
+	slots.Interval = mustTimeInterval(first.Add(-day), first.Add(time.Duration(days)*day))
+	w := slots.MustRandWindow(day)
+	k := int64((w.Start().Sub(first) + day - 1) / day)
+	if k < 0 {
+		k = 0
+	} else if k >= days {
+		k = days - 1
+	}
+	start := first.Add(time.Duration(k) * day)
+	return mustTimeInterval(start, start.Add(window))
+}
+
+func mustTimeInterval(start, end time.Time) *internalutils.TimeInterval {
+	res, err := internalutils.NewTimeInterval(start, end)
+	if err != nil {
+		panic(err.Error())
+	}
+	return res
+}
+
+// DailyConsumptionFiller is a type that can fill in a daily consumption per
+// region query.
+type DailyConsumptionFiller interface {
+	DailyConsumption(query.Query)
+}
+
+// PeakHourLoadFiller is a type that can fill in a query for the hourly load
+// per region during the evening peak hours.
+type PeakHourLoadFiller interface {
+	PeakHourLoad(query.Query)
+}
+
+// RandomDaysValidator is a type that can check whether its time range
+// contains a number of whole days.
+type RandomDaysValidator interface {
+	ValidateRandomDays(days int) error
+}
+
+// RandomPeakHoursValidator is a type that can check whether its time range
+// contains the peak hours of a day.
+type RandomPeakHoursValidator interface {
+	ValidateRandomPeakHours() error
+}
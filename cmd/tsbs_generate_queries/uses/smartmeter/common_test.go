@@ -0,0 +1,212 @@
+package smartmeter
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+func TestNewCore(t *testing.T) {
+	s := time.Now()
+	e := s.Add(time.Hour)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Scale; got != 10 {
+		t.Errorf("NewCore does not have right scale: got %d want %d", got, 10)
+	}
+}
+
+func TestGetRandomDays(t *testing.T) {
+	rand.Seed(123)
+	s := time.Date(2016, 1, 1, 6, 30, 0, 0, time.UTC)
+	e := s.Add(5 * day)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		ti := c.GetRandomDays(2)
+		if got := ti.Duration(); got != 2*day {
+			t.Fatalf("incorrect duration: got %v", got)
+		}
+		if !ti.Start().Equal(ti.Start().Truncate(day)) {
+			t.Fatalf("window does not start at midnight: %v", ti.Start())
+		}
+		if ti.Start().Before(s) || ti.End().After(e) {
+			t.Fatalf("window out of the time range: %v - %v", ti.Start(), ti.End())
+		}
+	}
+}
+
+func TestGetRandomPeakHours(t *testing.T) {
+	rand.Seed(123)
+	s := time.Date(2016, 1, 1, 18, 0, 0, 0, time.UTC)
+	e := s.Add(2 * day)
+	c, err := NewCore(s, e, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		ti := c.GetRandomPeakHours()
+		if got := ti.Duration(); got != PeakHoursDuration {
+			t.Fatalf("incorrect duration: got %v", got)
+		}
+		if got := ti.Start().Sub(ti.Start().Truncate(day)); got != PeakHoursStart {
+			t.Fatalf("incorrect time of day: got %v", got)
+		}
+		if ti.Start().Before(s) || ti.End().After(e) {
+			t.Fatalf("window out of the time range: %v - %v", ti.Start(), ti.End())
+		}
+	}
+}
+
+func TestGetRandomDaysWholeRange(t *testing.T) {
+	rand.Seed(123)
+	s := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := NewCore(s, s.Add(3*day), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	starts := map[time.Time]bool{}
+	for i := 0; i < 100; i++ {
+		starts[c.GetRandomDays(DailyConsumptionDays).Start()] = true
+	}
+	if len(starts) != 2 || !starts[s] || !starts[s.Add(day)] {
+		t.Errorf("incorrect starts: got %v want %v and %v", starts, s, s.Add(day))
+	}
+}
+
+func TestGetRandomPeakHoursDefaultRange(t *testing.T) {
+	rand.Seed(123)
+	s := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := NewCore(s, s.Add(day), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := s.Add(PeakHoursStart)
+	for i := 0; i < 10; i++ {
+		if got := c.GetRandomPeakHours().Start(); !got.Equal(want) {
+			t.Fatalf("incorrect start: got %v want %v", got, want)
+		}
+	}
+}
+
+func TestGetRandomDaysLatestPlacement(t *testing.T) {
+	s := time.Date(2016, 1, 1, 6, 30, 0, 0, time.UTC)
+	c, err := NewCore(s, s.Add(5*day), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.SetWindowPlacement(common.WindowPlacementLatest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The last whole days end at midnight before the end of the range
+	want := time.Date(2016, 1, 4, 0, 0, 0, 0, time.UTC)
+	if got := c.GetRandomDays(DailyConsumptionDays).Start(); !got.Equal(want) {
+		t.Errorf("incorrect start: got %v want %v", got, want)
+	}
+	want = time.Date(2016, 1, 5, 17, 0, 0, 0, time.UTC)
+	if got := c.GetRandomPeakHours().Start(); !got.Equal(want) {
+		t.Errorf("incorrect peak hours start: got %v want %v", got, want)
+	}
+}
+
+func TestGetRandomPeakHoursTooShort(t *testing.T) {
+	s := time.Date(2016, 1, 1, 18, 0, 0, 0, time.UTC)
+	c, err := NewCore(s, s.Add(day), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("did not panic for a time range without peak hours")
+		}
+	}()
+	c.GetRandomPeakHours()
+}
+
+type testGenerator struct {
+	*Core
+}
+
+func (g *testGenerator) GenerateEmptyQuery() query.Query {
+	return query.NewHTTP()
+}
+
+func TestFillersValidate(t *testing.T) {
+	midnight := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	evening := time.Date(2016, 1, 1, 18, 0, 0, 0, time.UTC)
+	cases := []struct {
+		desc       string
+		start      time.Time
+		end        time.Time
+		maker      utils.QueryFillerMaker
+		wantErrMsg string
+	}{
+		{
+			desc:       "daily consumption on a day",
+			start:      midnight,
+			end:        midnight.Add(day),
+			maker:      NewDailyConsumption,
+			wantErrMsg: fmt.Sprintf(errNoRandomDaysFmt, DailyConsumptionDays),
+		},
+		{
+			desc:       "daily consumption on two days from the evening",
+			start:      evening,
+			end:        evening.Add(2 * day),
+			maker:      NewDailyConsumption,
+			wantErrMsg: fmt.Sprintf(errNoRandomDaysFmt, DailyConsumptionDays),
+		},
+		{
+			desc:  "daily consumption on two days",
+			start: midnight,
+			end:   midnight.Add(2 * day),
+			maker: NewDailyConsumption,
+		},
+		{
+			desc:  "peak hour load on a day",
+			start: midnight,
+			end:   midnight.Add(day),
+			maker: NewPeakHourLoad,
+		},
+		{
+			desc:       "peak hour load from the evening",
+			start:      evening,
+			end:        evening.Add(day),
+			maker:      NewPeakHourLoad,
+			wantErrMsg: fmt.Sprintf(errNoRandomPeakHoursFmt, PeakHoursStart, PeakHoursStart+PeakHoursDuration),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			core, err := NewCore(c.start, c.end, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			v, ok := c.maker(&testGenerator{core}).(utils.QueryFillerValidator)
+			if !ok {
+				t.Fatalf("filler does not validate")
+			}
+			err = v.Validate()
+			if c.wantErrMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != c.wantErrMsg {
+				t.Errorf("incorrect error: got %v want %s", err, c.wantErrMsg)
+			}
+		})
+	}
+}
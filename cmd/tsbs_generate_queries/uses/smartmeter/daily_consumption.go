@@ -0,0 +1,37 @@
+package smartmeter
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// DailyConsumption contains info for filling in daily consumption queries.
+type DailyConsumption struct {
+	core utils.QueryGenerator
+}
+
+// NewDailyConsumption creates a new daily consumption query filler.
+func NewDailyConsumption(core utils.QueryGenerator) utils.QueryFiller {
+	return &DailyConsumption{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *DailyConsumption) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(DailyConsumptionFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.DailyConsumption(q)
+	return q
+}
+
+// Validate checks that the time range contains the days of the query.
+func (i *DailyConsumption) Validate() error {
+	if v, ok := i.core.(RandomDaysValidator); ok {
+		return v.ValidateRandomDays(DailyConsumptionDays)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package smartmeter
+
+import (
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// PeakHourLoad contains info for filling in peak hour load queries.
+type PeakHourLoad struct {
+	core utils.QueryGenerator
+}
+
+// NewPeakHourLoad creates a new peak hour load query filler.
+func NewPeakHourLoad(core utils.QueryGenerator) utils.QueryFiller {
+	return &PeakHourLoad{
+		core: core,
+	}
+}
+
+// Fill fills in the query.Query with query details.
+func (i *PeakHourLoad) Fill(q query.Query) query.Query {
+	fc, ok := i.core.(PeakHourLoadFiller)
+	if !ok {
+		common.PanicUnimplementedQuery(i.core)
+	}
+	fc.PeakHourLoad(q)
+	return q
+}
+
+// Validate checks that the time range contains the peak hours of a day.
+func (i *PeakHourLoad) Validate() error {
+	if v, ok := i.core.(RandomPeakHoursValidator); ok {
+		return v.ValidateRandomPeakHours()
+	}
+	return nil
+}
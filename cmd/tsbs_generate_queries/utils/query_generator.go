@@ -16,5 +16,13 @@ type QueryFiller interface {
 	Fill(query.Query) query.Query
 }
 
+// QueryFillerValidator is a QueryFiller that can check whether it is able to
+// fill in queries before any of them is generated
+type QueryFillerValidator interface {
+	QueryFiller
+	// Validate returns an error if the queries can not be filled in
+	Validate() error
+}
+
 // QueryFillerMaker is a function that takes a QueryGenerator and returns a QueryFiller
 type QueryFillerMaker func(QueryGenerator) QueryFiller
@@ -96,6 +96,11 @@ func addDataSourceFlags(fs *pflag.FlagSet) {
 		100,
 		"Max number of metric fields to generate per host. Used only in devops-generic use-case",
 	)
+	fs.String(
+		"data-source.simulator.schema-file",
+		"",
+		"YAML file describing the measurements, tags and generators to simulate. Used only in custom use-case",
+	)
 	fs.Uint64(
 		"data-source.simulator.scale",
 		defaultScale,
@@ -137,6 +137,7 @@ func convertDataSourceConfigToInternalRepresentation(format string, d *DataSourc
 			Limit:                 d.Simulator.Limit,
 			LogInterval:           d.Simulator.LogInterval,
 			MaxMetricCountPerHost: d.Simulator.MaxMetricCountPerHost,
+			SchemaFile:            d.Simulator.SchemaFile,
 			InterleavedNumGroups:  1,
 		}
 	}
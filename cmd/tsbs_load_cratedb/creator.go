@@ -17,6 +17,7 @@ type tableDef struct {
 	tags     []string
 	tagTypes []string
 	cols     []string
+	colTypes []string
 }
 
 // fqn returns the fully-qualified name of a table
@@ -80,6 +81,7 @@ func (d *dbCreator) readDataHeader(header *common.GeneratedDataHeaders) ([]*tabl
 				tags:     header.TagKeys,
 				tagTypes: header.TagTypes,
 				cols:     fieldCols,
+				colTypes: header.FieldTypes[tableName],
 			},
 		)
 	}
@@ -102,19 +104,28 @@ func (d *dbCreator) CreateDB(dbName string) error {
 func (d *dbCreator) createMetricsTable(table *tableDef) error {
 	var tagsObjectChildCols []string
 	for i, column := range table.tags {
-		if table.tagTypes[i] != "string" {
-			return fmt.Errorf("cratedb db creator does not support non-string tags")
+		colType, err := tagColumnType(table.tagTypes[i])
+		if err != nil {
+			return err
 		}
 		tagsObjectChildCols = append(
 			tagsObjectChildCols,
-			fmt.Sprintf("%s %s", column, "string"))
+			fmt.Sprintf("%s %s", column, colType))
 	}
 
 	var metricCols []string
-	for _, column := range table.cols {
+	for i, column := range table.cols {
+		colType := "double"
+		if i < len(table.colTypes) {
+			var err error
+			colType, err = tagColumnType(table.colTypes[i])
+			if err != nil {
+				return err
+			}
+		}
 		metricCols = append(
 			metricCols,
-			fmt.Sprintf("%s %s", column, "double"))
+			fmt.Sprintf("%s %s", column, colType))
 	}
 
 	// TODO partition table by configurable time interval
@@ -137,6 +148,26 @@ func (d *dbCreator) createMetricsTable(table *tableDef) error {
 	return nil
 }
 
+// tagColumnType maps the Go type of a tag, as written in the data header,
+// to the type of its column in the tags object. It is also used for the
+// metric columns of tables whose field types are declared in the header.
+func tagColumnType(tagType string) (string, error) {
+	switch tagType {
+	case "string":
+		return "string", nil
+	case "float32":
+		return "real", nil
+	case "float64":
+		return "double", nil
+	case "int32":
+		return "integer", nil
+	case "int64":
+		return "bigint", nil
+	default:
+		return "", fmt.Errorf("cratedb db creator does not support tags of type %s", tagType)
+	}
+}
+
 // loader.DBCreator interface implementation
 //
 // returns true if there are any tables in a schema
@@ -93,6 +93,37 @@ func TestDBCreatorReadDataHeader(t *testing.T) {
 	}
 }
 
+func TestTagColumnType(t *testing.T) {
+	cases := []struct {
+		tagType        string
+		expectedType   string
+		expectedToFail bool
+	}{
+		{tagType: "string", expectedType: "string"},
+		{tagType: "float32", expectedType: "real"},
+		{tagType: "float64", expectedType: "double"},
+		{tagType: "int32", expectedType: "integer"},
+		{tagType: "int64", expectedType: "bigint"},
+		{tagType: "bool", expectedToFail: true},
+	}
+
+	for _, c := range cases {
+		colType, err := tagColumnType(c.tagType)
+		if c.expectedToFail {
+			if err == nil {
+				t.Errorf("%s: unsupported tag type, must have failed", c.tagType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.tagType, err)
+		}
+		if colType != c.expectedType {
+			t.Errorf("%s: incorrect column type: got %s want %s", c.tagType, colType, c.expectedType)
+		}
+	}
+}
+
 func arrEq(a []string, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -62,8 +62,9 @@ type fileDataSource struct {
 // Decodes a data point of a following format:
 //       <measurement_type>\t<tags>\t<timestamp>\t<metric1>\t...\t<metricN>
 //
-// Converts metric values to double-precision floating-point number, timestamp
-// to time.Time and tags to bytes array.
+// Converts metric values to double-precision floating-point number (or nil
+// when the value is missing), unless the header declares them as strings,
+// timestamp to time.Time and tags to bytes array.
 func (d *fileDataSource) NextItem() data.LoadedPoint {
 	ok := d.scanner.Scan()
 	if !ok && d.scanner.Err() == nil {
@@ -84,7 +85,11 @@ func (d *fileDataSource) NextItem() data.LoadedPoint {
 	table := parts[0]
 	tags := []byte(parts[1])
 
-	metrics, err := parseMetrics(strings.Split(parts[3], "\t"))
+	var fieldTypes []string
+	if d.headers != nil {
+		fieldTypes = d.headers.FieldTypes[table]
+	}
+	metrics, err := parseMetrics(strings.Split(parts[3], "\t"), fieldTypes)
 	if err != nil {
 		fatal("cannot parse metrics: %v", err)
 		return data.LoadedPoint{}
@@ -135,6 +140,7 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 		tagTypes[i] = tagAndTypeSplit[1]
 	}
 	fields := make(map[string][]string)
+	var fieldTypes map[string][]string
 	for {
 		ok := d.scanner.Scan()
 		if !ok && d.scanner.Err() == nil {
@@ -156,12 +162,20 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 			fatal("metric columns are missing")
 			return nil
 		}
-		fields[parts[0]] = strings.Split(parts[1], ",")
+		names, types := common.ParseFieldColumns(strings.Split(parts[1], ","))
+		fields[parts[0]] = names
+		if types != nil {
+			if fieldTypes == nil {
+				fieldTypes = make(map[string][]string)
+			}
+			fieldTypes[parts[0]] = types
+		}
 	}
 	d.headers = &common.GeneratedDataHeaders{
-		TagTypes:  tagTypes,
-		TagKeys:   tags,
-		FieldKeys: fields,
+		TagTypes:   tagTypes,
+		TagKeys:    tags,
+		FieldKeys:  fields,
+		FieldTypes: fieldTypes,
 	}
 	return d.headers
 }
@@ -174,9 +188,18 @@ func parseTime(v string) (time.Time, error) {
 	return time.Unix(0, ts), nil
 }
 
-func parseMetrics(values []string) (row, error) {
+func parseMetrics(values []string, types []string) (row, error) {
 	metrics := make(row, len(values))
 	for i := range values {
+		// missing values are serialized as empty strings
+		if values[i] == "" {
+			metrics[i] = nil
+			continue
+		}
+		if i < len(types) && types[i] == "string" {
+			metrics[i] = values[i]
+			continue
+		}
 		metric, err := strconv.ParseFloat(values[i], 64)
 		if err != nil {
 			return nil, err
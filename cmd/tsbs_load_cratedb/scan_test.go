@@ -100,6 +100,17 @@ func TestDecode(t *testing.T) {
 				38.24311829,
 			},
 		},
+		{
+			desc:          "correct input: missing metric",
+			input:         "readings\t{\"name\":null}\t1454608400000000000\t\t38.24311829",
+			expectedTable: "readings",
+			expectedRow: row{
+				[]byte("{\"name\":null}"),
+				time.Unix(0, 1454608400000000000),
+				nil,
+				38.24311829,
+			},
+		},
 		{
 			desc:           "incorrect input:, missing timestamp",
 			input:          "mem\tnull\t\t38.24311829",
@@ -155,6 +166,24 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeStringFields(t *testing.T) {
+	input := "tags,service string\nlogs,severity string,duration_ms float64\n\n" +
+		"logs\t{\"service\":\"api\"}\t1454608400000000000\tERROR\t12.5\n"
+	br := bufio.NewReader(bytes.NewReader([]byte(input)))
+	decoder := &fileDataSource{scanner: bufio.NewScanner(br)}
+	decoder.Headers()
+	p := decoder.NextItem().Data.(*point)
+	want := row{
+		[]byte("{\"service\":\"api\"}"),
+		time.Unix(0, 1454608400000000000),
+		"ERROR",
+		12.5,
+	}
+	if !reflect.DeepEqual(p.row, want) {
+		t.Errorf("incorrect row: got %v want %v", p.row, want)
+	}
+}
+
 func TestDecodeEOF(t *testing.T) {
 	input := []byte("cpu\t{\"hostname\":\"host_0\"}\t1454608400000000000\t38.24311829\n")
 	br := bufio.NewReader(bytes.NewReader([]byte(input)))
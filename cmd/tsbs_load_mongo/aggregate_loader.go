@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,22 +20,13 @@ type hostnameIndexer struct {
 	partitions uint
 }
 
+// GetIndex partitions the points by their series, i.e. their full tag set,
+// so all the points of an aggregated document are processed by one worker.
 func (i *hostnameIndexer) GetIndex(item data.LoadedPoint) uint {
 	p := item.Data.(*mongo.MongoPoint)
-	t := &mongo.MongoTag{}
-	for j := 0; j < p.TagsLength(); j++ {
-		p.Tags(t, j)
-		key := string(t.Key())
-		if key == "hostname" || key == "name" {
-			// the hostame is the defacto index for devops tags
-			// the truck name is the defacto index for iot tags
-			h := fnv.New32a()
-			h.Write([]byte(string(t.Value())))
-			return uint(h.Sum32()) % i.partitions
-		}
-	}
-	// name tag may be skipped in iot use-case
-	return 0
+	h := fnv.New32a()
+	h.Write([]byte(seriesKey(p)))
+	return uint(h.Sum32()) % i.partitions
 }
 
 // aggBenchmark allows you to run a benchmark using the aggregated document format
@@ -136,7 +128,7 @@ func (p *aggProcessor) ProcessBatch(b targets.Batch, doLoad bool) (uint64, uint6
 		// Determine which document this event belongs too
 		ts := event.Timestamp()
 		dateKey := time.Unix(0, ts).UTC().Format(aggDateFmt)
-		docKey := fmt.Sprintf("day_%s_%s_%s", tagsMap["hostname"], dateKey, string(event.MeasurementName()))
+		docKey := fmt.Sprintf("day_%s_%s_%s", seriesKey(event), dateKey, string(event.MeasurementName()))
 
 		// Check that it has been created using a cached map, if not, add
 		// to creation queue
@@ -164,7 +156,7 @@ func (p *aggProcessor) ProcessBatch(b targets.Batch, doLoad bool) (uint64, uint6
 		f := &mongo.MongoReading{}
 		for j := 0; j < event.FieldsLength(); j++ {
 			event.Fields(f, j)
-			x.Fields[string(f.Key())] = f.Value()
+			x.Fields[string(f.Key())] = mongo.ReadingValue(f)
 		}
 		x.Timestamp = ts
 		eventCnt += uint64(len(x.Fields))
@@ -213,6 +205,24 @@ func (p *aggProcessor) ProcessBatch(b targets.Batch, doLoad bool) (uint64, uint6
 	return eventCnt, 0
 }
 
+// seriesKey returns the tags of the series an event belongs to, e.g.
+// hostname=host_0,region=eu-west-1,... for devops. All tags are used, as only
+// some use cases have a tag, like hostname, that identifies their series.
+func seriesKey(p *mongo.MongoPoint) string {
+	var sb strings.Builder
+	t := &mongo.MongoTag{}
+	for j := 0; j < p.TagsLength(); j++ {
+		p.Tags(t, j)
+		if j > 0 {
+			sb.WriteByte(',')
+		}
+		sb.Write(t.Key())
+		sb.WriteByte('=')
+		sb.Write(t.Value())
+	}
+	return sb.String()
+}
+
 // insertNewAggregateDocs handles creating new aggregated documents when new devices
 // or time periods are encountered
 func insertNewAggregateDocs(collection *mgo.Collection, bulk *mgo.Bulk, createQueue []interface{}) *mgo.Bulk {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/targets/mongo"
+)
+
+func newTestMongoPoint(t *testing.T, tagKeys []string, tagValues []string) *mongo.MongoPoint {
+	p := data.NewPoint()
+	p.SetMeasurementName([]byte("logs"))
+	ts := time.Unix(0, 0)
+	p.SetTimestamp(&ts)
+	for i, k := range tagKeys {
+		p.AppendTag([]byte(k), tagValues[i])
+	}
+	p.AppendField([]byte("duration_ms"), 1.0)
+
+	buf := &bytes.Buffer{}
+	if err := (&mongo.Serializer{}).Serialize(p, buf); err != nil {
+		t.Fatalf("unexpected error serializing point: %v", err)
+	}
+	// skip the length prefix
+	b := buf.Bytes()[8:]
+	mp := &mongo.MongoPoint{}
+	mp.Init(b, flatbuffers.GetUOffsetT(b))
+	return mp
+}
+
+func TestSeriesKey(t *testing.T) {
+	keys := []string{"instance", "service"}
+	a := newTestMongoPoint(t, keys, []string{"instance_0", "auth"})
+	b := newTestMongoPoint(t, keys, []string{"instance_1", "auth"})
+
+	if got, want := seriesKey(a), "instance=instance_0,service=auth"; got != want {
+		t.Errorf("incorrect series key: got %s want %s", got, want)
+	}
+	if seriesKey(a) == seriesKey(b) {
+		t.Errorf("points of different series have the same key: %s", seriesKey(a))
+	}
+
+	devops := newTestMongoPoint(t, []string{"hostname", "region"}, []string{"host_0", "eu-west-1"})
+	if got, want := seriesKey(devops), "hostname=host_0,region=eu-west-1"; got != want {
+		t.Errorf("incorrect series key: got %s want %s", got, want)
+	}
+}
+
+func TestHostnameIndexerGetIndex(t *testing.T) {
+	keys := []string{"instance", "service"}
+	i := &hostnameIndexer{partitions: 8}
+	seen := map[uint]bool{}
+	for _, instance := range []string{"instance_0", "instance_1", "instance_2", "instance_3", "instance_4"} {
+		p := newTestMongoPoint(t, keys, []string{instance, "auth"})
+		idx := i.GetIndex(data.NewLoadedPoint(p))
+		if idx >= 8 {
+			t.Fatalf("index out of range: %d", idx)
+		}
+		if again := i.GetIndex(data.NewLoadedPoint(newTestMongoPoint(t, keys, []string{instance, "auth"}))); again != idx {
+			t.Errorf("points of series %s have different indexes: %d and %d", instance, idx, again)
+		}
+		seen[idx] = true
+	}
+	// the points are not all sent to one partition for lack of a hostname
+	if len(seen) < 2 {
+		t.Errorf("all series sent to the same partition")
+	}
+}
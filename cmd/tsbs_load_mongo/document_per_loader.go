@@ -73,7 +73,7 @@ func (p *naiveProcessor) ProcessBatch(b targets.Batch, doLoad bool) (uint64, uin
 		f := &mongo.MongoReading{}
 		for j := 0; j < event.FieldsLength(); j++ {
 			event.Fields(f, j)
-			x.Fields[string(f.Key())] = f.Value()
+			x.Fields[string(f.Key())] = mongo.ReadingValue(f)
 		}
 		t := &mongo.MongoTag{}
 		for j := 0; j < event.TagsLength(); j++ {
@@ -26,6 +26,7 @@ var (
 	password  string
 
 	showExplain bool
+	usePrepared bool
 )
 
 // Global vars:
@@ -45,6 +46,7 @@ func init() {
 		"Comma separated list of ClickHouse hosts (pass multiple values for sharding reads on a multi-node setup)")
 	pflag.String("user", "default", "User to connect to ClickHouse as")
 	pflag.String("password", "", "Password to connect to ClickHouse")
+	pflag.Bool("use-prepared-statements", false, "Execute parameterized queries as prepared statements cached per worker connection")
 
 	pflag.Parse()
 
@@ -62,6 +64,7 @@ func init() {
 	hosts = viper.GetString("hosts")
 	user = viper.GetString("user")
 	password = viper.GetString("password")
+	usePrepared = viper.GetBool("use-prepared-statements")
 
 	// Parse comma separated string of hosts and put in a slice (for multi-node setups)
 	for _, host := range strings.Split(hosts, ",") {
@@ -93,6 +96,9 @@ func getConnectString(workerNumber int) string {
 func prettyPrintResponse(rows *sqlx.Rows, q *query.ClickHouse) {
 	resp := make(map[string]interface{})
 	resp["query"] = string(q.SqlQuery)
+	if len(q.SqlArgs) > 0 {
+		resp["args"] = q.SqlArgs
+	}
 
 	results := []map[string]interface{}{}
 	for rows.Next() {
@@ -116,12 +122,14 @@ type queryExecutorOptions struct {
 	showExplain   bool
 	debug         bool
 	printResponse bool
+	usePrepared   bool
 }
 
 // query.Processor interface implementation
 type processor struct {
-	db   *sqlx.DB
-	opts *queryExecutorOptions
+	db    *sqlx.DB
+	stmts map[string]*sqlx.Stmt
+	opts  *queryExecutorOptions
 }
 
 // query.Processor interface implementation
@@ -132,12 +140,46 @@ func newProcessor() query.Processor {
 // query.Processor interface implementation
 func (p *processor) Init(workerNumber int) {
 	p.db = sqlx.MustConnect("clickhouse", getConnectString(workerNumber))
+	p.stmts = make(map[string]*sqlx.Stmt)
 	p.opts = &queryExecutorOptions{
 		// ClickHouse could not do EXPLAIN
 		showExplain:   false,
 		debug:         runner.DebugLevel() > 0,
 		printResponse: runner.DoPrintResponses(),
+		usePrepared:   usePrepared,
+	}
+}
+
+// query runs sql, binding args when it is a parameterized template. With
+// prepared statements enabled each distinct template is prepared once per
+// worker and reused.
+func (p *processor) query(sql string, args []string) (*sqlx.Rows, error) {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	if len(args) == 0 || !p.opts.usePrepared {
+		return p.db.Queryx(sql, values...)
+	}
+
+	stmt, ok := p.stmts[sql]
+	if !ok {
+		var err error
+		stmt, err = p.db.Preparex(sql)
+		if err != nil {
+			return nil, err
+		}
+		p.stmts[sql] = stmt
+	}
+	return stmt.Queryx(values...)
+}
+
+// query.ProcessorCloser interface implementation
+func (p *processor) Close() {
+	for _, stmt := range p.stmts {
+		stmt.Close()
 	}
+	p.db.Close()
 }
 
 // query.Processor interface implementation
@@ -156,7 +198,7 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	sql := string(chQuery.SqlQuery)
 
 	// Main action - run the query
-	rows, err := p.db.Queryx(sql)
+	rows, err := p.query(sql, chQuery.SqlArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -164,17 +206,37 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	// Print some extra info if needed
 	if p.opts.debug {
 		fmt.Println(sql)
+		if len(chQuery.SqlArgs) > 0 {
+			fmt.Println(chQuery.SqlArgs)
+		}
 	}
 	if p.opts.printResponse {
 		prettyPrintResponse(rows, chQuery)
 	}
 
+	// Fetch all the rows so that the result transfer is measured too
+	numRows := uint64(0)
+	timeToFirst := 0.0
+	for rows.Next() {
+		if numRows == 0 {
+			timeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6
+		}
+		numRows++
+	}
+
 	// Finalize the query
 	rows.Close()
 	took := float64(time.Since(start).Nanoseconds()) / 1e6
 
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), took)
+	// Rows already consumed for printing can not be measured
+	if !p.opts.printResponse {
+		if numRows == 0 {
+			timeToFirst = took
+		}
+		stat.SetResponse(numRows, 0, timeToFirst)
+	}
 
 	return []*query.Stat{stat}, err
 }
@@ -23,6 +23,7 @@ var (
 	pass        string
 	port        int
 	showExplain bool
+	usePrepared bool
 )
 
 var runner *query.BenchmarkRunner
@@ -36,6 +37,7 @@ func init() {
 	pflag.String("pass", "", "Password for user connecting to CrateDB")
 	pflag.Int("port", 5432, "A port to connect to database instances")
 	pflag.Bool("show-explain", false, "Print out the EXPLAIN output for sample query")
+	pflag.Bool("use-prepared-statements", false, "Execute parameterized queries as prepared statements cached per worker connection")
 
 	pflag.Parse()
 
@@ -54,6 +56,7 @@ func init() {
 	pass = viper.GetString("pass")
 	port = viper.GetInt("port")
 	showExplain = viper.GetBool("show-explain")
+	usePrepared = viper.GetBool("use-prepared-statements")
 
 	runner = query.NewBenchmarkRunner(config)
 
@@ -63,25 +66,32 @@ func init() {
 }
 
 func main() {
-	processor, err := newProcessor()
-	if err != nil {
-		panic(err)
-	}
 	runner.Run(&query.CrateDBPool, func() query.Processor {
+		processor, err := newProcessor()
+		if err != nil {
+			panic(err)
+		}
 		return processor
 	})
 }
 
 type processor struct {
-	conn    *pgx.Conn
-	connCfg *pgx.ConnConfig
-	opts    *executorOptions
+	conn *pgx.Conn
+	// unpreparedConn runs the parameterized queries when prepared statements
+	// are not used. It is only opened for the first such query, so that the
+	// connection of the literal queries is left as is.
+	unpreparedConn *pgx.Conn
+	connCfg        *pgx.ConnConfig
+	opts           *executorOptions
+	// stmts maps a query template to the name it was prepared under
+	stmts map[string]string
 }
 
 type executorOptions struct {
 	showExplain   bool
 	debug         bool
 	printResponse bool
+	usePrepared   bool
 }
 
 func newProcessor() (query.Processor, error) {
@@ -96,7 +106,9 @@ func newProcessor() (query.Processor, error) {
 			showExplain:   showExplain,
 			debug:         runner.DebugLevel() > 0,
 			printResponse: runner.DoPrintResponses(),
+			usePrepared:   usePrepared,
 		},
+		stmts: make(map[string]string),
 	}, nil
 }
 
@@ -108,6 +120,66 @@ func (p *processor) Init(workerNumber int) {
 	p.conn = conn
 }
 
+// query runs qry, binding args when it is a parameterized template. With
+// prepared statements enabled each distinct template is prepared once per
+// worker connection and reused, so planning is not repeated on every execution.
+func (p *processor) query(qry string, args []string) (pgx.Rows, error) {
+	if len(args) == 0 {
+		return p.conn.Query(context.Background(), qry)
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	if !p.opts.usePrepared || p.opts.showExplain {
+		conn, err := p.getUnpreparedConn()
+		if err != nil {
+			return nil, err
+		}
+		return conn.Query(context.Background(), qry, values...)
+	}
+
+	name, ok := p.stmts[qry]
+	if !ok {
+		name = fmt.Sprintf("tsbs_%d", len(p.stmts))
+		if _, err := p.conn.Prepare(context.Background(), name, qry); err != nil {
+			return nil, err
+		}
+		p.stmts[qry] = name
+	}
+	return p.conn.Query(context.Background(), name, values...)
+}
+
+// getUnpreparedConn returns the connection running parameterized queries
+// without prepared statements. pgx implicitly prepares and caches every
+// statement, so its statement cache is disabled for this connection to have
+// the queries planned on each execution.
+func (p *processor) getUnpreparedConn() (*pgx.Conn, error) {
+	if p.unpreparedConn == nil {
+		connConfig := p.connCfg.Copy()
+		connConfig.BuildStatementCache = nil
+		conn, err := pgx.ConnectConfig(context.Background(), connConfig)
+		if err != nil {
+			return nil, err
+		}
+		p.unpreparedConn = conn
+	}
+	return p.unpreparedConn, nil
+}
+
+// Close deallocates the prepared statements and closes the connections of
+// the processor.
+func (p *processor) Close() {
+	ctx := context.Background()
+	for _, name := range p.stmts {
+		p.conn.Deallocate(ctx, name)
+	}
+	if p.unpreparedConn != nil {
+		p.unpreparedConn.Close(ctx)
+	}
+	p.conn.Close(ctx)
+}
+
 func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, error) {
 	// No need to run again for EXPLAIN
 	if isWarm && p.opts.showExplain {
@@ -120,13 +192,16 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	if showExplain {
 		qry = "EXPLAIN ANALYZE " + qry
 	}
-	rows, err := p.conn.Query(context.Background(), qry)
+	rows, err := p.query(qry, tq.SqlArgs)
 	if err != nil {
 		return nil, err
 	}
 
 	if p.opts.debug {
 		fmt.Println(qry)
+		if len(tq.SqlArgs) > 0 {
+			fmt.Println(tq.SqlArgs)
+		}
 	}
 	if showExplain {
 		fmt.Printf("Explian Query:\n")
@@ -135,11 +210,34 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	} else if p.opts.printResponse {
 		prettyPrintResponse(rows, tq)
 	}
-	defer rows.Close()
+
+	// Fetching all the rows so that the result transfer is measured too
+	numRows, numBytes := uint64(0), uint64(0)
+	timeToFirst := 0.0
+	for rows.Next() {
+		if numRows == 0 {
+			timeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6
+		}
+		numRows++
+		for _, v := range rows.RawValues() {
+			numBytes += uint64(len(v))
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
 	took := float64(time.Since(start).Nanoseconds()) / 1e6
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), took)
+	// Rows already consumed for printing can not be measured
+	if !showExplain && !p.opts.printResponse {
+		if numRows == 0 {
+			timeToFirst = took
+		}
+		stat.SetResponse(numRows, numBytes, timeToFirst)
+	}
 
 	return []*query.Stat{stat}, err
 }
@@ -150,6 +248,9 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 func prettyPrintResponse(rows pgx.Rows, q *query.CrateDB) {
 	resp := make(map[string]interface{})
 	resp["query"] = string(q.SqlQuery)
+	if len(q.SqlArgs) > 0 {
+		resp["args"] = q.SqlArgs
+	}
 	resp["results"] = mapRows(rows)
 
 	line, err := json.MarshalIndent(resp, "", "  ")
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,6 +33,57 @@ type HTTPClientDoOptions struct {
 	PrettyPrintResponses bool
 	chunkSize            uint64
 	database             string
+	authToken            string
+	organization         string
+}
+
+// influxResponse is the subset of an InfluxQL JSON response needed to count
+// the returned rows.
+type influxResponse struct {
+	Results []struct {
+		Series []struct {
+			Values []json.RawMessage `json:"values"`
+		} `json:"series"`
+	} `json:"results"`
+}
+
+// countCSVRows counts the data rows of a Flux annotated CSV response. Each
+// table of the response starts with a header row, optionally preceded by
+// annotation rows, and tables are separated by empty lines.
+func countCSVRows(body []byte) uint64 {
+	rows := uint64(0)
+	header := true
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		switch {
+		case len(line) == 0:
+			header = true
+		case line[0] == '#':
+		case header:
+			header = false
+		default:
+			rows++
+		}
+	}
+	return rows
+}
+
+// countRows counts the values of all series in an InfluxQL JSON response,
+// which is a sequence of JSON objects when the response is chunked.
+func countRows(body []byte) uint64 {
+	rows := uint64(0)
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var r influxResponse
+		if err := dec.Decode(&r); err != nil {
+			return rows
+		}
+		for _, result := range r.Results {
+			for _, series := range result.Series {
+				rows += uint64(len(series.Values))
+			}
+		}
+	}
 }
 
 var httpClientOnce = sync.Once{}
@@ -57,23 +111,41 @@ func NewHTTPClient(host string) *HTTPClient {
 
 // Do performs the action specified by the given Query. It uses fasthttp, and
 // tries to minimize heap allocations.
-func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64, err error) {
+func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64, info query.ResponseInfo, err error) {
+	// Flux queries are sent in the body, InfluxQL queries in the path:
+	isFlux := len(q.Body) > 0
+
 	// populate uri from the reusable byte slice:
 	w.uri = w.uri[:0]
 	w.uri = append(w.uri, w.Host...)
 	//w.uri = append(w.uri, bytesSlash...)
 	w.uri = append(w.uri, q.Path...)
-	w.uri = append(w.uri, []byte("&db="+url.QueryEscape(opts.database))...)
-	if opts.chunkSize > 0 {
-		s := fmt.Sprintf("&chunked=true&chunk_size=%d", opts.chunkSize)
-		w.uri = append(w.uri, []byte(s)...)
+	if isFlux {
+		w.uri = append(w.uri, []byte("?org="+url.QueryEscape(opts.organization))...)
+	} else {
+		w.uri = append(w.uri, []byte("&db="+url.QueryEscape(opts.database))...)
+		if opts.chunkSize > 0 {
+			s := fmt.Sprintf("&chunked=true&chunk_size=%d", opts.chunkSize)
+			w.uri = append(w.uri, []byte(s)...)
+		}
 	}
 
 	// populate a request with data from the Query:
-	req, err := http.NewRequest(string(q.Method), string(w.uri), nil)
+	var reqBody io.Reader
+	if isFlux {
+		reqBody = bytes.NewReader(q.Body)
+	}
+	req, err := http.NewRequest(string(q.Method), string(w.uri), reqBody)
 	if err != nil {
 		panic(err)
 	}
+	if opts.authToken != "" {
+		req.Header.Set("Authorization", "Token "+opts.authToken)
+	}
+	if isFlux {
+		req.Header.Set("Content-Type", "application/vnd.flux")
+		req.Header.Set("Accept", "application/csv")
+	}
 
 	// Perform the request while tracking latency:
 	start := time.Now()
@@ -85,6 +157,7 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 	if resp.StatusCode != http.StatusOK {
 		panic("http request did not return status 200 OK")
 	}
+	info.TimeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
 
 	var body []byte
 	body, err = ioutil.ReadAll(resp.Body)
@@ -94,6 +167,12 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 	}
 
 	lag = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	info.Bytes = uint64(len(body))
+	if isFlux {
+		info.Rows = countCSVRows(body)
+	} else {
+		info.Rows = countRows(body)
+	}
 
 	if opts != nil {
 		// Print debug messages, if applicable:
@@ -114,16 +193,22 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 
 		// Pretty print JSON responses, if applicable:
 		if opts.PrettyPrintResponses {
-			// Assumes the response is JSON! This holds for Influx
+			// Assumes InfluxQL responses are JSON! This holds for Influx
 			// and Elastic.
 
 			prefix := fmt.Sprintf("ID %d: ", q.GetID())
 			var v interface{}
 			var line []byte
 			full := make(map[string]interface{})
-			full["influxql"] = string(q.RawQuery)
-			json.Unmarshal(body, &v)
-			full["response"] = v
+			if isFlux {
+				// Flux responses are CSV, printed line by line.
+				full["flux"] = string(q.RawQuery)
+				full["response"] = strings.Split(strings.TrimSpace(string(body)), "\n")
+			} else {
+				full["influxql"] = string(q.RawQuery)
+				json.Unmarshal(body, &v)
+				full["response"] = v
+			}
 			line, err = json.MarshalIndent(full, prefix, "  ")
 			if err != nil {
 				return
@@ -132,5 +217,5 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 		}
 	}
 
-	return lag, err
+	return lag, info, err
 }
@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestCountRows(t *testing.T) {
+	cases := []struct {
+		desc string
+		body string
+		want uint64
+	}{
+		{
+			desc: "single response",
+			body: `{"results":[{"statement_id":0,"series":[` +
+				`{"name":"cpu","columns":["time","max"],"values":[["2016-01-01T00:00:00Z",1],["2016-01-01T00:01:00Z",2]]},` +
+				`{"name":"cpu","columns":["time","max"],"values":[["2016-01-01T00:00:00Z",3]]}]}]}`,
+			want: 3,
+		},
+		{
+			desc: "chunked response",
+			body: `{"results":[{"statement_id":0,"series":[{"name":"cpu","values":[[1,1],[2,2]]}],"partial":true}]}` + "\n" +
+				`{"results":[{"statement_id":0,"series":[{"name":"cpu","values":[[3,3]]}]}]}` + "\n",
+			want: 3,
+		},
+		{
+			desc: "no series",
+			body: `{"results":[{"statement_id":0}]}`,
+			want: 0,
+		},
+		{
+			desc: "invalid JSON",
+			body: `not json`,
+			want: 0,
+		},
+	}
+	for _, c := range cases {
+		if got := countRows([]byte(c.body)); got != c.want {
+			t.Errorf("%s: incorrect rows: got %d want %d", c.desc, got, c.want)
+		}
+	}
+}
+
+func TestCountCSVRows(t *testing.T) {
+	body := "#datatype,string,long,double\r\n" +
+		"#group,false,false,false\r\n" +
+		",result,table,_value\r\n" +
+		",_result,0,1\r\n" +
+		",_result,0,2\r\n" +
+		"\r\n" +
+		",result,table,_value\r\n" +
+		",_result,1,3\r\n" +
+		"\r\n"
+	if got := countCSVRows([]byte(body)); got != 3 {
+		t.Errorf("incorrect rows: got %d want 3", got)
+	}
+	if got := countCSVRows(nil); got != 0 {
+		t.Errorf("incorrect rows for an empty body: got %d want 0", got)
+	}
+}
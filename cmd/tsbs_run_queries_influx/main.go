@@ -2,7 +2,8 @@
 //
 // It reads encoded Query objects from stdin, and makes concurrent requests
 // to the provided HTTP endpoint. This program has no knowledge of the
-// internals of the endpoint.
+// internals of the endpoint. InfluxQL queries are sent in the request path
+// and Flux queries in the request body, to the InfluxDB 2.x query API.
 package main
 
 import (
@@ -18,8 +19,10 @@ import (
 
 // Program option vars:
 var (
-	daemonUrls []string
-	chunkSize  uint64
+	daemonUrls   []string
+	chunkSize    uint64
+	authToken    string
+	organization string
 )
 
 // Global vars:
@@ -35,6 +38,8 @@ func init() {
 
 	pflag.String("urls", "http://localhost:8086", "Daemon URLs, comma-separated. Will be used in a round-robin fashion.")
 	pflag.Uint64("chunk-response-size", 0, "Number of series to chunk results into. 0 means no chunking.")
+	pflag.String("auth-token", "", "API token sent with every request, required by InfluxDB 2.x.")
+	pflag.String("organization", "", "Organization of the bucket read by Flux queries.")
 
 	pflag.Parse()
 
@@ -50,6 +55,8 @@ func init() {
 
 	csvDaemonUrls = viper.GetString("urls")
 	chunkSize = viper.GetUint64("chunk-response-size")
+	authToken = viper.GetString("auth-token")
+	organization = viper.GetString("organization")
 
 	daemonUrls = strings.Split(csvDaemonUrls, ",")
 	if len(daemonUrls) == 0 {
@@ -76,6 +83,8 @@ func (p *processor) Init(workerNumber int) {
 		PrettyPrintResponses: runner.DoPrintResponses(),
 		chunkSize:            chunkSize,
 		database:             runner.DatabaseName(),
+		authToken:            authToken,
+		organization:         organization,
 	}
 	url := daemonUrls[workerNumber%len(daemonUrls)]
 	p.w = NewHTTPClient(url)
@@ -83,11 +92,12 @@ func (p *processor) Init(workerNumber int) {
 
 func (p *processor) ProcessQuery(q query.Query, _ bool) ([]*query.Stat, error) {
 	hq := q.(*query.HTTP)
-	lag, err := p.w.Do(hq, p.opts)
+	lag, info, err := p.w.Do(hq, p.opts)
 	if err != nil {
 		return nil, err
 	}
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), lag)
+	stat.SetResponse(info.Rows, info.Bytes, info.TimeToFirst)
 	return []*query.Stat{stat}, nil
 }
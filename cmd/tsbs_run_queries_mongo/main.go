@@ -92,13 +92,25 @@ func (p *processor) ProcessQuery(q query.Query, _ bool) ([]*query.Stat, error) {
 	if runner.DebugLevel() > 0 {
 		fmt.Println(mq.BsonDoc)
 	}
-	var result map[string]interface{}
+	// Documents are fetched raw so that their size can be measured and only
+	// decoded when they are printed
+	var raw bson.Raw
 	cnt := 0
-	for iter.Next(&result) {
+	size := 0
+	timeToFirst := 0.0
+	for iter.Next(&raw) {
+		if cnt == 0 {
+			timeToFirst = float64(time.Now().UnixNano()-start) / 1e6
+		}
 		if runner.DoPrintResponses() {
+			var result map[string]interface{}
+			if err := raw.Unmarshal(&result); err != nil {
+				return nil, err
+			}
 			fmt.Printf("ID %d: %v\n", q.GetID(), result)
 		}
 		cnt++
+		size += len(raw.Data)
 	}
 	if runner.DebugLevel() > 0 {
 		fmt.Println(cnt)
@@ -107,7 +119,11 @@ func (p *processor) ProcessQuery(q query.Query, _ bool) ([]*query.Stat, error) {
 
 	took := time.Now().UnixNano() - start
 	lag := float64(took) / 1e6 // milliseconds
+	if cnt == 0 {
+		timeToFirst = lag
+	}
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), lag)
+	stat.SetResponse(uint64(cnt), uint64(size), timeToFirst)
 	return []*query.Stat{stat}, err
 }
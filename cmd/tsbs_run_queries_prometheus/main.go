@@ -0,0 +1,199 @@
+// tsbs_run_queries_prometheus speed tests Prometheus using requests from stdin or file.
+//
+// It reads encoded Query objects from stdin, and makes concurrent requests
+// to the provided HTTP endpoint. Queries with a body are remote-read
+// requests, all others are requests to the Prometheus HTTP API, so any
+// storage with a Prometheus-compatible read path can be benchmarked.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blagojts/viper"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/spf13/pflag"
+	"github.com/timescale/promscale/pkg/prompb"
+	"github.com/timescale/tsbs/internal/utils"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+// Program option vars:
+var (
+	promURLs       []string
+	remoteReadPath string
+)
+
+// Global vars:
+var (
+	runner *query.BenchmarkRunner
+)
+
+// Parse args:
+func init() {
+	var config query.BenchmarkRunnerConfig
+	config.AddToFlagSet(pflag.CommandLine)
+
+	pflag.String("urls", "http://localhost:9090",
+		"Comma-separated list of Prometheus or Prometheus-compatible query URLs")
+	pflag.String("remote-read-path", "",
+		"Path of the remote-read endpoint, replacing the one of the queries, e.g. /read for Promscale")
+
+	pflag.Parse()
+
+	if err := utils.SetupConfigFile(); err != nil {
+		panic(fmt.Errorf("fatal error config file: %s", err))
+	}
+	if err := viper.Unmarshal(&config); err != nil {
+		panic(fmt.Errorf("unable to decode config: %s", err))
+	}
+
+	urls := viper.GetString("urls")
+	if len(urls) == 0 {
+		log.Fatalf("missing `urls` flag")
+	}
+	promURLs = strings.Split(urls, ",")
+	remoteReadPath = viper.GetString("remote-read-path")
+	runner = query.NewBenchmarkRunner(config)
+}
+
+func main() {
+	runner.Run(&query.HTTPPool, newProcessor)
+}
+
+func newProcessor() query.Processor {
+	return &processor{}
+}
+
+// query.Processor interface implementation
+type processor struct {
+	url string
+
+	prettyPrintResponses bool
+}
+
+// query.Processor interface implementation
+func (p *processor) Init(workerNum int) {
+	p.url = promURLs[workerNum%len(promURLs)]
+	p.prettyPrintResponses = runner.DoPrintResponses()
+}
+
+// query.Processor interface implementation
+func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, error) {
+	hq := q.(*query.HTTP)
+	lag, info, err := p.do(hq)
+	if err != nil {
+		return nil, err
+	}
+	stat := query.GetStat()
+	stat.Init(q.HumanLabelName(), lag)
+	stat.SetResponse(info.Rows, info.Bytes, info.TimeToFirst)
+	return []*query.Stat{stat}, nil
+}
+
+// decodeReadResponse decodes a snappy-compressed remote-read response.
+func decodeReadResponse(body []byte) (*prompb.ReadResponse, error) {
+	b, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("error while decompressing remote read response: %s", err)
+	}
+	var resp prompb.ReadResponse
+	if err := proto.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("error while decoding remote read response: %s", err)
+	}
+	return &resp, nil
+}
+
+// countSamples counts the samples of all the series in a remote-read response.
+func countSamples(resp *prompb.ReadResponse) uint64 {
+	rows := uint64(0)
+	for _, result := range resp.Results {
+		for _, ts := range result.Timeseries {
+			rows += uint64(len(ts.Samples))
+		}
+	}
+	return rows
+}
+
+func (p *processor) newRequest(q *query.HTTP) (*http.Request, error) {
+	if len(q.Body) == 0 {
+		return http.NewRequest(string(q.Method), p.url+string(q.Path), nil)
+	}
+	path := string(q.Path)
+	if remoteReadPath != "" {
+		path = remoteReadPath
+	}
+	req, err := http.NewRequest(string(q.Method), p.url+path, bytes.NewReader(q.Body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Read-Version", "0.1.0")
+	return req, nil
+}
+
+func (p *processor) do(q *query.HTTP) (float64, query.ResponseInfo, error) {
+	var info query.ResponseInfo
+	// populate a request with data from the Query:
+	req, err := p.newRequest(q)
+	if err != nil {
+		return 0, info, fmt.Errorf("error while creating request: %s", err)
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, info, fmt.Errorf("query execution error: %s", err)
+	}
+	defer resp.Body.Close()
+	info.TimeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, info, fmt.Errorf("error while reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, info, fmt.Errorf("non-200 statuscode received: %d; Body: %s", resp.StatusCode, string(body))
+	}
+	lag := float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	info.Bytes = uint64(len(body))
+
+	if len(q.Body) > 0 {
+		rr, err := decodeReadResponse(body)
+		if err != nil {
+			return lag, info, err
+		}
+		info.Rows = countSamples(rr)
+		// Print remote read responses as JSON, if applicable:
+		if p.prettyPrintResponses {
+			body, err = json.Marshal(rr)
+			if err != nil {
+				return lag, info, err
+			}
+		}
+	} else {
+		info.Rows = query.CountPrometheusRows(body)
+	}
+
+	// Pretty print JSON responses, if applicable:
+	if p.prettyPrintResponses {
+		var pretty bytes.Buffer
+		prefix := fmt.Sprintf("ID %d: ", q.GetID())
+		if err := json.Indent(&pretty, body, prefix, "  "); err != nil {
+			return lag, info, err
+		}
+		_, err = fmt.Fprintf(os.Stderr, "%s%s\n", prefix, pretty.Bytes())
+		if err != nil {
+			return lag, info, err
+		}
+	}
+	return lag, info, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/timescale/promscale/pkg/prompb"
+)
+
+func TestDecodeReadResponse(t *testing.T) {
+	want := &prompb.ReadResponse{
+		Results: []*prompb.QueryResult{
+			{
+				Timeseries: []*prompb.TimeSeries{
+					{
+						Labels:  []prompb.Label{{Name: "hostname", Value: "host_0"}},
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}, {Value: 2, Timestamp: 2000}},
+					},
+					{
+						Labels:  []prompb.Label{{Name: "hostname", Value: "host_1"}},
+						Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+					},
+				},
+			},
+			{
+				Timeseries: []*prompb.TimeSeries{
+					{
+						Labels:  []prompb.Label{{Name: "hostname", Value: "host_2"}},
+						Samples: []prompb.Sample{{Value: 4, Timestamp: 1000}},
+					},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling response: %v", err)
+	}
+
+	got, err := decodeReadResponse(snappy.Encode(nil, b))
+	if err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("incorrect response: got %v want %v", got, want)
+	}
+	if rows := countSamples(got); rows != 4 {
+		t.Errorf("incorrect number of samples: got %d want 4", rows)
+	}
+}
+
+func TestDecodeReadResponseErrors(t *testing.T) {
+	if _, err := decodeReadResponse([]byte("not snappy")); err == nil {
+		t.Errorf("expected an error for a response that is not snappy-compressed")
+	}
+	if _, err := decodeReadResponse(snappy.Encode(nil, []byte{0xff, 0xff})); err == nil {
+		t.Errorf("expected an error for a response that is not a ReadResponse")
+	}
+}
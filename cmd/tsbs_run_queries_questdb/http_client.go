@@ -32,6 +32,17 @@ type HTTPClientDoOptions struct {
 	database             string
 }
 
+// countRows returns the number of rows reported by a QuestDB /exec response.
+func countRows(body []byte) uint64 {
+	var r struct {
+		Count uint64 `json:"count"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return 0
+	}
+	return r.Count
+}
+
 var httpClientOnce = sync.Once{}
 var httpClient *http.Client
 
@@ -60,7 +71,7 @@ func NewHTTPClient(host string) *HTTPClient {
 
 // Do performs the action specified by the given Query. It uses fasthttp, and
 // tries to minimize heap allocations.
-func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64, err error) {
+func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64, info query.ResponseInfo, err error) {
 	// populate uri from the reusable byte slice:
 	w.uri = w.uri[:0]
 	w.uri = append(w.uri, w.Host...)
@@ -82,6 +93,7 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 	if resp.StatusCode != http.StatusOK {
 		panic("http request did not return status 200 OK")
 	}
+	info.TimeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
 
 	var body []byte
 	body, err = ioutil.ReadAll(resp.Body)
@@ -91,23 +103,12 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 	}
 
 	lag = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	info.Bytes = uint64(len(body))
+	info.Rows = countRows(body)
 
 	if opts != nil {
 		// Print debug messages, if applicable:
-		switch opts.Debug {
-		case 1:
-			fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms\n", q.HumanLabel, lag)
-		case 2:
-			fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
-		case 3:
-			fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
-			fmt.Fprintf(os.Stderr, "debug:   request: %s\n", string(q.String()))
-		case 4:
-			fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
-			fmt.Fprintf(os.Stderr, "debug:   request: %s\n", string(q.String()))
-			fmt.Fprintf(os.Stderr, "debug:   response: %s\n", string(body))
-		default:
-		}
+		printDebug(q, lag, opts.Debug, body)
 
 		// Pretty print JSON responses, if applicable:
 		if opts.PrettyPrintResponses {
@@ -129,5 +130,23 @@ func (w *HTTPClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64,
 		}
 	}
 
-	return lag, err
+	return lag, info, err
+}
+
+// printDebug prints the debug messages of a query, if applicable.
+func printDebug(q *query.HTTP, lag float64, debug int, response []byte) {
+	switch debug {
+	case 1:
+		fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms\n", q.HumanLabel, lag)
+	case 2:
+		fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
+	case 3:
+		fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
+		fmt.Fprintf(os.Stderr, "debug:   request: %s\n", string(q.String()))
+	case 4:
+		fmt.Fprintf(os.Stderr, "debug: %s in %7.2fms -- %s\n", q.HumanLabel, lag, q.HumanDescription)
+		fmt.Fprintf(os.Stderr, "debug:   request: %s\n", string(q.String()))
+		fmt.Fprintf(os.Stderr, "debug:   response: %s\n", string(response))
+	default:
+	}
 }
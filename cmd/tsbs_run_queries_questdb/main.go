@@ -1,8 +1,8 @@
-// tsbs_run_queries_influx speed tests InfluxDB using requests from stdin.
+// tsbs_run_queries_questdb speed tests QuestDB using requests from stdin.
 //
 // It reads encoded Query objects from stdin, and makes concurrent requests
-// to the provided HTTP endpoint. This program has no knowledge of the
-// internals of the endpoint.
+// to the provided HTTP endpoint, or runs their SQL over the PostgreSQL wire
+// protocol. This program has no knowledge of the internals of the endpoint.
 package main
 
 import (
@@ -21,9 +21,16 @@ import (
 	"github.com/timescale/tsbs/pkg/query"
 )
 
+const (
+	protocolREST   = "rest"
+	protocolPGWire = "pgwire"
+)
+
 // Program option vars:
 var (
-	daemonUrls []string
+	daemonUrls    []string
+	protocol      string
+	pgwireConnect string
 )
 
 // Global vars:
@@ -38,6 +45,9 @@ func init() {
 	var csvDaemonUrls string
 
 	pflag.String("urls", "http://localhost:9000/", "Daemon URLs, comma-separated. Will be used in a round-robin fashion.")
+	pflag.String("protocol", protocolREST, "Protocol to run the queries with: rest for the /exec endpoint or pgwire for the PostgreSQL wire protocol.")
+	pflag.String("pgwire-connect", "host=localhost port=8812 user=admin password=quest dbname=qdb sslmode=disable",
+		"PostgreSQL connection string used by the pgwire protocol.")
 
 	pflag.Parse()
 
@@ -52,6 +62,11 @@ func init() {
 	}
 
 	csvDaemonUrls = viper.GetString("urls")
+	protocol = viper.GetString("protocol")
+	pgwireConnect = viper.GetString("pgwire-connect")
+	if protocol != protocolREST && protocol != protocolPGWire {
+		log.Fatalf("invalid protocol %q: must be %s or %s", protocol, protocolREST, protocolPGWire)
+	}
 
 	daemonUrls = strings.Split(csvDaemonUrls, ",")
 	if len(daemonUrls) == 0 {
@@ -77,8 +92,13 @@ func main() {
 	runner.Run(&query.HTTPPool, newProcessor)
 }
 
+// queryExecutor runs a query and describes its response.
+type queryExecutor interface {
+	Do(q *query.HTTP, opts *HTTPClientDoOptions) (float64, query.ResponseInfo, error)
+}
+
 type processor struct {
-	w    *HTTPClient
+	w    queryExecutor
 	opts *HTTPClientDoOptions
 }
 
@@ -89,18 +109,31 @@ func (p *processor) Init(workerNumber int) {
 		Debug:                runner.DebugLevel(),
 		PrettyPrintResponses: runner.DoPrintResponses(),
 	}
+	if protocol == protocolPGWire {
+		p.w = NewPGWireClient(pgwireConnect)
+		return
+	}
 	url := daemonUrls[workerNumber%len(daemonUrls)]
 	p.w = NewHTTPClient(url)
 }
 
+// Close closes the connections of the pgwire client, the HTTP client has
+// none of its own to close.
+func (p *processor) Close() {
+	if c, ok := p.w.(*PGWireClient); ok {
+		c.Close()
+	}
+}
+
 func (p *processor) ProcessQuery(q query.Query, _ bool) ([]*query.Stat, error) {
 	hq := q.(*query.HTTP)
-	lag, err := p.w.Do(hq, p.opts)
+	lag, info, err := p.w.Do(hq, p.opts)
 	if err != nil {
 		return nil, err
 	}
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), lag)
+	stat.SetResponse(info.Rows, info.Bytes, info.TimeToFirst)
 	return []*query.Stat{stat}, nil
 }
 
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/pkg/errors"
+	"github.com/timescale/tsbs/pkg/query"
+)
+
+const pgxDriver = "pgx"
+
+// PGWireClient runs the SQL of the queries over the PostgreSQL wire
+// protocol, instead of the REST API they are generated for.
+type PGWireClient struct {
+	db *sql.DB
+}
+
+// NewPGWireClient creates a new PGWireClient connecting with the given
+// PostgreSQL connection string. The connection is checked right away, so a
+// wrong connection string fails before any query is run.
+func NewPGWireClient(connect string) *PGWireClient {
+	db, err := sql.Open(pgxDriver, connect)
+	if err != nil {
+		panic(err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		panic(err)
+	}
+	return &PGWireClient{db: db}
+}
+
+// Close closes the connections of the client.
+func (w *PGWireClient) Close() {
+	w.db.Close()
+}
+
+// Do runs the SQL of the given Query and fetches all the rows of its result.
+// The size of the response is not known over the wire protocol, so only its
+// rows are reported.
+func (w *PGWireClient) Do(q *query.HTTP, opts *HTTPClientDoOptions) (lag float64, info query.ResponseInfo, err error) {
+	start := time.Now()
+	rows, err := w.db.Query(string(q.RawQuery))
+	if err != nil {
+		return 0, info, err
+	}
+	defer rows.Close()
+
+	// Keep the rows only when they are printed:
+	keep := opts != nil && (opts.PrettyPrintResponses || opts.Debug >= 4)
+	var results []map[string]interface{}
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, info, err
+	}
+	for rows.Next() {
+		if info.Rows == 0 {
+			info.TimeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+		}
+		info.Rows++
+		if keep {
+			row, err := scanRow(rows, cols)
+			if err != nil {
+				return 0, info, err
+			}
+			results = append(results, row)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, info, err
+	}
+
+	lag = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	if info.Rows == 0 {
+		info.TimeToFirst = lag
+	}
+
+	if keep {
+		full := make(map[string]interface{})
+		full["query"] = string(q.RawQuery)
+		full["results"] = results
+		prefix := fmt.Sprintf("ID %d: ", q.GetID())
+		line, err := json.MarshalIndent(full, prefix, "  ")
+		if err != nil {
+			return lag, info, err
+		}
+
+		// Print debug messages, if applicable:
+		printDebug(q, lag, opts.Debug, line)
+
+		// Pretty print JSON responses, if applicable:
+		if opts.PrettyPrintResponses {
+			fmt.Println(string(line) + "\n")
+		}
+	} else if opts != nil {
+		printDebug(q, lag, opts.Debug, nil)
+	}
+
+	return lag, info, nil
+}
+
+// scanRow reads the current row into a map from column names to values.
+func scanRow(rows *sql.Rows, cols []string) (map[string]interface{}, error) {
+	values := make([]interface{}, len(cols))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+	if err := rows.Scan(values...); err != nil {
+		return nil, errors.Wrap(err, "error while reading values")
+	}
+	row := make(map[string]interface{}, len(cols))
+	for i, column := range cols {
+		row[column] = *values[i].(*interface{})
+	}
+	return row, nil
+}
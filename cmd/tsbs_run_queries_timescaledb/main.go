@@ -34,6 +34,7 @@ var (
 	port            string
 	showExplain     bool
 	forceTextFormat bool
+	usePrepared     bool
 )
 
 // Global vars:
@@ -56,6 +57,7 @@ func init() {
 
 	pflag.Bool("show-explain", false, "Print out the EXPLAIN output for sample query")
 	pflag.Bool("force-text-format", false, "Send/receive data in text format")
+	pflag.Bool("use-prepared-statements", false, "Execute parameterized queries as prepared statements cached per worker connection")
 
 	pflag.Parse()
 
@@ -76,6 +78,7 @@ func init() {
 	port = viper.GetString("port")
 	showExplain = viper.GetBool("show-explain")
 	forceTextFormat = viper.GetBool("force-text-format")
+	usePrepared = viper.GetBool("use-prepared-statements")
 
 	runner = query.NewBenchmarkRunner(config)
 
@@ -134,6 +137,9 @@ func getConnectString(workerNumber int) string {
 func prettyPrintResponse(rows *sql.Rows, q *query.TimescaleDB) {
 	resp := make(map[string]interface{})
 	resp["query"] = string(q.SqlQuery)
+	if len(q.SqlArgs) > 0 {
+		resp["args"] = q.SqlArgs
+	}
 	resp["results"] = mapRows(rows)
 
 	line, err := json.MarshalIndent(resp, "", "  ")
@@ -171,11 +177,18 @@ type queryExecutorOptions struct {
 	showExplain   bool
 	debug         bool
 	printResponse bool
+	usePrepared   bool
 }
 
 type processor struct {
-	db   *sql.DB
-	opts *queryExecutorOptions
+	db *sql.DB
+	// unpreparedDB runs the parameterized queries when prepared statements
+	// are not used. It is only opened for the first such query, so that the
+	// connection of the literal queries is left as is.
+	unpreparedDB *sql.DB
+	stmts        map[string]*sql.Stmt
+	workerNumber int
+	opts         *queryExecutorOptions
 }
 
 func newProcessor() query.Processor { return &processor{} }
@@ -186,11 +199,74 @@ func (p *processor) Init(workerNumber int) {
 		panic(err)
 	}
 	p.db = db
+	p.workerNumber = workerNumber
+	p.stmts = make(map[string]*sql.Stmt)
 	p.opts = &queryExecutorOptions{
 		showExplain:   showExplain,
 		debug:         runner.DebugLevel() > 0,
 		printResponse: runner.DoPrintResponses(),
+		usePrepared:   usePrepared,
+	}
+}
+
+// query runs qry, binding args when it is a parameterized template. With
+// prepared statements enabled each distinct template is prepared once per
+// worker and reused, so planning is not repeated on every execution.
+func (p *processor) query(qry string, args []string) (*sql.Rows, error) {
+	if len(args) == 0 {
+		return p.db.Query(qry)
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	if !p.opts.usePrepared || p.opts.showExplain {
+		db, err := p.getUnpreparedDB()
+		if err != nil {
+			return nil, err
+		}
+		return db.Query(qry, values...)
+	}
+
+	stmt, ok := p.stmts[qry]
+	if !ok {
+		var err error
+		stmt, err = p.db.Prepare(qry)
+		if err != nil {
+			return nil, err
+		}
+		p.stmts[qry] = stmt
+	}
+	return stmt.Query(values...)
+}
+
+// getUnpreparedDB returns the connection running parameterized queries
+// without prepared statements. pgx implicitly prepares and caches every
+// statement, so its statement cache is disabled for this connection to have
+// the queries planned on each execution.
+func (p *processor) getUnpreparedDB() (*sql.DB, error) {
+	if driver != pgxDriver {
+		return p.db, nil
 	}
+	if p.unpreparedDB == nil {
+		db, err := sql.Open(driver, getConnectString(p.workerNumber)+" statement_cache_capacity=0")
+		if err != nil {
+			return nil, err
+		}
+		p.unpreparedDB = db
+	}
+	return p.unpreparedDB, nil
+}
+
+// Close closes the prepared statements and the connections of the processor.
+func (p *processor) Close() {
+	for _, stmt := range p.stmts {
+		stmt.Close()
+	}
+	if p.unpreparedDB != nil {
+		p.unpreparedDB.Close()
+	}
+	p.db.Close()
 }
 
 func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, error) {
@@ -205,13 +281,16 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	if showExplain {
 		qry = "EXPLAIN ANALYZE " + qry
 	}
-	rows, err := p.db.Query(qry)
+	rows, err := p.query(qry, tq.SqlArgs)
 	if err != nil {
 		return nil, err
 	}
 
 	if p.opts.debug {
 		fmt.Println(qry)
+		if len(tq.SqlArgs) > 0 {
+			fmt.Println(tq.SqlArgs)
+		}
 	}
 	if showExplain {
 		text := ""
@@ -227,7 +306,13 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 		prettyPrintResponse(rows, tq)
 	}
 	// Fetching all the rows to confirm that the query is fully completed.
+	numRows := uint64(0)
+	timeToFirst := 0.0
 	for rows.Next() {
+		if numRows == 0 {
+			timeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6
+		}
+		numRows++
 	}
 	rows.Close()
 	if err := rows.Err(); err != nil {
@@ -236,6 +321,13 @@ func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, err
 	took := float64(time.Since(start).Nanoseconds()) / 1e6
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), took)
+	// Rows already consumed for printing can not be measured
+	if !showExplain && !p.opts.printResponse {
+		if numRows == 0 {
+			timeToFirst = took
+		}
+		stat.SetResponse(numRows, 0, timeToFirst)
+	}
 
 	return []*query.Stat{stat}, err
 }
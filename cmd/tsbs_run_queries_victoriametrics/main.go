@@ -81,48 +81,53 @@ func (p *processor) Init(workerNum int) {
 // query.Processor interface implementation
 func (p *processor) ProcessQuery(q query.Query, isWarm bool) ([]*query.Stat, error) {
 	hq := q.(*query.HTTP)
-	lag, err := p.do(hq)
+	lag, info, err := p.do(hq)
 	if err != nil {
 		return nil, err
 	}
 	stat := query.GetStat()
 	stat.Init(q.HumanLabelName(), lag)
+	stat.SetResponse(info.Rows, info.Bytes, info.TimeToFirst)
 	return []*query.Stat{stat}, nil
 }
 
-func (p *processor) do(q *query.HTTP) (float64, error) {
+func (p *processor) do(q *query.HTTP) (float64, query.ResponseInfo, error) {
+	var info query.ResponseInfo
 	// populate a request with data from the Query:
 	req, err := http.NewRequest(string(q.Method), p.url+string(q.Path), nil)
 	if err != nil {
-		return 0, fmt.Errorf("error while creating request: %s", err)
+		return 0, info, fmt.Errorf("error while creating request: %s", err)
 	}
 
 	start := time.Now()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("query execution error: %s", err)
+		return 0, info, fmt.Errorf("query execution error: %s", err)
 	}
 	defer resp.Body.Close()
+	info.TimeToFirst = float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("error while reading response body: %s", err)
+		return 0, info, fmt.Errorf("error while reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("non-200 statuscode received: %d; Body: %s", resp.StatusCode, string(body))
+		return 0, info, fmt.Errorf("non-200 statuscode received: %d; Body: %s", resp.StatusCode, string(body))
 	}
 	lag := float64(time.Since(start).Nanoseconds()) / 1e6 // milliseconds
+	info.Bytes = uint64(len(body))
+	info.Rows = query.CountPrometheusRows(body)
 
 	// Pretty print JSON responses, if applicable:
 	if p.prettyPrintResponses {
 		var pretty bytes.Buffer
 		prefix := fmt.Sprintf("ID %d: ", q.GetID())
 		if err := json.Indent(&pretty, body, prefix, "  "); err != nil {
-			return lag, err
+			return lag, info, err
 		}
 		_, err = fmt.Fprintf(os.Stderr, "%s%s\n", prefix, pretty.Bytes())
 		if err != nil {
-			return lag, err
+			return lag, info, err
 		}
 	}
-	return lag, nil
+	return lag, info, nil
 }
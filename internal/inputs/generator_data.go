@@ -20,8 +20,23 @@ import (
 const (
 	ErrNoConfig          = "no GeneratorConfig provided"
 	ErrInvalidDataConfig = "invalid config: DataGenerator needs a DataGeneratorConfig"
+
+	errStringFieldsUnsupportedFmt = "format '%s' does not support the string fields of use case '%s'"
 )
 
+// stringFieldFormats are the formats whose serializers and loaders handle
+// string field values.
+var stringFieldFormats = map[string]bool{
+	constants.FormatCassandra:   true,
+	constants.FormatClickhouse:  true,
+	constants.FormatCrateDB:     true,
+	constants.FormatInflux:      true,
+	constants.FormatMongo:       true,
+	constants.FormatQuestDB:     true,
+	constants.FormatTimescaleDB: true,
+	constants.FormatTimestream:  true,
+}
+
 // DataGenerator is a type of Generator for creating data that will be consumed
 // by a database's write/insert operations. The output is specific to the type
 // of database, but is consumed by TSBS loaders like tsbs_load_timescaledb.
@@ -128,17 +143,35 @@ func (g *DataGenerator) runSimulator(sim common.Simulator, serializer serialize.
 }
 
 func (g *DataGenerator) getSerializer(sim common.Simulator, target targets.ImplementedTarget) (serialize.PointSerializer, error) {
+	if hasStringFields(sim.Headers()) && !stringFieldFormats[target.TargetName()] {
+		return nil, fmt.Errorf(errStringFieldsUnsupportedFmt, target.TargetName(), g.config.Use)
+	}
 	switch target.TargetName() {
 	case constants.FormatCrateDB:
 		fallthrough
 	case constants.FormatClickhouse:
 		fallthrough
+	case constants.FormatTimestream:
+		fallthrough
 	case constants.FormatTimescaleDB:
 		g.writeHeader(sim.Headers())
 	}
 	return target.Serializer(), nil
 }
 
+// hasStringFields tells whether any measurement of the headers has a string
+// field.
+func hasStringFields(headers *common.GeneratedDataHeaders) bool {
+	for _, types := range headers.FieldTypes {
+		for _, t := range types {
+			if t == "string" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 //TODO should be implemented in targets package
 func (g *DataGenerator) writeHeader(headers *common.GeneratedDataHeaders) {
 	g.bufOut.WriteString("tags")
@@ -160,9 +193,16 @@ func (g *DataGenerator) writeHeader(headers *common.GeneratedDataHeaders) {
 	sort.Strings(keys)
 	for _, measurementName := range keys {
 		g.bufOut.WriteString(measurementName)
-		for _, field := range fields[measurementName] {
+		// field types are only written for measurements that declare them,
+		// so the headers of numeric use cases stay bare field names
+		fieldTypes := headers.FieldTypes[measurementName]
+		for i, field := range fields[measurementName] {
 			g.bufOut.WriteString(",")
 			g.bufOut.Write([]byte(field))
+			if fieldTypes != nil {
+				g.bufOut.WriteString(" ")
+				g.bufOut.WriteString(headers.FieldType(measurementName, i))
+			}
 		}
 		g.bufOut.WriteString("\n")
 	}
@@ -338,10 +338,60 @@ func TestGetSerializer(t *testing.T) {
 	checkWriteHeader(constants.FormatCrateDB, true)
 	checkWriteHeader(constants.FormatPrometheus, false)
 	checkWriteHeader(constants.FormatTimescaleDB, true)
+	checkWriteHeader(constants.FormatTimestream, true)
 	checkWriteHeader(constants.FormatVictoriaMetrics, false)
 	checkWriteHeader(constants.FormatQuestDB, false)
 }
 
+func TestGetSerializerStringFields(t *testing.T) {
+	dgc := &common.DataGeneratorConfig{
+		BaseConfig: common.BaseConfig{
+			Use:       common.UseCaseLogs,
+			Scale:     1,
+			TimeStart: defaultTimeStart,
+			TimeEnd:   defaultTimeEnd,
+		},
+		InitialScale: 1,
+		LogInterval:  defaultLogInterval,
+	}
+	var buf bytes.Buffer
+	g := &DataGenerator{
+		config: dgc,
+		bufOut: bufio.NewWriter(&buf),
+	}
+
+	scfg, err := usecases.GetSimulatorConfig(dgc)
+	if err != nil {
+		t.Fatalf("unexpected error creating scfg: %v", err)
+	}
+	sim := scfg.NewSimulator(dgc.LogInterval, 0)
+
+	checkSupported := func(format string, shouldSupport bool) {
+		target := &mockTarget{
+			name:       format,
+			serializer: &mockSerializer{},
+		}
+		_, err := g.getSerializer(sim, target)
+		if shouldSupport && err != nil {
+			t.Errorf("unexpected error for format %s: %v", format, err)
+		} else if !shouldSupport && err == nil {
+			t.Errorf("expected an error for format %s", format)
+		}
+	}
+
+	checkSupported(constants.FormatCassandra, true)
+	checkSupported(constants.FormatClickhouse, true)
+	checkSupported(constants.FormatCrateDB, true)
+	checkSupported(constants.FormatInflux, true)
+	checkSupported(constants.FormatMongo, true)
+	checkSupported(constants.FormatPrometheus, false)
+	checkSupported(constants.FormatQuestDB, true)
+	checkSupported(constants.FormatSiriDB, false)
+	checkSupported(constants.FormatTimescaleDB, true)
+	checkSupported(constants.FormatTimestream, true)
+	checkSupported(constants.FormatVictoriaMetrics, false)
+}
+
 type mockSerializer struct {
 	numCalledSerialize int
 	sentPoints         []*data.Point
@@ -373,3 +423,29 @@ func (m *mockTarget) TargetSpecificFlags(string, *pflag.FlagSet) {
 func (m *mockTarget) TargetName() string {
 	return m.name
 }
+
+func TestWriteHeader(t *testing.T) {
+	headers := &common.GeneratedDataHeaders{
+		TagTypes: []string{"string", "string"},
+		TagKeys:  []string{"instance", "service"},
+		FieldKeys: map[string][]string{
+			"logs": {"severity", "message", "duration_ms"},
+			"cpu":  {"usage_user", "usage_system"},
+		},
+		FieldTypes: map[string][]string{
+			"logs": {"string", "string", "float64"},
+		},
+	}
+	var buf bytes.Buffer
+	g := &DataGenerator{bufOut: bufio.NewWriter(&buf)}
+	g.writeHeader(headers)
+	g.bufOut.Flush()
+
+	want := "tags,instance string,service string\n" +
+		"cpu,usage_user,usage_system\n" +
+		"logs,severity string,message string,duration_ms float64\n" +
+		"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("incorrect header:\ngot\n%s\nwant\n%s", got, want)
+	}
+}
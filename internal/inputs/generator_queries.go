@@ -10,6 +10,7 @@ import (
 	"sort"
 	"time"
 
+	usesCommon "github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
 	queryUtils "github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	internalUtils "github.com/timescale/tsbs/internal/utils"
 	"github.com/timescale/tsbs/pkg/data/usecases/common"
@@ -24,10 +25,12 @@ const (
 	errCouldNotEncodeQueryFmt   = "could not encode query: %v"
 	errCouldNotQueryStatsFmt    = "could not output query stats: %v"
 	errUseCaseNotImplementedFmt = "use case '%s' not implemented for format '%s'"
+	errInvalidQueryTypeFmt      = "cannot generate query type '%s' for format '%s': %v"
 	errInvalidFactory           = "query generator factory for database '%s' does not implement the correct interface"
 	errUnknownUseCaseFmt        = "use case '%s' is undefined"
 	errCannotParseTimeFmt       = "cannot parse time from string '%s': %v"
 	errBadUseFmt                = "invalid use case specified: '%v'"
+	errWindowPlacementFmt       = "window placement not supported by format '%s'"
 )
 
 // DevopsGeneratorMaker creates a query generator for devops use case
@@ -40,6 +43,31 @@ type IoTGeneratorMaker interface {
 	NewIoT(start, end time.Time, scale int) (queryUtils.QueryGenerator, error)
 }
 
+// DevopsGenericGeneratorMaker creates a query generator for devops-generic use case
+type DevopsGenericGeneratorMaker interface {
+	NewDevopsGeneric(start, end time.Time, scale, maxMetricCount int) (queryUtils.QueryGenerator, error)
+}
+
+// FinanceGeneratorMaker creates a query generator for finance use case
+type FinanceGeneratorMaker interface {
+	NewFinance(start, end time.Time, scale int) (queryUtils.QueryGenerator, error)
+}
+
+// K8sGeneratorMaker creates a query generator for k8s use case
+type K8sGeneratorMaker interface {
+	NewK8s(start, end time.Time, scale int) (queryUtils.QueryGenerator, error)
+}
+
+// LogsGeneratorMaker creates a query generator for logs use case
+type LogsGeneratorMaker interface {
+	NewLogs(start, end time.Time, scale int) (queryUtils.QueryGenerator, error)
+}
+
+// SmartMeterGeneratorMaker creates a query generator for smart-meter use case
+type SmartMeterGeneratorMaker interface {
+	NewSmartMeter(start, end time.Time, scale int) (queryUtils.QueryGenerator, error)
+}
+
 // QueryGenerator is a type of Generator for creating queries to test against a
 // database. The output is specific to the type of database (due to each using
 // different querying techniques, e.g. SQL or REST), but is consumed by TSBS
@@ -87,7 +115,16 @@ func (g *QueryGenerator) Generate(config common.GeneratorConfig) error {
 		return err
 	}
 
+	if err := g.setWindowPlacement(useGen, g.conf); err != nil {
+		return err
+	}
+
 	filler := g.useCaseMatrix[g.conf.Use][g.conf.QueryType](useGen)
+	if v, ok := filler.(queryUtils.QueryFillerValidator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf(errInvalidQueryTypeFmt, g.conf.QueryType, g.conf.Format, err)
+		}
+	}
 
 	return g.runQueryGeneration(useGen, filler, g.conf)
 }
@@ -158,7 +195,7 @@ func (g *QueryGenerator) addFactory(database string, factory interface{}) error
 	validFactory := false
 
 	switch factory.(type) {
-	case DevopsGeneratorMaker, IoTGeneratorMaker:
+	case DevopsGeneratorMaker, IoTGeneratorMaker, DevopsGenericGeneratorMaker, FinanceGeneratorMaker, K8sGeneratorMaker, LogsGeneratorMaker, SmartMeterGeneratorMaker:
 		validFactory = true
 	}
 
@@ -196,11 +233,59 @@ func (g *QueryGenerator) getUseCaseGenerator(c *config.QueryGeneratorConfig) (qu
 		}
 
 		return devopsFactory.NewDevops(g.tsStart, g.tsEnd, scale)
+	case common.UseCaseDevopsGeneric:
+		genericFactory, ok := factory.(DevopsGenericGeneratorMaker)
+		if !ok {
+			return nil, fmt.Errorf(errUseCaseNotImplementedFmt, c.Use, c.Format)
+		}
+
+		return genericFactory.NewDevopsGeneric(g.tsStart, g.tsEnd, scale, int(c.MaxMetricCountPerHost))
+	case common.UseCaseFinance:
+		financeFactory, ok := factory.(FinanceGeneratorMaker)
+		if !ok {
+			return nil, fmt.Errorf(errUseCaseNotImplementedFmt, c.Use, c.Format)
+		}
+
+		return financeFactory.NewFinance(g.tsStart, g.tsEnd, scale)
+	case common.UseCaseK8s:
+		k8sFactory, ok := factory.(K8sGeneratorMaker)
+		if !ok {
+			return nil, fmt.Errorf(errUseCaseNotImplementedFmt, c.Use, c.Format)
+		}
+
+		return k8sFactory.NewK8s(g.tsStart, g.tsEnd, scale)
+	case common.UseCaseLogs:
+		logsFactory, ok := factory.(LogsGeneratorMaker)
+		if !ok {
+			return nil, fmt.Errorf(errUseCaseNotImplementedFmt, c.Use, c.Format)
+		}
+
+		return logsFactory.NewLogs(g.tsStart, g.tsEnd, scale)
+	case common.UseCaseSmartMeter:
+		smartMeterFactory, ok := factory.(SmartMeterGeneratorMaker)
+		if !ok {
+			return nil, fmt.Errorf(errUseCaseNotImplementedFmt, c.Use, c.Format)
+		}
+
+		return smartMeterFactory.NewSmartMeter(g.tsStart, g.tsEnd, scale)
 	default:
 		return nil, fmt.Errorf(errUnknownUseCaseFmt, c.Use)
 	}
 }
 
+// setWindowPlacement sets how the query windows of useGen are placed, when the
+// placement differs from the uniform default.
+func (g *QueryGenerator) setWindowPlacement(useGen queryUtils.QueryGenerator, c *config.QueryGeneratorConfig) error {
+	if c.WindowPlacement == "" || c.WindowPlacement == usesCommon.WindowPlacementUniform {
+		return nil
+	}
+	placer, ok := useGen.(usesCommon.WindowPlacer)
+	if !ok {
+		return fmt.Errorf(errWindowPlacementFmt, c.Format)
+	}
+	return placer.SetWindowPlacement(c.WindowPlacement)
+}
+
 func (g *QueryGenerator) runQueryGeneration(useGen queryUtils.QueryGenerator, filler queryUtils.QueryFiller, c *config.QueryGeneratorConfig) error {
 	stats := make(map[string]int64)
 	currentGroup := uint(0)
@@ -21,7 +21,13 @@ import (
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/questdb"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/siridb"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/timescaledb"
+	usesCommon "github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/common"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devops"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/devopsgeneric"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/finance"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/k8s"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/logs"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/uses/smartmeter"
 	queryUtils "github.com/timescale/tsbs/cmd/tsbs_generate_queries/utils"
 	internalUtils "github.com/timescale/tsbs/internal/utils"
 	"github.com/timescale/tsbs/pkg/data/usecases/common"
@@ -223,6 +229,21 @@ func TestGetUseCaseGenerator(t *testing.T) {
 		"devops": {
 			devops.LabelLastpoint: devops.NewLastPointPerHost,
 		},
+		common.UseCaseDevopsGeneric: {
+			devopsgeneric.LabelLastpoint: devopsgeneric.NewLastPointPerHost,
+		},
+		common.UseCaseFinance: {
+			finance.LabelLastQuote: finance.NewLastQuotePerSymbol,
+		},
+		common.UseCaseK8s: {
+			k8s.LabelRestarts: k8s.NewRestarts,
+		},
+		common.UseCaseLogs: {
+			logs.LabelMessageSearch: logs.NewMessageSearch,
+		},
+		common.UseCaseSmartMeter: {
+			smartmeter.LabelDailyConsumption: smartmeter.NewDailyConsumption,
+		},
 	}
 	const scale = 10
 	tsStart, _ := internalUtils.ParseUTCTime(defaultTimeStart)
@@ -361,7 +382,83 @@ func TestGetUseCaseGenerator(t *testing.T) {
 		t.Errorf("timescaledb UseTimeBucket not set correctly: got %v want %v", got, c.TimescaleUseTimeBucket)
 	}
 
+	// Use cases other than devops, with a format which implements them and
+	// one which does not
+	cases := []struct {
+		use         string
+		queryType   string
+		format      string
+		want        queryUtils.QueryGenerator
+		unsupported string
+	}{
+		{
+			use:         common.UseCaseDevopsGeneric,
+			queryType:   devopsgeneric.LabelLastpoint,
+			format:      constants.FormatTimescaleDB,
+			want:        &timescaledb.DevopsGeneric{},
+			unsupported: constants.FormatCassandra,
+		},
+		{
+			use:         common.UseCaseFinance,
+			queryType:   finance.LabelLastQuote,
+			format:      constants.FormatTimescaleDB,
+			want:        &timescaledb.Finance{},
+			unsupported: constants.FormatCassandra,
+		},
+		{
+			use:         common.UseCaseK8s,
+			queryType:   k8s.LabelRestarts,
+			format:      constants.FormatTimescaleDB,
+			want:        &timescaledb.K8s{},
+			unsupported: constants.FormatClickhouse,
+		},
+		{
+			use:         common.UseCaseLogs,
+			queryType:   logs.LabelMessageSearch,
+			format:      constants.FormatTimescaleDB,
+			want:        &timescaledb.Logs{},
+			unsupported: constants.FormatMongo,
+		},
+		{
+			use:         common.UseCaseSmartMeter,
+			queryType:   smartmeter.LabelDailyConsumption,
+			format:      constants.FormatTimescaleDB,
+			want:        &timescaledb.SmartMeter{},
+			unsupported: constants.FormatMongo,
+		},
+	}
+	c.MaxMetricCountPerHost = 20
+	for _, tc := range cases {
+		c.Format = tc.format
+		c.Use = tc.use
+		c.QueryType = tc.queryType
+		if err := g.init(c); err != nil {
+			t.Fatalf("%s: error initializing query generator: %s", tc.use, err)
+		}
+
+		useGen, err := g.getUseCaseGenerator(c)
+		if err != nil {
+			t.Errorf("%s: unexpected error with format '%s': %v", tc.use, tc.format, err)
+		} else if got, want := reflect.TypeOf(useGen), reflect.TypeOf(tc.want); got != want {
+			t.Errorf("%s: format '%s' does not give right use case gen: got %v want %v", tc.use, tc.format, got, want)
+		}
+		if dg, ok := useGen.(*timescaledb.DevopsGeneric); ok && dg.MaxMetricCount != 20 {
+			t.Errorf("incorrect max metric count: got %d want %d", dg.MaxMetricCount, 20)
+		}
+
+		c.Format = tc.unsupported
+		useGen, err = g.getUseCaseGenerator(c)
+		if err == nil {
+			t.Errorf("%s: unexpected lack of error for unimplemented use case", tc.use)
+		} else if got, want := err.Error(), fmt.Sprintf(errUseCaseNotImplementedFmt, c.Use, c.Format); got != want {
+			t.Errorf("incorrect error:\ngot\n%s\nwant\n%s", got, want)
+		} else if useGen != nil {
+			t.Errorf("%s: useGen was not nil", tc.use)
+		}
+	}
+
 	// Test error condition
+	c.Use = common.UseCaseDevops
 	c.Format = "bad format"
 	useGen, err := g.getUseCaseGenerator(c)
 	if err == nil {
@@ -466,6 +563,35 @@ func checkGeneratedOutput(t *testing.T, buf *bytes.Buffer) {
 	}
 }
 
+func TestQueryGeneratorSetWindowPlacement(t *testing.T) {
+	c, g := getTestConfigAndGenerator()
+	b := timescaledb.BaseGenerator{}
+	useGen, err := b.NewDevops(g.tsStart, g.tsEnd, int(c.Scale))
+	if err != nil {
+		t.Fatalf("Error creating timescaledb query generator")
+	}
+	d := useGen.(*timescaledb.Devops)
+
+	c.WindowPlacement = usesCommon.WindowPlacementLatest
+	if err := g.setWindowPlacement(useGen, c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := d.WindowPlacement; got != usesCommon.WindowPlacementLatest {
+		t.Errorf("incorrect window placement: got %s want %s", got, usesCommon.WindowPlacementLatest)
+	}
+
+	c.WindowPlacement = "foo"
+	if err := g.setWindowPlacement(useGen, c); err == nil {
+		t.Errorf("unexpected lack of error for unknown window placement")
+	}
+
+	c.WindowPlacement = usesCommon.WindowPlacementZipf
+	want := fmt.Sprintf(errWindowPlacementFmt, c.Format)
+	if err := g.setWindowPlacement(struct{ queryUtils.QueryGenerator }{useGen}, c); err == nil || err.Error() != want {
+		t.Errorf("incorrect error for generator without window placement: got %v want %s", err, want)
+	}
+}
+
 func TestQueryGeneratorRunQueryGeneration(t *testing.T) {
 	seedLine := "using random seed 123"
 	summaryLine := "TimescaleDB 1 cpu metric(s), random    1 hosts, random 1h0m0s by 1m: 3 points"
@@ -623,3 +749,57 @@ func TestQueryGeneratorGenerate(t *testing.T) {
 	}
 	checkGeneratedOutput(t, &buf)
 }
+
+func TestQueryGeneratorGenerateInvalidFiller(t *testing.T) {
+	cases := []struct {
+		desc      string
+		format    string
+		useCase   string
+		queryType string
+		maker     queryUtils.QueryFillerMaker
+		want      string
+	}{
+		{
+			// The default time range does not contain the two days of the query
+			desc:      "time range too short",
+			format:    constants.FormatTimescaleDB,
+			useCase:   common.UseCaseSmartMeter,
+			queryType: smartmeter.LabelDailyConsumption,
+			maker:     smartmeter.NewDailyConsumption,
+			want:      "cannot generate query type 'daily-consumption' for format 'timescaledb': time range does not contain 2 whole days",
+		},
+		{
+			desc:      "query not implemented",
+			format:    constants.FormatCassandra,
+			useCase:   common.UseCaseCPUOnly,
+			queryType: "top-k-hosts-10",
+			maker:     devops.NewTopKHosts(10),
+			want:      "cannot generate query type 'top-k-hosts-10' for format 'cassandra': database (*cassandra.Devops) does not implement query",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, g := getTestConfigAndGenerator()
+			c.Format = tc.format
+			c.Use = tc.useCase
+			c.QueryType = tc.queryType
+			g.useCaseMatrix = map[string]map[string]queryUtils.QueryFillerMaker{
+				tc.useCase: {tc.queryType: tc.maker},
+			}
+			var buf bytes.Buffer
+			g.Out = &buf
+			g.DebugOut = ioutil.Discard
+
+			err := g.Generate(c)
+			if err == nil {
+				t.Fatalf("unexpected lack of error")
+			}
+			if !strings.HasPrefix(err.Error(), tc.want) {
+				t.Errorf("incorrect error: got %q want it to start with %q", err.Error(), tc.want)
+			}
+			if buf.Len() > 0 {
+				t.Errorf("queries generated despite the error")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package serialize
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Utility function for appending various data types to a byte string
@@ -34,3 +35,34 @@ func FastFormatAppend(v interface{}, buf []byte) []byte {
 		panic(fmt.Sprintf("unknown field type for %#v", v))
 	}
 }
+
+// AppendQuotedString appends s to buf as a double-quoted string field value,
+// escaping the double quotes and backslashes it contains, as required by the
+// InfluxDB line protocol.
+func AppendQuotedString(s string, buf []byte) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf = append(buf, '\\')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
+
+// AppendCSVString appends s to buf as a CSV value. The value is only quoted
+// when it contains a comma, a double quote or a line break, in which case the
+// double quotes it contains are doubled.
+func AppendCSVString(s string, buf []byte) []byte {
+	if !strings.ContainsAny(s, ",\"\r\n") {
+		return append(buf, s...)
+	}
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			buf = append(buf, '"')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
@@ -90,3 +90,45 @@ func TestFastFormatAppend(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendQuotedString(t *testing.T) {
+	cases := []struct {
+		desc   string
+		input  string
+		output string
+	}{
+		{desc: "plain string", input: "hello world", output: `values,"hello world"`},
+		{desc: "string with a comma", input: "a, b", output: `values,"a, b"`},
+		{desc: "string with quotes", input: `say "hi"`, output: `values,"say \"hi\""`},
+		{desc: "string with a backslash", input: `C:\tmp`, output: `values,"C:\\tmp"`},
+		{desc: "empty string", input: "", output: `values,""`},
+	}
+
+	for _, c := range cases {
+		got := AppendQuotedString(c.input, []byte("values,"))
+		if string(got) != c.output {
+			t.Errorf("%s \nOutput incorrect: Want: %s Got: %s", c.desc, c.output, got)
+		}
+	}
+}
+
+func TestAppendCSVString(t *testing.T) {
+	cases := []struct {
+		desc   string
+		input  string
+		output string
+	}{
+		{desc: "plain string", input: "hello world", output: "values,hello world"},
+		{desc: "string with a comma", input: "a, b", output: `values,"a, b"`},
+		{desc: "string with quotes", input: `say "hi"`, output: `values,"say ""hi"""`},
+		{desc: "string with a newline", input: "a\nb", output: "values,\"a\nb\""},
+		{desc: "empty string", input: "", output: "values,"},
+	}
+
+	for _, c := range cases {
+		got := AppendCSVString(c.input, []byte("values,"))
+		if string(got) != c.output {
+			t.Errorf("%s \nOutput incorrect: Want: %s Got: %s", c.desc, c.output, got)
+		}
+	}
+}
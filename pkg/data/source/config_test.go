@@ -12,6 +12,7 @@ const (
 	errInvalidGroupsFmt = "incorrect interleaved groups configuration: id %d >= total groups %d"
 	errTotalGroupsZero  = "incorrect interleaved groups configuration: total groups = 0"
 	errLogIntervalZero  = "cannot have log interval of 0"
+	errNoSchemaFile     = "custom use case requires a schema file"
 )
 
 func TestDataGeneratorConfigValidate(t *testing.T) {
@@ -90,4 +91,18 @@ func TestDataGeneratorConfigValidate(t *testing.T) {
 			t.Errorf("incorrect error for group id > num groups: got\n%s\nwant\n%s", got, want)
 		}
 	}
+	c.InterleavedGroupID = 0
+
+	// Test schema file validation
+	c.Use = common.UseCaseCustom
+	err = c.Validate()
+	if err == nil {
+		t.Errorf("unexpected lack of error for custom use case without schema file")
+	} else if got := err.Error(); got != errNoSchemaFile {
+		t.Errorf("incorrect error for missing schema file: got\n%s\nwant\n%s", got, errNoSchemaFile)
+	}
+	c.SchemaFile = "schema.yaml"
+	if err := c.Validate(); err != nil {
+		t.Errorf("unexpected error for custom use case with schema file: %v", err)
+	}
 }
@@ -24,6 +24,11 @@ const (
 	UseCaseDevops        = "devops"
 	UseCaseIoT           = "iot"
 	UseCaseDevopsGeneric = "devops-generic"
+	UseCaseFinance       = "finance"
+	UseCaseK8s           = "k8s"
+	UseCaseLogs          = "logs"
+	UseCaseSmartMeter    = "smart-meter"
+	UseCaseCustom        = "custom"
 )
 
 var UseCaseChoices = []string{
@@ -32,4 +37,9 @@ var UseCaseChoices = []string{
 	UseCaseDevops,
 	UseCaseIoT,
 	UseCaseDevopsGeneric,
+	UseCaseFinance,
+	UseCaseK8s,
+	UseCaseLogs,
+	UseCaseSmartMeter,
+	UseCaseCustom,
 }
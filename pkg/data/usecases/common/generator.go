@@ -25,6 +25,7 @@ type Tag struct {
 const (
 	errMaxMetricCountValue = "max metric count per host has to be greater than 0"
 	errLogIntervalZero     = "cannot have log interval of 0"
+	errNoSchemaFile        = "custom use case requires a schema file"
 	defaultLogInterval     = 10 * time.Second
 )
 
@@ -40,6 +41,7 @@ type DataGeneratorConfig struct {
 	InterleavedGroupID    uint          `yaml:"interleaved-generation-group-id" mapstructure:"interleaved-generation-group-id"`
 	InterleavedNumGroups  uint          `yaml:"interleaved-generation-groups" mapstructure:"interleaved-generation-groups"`
 	MaxMetricCountPerHost uint64        `yaml:"max-metric-count" mapstructure:"max-metric-count"`
+	SchemaFile            string        `yaml:"schema-file" mapstructure:"schema-file"`
 }
 
 // Validate checks that the values of the DataGeneratorConfig are reasonable.
@@ -63,6 +65,10 @@ func (c *DataGeneratorConfig) Validate() error {
 		return fmt.Errorf(errMaxMetricCountValue)
 	}
 
+	if c.Use == UseCaseCustom && c.SchemaFile == "" {
+		return fmt.Errorf(errNoSchemaFile)
+	}
+
 	return err
 }
 
@@ -77,6 +83,7 @@ func (c *DataGeneratorConfig) AddToFlagSet(fs *pflag.FlagSet) {
 	fs.Uint("interleaved-generation-groups", 1,
 		"The number of round-robin serialization groups. Use this to scale up data generation to multiple processes.")
 	fs.Uint64("max-metric-count", 100, "Max number of metric fields to generate per host. Used only in devops-generic use-case")
+	fs.String("schema-file", "", "YAML file describing the measurements, tags and generators to simulate. Used only in custom use-case")
 }
 
 const defaultTimeStart = "2016-01-01T00:00:00Z"
@@ -3,6 +3,7 @@ package common
 import (
 	"github.com/timescale/tsbs/pkg/data"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -63,10 +64,49 @@ func (sc *BaseSimulatorConfig) NewSimulator(interval time.Duration, limit uint64
 	return sim
 }
 
+// DefaultFieldType is the type of the fields of measurements that don't
+// declare their field types.
+const DefaultFieldType = "float64"
+
 type GeneratedDataHeaders struct {
 	TagTypes  []string
 	TagKeys   []string
 	FieldKeys map[string][]string
+	// FieldTypes holds the type of each field per measurement. It is only set
+	// for measurements that have non-numeric fields.
+	FieldTypes map[string][]string
+}
+
+// FieldType returns the type of the i-th field of the measurement.
+func (h *GeneratedDataHeaders) FieldType(measurement string, i int) string {
+	types := h.FieldTypes[measurement]
+	if i >= len(types) || types[i] == "" {
+		return DefaultFieldType
+	}
+	return types[i]
+}
+
+// ParseFieldColumns splits the field columns of a data header, each either a
+// bare field name or a field name and its type separated by a space, into
+// their names and types. The returned types are nil when no column declares
+// one.
+func ParseFieldColumns(columns []string) ([]string, []string) {
+	names := make([]string, len(columns))
+	types := make([]string, len(columns))
+	typed := false
+	for i, column := range columns {
+		parts := strings.SplitN(column, " ", 2)
+		names[i] = parts[0]
+		types[i] = DefaultFieldType
+		if len(parts) == 2 {
+			types[i] = parts[1]
+			typed = true
+		}
+	}
+	if !typed {
+		return names, nil
+	}
+	return names, types
 }
 
 // Simulator simulates a use case.
@@ -188,11 +228,47 @@ func (s *BaseSimulator) TagTypes() []string {
 	return types
 }
 
+// FieldTypes returns the type of each field, extracted from the generated
+// values, for the simulated measurements that have string fields. Missing
+// (nil) values are reported with the default field type.
+func (s *BaseSimulator) FieldTypes() map[string][]string {
+	if len(s.generators) <= 0 {
+		panic("cannot get field types because no Generators added")
+	}
+
+	var toReturn map[string][]string
+	for _, sm := range s.generators[0].Measurements() {
+		point := data.NewPoint()
+		sm.ToPoint(point)
+		fieldValues := point.FieldValues()
+		types := make([]string, len(fieldValues))
+		hasString := false
+		for i, v := range fieldValues {
+			if v == nil {
+				types[i] = DefaultFieldType
+				continue
+			}
+			types[i] = reflect.TypeOf(v).String()
+			hasString = hasString || types[i] == "string"
+		}
+		if !hasString {
+			continue
+		}
+		if toReturn == nil {
+			toReturn = make(map[string][]string)
+		}
+		toReturn[string(point.MeasurementName())] = types
+	}
+
+	return toReturn
+}
+
 func (s *BaseSimulator) Headers() *GeneratedDataHeaders {
 	return &GeneratedDataHeaders{
-		TagTypes:  s.TagTypes(),
-		TagKeys:   s.TagKeys(),
-		FieldKeys: s.Fields(),
+		TagTypes:   s.TagTypes(),
+		TagKeys:    s.TagKeys(),
+		FieldKeys:  s.Fields(),
+		FieldTypes: s.FieldTypes(),
 	}
 }
 
@@ -3,6 +3,7 @@ package common
 import (
 	"fmt"
 	"github.com/timescale/tsbs/pkg/data"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -192,6 +193,52 @@ func TestBaseSimulatorFieldsPanic(t *testing.T) {
 	t.Fatalf("test should have stopped at this point")
 }
 
+type stringMeasurement struct{}
+
+func (m *stringMeasurement) Tick(time.Duration) {}
+
+func (m *stringMeasurement) ToPoint(p *data.Point) {
+	p.SetMeasurementName([]byte("events"))
+	p.AppendField([]byte("message"), "started")
+	p.AppendField([]byte("duration"), 1.5)
+	p.AppendField([]byte("missing"), nil)
+}
+
+type stringGenerator struct {
+	dummyGenerator
+}
+
+func (g stringGenerator) Measurements() []SimulatedMeasurement {
+	return []SimulatedMeasurement{&dummyMeasurement{}, &stringMeasurement{}}
+}
+
+func TestBaseSimulatorFieldTypes(t *testing.T) {
+	s := testBaseConf.NewSimulator(time.Second, 0).(*BaseSimulator)
+	if got := s.FieldTypes(); got != nil {
+		t.Errorf("unexpected field types for measurements without string fields: %v", got)
+	}
+
+	s = &BaseSimulator{generators: []Generator{stringGenerator{}}}
+	want := map[string][]string{"events": {"string", "float64", DefaultFieldType}}
+	if got := s.FieldTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("incorrect field types: got %v want %v", got, want)
+	}
+}
+
+func TestBaseSimulatorFieldTypesPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("did not panic when should")
+		}
+	}()
+
+	s := BaseSimulator{}
+	s.FieldTypes()
+
+	t.Fatalf("test should have stopped at this point")
+}
+
 func TestBaseSimulatorConfigNewSimulator(t *testing.T) {
 	duration := time.Second
 	start := time.Now()
@@ -249,3 +296,56 @@ func TestBaseSimulatorConfigNewSimulator(t *testing.T) {
 	}
 
 }
+
+func TestParseFieldColumns(t *testing.T) {
+	cases := []struct {
+		desc      string
+		columns   []string
+		wantNames []string
+		wantTypes []string
+	}{
+		{
+			desc:      "untyped columns",
+			columns:   []string{"usage_user", "usage_system"},
+			wantNames: []string{"usage_user", "usage_system"},
+		},
+		{
+			desc:      "typed columns",
+			columns:   []string{"severity string", "duration_ms float64"},
+			wantNames: []string{"severity", "duration_ms"},
+			wantTypes: []string{"string", "float64"},
+		},
+		{
+			desc:      "partially typed columns",
+			columns:   []string{"message string", "duration_ms"},
+			wantNames: []string{"message", "duration_ms"},
+			wantTypes: []string{"string", DefaultFieldType},
+		},
+	}
+
+	for _, c := range cases {
+		names, types := ParseFieldColumns(c.columns)
+		if !reflect.DeepEqual(names, c.wantNames) {
+			t.Errorf("%s: incorrect names: got %v want %v", c.desc, names, c.wantNames)
+		}
+		if !reflect.DeepEqual(types, c.wantTypes) {
+			t.Errorf("%s: incorrect types: got %v want %v", c.desc, types, c.wantTypes)
+		}
+	}
+}
+
+func TestGeneratedDataHeadersFieldType(t *testing.T) {
+	h := &GeneratedDataHeaders{
+		FieldKeys:  map[string][]string{"cpu": {"usage_user"}, "logs": {"message", "duration_ms"}},
+		FieldTypes: map[string][]string{"logs": {"string", "float64"}},
+	}
+	if got := h.FieldType("cpu", 0); got != DefaultFieldType {
+		t.Errorf("incorrect type for untyped field: got %s", got)
+	}
+	if got := h.FieldType("logs", 0); got != "string" {
+		t.Errorf("incorrect type for typed field: got %s", got)
+	}
+	if got := h.FieldType("logs", 1); got != "float64" {
+		t.Errorf("incorrect type for typed field: got %s", got)
+	}
+}
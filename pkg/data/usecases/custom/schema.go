@@ -0,0 +1,312 @@
+package custom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+	"gopkg.in/yaml.v2"
+)
+
+// Distribution types of a DistributionSpec.
+const (
+	DistributionNormal     = "normal"
+	DistributionUniform    = "uniform"
+	DistributionRandomWalk = "random-walk"
+	DistributionClamped    = "clamped"
+	DistributionMonotonic  = "monotonic"
+	DistributionConstant   = "constant"
+)
+
+const (
+	// headerTagsName is the name starting the tags line of the data header,
+	// which cannot be used as a measurement name.
+	headerTagsName = "tags"
+
+	errNoTags                 = "schema defines no tags"
+	errNoGenerators           = "schema defines no generators"
+	errNoMeasurementsFmt      = "generator %q defines no measurements"
+	errNoFields               = "no fields defined"
+	errEmptyNameFmt           = "%s name cannot be empty"
+	errDuplicateFmt           = "%s %q defined more than once"
+	errReservedMeasurementFmt = "measurement name %q is reserved"
+	errTagValuesAndCardFmt    = "tag %q cannot have both values and a cardinality"
+	errNegativeFmt            = "%s cannot be negative"
+	errUnknownDistributionFmt = "unknown distribution type %q"
+	errNoStep                 = "distribution requires a step distribution"
+	errUnexpectedStepFmt      = "%s distribution does not take a step distribution"
+	errBadRangeFmt            = "%s distribution requires %s <= %s"
+	errStartOutOfRange        = "clamped distribution requires min <= start <= max"
+	errBadPrecision           = "precision must be between 0 and 5"
+)
+
+// Schema describes the data of the custom use case, e.g.:
+//
+//	tags:
+//	  - key: device
+//	  - key: site
+//	    values: [north, south, east, west]
+//	  - key: rack
+//	    cardinality: 20
+//	generators:
+//	  - name: sensor
+//	    count: 10
+//	    measurements:
+//	      - name: climate
+//	        interval: 30s
+//	        fields:
+//	          - name: temperature
+//	            precision: 1
+//	            distribution:
+//	              type: clamped
+//	              step: {type: normal, mean: 0, stddev: 0.5}
+//	              min: -20
+//	              max: 50
+//	              start: 20
+type Schema struct {
+	// Tags are the tags of every generator, in order
+	Tags []TagSpec `yaml:"tags"`
+	// Generators are the groups of generators, each reporting its own
+	// measurements
+	Generators []GeneratorSpec `yaml:"generators"`
+}
+
+// TagSpec describes how the value of a tag is picked for each generator: from
+// Values if set, as one of Cardinality values <key>_<n> if set, and as the
+// unique <key>_<generator number> otherwise.
+type TagSpec struct {
+	Key         string   `yaml:"key"`
+	Values      []string `yaml:"values"`
+	Cardinality int      `yaml:"cardinality"`
+}
+
+// GeneratorSpec describes a group of generators, e.g. one kind of device.
+type GeneratorSpec struct {
+	Name string `yaml:"name"`
+	// Count is the number of generators of the group per unit of scale, 1 if
+	// not set
+	Count        uint64            `yaml:"count"`
+	Measurements []MeasurementSpec `yaml:"measurements"`
+}
+
+// MeasurementSpec describes a measurement reported by a generator.
+type MeasurementSpec struct {
+	Name string `yaml:"name"`
+	// Interval is the time between two points of the measurement, the log
+	// interval if not set
+	Interval time.Duration `yaml:"interval"`
+	Fields   []FieldSpec   `yaml:"fields"`
+}
+
+// FieldSpec describes a field of a measurement and the distribution of its
+// values.
+type FieldSpec struct {
+	Name         string           `yaml:"name"`
+	Distribution DistributionSpec `yaml:"distribution"`
+	// Precision is the number of decimals of the values, if set
+	Precision *int `yaml:"precision"`
+}
+
+// DistributionSpec describes one of the distributions of the common package.
+// Which of its parameters are used depends on its type:
+//
+//	normal:      mean, stddev
+//	uniform:     low, high
+//	random-walk: step, start
+//	clamped:     step, min, max, start
+//	monotonic:   step, start
+//	constant:    value
+type DistributionSpec struct {
+	Type   string            `yaml:"type"`
+	Mean   float64           `yaml:"mean"`
+	StdDev float64           `yaml:"stddev"`
+	Low    float64           `yaml:"low"`
+	High   float64           `yaml:"high"`
+	Step   *DistributionSpec `yaml:"step"`
+	Start  float64           `yaml:"start"`
+	Min    float64           `yaml:"min"`
+	Max    float64           `yaml:"max"`
+	Value  float64           `yaml:"value"`
+}
+
+// LoadSchema reads and validates the schema in the YAML file at path.
+func LoadSchema(path string) (*Schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read schema file: %v", err)
+	}
+	return ParseSchema(b)
+}
+
+// ParseSchema parses and validates the schema in the YAML document b.
+func ParseSchema(b []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, fmt.Errorf("cannot parse schema: %v", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks that the schema defines at least one tag and one
+// measurement, that all names are set and unique, and that all distributions
+// are well-formed.
+func (s *Schema) Validate() error {
+	if len(s.Tags) == 0 {
+		return fmt.Errorf(errNoTags)
+	}
+	tagKeys := map[string]bool{}
+	for _, t := range s.Tags {
+		if t.Key == "" {
+			return fmt.Errorf(errEmptyNameFmt, "tag")
+		}
+		if tagKeys[t.Key] {
+			return fmt.Errorf(errDuplicateFmt, "tag", t.Key)
+		}
+		tagKeys[t.Key] = true
+		if t.Cardinality < 0 {
+			return fmt.Errorf(errNegativeFmt, fmt.Sprintf("cardinality of tag %q", t.Key))
+		}
+		if len(t.Values) > 0 && t.Cardinality > 0 {
+			return fmt.Errorf(errTagValuesAndCardFmt, t.Key)
+		}
+	}
+
+	if len(s.Generators) == 0 {
+		return fmt.Errorf(errNoGenerators)
+	}
+	measurements := map[string]bool{}
+	for _, g := range s.Generators {
+		if len(g.Measurements) == 0 {
+			return fmt.Errorf(errNoMeasurementsFmt, g.Name)
+		}
+		for _, m := range g.Measurements {
+			if m.Name == "" {
+				return fmt.Errorf(errEmptyNameFmt, "measurement")
+			}
+			if m.Name == headerTagsName {
+				return fmt.Errorf(errReservedMeasurementFmt, m.Name)
+			}
+			if measurements[m.Name] {
+				return fmt.Errorf(errDuplicateFmt, "measurement", m.Name)
+			}
+			measurements[m.Name] = true
+			if err := m.validate(); err != nil {
+				return fmt.Errorf("measurement %q: %v", m.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MeasurementSpec) validate() error {
+	if m.Interval < 0 {
+		return fmt.Errorf(errNegativeFmt, "interval")
+	}
+	if len(m.Fields) == 0 {
+		return fmt.Errorf(errNoFields)
+	}
+	fields := map[string]bool{}
+	for _, f := range m.Fields {
+		if f.Name == "" {
+			return fmt.Errorf(errEmptyNameFmt, "field")
+		}
+		if fields[f.Name] {
+			return fmt.Errorf(errDuplicateFmt, "field", f.Name)
+		}
+		fields[f.Name] = true
+		if f.Precision != nil && (*f.Precision < 0 || *f.Precision > 5) {
+			return fmt.Errorf("field %q: %s", f.Name, errBadPrecision)
+		}
+		if err := f.Distribution.validate(); err != nil {
+			return fmt.Errorf("field %q: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (d *DistributionSpec) validate() error {
+	switch d.Type {
+	case DistributionRandomWalk, DistributionClamped, DistributionMonotonic:
+		if d.Step == nil {
+			return fmt.Errorf(errNoStep)
+		}
+		if err := d.Step.validate(); err != nil {
+			return fmt.Errorf("step: %v", err)
+		}
+	case DistributionNormal, DistributionUniform, DistributionConstant:
+		if d.Step != nil {
+			return fmt.Errorf(errUnexpectedStepFmt, d.Type)
+		}
+	default:
+		return fmt.Errorf(errUnknownDistributionFmt, d.Type)
+	}
+
+	switch d.Type {
+	case DistributionNormal:
+		if d.StdDev < 0 {
+			return fmt.Errorf(errNegativeFmt, "stddev")
+		}
+	case DistributionUniform:
+		if d.Low > d.High {
+			return fmt.Errorf(errBadRangeFmt, d.Type, "low", "high")
+		}
+	case DistributionClamped:
+		if d.Min > d.Max {
+			return fmt.Errorf(errBadRangeFmt, d.Type, "min", "max")
+		}
+		if d.Start < d.Min || d.Start > d.Max {
+			return fmt.Errorf(errStartOutOfRange)
+		}
+	}
+	return nil
+}
+
+// new creates a distribution as described by the spec, which must be valid.
+// The stateless distributions are advanced once, so their first value is
+// drawn from them as well.
+func (d *DistributionSpec) new() common.Distribution {
+	switch d.Type {
+	case DistributionNormal:
+		nd := common.ND(d.Mean, d.StdDev)
+		nd.Advance()
+		return nd
+	case DistributionUniform:
+		ud := common.UD(d.Low, d.High)
+		ud.Advance()
+		return ud
+	case DistributionRandomWalk:
+		return common.WD(d.Step.new(), d.Start)
+	case DistributionClamped:
+		return common.CWD(d.Step.new(), d.Min, d.Max, d.Start)
+	case DistributionMonotonic:
+		return common.MWD(d.Step.new(), d.Start)
+	case DistributionConstant:
+		return &common.ConstantDistribution{State: d.Value}
+	default:
+		panic(fmt.Sprintf(errUnknownDistributionFmt, d.Type))
+	}
+}
+
+// distributionMakers returns the labeled distribution makers of the fields of
+// the measurement.
+func (m *MeasurementSpec) distributionMakers() []common.LabeledDistributionMaker {
+	makers := make([]common.LabeledDistributionMaker, len(m.Fields))
+	for i := range m.Fields {
+		f := m.Fields[i]
+		makers[i] = common.LabeledDistributionMaker{
+			Label: []byte(f.Name),
+			DistributionMaker: func() common.Distribution {
+				d := f.Distribution.new()
+				if f.Precision != nil {
+					return common.FP(d, *f.Precision)
+				}
+				return d
+			},
+		}
+	}
+	return makers
+}
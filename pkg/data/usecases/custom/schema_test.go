@@ -0,0 +1,208 @@
+package custom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const testSchema = `
+tags:
+  - key: device
+  - key: site
+    values: [north, south]
+  - key: rack
+    cardinality: 3
+generators:
+  - name: sensor
+    count: 2
+    measurements:
+      - name: climate
+        interval: 30s
+        fields:
+          - name: temperature
+            precision: 1
+            distribution:
+              type: clamped
+              step: {type: normal, mean: 0, stddev: 0.5}
+              min: -20
+              max: 50
+              start: 20
+          - name: humidity
+            distribution: {type: uniform, low: 0, high: 100}
+  - name: gateway
+    measurements:
+      - name: traffic
+        fields:
+          - name: bytes
+            distribution:
+              type: monotonic
+              step: {type: uniform, low: 0, high: 1000}
+          - name: firmware
+            distribution: {type: constant, value: 3}
+          - name: load
+            distribution:
+              type: random-walk
+              step: {type: normal, mean: 0, stddev: 1}
+              start: 10
+`
+
+func TestParseSchema(t *testing.T) {
+	s, err := ParseSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(s.Tags); got != 3 {
+		t.Errorf("incorrect number of tags: got %d", got)
+	}
+	if got := s.Generators[0].Measurements[0].Interval; got != 30*time.Second {
+		t.Errorf("incorrect interval: got %v", got)
+	}
+	if got := s.Generators[1].Measurements[0].Interval; got != 0 {
+		t.Errorf("incorrect default interval: got %v", got)
+	}
+	if got := s.Generators[0].Measurements[0].Fields[0].Distribution.Step.StdDev; got != 0.5 {
+		t.Errorf("incorrect step stddev: got %f", got)
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	const measurement = `
+generators:
+  - measurements:
+      - name: m
+        fields:
+          - name: f
+            distribution: %s
+`
+	withTags := func(s string) string {
+		return "tags:\n  - key: id\n" + s
+	}
+	withDistribution := func(d string) string {
+		return withTags(strings.Replace(measurement, "%s", d, 1))
+	}
+	valid := strings.Replace(measurement, "%s", "{type: constant}", 1)
+	cases := []struct {
+		desc   string
+		schema string
+		want   string
+	}{
+		{desc: "unknown key", schema: withTags("bogus: 1\n"), want: "cannot parse schema"},
+		{desc: "no tags", schema: valid, want: errNoTags},
+		{desc: "no generators", schema: withTags(""), want: errNoGenerators},
+		{desc: "empty tag key", schema: "tags:\n  - values: [a]\n", want: "tag name cannot be empty"},
+		{desc: "duplicate tag", schema: "tags:\n  - key: a\n  - key: a\n", want: `tag "a" defined more than once`},
+		{desc: "values and cardinality", schema: "tags:\n  - key: a\n    values: [x]\n    cardinality: 2\n", want: "cannot have both values and a cardinality"},
+		{desc: "negative cardinality", schema: "tags:\n  - key: a\n    cardinality: -1\n", want: "cannot be negative"},
+		{desc: "no measurements", schema: withTags("generators:\n  - name: g\n"), want: `generator "g" defines no measurements`},
+		{desc: "no fields", schema: withTags("generators:\n  - measurements:\n      - name: m\n"), want: errNoFields},
+		{desc: "reserved measurement", schema: withTags(strings.Replace(valid, "name: m", "name: tags", 1)), want: "is reserved"},
+		{desc: "negative interval", schema: withTags(strings.Replace(valid, "name: m", "name: m\n        interval: -1s", 1)), want: "interval cannot be negative"},
+		{desc: "bad precision", schema: withDistribution("{type: constant}\n            precision: 6"), want: errBadPrecision},
+		{desc: "unknown distribution", schema: withDistribution("{type: poisson}"), want: `unknown distribution type "poisson"`},
+		{desc: "walk without step", schema: withDistribution("{type: random-walk}"), want: errNoStep},
+		{desc: "bad step", schema: withDistribution("{type: monotonic, step: {type: normal, stddev: -1}}"), want: "step: stddev cannot be negative"},
+		{desc: "unexpected step", schema: withDistribution("{type: constant, step: {type: constant}}"), want: "does not take a step"},
+		{desc: "bad uniform range", schema: withDistribution("{type: uniform, low: 2, high: 1}"), want: "requires low <= high"},
+		{desc: "bad clamped range", schema: withDistribution("{type: clamped, step: {type: constant}, min: 2, max: 1}"), want: "requires min <= max"},
+		{desc: "clamped start", schema: withDistribution("{type: clamped, step: {type: constant}, min: 1, max: 2}"), want: errStartOutOfRange},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := ParseSchema([]byte(c.schema))
+			if err == nil {
+				t.Fatalf("unexpected lack of error")
+			}
+			if !strings.Contains(err.Error(), c.want) {
+				t.Errorf("incorrect error: got %q want it to contain %q", err.Error(), c.want)
+			}
+		})
+	}
+
+	dup := withTags(valid + "  - measurements:\n      - name: m\n        fields:\n          - name: f\n            distribution: {type: constant}\n")
+	if _, err := ParseSchema([]byte(dup)); err == nil || !strings.Contains(err.Error(), `measurement "m" defined more than once`) {
+		t.Errorf("incorrect error for duplicate measurement: %v", err)
+	}
+}
+
+func TestLoadSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "schema.yaml")
+	if err := ioutil.WriteFile(path, []byte(testSchema), 0644); err != nil {
+		t.Fatalf("could not write schema: %v", err)
+	}
+	if _, err := LoadSchema(path); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := LoadSchema(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("unexpected lack of error for missing file")
+	}
+}
+
+func TestDistributionSpecNew(t *testing.T) {
+	normal := &DistributionSpec{Type: DistributionNormal, Mean: 5}
+	cases := []struct {
+		spec *DistributionSpec
+		want common.Distribution
+	}{
+		{spec: normal, want: &common.NormalDistribution{}},
+		{spec: &DistributionSpec{Type: DistributionUniform, Low: 1, High: 1}, want: &common.UniformDistribution{}},
+		{spec: &DistributionSpec{Type: DistributionRandomWalk, Step: normal}, want: &common.RandomWalkDistribution{}},
+		{spec: &DistributionSpec{Type: DistributionClamped, Step: normal, Max: 1}, want: &common.ClampedRandomWalkDistribution{}},
+		{spec: &DistributionSpec{Type: DistributionMonotonic, Step: normal}, want: &common.MonotonicRandomWalkDistribution{}},
+		{spec: &DistributionSpec{Type: DistributionConstant, Value: 3}, want: &common.ConstantDistribution{}},
+	}
+	for _, c := range cases {
+		d := c.spec.new()
+		if got, want := fmt.Sprintf("%T", d), fmt.Sprintf("%T", c.want); got != want {
+			t.Errorf("incorrect distribution for %s: got %s want %s", c.spec.Type, got, want)
+		}
+	}
+
+	// Stateless distributions start with a drawn value
+	if got := (&DistributionSpec{Type: DistributionNormal, Mean: 5}).new().Get(); got != 5 {
+		t.Errorf("normal distribution not advanced: got %f", got)
+	}
+	if got := (&DistributionSpec{Type: DistributionUniform, Low: 2, High: 2}).new().Get(); got != 2 {
+		t.Errorf("uniform distribution not advanced: got %f", got)
+	}
+	if got := (&DistributionSpec{Type: DistributionConstant, Value: 3}).new().Get(); got != 3 {
+		t.Errorf("incorrect constant: got %f", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("did not panic for unknown distribution")
+		}
+	}()
+	(&DistributionSpec{Type: "bogus"}).new()
+}
+
+func TestDistributionMakersPrecision(t *testing.T) {
+	precision := 1
+	m := &MeasurementSpec{Fields: []FieldSpec{
+		{Name: "a", Distribution: DistributionSpec{Type: DistributionConstant, Value: 1.234}, Precision: &precision},
+		{Name: "b", Distribution: DistributionSpec{Type: DistributionConstant, Value: 1.234}},
+	}}
+	makers := m.distributionMakers()
+	if got := string(makers[0].Label); got != "a" {
+		t.Errorf("incorrect label: got %s", got)
+	}
+	if got := makers[0].DistributionMaker().Get(); got != 1.2 {
+		t.Errorf("incorrect value with precision: got %f", got)
+	}
+	if got := makers[1].DistributionMaker().Get(); got != 1.234 {
+		t.Errorf("incorrect value without precision: got %f", got)
+	}
+}
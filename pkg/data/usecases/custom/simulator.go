@@ -0,0 +1,198 @@
+package custom
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const tagValueFmt = "%s_%d"
+
+// SimulatorConfig is used to create a custom Simulator.
+// It fulfills the common.SimulatorConfig interface.
+type SimulatorConfig struct {
+	// Start is the beginning time for the Simulator
+	Start time.Time
+	// End is the ending time for the Simulator
+	End time.Time
+	// Scale multiplies the generator counts of the schema
+	Scale uint64
+	// Schema describes the generated data
+	Schema *Schema
+}
+
+// series is a measurement of a generator.
+type series struct {
+	index    int
+	tags     []common.Tag
+	name     []byte
+	interval time.Duration
+	labels   []common.LabeledDistributionMaker
+	*common.SubsystemMeasurement
+}
+
+// seriesQueue orders series by the time of their next point.
+// It implements heap.Interface.
+type seriesQueue []*series
+
+func (q seriesQueue) Len() int { return len(q) }
+
+func (q seriesQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.index < b.index
+}
+
+func (q seriesQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *seriesQueue) Push(x interface{}) { *q = append(*q, x.(*series)) }
+
+func (q *seriesQueue) Pop() interface{} {
+	old := *q
+	s := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return s
+}
+
+// Simulator generates the data described by a Schema. Since every
+// measurement has its own interval, it emits the points of all series in
+// time order rather than ticking all of them every log interval.
+type Simulator struct {
+	madePoints uint64
+	maxPoints  uint64
+
+	schema *Schema
+	queue  seriesQueue
+	end    time.Time
+}
+
+// NewSimulator produces a custom Simulator with the given config over the
+// specified interval and points limit. The interval is used by the
+// measurements which do not set their own.
+func (c *SimulatorConfig) NewSimulator(interval time.Duration, limit uint64) common.Simulator {
+	var q seriesQueue
+	generator := 0
+	for _, g := range c.Schema.Generators {
+		count := g.Count
+		if count == 0 {
+			count = 1
+		}
+		for i := uint64(0); i < count*c.Scale; i++ {
+			tags := c.Schema.newTags(generator)
+			for j := range g.Measurements {
+				m := &g.Measurements[j]
+				s := &series{
+					index:    len(q),
+					tags:     tags,
+					name:     []byte(m.Name),
+					interval: m.Interval,
+					labels:   m.distributionMakers(),
+				}
+				if s.interval == 0 {
+					s.interval = interval
+				}
+				s.SubsystemMeasurement = common.NewSubsystemMeasurementWithDistributionMakers(c.Start, s.labels)
+				q = append(q, s)
+			}
+			generator++
+		}
+	}
+	heap.Init(&q)
+
+	return &Simulator{
+		maxPoints: limit,
+		schema:    c.Schema,
+		queue:     q,
+		end:       c.End,
+	}
+}
+
+// newTags picks the tag values of the i-th generator.
+func (s *Schema) newTags(i int) []common.Tag {
+	tags := make([]common.Tag, len(s.Tags))
+	for j, t := range s.Tags {
+		var value string
+		switch {
+		case len(t.Values) > 0:
+			value = common.RandomStringSliceChoice(t.Values)
+		case t.Cardinality > 0:
+			value = fmt.Sprintf(tagValueFmt, t.Key, rand.Intn(t.Cardinality))
+		default:
+			value = fmt.Sprintf(tagValueFmt, t.Key, i)
+		}
+		tags[j] = common.Tag{Key: []byte(t.Key), Value: value}
+	}
+	return tags
+}
+
+// Finished tells whether we have simulated all the necessary points, either
+// reaching the points limit or the end of the simulation.
+func (s *Simulator) Finished() bool {
+	if s.maxPoints > 0 && s.madePoints >= s.maxPoints {
+		return true
+	}
+	return len(s.queue) == 0 || !s.queue[0].Timestamp.Before(s.end)
+}
+
+// Next advances a Point to the next point in time of any series.
+func (s *Simulator) Next(p *data.Point) bool {
+	ser := s.queue[0]
+	for _, tag := range ser.tags {
+		p.AppendTag(tag.Key, tag.Value)
+	}
+	ser.ToPoint(p, ser.name, ser.labels)
+	// ToPoint references the timestamp of the series, which is advanced below
+	ts := ser.Timestamp
+	p.SetTimestamp(&ts)
+	ser.Tick(ser.interval)
+	heap.Fix(&s.queue, 0)
+	s.madePoints++
+	return true
+}
+
+// Fields returns the fields of every measurement of the schema.
+func (s *Simulator) Fields() map[string][]string {
+	fields := map[string][]string{}
+	for _, g := range s.schema.Generators {
+		for _, m := range g.Measurements {
+			names := make([]string, len(m.Fields))
+			for i, f := range m.Fields {
+				names[i] = f.Name
+			}
+			fields[m.Name] = names
+		}
+	}
+	return fields
+}
+
+// TagKeys returns the tag keys of the schema.
+func (s *Simulator) TagKeys() []string {
+	keys := make([]string, len(s.schema.Tags))
+	for i, t := range s.schema.Tags {
+		keys[i] = t.Key
+	}
+	return keys
+}
+
+// TagTypes returns the type for each tag, all of them being strings.
+func (s *Simulator) TagTypes() []string {
+	types := make([]string, len(s.schema.Tags))
+	for i := range types {
+		types[i] = "string"
+	}
+	return types
+}
+
+func (s *Simulator) Headers() *common.GeneratedDataHeaders {
+	return &common.GeneratedDataHeaders{
+		TagTypes:  s.TagTypes(),
+		TagKeys:   s.TagKeys(),
+		FieldKeys: s.Fields(),
+	}
+}
@@ -0,0 +1,136 @@
+package custom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func newTestSimulator(t *testing.T, scale, limit uint64) *Simulator {
+	s, err := ParseSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), Scale: scale, Schema: s}
+	return c.NewSimulator(10*time.Second, limit).(*Simulator)
+}
+
+func TestSimulatorNext(t *testing.T) {
+	s := newTestSimulator(t, 3, 0)
+	// 2 sensors and 1 gateway per unit of scale
+	if got := len(s.queue); got != 9 {
+		t.Fatalf("incorrect number of series: got %d want 9", got)
+	}
+
+	counts := map[string]int{}
+	devices := map[string]bool{}
+	var last time.Time
+	for !s.Finished() {
+		p := data.NewPoint()
+		if !s.Next(p) {
+			t.Fatalf("point not written")
+		}
+		ts := *p.Timestamp()
+		if ts.Before(last) {
+			t.Fatalf("point out of time order: %v after %v", ts, last)
+		}
+		last = ts
+		counts[string(p.MeasurementName())]++
+		devices[p.GetTagValue([]byte("device")).(string)] = true
+
+		if got := len(p.TagKeys()); got != 3 {
+			t.Fatalf("incorrect number of tags: got %d", got)
+		}
+		if site := p.GetTagValue([]byte("site")); site != "north" && site != "south" {
+			t.Fatalf("incorrect site: %v", site)
+		}
+		if rack := p.GetTagValue([]byte("rack")).(string); rack != "rack_0" && rack != "rack_1" && rack != "rack_2" {
+			t.Fatalf("incorrect rack: %v", rack)
+		}
+		if p.GetTagValue([]byte("device")) == "device_0" && string(p.MeasurementName()) != "climate" {
+			t.Fatalf("incorrect measurement of the first sensor: %s", p.MeasurementName())
+		}
+	}
+
+	// 6 sensors every 30s, 3 gateways every log interval of 10s
+	if got := counts["climate"]; got != 6*120 {
+		t.Errorf("incorrect number of climate points: got %d want %d", got, 6*120)
+	}
+	if got := counts["traffic"]; got != 3*360 {
+		t.Errorf("incorrect number of traffic points: got %d want %d", got, 3*360)
+	}
+	if len(devices) != 9 {
+		t.Errorf("incorrect number of devices: got %d want 9", len(devices))
+	}
+}
+
+func TestSimulatorNextValues(t *testing.T) {
+	s := newTestSimulator(t, 1, 0)
+	var prevBytes float64
+	for !s.Finished() {
+		p := data.NewPoint()
+		s.Next(p)
+		switch string(p.MeasurementName()) {
+		case "climate":
+			temp := p.GetFieldValue([]byte("temperature")).(float64)
+			if temp < -20 || temp > 50 {
+				t.Fatalf("temperature out of bounds: %f", temp)
+			}
+		case "traffic":
+			bytes := p.GetFieldValue([]byte("bytes")).(float64)
+			if bytes < prevBytes {
+				t.Fatalf("monotonic field decreased: %f after %f", bytes, prevBytes)
+			}
+			prevBytes = bytes
+			if got := p.GetFieldValue([]byte("firmware")); got != 3.0 {
+				t.Fatalf("incorrect constant field: got %v", got)
+			}
+		}
+	}
+}
+
+func TestSimulatorHeaders(t *testing.T) {
+	h := newTestSimulator(t, 1, 0).Headers()
+	wantTags := []string{"device", "site", "rack"}
+	if len(h.TagKeys) != len(wantTags) {
+		t.Fatalf("incorrect tag keys: got %v want %v", h.TagKeys, wantTags)
+	}
+	for i, k := range wantTags {
+		if h.TagKeys[i] != k || h.TagTypes[i] != "string" {
+			t.Errorf("incorrect tag %d: got %s %s want %s string", i, h.TagKeys[i], h.TagTypes[i], k)
+		}
+	}
+	wantFields := map[string][]string{
+		"climate": {"temperature", "humidity"},
+		"traffic": {"bytes", "firmware", "load"},
+	}
+	if len(h.FieldKeys) != len(wantFields) {
+		t.Fatalf("incorrect measurements: got %v", h.FieldKeys)
+	}
+	for m, fields := range wantFields {
+		got := h.FieldKeys[m]
+		if len(got) != len(fields) {
+			t.Fatalf("incorrect fields of %s: got %v want %v", m, got, fields)
+		}
+		for i := range fields {
+			if got[i] != fields[i] {
+				t.Errorf("incorrect field %d of %s: got %s want %s", i, m, got[i], fields[i])
+			}
+		}
+	}
+}
+
+func TestSimulatorLimit(t *testing.T) {
+	s := newTestSimulator(t, 1, 5)
+	for i := 0; i < 5; i++ {
+		if s.Finished() {
+			t.Fatalf("finished after %d points", i)
+		}
+		s.Next(data.NewPoint())
+	}
+	if !s.Finished() {
+		t.Errorf("not finished after the limit")
+	}
+}
@@ -253,3 +253,12 @@ func getStringRandomInt(limit int64) string {
 func randomRegionSliceChoice(s []region) *region {
 	return &s[rand.Intn(len(s))]
 }
+
+// RegionNames returns the names of the regions hosts are spread across.
+func RegionNames() []string {
+	names := make([]string, len(regions))
+	for i, r := range regions {
+		names[i] = r.Name
+	}
+	return names
+}
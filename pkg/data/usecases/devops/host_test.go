@@ -284,3 +284,15 @@ func TestRandomRegionSliceChoice(t *testing.T) {
 		testIfInRegionSlice(t, regions, r)
 	}
 }
+
+func TestRegionNames(t *testing.T) {
+	names := RegionNames()
+	if got := len(names); got != len(regions) {
+		t.Fatalf("incorrect number of region names: got %d want %d", got, len(regions))
+	}
+	for i, name := range names {
+		if name != regions[i].Name {
+			t.Errorf("incorrect region name at %d: got %s want %s", i, name, regions[i].Name)
+		}
+	}
+}
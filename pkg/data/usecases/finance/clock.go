@@ -0,0 +1,32 @@
+package finance
+
+import (
+	"math/rand"
+	"time"
+)
+
+// eventClock keeps the time of the latest event of a measurement. Events are
+// placed at a random offset within each reporting interval, so consecutive
+// events of a symbol are irregularly spaced with sub-second precision.
+type eventClock struct {
+	interval time.Time
+	offset   time.Duration
+}
+
+func newEventClock(start time.Time) eventClock {
+	return eventClock{interval: start}
+}
+
+// tick moves the clock to a random point of the next interval of length d.
+func (c *eventClock) tick(d time.Duration) {
+	c.interval = c.interval.Add(d)
+	c.offset = 0
+	if d > 0 {
+		c.offset = time.Duration(rand.Int63n(int64(d)))
+	}
+}
+
+// time returns the time of the latest event.
+func (c *eventClock) time() time.Time {
+	return c.interval.Add(c.offset)
+}
@@ -0,0 +1,29 @@
+package finance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	c := newEventClock(start)
+	if got := c.time(); got != start {
+		t.Errorf("incorrect time before tick: got %v want %v", got, start)
+	}
+
+	interval := start
+	for i := 0; i < 1000; i++ {
+		c.tick(time.Second)
+		interval = interval.Add(time.Second)
+		got := c.time()
+		if got.Before(interval) || !got.Before(interval.Add(time.Second)) {
+			t.Fatalf("time %v not within interval starting %v", got, interval)
+		}
+	}
+
+	c.tick(0)
+	if got := c.time(); got != interval {
+		t.Errorf("incorrect time after empty tick: got %v want %v", got, interval)
+	}
+}
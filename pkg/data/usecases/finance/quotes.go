@@ -0,0 +1,84 @@
+package finance
+
+import (
+	"math"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const (
+	quoteMedianSize       = 200
+	quoteBurstProbability = 0.005
+
+	minSpread = 0.01
+	maxSpread = 0.5
+)
+
+var (
+	labelQuotes   = []byte("quotes")
+	labelBidPrice = []byte("bid_price")
+	labelBidSize  = []byte("bid_size")
+	labelAskPrice = []byte("ask_price")
+	labelAskSize  = []byte("ask_size")
+
+	spreadStepUD = common.UD(-0.005, 0.005)
+)
+
+// QuotesMeasurement represents the best bid and ask quoted for a symbol.
+// Quotes are made around the latest trade price.
+type QuotesMeasurement struct {
+	clock   eventClock
+	price   common.Distribution
+	spread  common.Distribution
+	bidSize common.Distribution
+	askSize common.Distribution
+}
+
+// NewQuotesMeasurement creates a new QuotesMeasurement with start time,
+// quoting around the given price distribution.
+func NewQuotesMeasurement(start time.Time, price common.Distribution) *QuotesMeasurement {
+	return &QuotesMeasurement{
+		clock:   newEventClock(start),
+		price:   price,
+		spread:  common.CWD(spreadStepUD, minSpread, maxSpread, minSpread*2),
+		bidSize: VD(quoteMedianSize, quoteBurstProbability),
+		askSize: VD(quoteMedianSize, quoteBurstProbability),
+	}
+}
+
+// Tick advances the quote time, spread and sizes. The price is advanced by
+// the trades of the symbol.
+func (m *QuotesMeasurement) Tick(d time.Duration) {
+	m.clock.tick(d)
+	m.spread.Advance()
+	m.bidSize.Advance()
+	m.askSize.Advance()
+}
+
+// ToPoint serializes QuotesMeasurement to data.Point.
+func (m *QuotesMeasurement) ToPoint(p *data.Point) {
+	p.SetMeasurementName(labelQuotes)
+	ts := m.clock.time()
+	p.SetTimestamp(&ts)
+
+	bid, ask := m.prices()
+	p.AppendField(labelBidPrice, bid)
+	p.AppendField(labelBidSize, int64(m.bidSize.Get()))
+	p.AppendField(labelAskPrice, ask)
+	p.AppendField(labelAskSize, int64(m.askSize.Get()))
+}
+
+// prices returns the bid and ask prices, rounded to cents away from the
+// price so that the ask is always above the bid.
+func (m *QuotesMeasurement) prices() (float64, float64) {
+	price := currentPrice(m.price)
+	half := m.spread.Get() / 2
+	bid := math.Max(math.Floor((price-half)*100)/100, minPrice)
+	ask := math.Ceil((price+half)*100) / 100
+	if ask <= bid {
+		ask = bid + minSpread
+	}
+	return bid, ask
+}
@@ -0,0 +1,51 @@
+package finance
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+func TestQuotesMeasurementToPoint(t *testing.T) {
+	rand.Seed(123)
+	now := time.Now()
+	price := newPriceDistribution()
+	m := NewQuotesMeasurement(now, price)
+
+	for i := 0; i < 1000; i++ {
+		price.Advance()
+		m.Tick(time.Second)
+
+		p := data.NewPoint()
+		m.ToPoint(p)
+		if got := string(p.MeasurementName()); got != string(labelQuotes) {
+			t.Fatalf("incorrect measurement name: got %s want %s", got, labelQuotes)
+		}
+
+		bid := p.GetFieldValue(labelBidPrice).(float64)
+		ask := p.GetFieldValue(labelAskPrice).(float64)
+		if bid < minPrice || ask <= bid {
+			t.Fatalf("invalid quote: bid %f ask %f", bid, ask)
+		}
+		if mid := currentPrice(price); bid > mid || ask < mid {
+			t.Fatalf("quote bid %f ask %f does not surround price %f", bid, ask, mid)
+		}
+		for _, label := range [][]byte{labelBidSize, labelAskSize} {
+			if got := p.GetFieldValue(label).(int64); got < 1 {
+				t.Fatalf("%s not positive: got %d", label, got)
+			}
+		}
+	}
+}
+
+func TestQuotesMeasurementTickKeepsPrice(t *testing.T) {
+	price := common.WD(common.UD(1, 1), 10)
+	m := NewQuotesMeasurement(time.Now(), price)
+	m.Tick(time.Second)
+	if got := price.Get(); got != 10 {
+		t.Errorf("quotes advanced the price: got %f want %f", got, 10.0)
+	}
+}
@@ -0,0 +1,17 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+// SimulatorConfig is used to create a finance Simulator.
+// It fulfills the common.SimulatorConfig interface.
+type SimulatorConfig common.BaseSimulatorConfig
+
+// NewSimulator produces a finance Simulator with the given
+// config over the specified interval and points limit.
+func (sc *SimulatorConfig) NewSimulator(interval time.Duration, limit uint64) common.Simulator {
+	return (*common.BaseSimulatorConfig)(sc).NewSimulator(interval, limit)
+}
@@ -0,0 +1,97 @@
+package finance
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const (
+	symbolNameFmt = "symbol_%d"
+
+	minStartPrice = 5.0
+	maxStartPrice = 500.0
+	// priceVolatility is the standard deviation of a price step relative to
+	// the starting price of a symbol.
+	priceVolatility = 0.0002
+	// minPrice is the lowest price a symbol can be traded or quoted at.
+	minPrice = 0.01
+)
+
+var (
+	// ExchangeChoices contains all the exchange name values for the finance use case
+	ExchangeChoices = []string{
+		"NYSE",
+		"NASDAQ",
+		"LSE",
+		"TSE",
+	}
+
+	sectorChoices = []string{
+		"technology",
+		"financials",
+		"energy",
+		"healthcare",
+		"industrials",
+		"utilities",
+	}
+)
+
+// Symbol models a traded instrument which reports its trades and quotes.
+type Symbol struct {
+	simulatedMeasurements []common.SimulatedMeasurement
+	tags                  []common.Tag
+}
+
+// TickAll advances all Distributions of a Symbol.
+func (s *Symbol) TickAll(d time.Duration) {
+	for i := range s.simulatedMeasurements {
+		s.simulatedMeasurements[i].Tick(d)
+	}
+}
+
+// Measurements returns the symbol measurements.
+func (s Symbol) Measurements() []common.SimulatedMeasurement {
+	return s.simulatedMeasurements
+}
+
+// Tags returns the symbol tags.
+func (s Symbol) Tags() []common.Tag {
+	return s.tags
+}
+
+// newPriceDistribution returns a random walk around a random starting price,
+// rounded to cents.
+func newPriceDistribution() common.Distribution {
+	start := minStartPrice + rand.Float64()*(maxStartPrice-minStartPrice)
+	return common.FP(common.WD(common.ND(0, start*priceVolatility), start), 2)
+}
+
+// newSymbolMeasurements returns the measurements of a symbol. The price is
+// shared: trades advance it and quotes are made around its latest value.
+func newSymbolMeasurements(start time.Time) []common.SimulatedMeasurement {
+	price := newPriceDistribution()
+	return []common.SimulatedMeasurement{
+		NewTradesMeasurement(start, price),
+		NewQuotesMeasurement(start, price),
+	}
+}
+
+// NewSymbol creates a new symbol in a simulated finance use case
+func NewSymbol(i int, start time.Time) common.Generator {
+	symbol := newSymbolWithMeasurementGenerator(i, start, newSymbolMeasurements)
+	return &symbol
+}
+
+func newSymbolWithMeasurementGenerator(i int, start time.Time, generator func(time.Time) []common.SimulatedMeasurement) Symbol {
+	return Symbol{
+		tags: []common.Tag{
+			{Key: []byte("symbol"), Value: fmt.Sprintf(symbolNameFmt, i)},
+			{Key: []byte("exchange"), Value: common.RandomStringSliceChoice(ExchangeChoices)},
+			{Key: []byte("sector"), Value: common.RandomStringSliceChoice(sectorChoices)},
+		},
+		simulatedMeasurements: generator(start),
+	}
+}
@@ -0,0 +1,76 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+func testGenerator(s time.Time) []common.SimulatedMeasurement {
+	return []common.SimulatedMeasurement{
+		&testMeasurement{ticks: 0},
+	}
+}
+
+type testMeasurement struct {
+	ticks int
+}
+
+func (m *testMeasurement) Tick(_ time.Duration)  { m.ticks++ }
+func (m *testMeasurement) ToPoint(_ *data.Point) {}
+
+func TestNewSymbolMeasurements(t *testing.T) {
+	start := time.Now()
+
+	measurements := newSymbolMeasurements(start)
+
+	if got := len(measurements); got != 2 {
+		t.Errorf("incorrect number of measurements: got %d want %d", got, 2)
+	}
+
+	// Cast each measurement to its type; will panic if wrong types
+	trades := measurements[0].(*TradesMeasurement)
+	quotes := measurements[1].(*QuotesMeasurement)
+	if trades.price != quotes.price {
+		t.Errorf("trades and quotes do not share the price distribution")
+	}
+	if got := currentPrice(trades.price); got < minStartPrice || got > maxStartPrice {
+		t.Errorf("start price out of range: got %f", got)
+	}
+}
+
+func TestNewSymbol(t *testing.T) {
+	start := time.Now()
+	generator := NewSymbol(1, start)
+
+	symbol := generator.(*Symbol)
+
+	if got := len(symbol.Measurements()); got != 2 {
+		t.Errorf("incorrect symbol measurement count: got %v want %v", got, 2)
+	}
+
+	tags := symbol.Tags()
+	if got := len(tags); got != 3 {
+		t.Errorf("incorrect symbol tag count: got %v want %v", got, 3)
+	}
+	if got := string(tags[0].Key); got != "symbol" {
+		t.Errorf("incorrect first tag key: got %s want %s", got, "symbol")
+	}
+	if got := tags[0].Value; got != "symbol_1" {
+		t.Errorf("incorrect symbol name: got %v want %v", got, "symbol_1")
+	}
+}
+
+func TestSymbolTickAll(t *testing.T) {
+	now := time.Now()
+	symbol := newSymbolWithMeasurementGenerator(0, now, testGenerator)
+	if got := symbol.simulatedMeasurements[0].(*testMeasurement).ticks; got != 0 {
+		t.Errorf("ticks not equal to 0 to start: got %d", got)
+	}
+	symbol.TickAll(time.Second)
+	if got := symbol.simulatedMeasurements[0].(*testMeasurement).ticks; got != 1 {
+		t.Errorf("ticks incorrect: got %d want %d", got, 1)
+	}
+}
@@ -0,0 +1,60 @@
+package finance
+
+import (
+	"math"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const (
+	tradeMedianSize       = 100
+	tradeBurstProbability = 0.01
+)
+
+var (
+	labelTrades = []byte("trades")
+	labelPrice  = []byte("price")
+	labelSize   = []byte("size")
+)
+
+// TradesMeasurement represents the trades executed for a symbol.
+type TradesMeasurement struct {
+	clock eventClock
+	price common.Distribution
+	size  common.Distribution
+}
+
+// NewTradesMeasurement creates a new TradesMeasurement with start time,
+// advancing the given price distribution with every trade.
+func NewTradesMeasurement(start time.Time, price common.Distribution) *TradesMeasurement {
+	return &TradesMeasurement{
+		clock: newEventClock(start),
+		price: price,
+		size:  VD(tradeMedianSize, tradeBurstProbability),
+	}
+}
+
+// Tick advances the trade time, price and size.
+func (m *TradesMeasurement) Tick(d time.Duration) {
+	m.clock.tick(d)
+	m.price.Advance()
+	m.size.Advance()
+}
+
+// ToPoint serializes TradesMeasurement to data.Point.
+func (m *TradesMeasurement) ToPoint(p *data.Point) {
+	p.SetMeasurementName(labelTrades)
+	ts := m.clock.time()
+	p.SetTimestamp(&ts)
+
+	p.AppendField(labelPrice, currentPrice(m.price))
+	p.AppendField(labelSize, int64(m.size.Get()))
+}
+
+// currentPrice returns the value of a price distribution, never going below
+// the minimum price.
+func currentPrice(price common.Distribution) float64 {
+	return math.Max(price.Get(), minPrice)
+}
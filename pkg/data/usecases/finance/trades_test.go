@@ -0,0 +1,50 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+func TestTradesMeasurementToPoint(t *testing.T) {
+	now := time.Now()
+	m := NewTradesMeasurement(now, newPriceDistribution())
+	duration := time.Second
+	m.Tick(duration)
+
+	p := data.NewPoint()
+	m.ToPoint(p)
+	if got := string(p.MeasurementName()); got != string(labelTrades) {
+		t.Errorf("incorrect measurement name: got %s want %s", got, labelTrades)
+	}
+
+	ts := *p.Timestamp()
+	if ts.Before(now.Add(duration)) || !ts.Before(now.Add(2*duration)) {
+		t.Errorf("timestamp %v not within the ticked interval", ts)
+	}
+
+	if got := p.GetFieldValue(labelPrice).(float64); got < minPrice {
+		t.Errorf("price below minimum: got %f", got)
+	}
+	if got := p.GetFieldValue(labelSize).(int64); got < 1 {
+		t.Errorf("size not positive: got %d", got)
+	}
+}
+
+func TestTradesMeasurementTickAdvancesPrice(t *testing.T) {
+	price := common.WD(common.UD(1, 1), 10)
+	m := NewTradesMeasurement(time.Now(), price)
+	m.Tick(time.Second)
+	m.Tick(time.Second)
+	if got := price.Get(); got != 12 {
+		t.Errorf("incorrect price after two trades: got %f want %f", got, 12.0)
+	}
+}
+
+func TestCurrentPrice(t *testing.T) {
+	if got := currentPrice(common.WD(common.UD(0, 0), -5)); got != minPrice {
+		t.Errorf("negative price not clamped: got %f want %f", got, minPrice)
+	}
+}
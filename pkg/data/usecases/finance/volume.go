@@ -0,0 +1,66 @@
+package finance
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const (
+	// maxBurstLength is the maximum number of ticks a burst lasts.
+	maxBurstLength = 20
+	// burstMultiplier scales the sizes drawn during a burst.
+	burstMultiplier = 10.0
+	// sizeSpread is the standard deviation of the logarithm of a size.
+	sizeSpread = 0.75
+)
+
+// VolumeDistribution models bursty trading volume. Sizes are drawn from a
+// log-normal distribution around a median; at every step a burst starts with
+// the given probability, multiplying the sizes for a random number of steps.
+type VolumeDistribution struct {
+	Median           float64
+	BurstProbability float64
+
+	logSize   common.Distribution
+	burstLeft int
+	value     float64
+}
+
+// VD creates a new VolumeDistribution with the given median size and
+// probability of a burst starting.
+func VD(median, burstProbability float64) *VolumeDistribution {
+	return &VolumeDistribution{
+		Median:           median,
+		BurstProbability: burstProbability,
+		logSize:          common.ND(math.Log(median), sizeSpread),
+		value:            median,
+	}
+}
+
+// Advance draws the next size, starting or ending a burst as needed.
+func (d *VolumeDistribution) Advance() {
+	if d.burstLeft > 0 {
+		d.burstLeft--
+	} else if rand.Float64() < d.BurstProbability {
+		d.burstLeft = 1 + rand.Intn(maxBurstLength)
+	}
+
+	d.logSize.Advance()
+	v := math.Exp(d.logSize.Get())
+	if d.InBurst() {
+		v *= burstMultiplier
+	}
+	d.value = math.Max(1, math.Round(v))
+}
+
+// Get returns the last size drawn.
+func (d *VolumeDistribution) Get() float64 {
+	return d.value
+}
+
+// InBurst reports whether the distribution is in a burst.
+func (d *VolumeDistribution) InBurst() bool {
+	return d.burstLeft > 0
+}
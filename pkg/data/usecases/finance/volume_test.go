@@ -0,0 +1,42 @@
+package finance
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVolumeDistribution(t *testing.T) {
+	rand.Seed(123)
+	d := VD(100, 0)
+	if got := d.Get(); got != 100 {
+		t.Errorf("incorrect initial size: got %f want %f", got, 100.0)
+	}
+	for i := 0; i < 1000; i++ {
+		d.Advance()
+		if d.InBurst() {
+			t.Fatalf("unexpected burst with zero probability")
+		}
+		if got := d.Get(); got < 1 || got != float64(int64(got)) {
+			t.Fatalf("size is not a positive whole number: %f", got)
+		}
+	}
+}
+
+func TestVolumeDistributionBurst(t *testing.T) {
+	rand.Seed(123)
+	d := VD(100, 1)
+	d.Advance()
+	if !d.InBurst() {
+		t.Fatalf("no burst with probability one")
+	}
+
+	// once started, a burst ends within maxBurstLength steps
+	steps := 0
+	for d.InBurst() {
+		d.Advance()
+		steps++
+		if steps > maxBurstLength {
+			t.Fatalf("burst longer than %d steps", maxBurstLength)
+		}
+	}
+}
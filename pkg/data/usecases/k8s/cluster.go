@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	nodeNameFmt = "node_%d"
+	// podsPerNode is the number of pod slots the cluster has a node for.
+	podsPerNode = 20
+)
+
+// cluster is a set of deployments whose pods are scheduled on a fixed set of
+// nodes. Its deployments draw the sizing, scheduling, autoscaling and rollout
+// decisions from rand.
+type cluster struct {
+	deployments []*deployment
+	nodes       int
+	now         time.Time
+	rand        *rand.Rand
+}
+
+// newCluster creates a cluster at start with deployments of random sizes,
+// adding up to podSlots pod slots.
+func newCluster(podSlots int, start time.Time, r *rand.Rand) *cluster {
+	c := &cluster{
+		nodes: (podSlots + podsPerNode - 1) / podsPerNode,
+		now:   start,
+		rand:  r,
+	}
+	for slots := 0; slots < podSlots; {
+		n := 1 + r.Intn(maxReplicas)
+		if n > podSlots-slots {
+			n = podSlots - slots
+		}
+		c.deployments = append(c.deployments, newDeployment(c, len(c.deployments), n))
+		slots += n
+	}
+	return c
+}
+
+// tick advances the cluster time and all of its deployments.
+func (c *cluster) tick(d time.Duration) {
+	c.now = c.now.Add(d)
+	for _, dep := range c.deployments {
+		dep.tick(d)
+	}
+}
+
+// randomNode returns the name of a random node of the cluster.
+func (c *cluster) randomNode() string {
+	return fmt.Sprintf(nodeNameFmt, c.rand.Intn(c.nodes))
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+var (
+	labelContainer   = []byte("container") // heap optimization
+	labelCPUUsage    = []byte("cpu_usage")
+	labelCPULimit    = []byte("cpu_limit")
+	labelMemoryUsage = []byte("memory_usage")
+	labelMemoryLimit = []byte("memory_limit")
+	labelRestarts    = []byte("restarts")
+
+	containerFieldKeys = [][]byte{
+		labelCPUUsage,
+		labelCPULimit,
+		labelMemoryUsage,
+		labelMemoryLimit,
+		labelRestarts,
+	}
+)
+
+// ContainerMeasurement represents the resource usage of a single container of
+// a pod. The restarts field counts the restarts of the container since its pod
+// was created.
+type ContainerMeasurement struct {
+	*common.SubsystemMeasurement
+	cpuLimit           float64
+	memoryLimit        int64
+	restartProbability float64
+	restarts           int64
+}
+
+// NewContainerMeasurement creates a new ContainerMeasurement with start time,
+// resource limits and the probability of the container restarting on every
+// tick.
+func NewContainerMeasurement(start time.Time, cpuLimit float64, memoryLimit int64, restartProbability float64) *ContainerMeasurement {
+	sub := common.NewSubsystemMeasurement(start, 2)
+	// cpu usage in cores
+	sub.Distributions[0] = common.FP(common.CWD(common.ND(0, cpuLimit/20), 0, cpuLimit, rand.Float64()*cpuLimit/2), 3)
+	// memory usage in bytes
+	sub.Distributions[1] = common.CWD(common.ND(0, float64(memoryLimit)/64), 0, float64(memoryLimit), rand.Float64()*float64(memoryLimit)/2)
+	return &ContainerMeasurement{
+		SubsystemMeasurement: sub,
+		cpuLimit:             cpuLimit,
+		memoryLimit:          memoryLimit,
+		restartProbability:   restartProbability,
+	}
+}
+
+// Tick advances the usage of the container, which may restart meanwhile.
+func (m *ContainerMeasurement) Tick(d time.Duration) {
+	m.SubsystemMeasurement.Tick(d)
+	if rand.Float64() < m.restartProbability {
+		m.restarts++
+	}
+}
+
+// ToPoint serializes ContainerMeasurement to data.Point.
+func (m *ContainerMeasurement) ToPoint(p *data.Point) {
+	p.SetMeasurementName(labelContainer)
+	p.SetTimestamp(&m.Timestamp)
+
+	p.AppendField(labelCPUUsage, m.Distributions[0].Get())
+	p.AppendField(labelCPULimit, m.cpuLimit)
+	p.AppendField(labelMemoryUsage, int64(m.Distributions[1].Get()))
+	p.AppendField(labelMemoryLimit, m.memoryLimit)
+	p.AppendField(labelRestarts, m.restarts)
+}
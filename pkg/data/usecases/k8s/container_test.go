@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func TestContainerMeasurementToPoint(t *testing.T) {
+	now := time.Now()
+	m := NewContainerMeasurement(now, 2, 1<<30, 0)
+	duration := time.Second
+	m.Tick(duration)
+
+	p := data.NewPoint()
+	m.ToPoint(p)
+	if got := string(p.MeasurementName()); got != string(labelContainer) {
+		t.Errorf("incorrect measurement name: got %s want %s", got, labelContainer)
+	}
+	if got := *p.Timestamp(); !got.Equal(now.Add(duration)) {
+		t.Errorf("incorrect timestamp: got %v want %v", got, now.Add(duration))
+	}
+
+	if got := p.GetFieldValue(labelCPUUsage).(float64); got < 0 || got > 2 {
+		t.Errorf("cpu usage out of limits: got %f", got)
+	}
+	if got := p.GetFieldValue(labelCPULimit).(float64); got != 2 {
+		t.Errorf("incorrect cpu limit: got %f want %f", got, 2.0)
+	}
+	if got := p.GetFieldValue(labelMemoryUsage).(int64); got < 0 || got > 1<<30 {
+		t.Errorf("memory usage out of limits: got %d", got)
+	}
+	if got := p.GetFieldValue(labelMemoryLimit).(int64); got != 1<<30 {
+		t.Errorf("incorrect memory limit: got %d want %d", got, 1<<30)
+	}
+	if got := p.GetFieldValue(labelRestarts).(int64); got != 0 {
+		t.Errorf("incorrect restarts: got %d want 0", got)
+	}
+}
+
+func TestContainerMeasurementTickRestarts(t *testing.T) {
+	m := NewContainerMeasurement(time.Now(), 1, 1<<30, 1)
+	for i := 0; i < 3; i++ {
+		m.Tick(time.Second)
+	}
+	if m.restarts != 3 {
+		t.Errorf("incorrect restarts: got %d want 3", m.restarts)
+	}
+}
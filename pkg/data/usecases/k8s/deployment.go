@@ -0,0 +1,173 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	deploymentNameFmt = "deployment_%d"
+
+	// maxReplicas is the largest number of pods a deployment can scale to.
+	maxReplicas = 10
+	// revisionLength and podSuffixLength are the lengths of the generated
+	// parts of a pod name, e.g. deployment_0-5f7d9c8b4x-k2x7z.
+	revisionLength  = 10
+	podSuffixLength = 5
+
+	// autoscaledProbability is the probability of a deployment being
+	// autoscaled, changing its number of replicas over time.
+	autoscaledProbability = 0.5
+	// scaleProbability is the probability of an autoscaled deployment adding
+	// or removing a replica on every tick.
+	scaleProbability = 0.01
+	// rolloutProbability is the probability of a deployment starting the
+	// rollout of a new revision on every tick.
+	rolloutProbability = 0.002
+
+	// flakyProbability is the probability of a deployment having containers
+	// which restart often.
+	flakyProbability        = 0.1
+	flakyRestartProbability = 0.005
+	restartProbability      = 0.0001
+
+	appContainerName     = "app"
+	sidecarContainerName = "sidecar"
+	sidecarProbability   = 0.3
+	sidecarCPULimit      = 0.1
+	sidecarMemoryLimit   = 128 << 20
+)
+
+var (
+	// NamespaceChoices contains all the namespace values for the k8s use case
+	NamespaceChoices = []string{
+		"default",
+		"kube-system",
+		"monitoring",
+		"ingress",
+		"payments",
+		"checkout",
+		"search",
+		"analytics",
+	}
+
+	cpuLimitChoices    = []float64{0.25, 0.5, 1, 2, 4}
+	memoryLimitChoices = []int64{256 << 20, 512 << 20, 1 << 30, 2 << 30, 4 << 30}
+)
+
+// containerSpec describes a container of the pods of a deployment.
+type containerSpec struct {
+	name        string
+	cpuLimit    float64
+	memoryLimit int64
+}
+
+// deployment manages a set of pod slots, only the first replicas of which run
+// a pod. Autoscaling changes the number of replicas and rollouts replace the
+// running pods one at a time with pods of a new revision.
+type deployment struct {
+	cluster            *cluster
+	name               string
+	namespace          string
+	revision           string
+	containers         []containerSpec
+	restartProbability float64
+	autoscaled         bool
+	rollingOut         bool
+	replicas           int
+	pods               []*pod
+}
+
+// newDeployment creates the i-th deployment of the cluster with the given
+// number of pod slots, running a random number of replicas.
+func newDeployment(c *cluster, i, slots int) *deployment {
+	r := c.rand
+	d := &deployment{
+		cluster:            c,
+		name:               fmt.Sprintf(deploymentNameFmt, i),
+		namespace:          NamespaceChoices[r.Intn(len(NamespaceChoices))],
+		revision:           randomName(r, revisionLength),
+		restartProbability: restartProbability,
+		autoscaled:         r.Float64() < autoscaledProbability,
+		pods:               make([]*pod, slots),
+	}
+	d.containers = []containerSpec{{
+		name:        appContainerName,
+		cpuLimit:    cpuLimitChoices[r.Intn(len(cpuLimitChoices))],
+		memoryLimit: memoryLimitChoices[r.Intn(len(memoryLimitChoices))],
+	}}
+	if r.Float64() < sidecarProbability {
+		d.containers = append(d.containers, containerSpec{
+			name:        sidecarContainerName,
+			cpuLimit:    sidecarCPULimit,
+			memoryLimit: sidecarMemoryLimit,
+		})
+	}
+	if r.Float64() < flakyProbability {
+		d.restartProbability = flakyRestartProbability
+	}
+
+	d.replicas = 1 + r.Intn(slots)
+	for j := 0; j < d.replicas; j++ {
+		d.pods[j] = d.newPod()
+	}
+	return d
+}
+
+// newPod creates a pod of the current revision on a random node.
+func (d *deployment) newPod() *pod {
+	return newPod(d, d.cluster.randomNode(), d.cluster.now)
+}
+
+// tick advances the running pods, then autoscales the deployment and carries
+// on with its rollout.
+func (d *deployment) tick(dur time.Duration) {
+	for _, p := range d.pods[:d.replicas] {
+		p.tick(dur)
+	}
+	r := d.cluster.rand
+	if d.autoscaled && r.Float64() < scaleProbability {
+		if r.Intn(2) == 0 {
+			d.scaleUp()
+		} else {
+			d.scaleDown()
+		}
+	}
+	if !d.rollingOut && r.Float64() < rolloutProbability {
+		d.revision = randomName(r, revisionLength)
+		d.rollingOut = true
+	}
+	if d.rollingOut {
+		d.rollOut()
+	}
+}
+
+// scaleUp starts a pod in the first free slot, if any.
+func (d *deployment) scaleUp() {
+	if d.replicas == len(d.pods) {
+		return
+	}
+	d.pods[d.replicas] = d.newPod()
+	d.replicas++
+}
+
+// scaleDown retires the pod of the last running slot, keeping at least one.
+func (d *deployment) scaleDown() {
+	if d.replicas == 1 {
+		return
+	}
+	d.replicas--
+	d.pods[d.replicas] = nil
+}
+
+// rollOut replaces the first running pod of an older revision, finishing the
+// rollout when there are none left.
+func (d *deployment) rollOut() {
+	for i, p := range d.pods[:d.replicas] {
+		if p.revision != d.revision {
+			d.pods[i] = d.newPod()
+			return
+		}
+	}
+	d.rollingOut = false
+}
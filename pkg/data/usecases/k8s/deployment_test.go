@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCluster(t *testing.T) {
+	cases := []int{1, 9, 10, 25, 100}
+	for _, podSlots := range cases {
+		c := newCluster(podSlots, time.Now(), rand.New(rand.NewSource(123)))
+		if want := (podSlots + podsPerNode - 1) / podsPerNode; c.nodes != want {
+			t.Errorf("incorrect number of nodes for %d slots: got %d want %d", podSlots, c.nodes, want)
+		}
+		slots := 0
+		for _, d := range c.deployments {
+			if len(d.pods) < 1 || len(d.pods) > maxReplicas {
+				t.Errorf("incorrect number of slots of %s: %d", d.name, len(d.pods))
+			}
+			if d.replicas < 1 || d.replicas > len(d.pods) {
+				t.Errorf("incorrect number of replicas of %s: %d", d.name, d.replicas)
+			}
+			slots += len(d.pods)
+		}
+		if slots != podSlots {
+			t.Errorf("incorrect number of slots: got %d want %d", slots, podSlots)
+		}
+	}
+}
+
+func TestNewPodTags(t *testing.T) {
+	c := newCluster(1, time.Now(), rand.New(rand.NewSource(123)))
+	d := c.deployments[0]
+	p := d.pods[0]
+	if len(p.tags) != len(d.containers) || len(p.containers) != len(d.containers) {
+		t.Fatalf("incorrect number of containers: got %d want %d", len(p.tags), len(d.containers))
+	}
+	for i, tags := range p.tags {
+		if len(tags) != len(tagKeys) {
+			t.Fatalf("incorrect number of tags: got %d want %d", len(tags), len(tagKeys))
+		}
+		for j, tag := range tags {
+			if string(tag.Key) != string(tagKeys[j]) {
+				t.Errorf("incorrect tag key: got %s want %s", tag.Key, tagKeys[j])
+			}
+		}
+		name := tags[0].Value.(string)
+		if !strings.HasPrefix(name, d.name+"-"+d.revision+"-") {
+			t.Errorf("pod name %s does not belong to revision %s of %s", name, d.revision, d.name)
+		}
+		if got := tags[1].Value.(string); got != d.containers[i].name {
+			t.Errorf("incorrect container name: got %s want %s", got, d.containers[i].name)
+		}
+		if got := tags[0].Value.(string); got != p.tags[0][0].Value.(string) {
+			t.Errorf("containers of a pod have different pod names: %s and %s", got, p.tags[0][0].Value)
+		}
+	}
+}
+
+func TestDeploymentScale(t *testing.T) {
+	c := newCluster(0, time.Now(), rand.New(rand.NewSource(123)))
+	c.nodes = 1
+	d := newDeployment(c, 0, 3)
+	for d.replicas < len(d.pods) {
+		d.scaleUp()
+	}
+	d.scaleUp()
+	if d.replicas != 3 {
+		t.Errorf("scaled up beyond the number of slots: %d", d.replicas)
+	}
+	for i, p := range d.pods {
+		if p == nil {
+			t.Errorf("slot %d has no pod after scaling up", i)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		d.scaleDown()
+	}
+	if d.replicas != 1 {
+		t.Errorf("incorrect replicas after scaling down: got %d want 1", d.replicas)
+	}
+	if d.pods[0] == nil || d.pods[1] != nil || d.pods[2] != nil {
+		t.Errorf("incorrect pods after scaling down: %v", d.pods)
+	}
+}
+
+func TestDeploymentRollOut(t *testing.T) {
+	c := newCluster(0, time.Now(), rand.New(rand.NewSource(123)))
+	c.nodes = 1
+	d := newDeployment(c, 0, 3)
+	for d.replicas < len(d.pods) {
+		d.scaleUp()
+	}
+	old := make([]*pod, len(d.pods))
+	copy(old, d.pods)
+
+	d.revision = randomName(c.rand, revisionLength)
+	d.rollingOut = true
+	for i := range d.pods {
+		d.rollOut()
+		for j, p := range d.pods {
+			replaced := p != old[j]
+			if replaced != (j <= i) {
+				t.Errorf("step %d: incorrect replacement of slot %d: %v", i, j, replaced)
+			}
+			if replaced && p.revision != d.revision {
+				t.Errorf("step %d: slot %d replaced with revision %s", i, j, p.revision)
+			}
+		}
+	}
+	if !d.rollingOut {
+		t.Errorf("rollout finished before noticing all pods are up to date")
+	}
+	d.rollOut()
+	if d.rollingOut {
+		t.Errorf("rollout not finished with all pods up to date")
+	}
+}
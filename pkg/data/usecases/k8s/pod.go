@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+// nameAlphabet contains the characters Kubernetes uses for generated names,
+// without vowels and easily confused digits.
+const nameAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+var (
+	labelPod        = []byte("pod")
+	labelNamespace  = []byte("namespace")
+	labelDeployment = []byte("deployment")
+	labelNode       = []byte("node")
+
+	// tagKeys are the keys of the tags of every container series. The pod
+	// comes first as loaders distribute the series among workers by their
+	// first tag.
+	tagKeys = [][]byte{
+		labelPod,
+		labelContainer,
+		labelNamespace,
+		labelDeployment,
+		labelNode,
+	}
+)
+
+// randomName returns a random generated name of n characters drawn from r.
+func randomName(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = nameAlphabet[r.Intn(len(nameAlphabet))]
+	}
+	return string(b)
+}
+
+// pod is a running instance of a deployment revision, scheduled on a node.
+type pod struct {
+	revision   string
+	containers []*ContainerMeasurement
+	tags       [][]common.Tag
+}
+
+// newPod creates a pod of the current revision of deployment d on a random
+// node, its containers starting at start.
+func newPod(d *deployment, node string, start time.Time) *pod {
+	name := d.name + "-" + d.revision + "-" + randomName(d.cluster.rand, podSuffixLength)
+	p := &pod{
+		revision:   d.revision,
+		containers: make([]*ContainerMeasurement, len(d.containers)),
+		tags:       make([][]common.Tag, len(d.containers)),
+	}
+	for i, c := range d.containers {
+		p.containers[i] = NewContainerMeasurement(start, c.cpuLimit, c.memoryLimit, d.restartProbability)
+		p.tags[i] = []common.Tag{
+			{Key: labelPod, Value: name},
+			{Key: labelContainer, Value: c.name},
+			{Key: labelNamespace, Value: d.namespace},
+			{Key: labelDeployment, Value: d.name},
+			{Key: labelNode, Value: node},
+		}
+	}
+	return p
+}
+
+// tick advances all the containers of the pod.
+func (p *pod) tick(d time.Duration) {
+	for _, c := range p.containers {
+		c.Tick(d)
+	}
+}
+
+// toPoint fills the point with the tags and measurement of the i-th container
+// of the pod.
+func (p *pod) toPoint(dp *data.Point, i int) {
+	for _, tag := range p.tags[i] {
+		dp.AppendTag(tag.Key, tag.Value)
+	}
+	p.containers[i].ToPoint(dp)
+}
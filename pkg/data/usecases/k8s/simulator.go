@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+// SimulatorConfig is used to create a k8s Simulator.
+// It fulfills the common.SimulatorConfig interface.
+type SimulatorConfig struct {
+	// Start is the beginning time for the Simulator
+	Start time.Time
+	// End is the ending time for the Simulator
+	End time.Time
+	// PodCount is the number of pod slots of the cluster, i.e. the largest
+	// number of pods which can run at the same time
+	PodCount uint64
+	// Rand is the source of the pod churn of the cluster. If nil, a source
+	// seeded from the global one is used, so the churn follows its seed.
+	Rand *rand.Rand
+}
+
+// series identifies the container series of a pod slot of a deployment.
+type series struct {
+	deployment *deployment
+	slot       int
+	container  int
+}
+
+// Simulator generates the container metrics of a Kubernetes cluster whose
+// pods are created and retired by autoscaling and rollouts. The series of a
+// pod slot change their tags whenever its pod is replaced, and are skipped
+// while the slot is scaled down.
+type Simulator struct {
+	madePoints uint64
+	maxPoints  uint64
+
+	cluster     *cluster
+	series      []series
+	seriesIndex int
+	interval    time.Duration
+}
+
+// NewSimulator produces a k8s Simulator with the given config over the
+// specified interval and points limit.
+func (c *SimulatorConfig) NewSimulator(interval time.Duration, limit uint64) common.Simulator {
+	r := c.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(rand.Int63()))
+	}
+	cl := newCluster(int(c.PodCount), c.Start, r)
+	var ss []series
+	for _, d := range cl.deployments {
+		for slot := range d.pods {
+			for container := range d.containers {
+				ss = append(ss, series{deployment: d, slot: slot, container: container})
+			}
+		}
+	}
+
+	// This upper limit counts the scaled down slots as well, which generate
+	// no points.
+	epochs := uint64(c.End.Sub(c.Start).Nanoseconds() / interval.Nanoseconds())
+	maxPoints := epochs * uint64(len(ss))
+	if limit > 0 && limit < maxPoints {
+		maxPoints = limit
+	}
+	return &Simulator{
+		maxPoints: maxPoints,
+		cluster:   cl,
+		series:    ss,
+		interval:  interval,
+	}
+}
+
+// Finished tells whether we have simulated all the necessary points.
+func (s *Simulator) Finished() bool {
+	return s.madePoints >= s.maxPoints
+}
+
+// Next advances a Point to the next state in the generator. It returns false
+// if the series of the point belongs to a scaled down pod slot.
+func (s *Simulator) Next(p *data.Point) bool {
+	if s.seriesIndex == len(s.series) {
+		s.seriesIndex = 0
+		s.cluster.tick(s.interval)
+	}
+
+	ser := s.series[s.seriesIndex]
+	s.seriesIndex++
+	s.madePoints++
+
+	pod := ser.deployment.pods[ser.slot]
+	if pod == nil {
+		return false
+	}
+	pod.toPoint(p, ser.container)
+	return true
+}
+
+// Fields returns the fields of the container measurement.
+func (s *Simulator) Fields() map[string][]string {
+	fields := make([]string, len(containerFieldKeys))
+	for i, k := range containerFieldKeys {
+		fields[i] = string(k)
+	}
+	return map[string][]string{string(labelContainer): fields}
+}
+
+// TagKeys returns the tag keys of the container series.
+func (s *Simulator) TagKeys() []string {
+	keys := make([]string, len(tagKeys))
+	for i, k := range tagKeys {
+		keys[i] = string(k)
+	}
+	return keys
+}
+
+// TagTypes returns the type for each tag, all of them being strings.
+func (s *Simulator) TagTypes() []string {
+	types := make([]string, len(tagKeys))
+	for i := range types {
+		types[i] = "string"
+	}
+	return types
+}
+
+func (s *Simulator) Headers() *common.GeneratedDataHeaders {
+	return &common.GeneratedDataHeaders{
+		TagTypes:  s.TagTypes(),
+		TagKeys:   s.TagKeys(),
+		FieldKeys: s.Fields(),
+	}
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func TestSimulatorNext(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), PodCount: 50, Rand: rand.New(rand.NewSource(123))}
+	s := c.NewSimulator(10*time.Second, 0).(*Simulator)
+	if want := uint64(360 * len(s.series)); s.maxPoints != want {
+		t.Fatalf("incorrect max points: got %d want %d", s.maxPoints, want)
+	}
+
+	running := 0
+	for _, d := range s.cluster.deployments {
+		running += d.replicas
+	}
+
+	pods := map[string]bool{}
+	written := 0
+	for !s.Finished() {
+		p := data.NewPoint()
+		if !s.Next(p) {
+			continue
+		}
+		written++
+		if got := string(p.MeasurementName()); got != string(labelContainer) {
+			t.Fatalf("incorrect measurement name: got %s", got)
+		}
+		if got := len(p.TagKeys()); got != len(tagKeys) {
+			t.Fatalf("incorrect number of tags: got %d want %d", got, len(tagKeys))
+		}
+		pods[p.GetTagValue(labelPod).(string)] = true
+	}
+	if written == 0 || uint64(written) > s.maxPoints {
+		t.Errorf("incorrect number of written points: %d", written)
+	}
+	if len(pods) <= running {
+		t.Errorf("no pod churn: only %d pods for %d initially running", len(pods), running)
+	}
+}
+
+func TestSimulatorNextSkipsScaledDownSlots(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), PodCount: 10}
+	s := c.NewSimulator(10*time.Second, 0).(*Simulator)
+	d := s.cluster.deployments[0]
+	for d.replicas < len(d.pods) {
+		d.scaleUp()
+	}
+	d.scaleDown()
+
+	for _, ser := range s.series {
+		want := ser.deployment.pods[ser.slot] != nil
+		if got := s.Next(data.NewPoint()); got != want {
+			t.Errorf("incorrect write for slot %d of %s: got %v want %v", ser.slot, ser.deployment.name, got, want)
+		}
+	}
+}
+
+func TestSimulatorHeaders(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), PodCount: 10}
+	h := c.NewSimulator(10*time.Second, 0).Headers()
+	wantTags := []string{"pod", "container", "namespace", "deployment", "node"}
+	if len(h.TagKeys) != len(wantTags) {
+		t.Fatalf("incorrect tag keys: got %v want %v", h.TagKeys, wantTags)
+	}
+	for i, k := range wantTags {
+		if h.TagKeys[i] != k || h.TagTypes[i] != "string" {
+			t.Errorf("incorrect tag %d: got %s %s want %s string", i, h.TagKeys[i], h.TagTypes[i], k)
+		}
+	}
+	fields := h.FieldKeys[string(labelContainer)]
+	if len(fields) != len(containerFieldKeys) {
+		t.Errorf("incorrect fields: got %v", fields)
+	}
+}
+
+func TestSimulatorLimit(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), PodCount: 10}
+	s := c.NewSimulator(10*time.Second, 5)
+	for i := 0; i < 5; i++ {
+		if s.Finished() {
+			t.Fatalf("finished after %d points", i)
+		}
+		s.Next(data.NewPoint())
+	}
+	if !s.Finished() {
+		t.Errorf("not finished after the limit")
+	}
+}
@@ -0,0 +1,194 @@
+package logs
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+const (
+	// SeverityError is the severity of the events reporting a failure.
+	SeverityError = "ERROR"
+	severityWarn  = "WARN"
+	severityInfo  = "INFO"
+	severityDebug = "DEBUG"
+
+	minErrorRate = 0.005
+	maxErrorRate = 0.05
+	warnRate     = 0.1
+	debugRate    = 0.2
+	// During an incident most of the events of an instance are errors.
+	incidentErrorRate   = 0.6
+	incidentProbability = 0.002
+	minIncidentTicks    = 30
+	maxIncidentTicks    = 120
+
+	meanDurationMillis = 40.0
+	// maxContextPairs is the largest number of key=value pairs appended to a
+	// message, making messages vary in length.
+	maxContextPairs = 8
+)
+
+var (
+	labelLogs     = []byte("logs")
+	labelSeverity = []byte("severity")
+	labelTraceID  = []byte("trace_id")
+	labelMessage  = []byte("message")
+	labelDuration = []byte("duration_ms")
+
+	// SearchTerms contains substrings of the event messages which the
+	// message search queries look for.
+	SearchTerms = []string{
+		"timeout",
+		"connection refused",
+		"slow query",
+		"insufficient funds",
+		"logged in",
+		"cache miss",
+	}
+
+	tableChoices    = []string{"orders", "users", "payments", "inventory", "sessions"}
+	endpointChoices = []string{"/api/v1/orders", "/api/v1/users", "/api/v1/cart", "/api/v1/search", "/healthz"}
+	contextKeys     = []string{"user_id", "order_id", "region", "attempt", "shard", "pool", "build", "span"}
+
+	messageTemplates = map[string][]func() string{
+		severityDebug: {
+			func() string {
+				return fmt.Sprintf(`cache miss for key "session:%d", loading from store`, rand.Intn(100000))
+			},
+			func() string {
+				return fmt.Sprintf("heartbeat sent to 10.0.%d.%d", rand.Intn(256), rand.Intn(256))
+			},
+		},
+		severityInfo: {
+			func() string {
+				return fmt.Sprintf("request GET %s/%d completed with status 200",
+					randomChoice(endpointChoices), rand.Intn(10000))
+			},
+			func() string {
+				return fmt.Sprintf("user user_%d logged in from 192.168.%d.%d", rand.Intn(100000), rand.Intn(256), rand.Intn(256))
+			},
+		},
+		severityWarn: {
+			func() string {
+				return fmt.Sprintf("slow query on table %s took %dms", randomChoice(tableChoices), 500+rand.Intn(5000))
+			},
+			func() string {
+				return fmt.Sprintf("retrying request to %s, attempt %d of 3", randomChoice(endpointChoices), 1+rand.Intn(3))
+			},
+		},
+		SeverityError: {
+			func() string {
+				return fmt.Sprintf(`timeout, retrying "%s" after %dms`, randomChoice(endpointChoices), 100*(1+rand.Intn(10)))
+			},
+			func() string {
+				return fmt.Sprintf("connection refused: dial tcp 10.0.%d.%d:5432, database %s unavailable",
+					rand.Intn(256), rand.Intn(256), randomChoice(tableChoices))
+			},
+			func() string {
+				return fmt.Sprintf("payment for order %d declined: insufficient funds", rand.Intn(1000000))
+			},
+		},
+	}
+)
+
+func randomChoice(s []string) string {
+	return s[rand.Intn(len(s))]
+}
+
+// EventsMeasurement represents the log events emitted by a service instance,
+// one per reporting interval at a random offset within it. The share of
+// errors is specific to each instance and rises sharply during incidents.
+type EventsMeasurement struct {
+	interval      time.Time
+	offset        time.Duration
+	errorRate     float64
+	incidentTicks int
+
+	severity string
+	traceID  string
+	message  string
+	duration float64
+}
+
+// NewEventsMeasurement creates a new EventsMeasurement with start time.
+func NewEventsMeasurement(start time.Time) *EventsMeasurement {
+	m := &EventsMeasurement{
+		interval:  start,
+		errorRate: minErrorRate + rand.Float64()*(maxErrorRate-minErrorRate),
+	}
+	m.newEvent()
+	return m
+}
+
+// Tick advances the event time, the incident state of the instance and
+// generates the next event.
+func (m *EventsMeasurement) Tick(d time.Duration) {
+	m.interval = m.interval.Add(d)
+	m.offset = 0
+	if d > 0 {
+		m.offset = time.Duration(rand.Int63n(int64(d)))
+	}
+
+	if m.incidentTicks > 0 {
+		m.incidentTicks--
+	} else if rand.Float64() < incidentProbability {
+		m.incidentTicks = minIncidentTicks + rand.Intn(maxIncidentTicks-minIncidentTicks+1)
+	}
+	m.newEvent()
+}
+
+// newEvent draws the severity, trace ID, message and duration of an event.
+func (m *EventsMeasurement) newEvent() {
+	m.severity = m.randomSeverity()
+	m.traceID = fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64())
+
+	templates := messageTemplates[m.severity]
+	var sb strings.Builder
+	sb.WriteString(templates[rand.Intn(len(templates))]())
+	for i := rand.Intn(maxContextPairs + 1); i > 0; i-- {
+		fmt.Fprintf(&sb, " %s=%d", randomChoice(contextKeys), rand.Intn(100000))
+	}
+	m.message = sb.String()
+
+	duration := rand.ExpFloat64() * meanDurationMillis
+	if m.severity == SeverityError {
+		// failed requests usually wait for a timeout
+		duration += 1000
+	}
+	m.duration = math.Round(duration*100) / 100
+}
+
+func (m *EventsMeasurement) randomSeverity() string {
+	errorRate := m.errorRate
+	if m.incidentTicks > 0 {
+		errorRate = incidentErrorRate
+	}
+	r := rand.Float64()
+	switch {
+	case r < errorRate:
+		return SeverityError
+	case r < errorRate+warnRate:
+		return severityWarn
+	case r < errorRate+warnRate+debugRate:
+		return severityDebug
+	default:
+		return severityInfo
+	}
+}
+
+// ToPoint serializes EventsMeasurement to data.Point.
+func (m *EventsMeasurement) ToPoint(p *data.Point) {
+	p.SetMeasurementName(labelLogs)
+	ts := m.interval.Add(m.offset)
+	p.SetTimestamp(&ts)
+
+	p.AppendField(labelSeverity, m.severity)
+	p.AppendField(labelTraceID, m.traceID)
+	p.AppendField(labelMessage, m.message)
+	p.AppendField(labelDuration, m.duration)
+}
@@ -0,0 +1,85 @@
+package logs
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func TestEventsMeasurementToPoint(t *testing.T) {
+	rand.Seed(123)
+	start := time.Unix(0, 0)
+	m := NewEventsMeasurement(start)
+	for i := 0; i < 100; i++ {
+		m.Tick(time.Second)
+
+		p := data.NewPoint()
+		m.ToPoint(p)
+		if got := string(p.MeasurementName()); got != string(labelLogs) {
+			t.Fatalf("incorrect measurement name: got %s want %s", got, labelLogs)
+		}
+		ts := p.Timestamp()
+		lo := start.Add(time.Duration(i+1) * time.Second)
+		if ts.Before(lo) || !ts.Before(lo.Add(time.Second)) {
+			t.Errorf("event time %v outside of its interval starting at %v", ts, lo)
+		}
+
+		values := p.FieldValues()
+		if got := len(values); got != 4 {
+			t.Fatalf("incorrect number of fields: got %d want 4", got)
+		}
+		if _, ok := messageTemplates[values[0].(string)]; !ok {
+			t.Errorf("unknown severity %s", values[0])
+		}
+		if got := len(values[1].(string)); got != 32 {
+			t.Errorf("incorrect trace ID length: got %d want 32", got)
+		}
+		if msg := values[2].(string); strings.ContainsAny(msg, "\t\n") {
+			t.Errorf("message contains a tab or a line break: %q", msg)
+		}
+		if d := values[3].(float64); d < 0 {
+			t.Errorf("negative duration: %f", d)
+		}
+	}
+}
+
+func TestEventsMeasurementIncident(t *testing.T) {
+	rand.Seed(123)
+	m := NewEventsMeasurement(time.Unix(0, 0))
+	m.errorRate = 0
+	m.incidentTicks = 1000
+
+	errors := 0
+	for i := 0; i < 1000; i++ {
+		m.Tick(time.Second)
+		if m.severity == SeverityError {
+			errors++
+		}
+	}
+	if errors < 500 {
+		t.Errorf("too few errors during an incident: got %d of 1000", errors)
+	}
+}
+
+func TestEventsMeasurementSearchTerms(t *testing.T) {
+	rand.Seed(123)
+	m := NewEventsMeasurement(time.Unix(0, 0))
+	m.errorRate = maxErrorRate
+	found := make(map[string]bool)
+	for i := 0; i < 10000; i++ {
+		m.Tick(time.Second)
+		for _, term := range SearchTerms {
+			if strings.Contains(m.message, term) {
+				found[term] = true
+			}
+		}
+	}
+	for _, term := range SearchTerms {
+		if !found[term] {
+			t.Errorf("search term %q never found in messages", term)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+const instanceNameFmt = "instance_%d"
+
+// ServiceChoices contains all the service name values for the logs use case
+var ServiceChoices = []string{
+	"api-gateway",
+	"auth",
+	"billing",
+	"catalog",
+	"checkout",
+	"notifications",
+	"search",
+	"shipping",
+}
+
+// Instance models a running instance of a service which emits log events.
+type Instance struct {
+	simulatedMeasurements []common.SimulatedMeasurement
+	tags                  []common.Tag
+}
+
+// TickAll advances all measurements of an Instance.
+func (i *Instance) TickAll(d time.Duration) {
+	for j := range i.simulatedMeasurements {
+		i.simulatedMeasurements[j].Tick(d)
+	}
+}
+
+// Measurements returns the instance measurements.
+func (i Instance) Measurements() []common.SimulatedMeasurement {
+	return i.simulatedMeasurements
+}
+
+// Tags returns the instance tags.
+func (i Instance) Tags() []common.Tag {
+	return i.tags
+}
+
+// NewInstance creates a new service instance in a simulated logs use case
+func NewInstance(i int, start time.Time) common.Generator {
+	return &Instance{
+		tags: []common.Tag{
+			{Key: []byte("instance"), Value: fmt.Sprintf(instanceNameFmt, i)},
+			{Key: []byte("service"), Value: common.RandomStringSliceChoice(ServiceChoices)},
+		},
+		simulatedMeasurements: []common.SimulatedMeasurement{
+			NewEventsMeasurement(start),
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package logs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewInstance(t *testing.T) {
+	generator := NewInstance(1, time.Now())
+	instance := generator.(*Instance)
+
+	if got := len(instance.Measurements()); got != 1 {
+		t.Errorf("incorrect number of measurements: got %d want %d", got, 1)
+	}
+	if _, ok := instance.Measurements()[0].(*EventsMeasurement); !ok {
+		t.Errorf("measurement is not an EventsMeasurement")
+	}
+
+	tags := instance.Tags()
+	if got := len(tags); got != 2 {
+		t.Fatalf("incorrect number of tags: got %d want %d", got, 2)
+	}
+	if got := tags[0].Value.(string); got != "instance_1" {
+		t.Errorf("incorrect instance tag: got %s want instance_1", got)
+	}
+	service := tags[1].Value.(string)
+	known := false
+	for _, s := range ServiceChoices {
+		known = known || s == service
+	}
+	if !known {
+		t.Errorf("unknown service %s", service)
+	}
+}
@@ -0,0 +1,29 @@
+package logs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulatorHeaders(t *testing.T) {
+	start := time.Unix(0, 0)
+	sc := &SimulatorConfig{
+		Start:                start,
+		End:                  start.Add(time.Hour),
+		InitGeneratorScale:   2,
+		GeneratorScale:       2,
+		GeneratorConstructor: NewInstance,
+	}
+	headers := sc.NewSimulator(time.Second, 0).Headers()
+
+	wantFields := []string{"severity", "trace_id", "message", "duration_ms"}
+	wantTypes := []string{"string", "string", "string", "float64"}
+	for i, f := range headers.FieldKeys["logs"] {
+		if f != wantFields[i] {
+			t.Errorf("incorrect field %d: got %s want %s", i, f, wantFields[i])
+		}
+		if got := headers.FieldType("logs", i); got != wantTypes[i] {
+			t.Errorf("incorrect type of field %s: got %s want %s", f, got, wantTypes[i])
+		}
+	}
+}
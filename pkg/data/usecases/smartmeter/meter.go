@@ -0,0 +1,220 @@
+package smartmeter
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+const (
+	meterNameFmt = "meter_%d"
+
+	cadence15Minutes = "15m"
+	cadenceHourly    = "1h"
+	cadenceOnDemand  = "on-demand"
+
+	// share15Minutes and shareHourly are the shares of the meters read every
+	// 15 minutes and every hour, the remaining meters are read on demand.
+	share15Minutes = 0.5
+	shareHourly    = 0.4
+
+	// minOnDemandGap and meanOnDemandGap set the time between two readings of
+	// an on-demand meter, the latter added as an exponentially distributed
+	// delay to the former.
+	minOnDemandGap  = 5 * time.Minute
+	meanOnDemandGap = 6 * time.Hour
+
+	// outagesPerDay is the rate at which interval meters lose their
+	// connection. Their readings are kept and uploaded once the outage ends.
+	outagesPerDay = 0.01
+	minOutageDays = 1
+	maxOutageDays = 3
+
+	// profileStep is the resolution of the consumption profile.
+	profileStep = 15 * time.Minute
+
+	minBaseLoad    = 0.1
+	maxBaseLoad    = 0.6
+	minPeakLoad    = 0.5
+	maxPeakLoad    = 3.0
+	nominalVoltage = 230.0
+	voltageSpread  = 5.0
+)
+
+var (
+	labelEnergy      = []byte("energy")
+	labelConsumption = []byte("consumption_kwh")
+	labelTotal       = []byte("total_kwh")
+	labelVoltage     = []byte("voltage")
+
+	labelMeter   = []byte("meter")
+	labelRegion  = []byte("region")
+	labelTariff  = []byte("tariff")
+	labelCadence = []byte("cadence")
+
+	tagKeys         = [][]byte{labelMeter, labelRegion, labelTariff, labelCadence}
+	energyFieldKeys = [][]byte{labelConsumption, labelTotal, labelVoltage}
+
+	// RegionChoices contains all the region values for the smart-meter use
+	// case
+	RegionChoices = []string{
+		"north",
+		"north-east",
+		"east",
+		"south-east",
+		"south",
+		"south-west",
+		"west",
+		"north-west",
+	}
+
+	// TariffChoices contains all the tariff values for the smart-meter use
+	// case
+	TariffChoices = []string{
+		"flat",
+		"time-of-use",
+		"dynamic",
+		"prepaid",
+	}
+)
+
+// meter is a smart meter which reads the energy consumption of a household
+// at its own cadence. The readings of a meter which lost its connection are
+// only uploaded once it reconnects, so they arrive late and out of order.
+type meter struct {
+	id      int
+	name    string
+	region  string
+	tariff  string
+	cadence string
+	// interval is the time between two readings, zero for on-demand meters
+	interval time.Duration
+
+	baseLoad float64
+	peakLoad float64
+
+	// readFrom is the start of the interval covered by the next reading,
+	// readAt the time it is taken at, at the end of that interval, and
+	// uploadAt the time it is uploaded, which is later while the meter is
+	// offline
+	readFrom time.Time
+	readAt   time.Time
+	uploadAt time.Time
+
+	consumption float64
+	total       float64
+	voltage     float64
+}
+
+// newMeter creates the i-th meter, taking its first reading after start.
+func newMeter(i int, start time.Time) *meter {
+	m := &meter{
+		id:       i,
+		name:     fmt.Sprintf(meterNameFmt, i),
+		region:   randomChoice(RegionChoices),
+		tariff:   randomChoice(TariffChoices),
+		baseLoad: minBaseLoad + rand.Float64()*(maxBaseLoad-minBaseLoad),
+		peakLoad: minPeakLoad + rand.Float64()*(maxPeakLoad-minPeakLoad),
+		readAt:   start,
+		uploadAt: start,
+		total:    math.Round(rand.Float64()*100000) / 100,
+	}
+	switch r := rand.Float64(); {
+	case r < share15Minutes:
+		m.cadence, m.interval = cadence15Minutes, 15*time.Minute
+	case r < share15Minutes+shareHourly:
+		m.cadence, m.interval = cadenceHourly, time.Hour
+	default:
+		m.cadence = cadenceOnDemand
+	}
+	m.next()
+	return m
+}
+
+func randomChoice(s []string) string {
+	return s[rand.Intn(len(s))]
+}
+
+// next takes the next reading of the meter, covering the consumption since
+// the previous one, and schedules its upload.
+func (m *meter) next() {
+	from := m.readAt
+	m.readFrom = from
+	if m.interval > 0 {
+		m.readAt = from.Add(m.interval)
+	} else {
+		gap := minOnDemandGap + time.Duration(rand.ExpFloat64()*float64(meanOnDemandGap))
+		m.readAt = from.Add(gap.Truncate(time.Second))
+	}
+
+	m.consumption = math.Round(m.consume(from, m.readAt)*1000) / 1000
+	m.total += m.consumption
+	m.voltage = math.Round((nominalVoltage+rand.NormFloat64()*voltageSpread)*10) / 10
+
+	if m.uploadAt.After(m.readAt) {
+		// still offline, the reading is backfilled on reconnection
+		return
+	}
+	m.uploadAt = m.readAt
+	// on-demand meters are only read while they are online
+	gapDays := m.readAt.Sub(from).Hours() / 24
+	if m.interval > 0 && rand.Float64() < outagesPerDay*gapDays {
+		days := minOutageDays + rand.Intn(maxOutageDays-minOutageDays+1)
+		m.uploadAt = m.readAt.Add(time.Duration(days) * 24 * time.Hour)
+	}
+}
+
+// consume returns the energy in kWh consumed between from and to.
+func (m *meter) consume(from, to time.Time) float64 {
+	kwh := 0.0
+	for t := from; t.Before(to); {
+		next := t.Add(profileStep)
+		if next.After(to) {
+			next = to
+		}
+		kwh += m.load(t) * next.Sub(t).Hours()
+		t = next
+	}
+	return kwh
+}
+
+// load returns the power in kW drawn at t, following a diurnal curve with a
+// morning and a larger evening peak on top of the base load.
+func (m *meter) load(t time.Time) float64 {
+	hour := float64(t.Hour()) + float64(t.Minute())/60
+	noise := 0.8 + 0.4*rand.Float64()
+	return (m.baseLoad + m.peakLoad*diurnal(hour)) * noise
+}
+
+// diurnal returns the share of the peak load drawn at the given hour of the
+// day.
+func diurnal(hour float64) float64 {
+	return 0.5*gauss(hour, 7.5, 1.5) + gauss(hour, 19, 2)
+}
+
+func gauss(x, mean, stddev float64) float64 {
+	d := (x - mean) / stddev
+	return math.Exp(-d * d / 2)
+}
+
+// toPoint serializes the next reading of the meter to data.Point. The
+// reading is stamped at the start of the interval it covers, so that the
+// consumption of an interval meter falls in the hour and day it was consumed
+// in when grouped by time.
+func (m *meter) toPoint(p *data.Point) {
+	p.SetMeasurementName(labelEnergy)
+	ts := m.readFrom
+	p.SetTimestamp(&ts)
+
+	p.AppendTag(labelMeter, m.name)
+	p.AppendTag(labelRegion, m.region)
+	p.AppendTag(labelTariff, m.tariff)
+	p.AppendTag(labelCadence, m.cadence)
+
+	p.AppendField(labelConsumption, m.consumption)
+	p.AppendField(labelTotal, math.Round(m.total*1000)/1000)
+	p.AppendField(labelVoltage, m.voltage)
+}
@@ -0,0 +1,126 @@
+package smartmeter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func TestNewMeter(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	cadences := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		m := newMeter(i, start)
+		cadences[m.cadence]++
+		switch m.cadence {
+		case cadence15Minutes, cadenceHourly:
+			if got := m.readAt.Sub(start); got != m.interval {
+				t.Errorf("incorrect first reading of %s meter: got %v after start", m.cadence, got)
+			}
+		case cadenceOnDemand:
+			if m.interval != 0 {
+				t.Errorf("on-demand meter has an interval: %v", m.interval)
+			}
+			if got := m.readAt.Sub(start); got < minOnDemandGap {
+				t.Errorf("on-demand meter read too early: %v after start", got)
+			}
+		default:
+			t.Fatalf("unknown cadence: %s", m.cadence)
+		}
+		if m.uploadAt.Before(m.readAt) {
+			t.Errorf("reading uploaded before it was taken")
+		}
+		if m.consumption <= 0 || m.total < m.consumption {
+			t.Errorf("incorrect consumption: got %f of total %f", m.consumption, m.total)
+		}
+	}
+	if len(cadences) != 3 {
+		t.Errorf("not all cadences used: %v", cadences)
+	}
+}
+
+func TestMeterNext(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newMeter(0, start)
+	m.cadence, m.interval = cadence15Minutes, 15*time.Minute
+	for i := 0; i < 100; i++ {
+		readAt, total := m.readAt, m.total
+		m.next()
+		if got := m.readAt.Sub(readAt); got != m.interval {
+			t.Fatalf("incorrect time between readings: got %v want %v", got, m.interval)
+		}
+		if !m.readFrom.Equal(readAt) {
+			t.Fatalf("reading does not start at the previous one: got %v want %v", m.readFrom, readAt)
+		}
+		if got := m.total - total; math.Abs(got-m.consumption) > 1e-9 {
+			t.Fatalf("total did not grow by the consumption: got %f want %f", got, m.consumption)
+		}
+		if m.voltage < nominalVoltage-10*voltageSpread || m.voltage > nominalVoltage+10*voltageSpread {
+			t.Fatalf("voltage out of range: %f", m.voltage)
+		}
+	}
+}
+
+func TestMeterNextBackfill(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newMeter(0, start)
+	m.cadence, m.interval = cadenceHourly, time.Hour
+	reconnect := m.readAt.Add(24 * time.Hour)
+	m.uploadAt = reconnect
+
+	for i := 0; i < 24; i++ {
+		m.next()
+		if !m.uploadAt.Equal(reconnect) {
+			t.Fatalf("reading %d not uploaded on reconnection: got %v want %v", i, m.uploadAt, reconnect)
+		}
+	}
+	m.next()
+	if m.uploadAt.Before(m.readAt) || m.uploadAt.Equal(reconnect) {
+		t.Errorf("reading after reconnection not uploaded on time: got %v, read at %v", m.uploadAt, m.readAt)
+	}
+}
+
+func TestMeterLoad(t *testing.T) {
+	m := &meter{baseLoad: 0.2, peakLoad: 2}
+	night := time.Date(2016, 1, 1, 3, 0, 0, 0, time.UTC)
+	evening := time.Date(2016, 1, 1, 19, 0, 0, 0, time.UTC)
+	nightLoad, eveningLoad := 0.0, 0.0
+	for i := 0; i < 100; i++ {
+		nightLoad += m.load(night)
+		eveningLoad += m.load(evening)
+	}
+	if eveningLoad <= 2*nightLoad {
+		t.Errorf("no evening peak: got %f in the evening and %f at night", eveningLoad, nightLoad)
+	}
+	if got := m.consume(night, night); got != 0 {
+		t.Errorf("incorrect consumption over no time: got %f", got)
+	}
+}
+
+func TestMeterToPoint(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newMeter(7, start)
+	p := data.NewPoint()
+	m.toPoint(p)
+
+	if got := string(p.MeasurementName()); got != string(labelEnergy) {
+		t.Errorf("incorrect measurement name: got %s", got)
+	}
+	if got := *p.Timestamp(); !got.Equal(start) {
+		t.Errorf("incorrect timestamp: got %v want %v", got, start)
+	}
+	if got := p.GetTagValue(labelMeter); got != "meter_7" {
+		t.Errorf("incorrect meter tag: got %v", got)
+	}
+	if got := p.GetTagValue(labelCadence); got != m.cadence {
+		t.Errorf("incorrect cadence tag: got %v want %s", got, m.cadence)
+	}
+	if got := p.GetFieldValue(labelConsumption); got != m.consumption {
+		t.Errorf("incorrect consumption: got %v want %f", got, m.consumption)
+	}
+	if got := len(p.FieldKeys()); got != len(energyFieldKeys) {
+		t.Errorf("incorrect number of fields: got %d", got)
+	}
+}
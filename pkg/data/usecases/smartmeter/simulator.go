@@ -0,0 +1,133 @@
+package smartmeter
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
+)
+
+// SimulatorConfig is used to create a smart-meter Simulator.
+// It fulfills the common.SimulatorConfig interface.
+type SimulatorConfig struct {
+	// Start is the beginning time for the Simulator
+	Start time.Time
+	// End is the ending time for the Simulator
+	End time.Time
+	// MeterCount is the number of simulated meters
+	MeterCount uint64
+}
+
+// uploadQueue orders meters by the upload time of their next reading, so
+// readings come out in the order a head-end system would receive them.
+// It implements heap.Interface.
+type uploadQueue []*meter
+
+func (q uploadQueue) Len() int { return len(q) }
+
+func (q uploadQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if !a.uploadAt.Equal(b.uploadAt) {
+		return a.uploadAt.Before(b.uploadAt)
+	}
+	if !a.readAt.Equal(b.readAt) {
+		return a.readAt.Before(b.readAt)
+	}
+	return a.id < b.id
+}
+
+func (q uploadQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *uploadQueue) Push(x interface{}) { *q = append(*q, x.(*meter)) }
+
+func (q *uploadQueue) Pop() interface{} {
+	old := *q
+	m := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return m
+}
+
+// Simulator generates the readings of a fleet of smart meters. Unlike the
+// other simulators it does not tick all of its series every log interval:
+// each meter reports at its own cadence, and meters which were offline
+// backfill the readings they missed once they reconnect. Readings which
+// would be uploaded after the end of the simulation are left out.
+type Simulator struct {
+	madePoints uint64
+	maxPoints  uint64
+
+	queue uploadQueue
+	end   time.Time
+}
+
+// NewSimulator produces a smart-meter Simulator with the given config and
+// points limit. The interval is not used, since every meter has a cadence
+// of its own.
+func (c *SimulatorConfig) NewSimulator(_ time.Duration, limit uint64) common.Simulator {
+	q := make(uploadQueue, c.MeterCount)
+	for i := range q {
+		q[i] = newMeter(i, c.Start)
+	}
+	heap.Init(&q)
+
+	return &Simulator{
+		maxPoints: limit,
+		queue:     q,
+		end:       c.End,
+	}
+}
+
+// Finished tells whether we have simulated all the necessary points, either
+// reaching the points limit or the end of the simulation.
+func (s *Simulator) Finished() bool {
+	if s.maxPoints > 0 && s.madePoints >= s.maxPoints {
+		return true
+	}
+	return len(s.queue) == 0 || !s.queue[0].uploadAt.Before(s.end)
+}
+
+// Next advances a Point to the next uploaded reading.
+func (s *Simulator) Next(p *data.Point) bool {
+	m := s.queue[0]
+	m.toPoint(p)
+	m.next()
+	heap.Fix(&s.queue, 0)
+	s.madePoints++
+	return true
+}
+
+// Fields returns the fields of the energy measurement.
+func (s *Simulator) Fields() map[string][]string {
+	fields := make([]string, len(energyFieldKeys))
+	for i, k := range energyFieldKeys {
+		fields[i] = string(k)
+	}
+	return map[string][]string{string(labelEnergy): fields}
+}
+
+// TagKeys returns the tag keys of the meters.
+func (s *Simulator) TagKeys() []string {
+	keys := make([]string, len(tagKeys))
+	for i, k := range tagKeys {
+		keys[i] = string(k)
+	}
+	return keys
+}
+
+// TagTypes returns the type for each tag, all of them being strings.
+func (s *Simulator) TagTypes() []string {
+	types := make([]string, len(tagKeys))
+	for i := range types {
+		types[i] = "string"
+	}
+	return types
+}
+
+func (s *Simulator) Headers() *common.GeneratedDataHeaders {
+	return &common.GeneratedDataHeaders{
+		TagTypes:  s.TagTypes(),
+		TagKeys:   s.TagKeys(),
+		FieldKeys: s.Fields(),
+	}
+}
@@ -0,0 +1,107 @@
+package smartmeter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tsbs/pkg/data"
+)
+
+func TestSimulatorNext(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(7 * 24 * time.Hour)
+	c := &SimulatorConfig{Start: start, End: end, MeterCount: 500}
+	s := c.NewSimulator(10*time.Second, 0).(*Simulator)
+
+	lastRead := map[string]time.Time{}
+	var latest time.Time
+	backfilled := 0
+	for !s.Finished() {
+		p := data.NewPoint()
+		if !s.Next(p) {
+			t.Fatalf("reading not written")
+		}
+		ts := *p.Timestamp()
+		if ts.Before(start) || !ts.Before(end) {
+			t.Fatalf("reading out of the simulated time range: %v", ts)
+		}
+		if ts.Before(latest) {
+			backfilled++
+		} else {
+			latest = ts
+		}
+
+		name := p.GetTagValue(labelMeter).(string)
+		prev, ok := lastRead[name]
+		if !ok && !ts.Equal(start) {
+			t.Fatalf("first reading of %s does not start at the beginning: %v", name, ts)
+		}
+		if ok {
+			gap := ts.Sub(prev)
+			switch p.GetTagValue(labelCadence) {
+			case cadence15Minutes:
+				if gap != 15*time.Minute {
+					t.Fatalf("incorrect gap for %s: %v", name, gap)
+				}
+			case cadenceHourly:
+				if gap != time.Hour {
+					t.Fatalf("incorrect gap for %s: %v", name, gap)
+				}
+			default:
+				if gap < minOnDemandGap {
+					t.Fatalf("incorrect gap for %s: %v", name, gap)
+				}
+			}
+		}
+		lastRead[name] = ts
+	}
+	if len(lastRead) != 500 {
+		t.Errorf("incorrect number of meters: got %d want 500", len(lastRead))
+	}
+	if backfilled == 0 {
+		t.Errorf("no backfilled readings")
+	}
+	if s.madePoints == 0 {
+		t.Errorf("no points made")
+	}
+}
+
+func TestSimulatorHeaders(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(time.Hour), MeterCount: 10}
+	h := c.NewSimulator(10*time.Second, 0).Headers()
+	wantTags := []string{"meter", "region", "tariff", "cadence"}
+	if len(h.TagKeys) != len(wantTags) {
+		t.Fatalf("incorrect tag keys: got %v want %v", h.TagKeys, wantTags)
+	}
+	for i, k := range wantTags {
+		if h.TagKeys[i] != k || h.TagTypes[i] != "string" {
+			t.Errorf("incorrect tag %d: got %s %s want %s string", i, h.TagKeys[i], h.TagTypes[i], k)
+		}
+	}
+	wantFields := []string{"consumption_kwh", "total_kwh", "voltage"}
+	fields := h.FieldKeys[string(labelEnergy)]
+	if len(fields) != len(wantFields) {
+		t.Fatalf("incorrect fields: got %v want %v", fields, wantFields)
+	}
+	for i, k := range wantFields {
+		if fields[i] != k {
+			t.Errorf("incorrect field %d: got %s want %s", i, fields[i], k)
+		}
+	}
+}
+
+func TestSimulatorLimit(t *testing.T) {
+	start := time.Now()
+	c := &SimulatorConfig{Start: start, End: start.Add(24 * time.Hour), MeterCount: 10}
+	s := c.NewSimulator(10*time.Second, 5)
+	for i := 0; i < 5; i++ {
+		if s.Finished() {
+			t.Fatalf("finished after %d points", i)
+		}
+		s.Next(data.NewPoint())
+	}
+	if !s.Finished() {
+		t.Errorf("not finished after the limit")
+	}
+}
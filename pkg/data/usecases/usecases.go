@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"github.com/timescale/tsbs/internal/utils"
 	"github.com/timescale/tsbs/pkg/data/usecases/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/custom"
 	"github.com/timescale/tsbs/pkg/data/usecases/devops"
+	"github.com/timescale/tsbs/pkg/data/usecases/finance"
 	"github.com/timescale/tsbs/pkg/data/usecases/iot"
+	"github.com/timescale/tsbs/pkg/data/usecases/k8s"
+	"github.com/timescale/tsbs/pkg/data/usecases/logs"
+	"github.com/timescale/tsbs/pkg/data/usecases/smartmeter"
 	"math"
 )
 
@@ -42,6 +47,50 @@ func GetSimulatorConfig(dgc *common.DataGeneratorConfig) (common.SimulatorConfig
 			GeneratorScale:       dgc.Scale,
 			GeneratorConstructor: iot.NewTruck,
 		}
+	case common.UseCaseFinance:
+		ret = &finance.SimulatorConfig{
+			Start: tsStart,
+			End:   tsEnd,
+
+			InitGeneratorScale:   dgc.InitialScale,
+			GeneratorScale:       dgc.Scale,
+			GeneratorConstructor: finance.NewSymbol,
+		}
+	case common.UseCaseK8s:
+		ret = &k8s.SimulatorConfig{
+			Start: tsStart,
+			End:   tsEnd,
+
+			PodCount: dgc.Scale,
+		}
+	case common.UseCaseLogs:
+		ret = &logs.SimulatorConfig{
+			Start: tsStart,
+			End:   tsEnd,
+
+			InitGeneratorScale:   dgc.InitialScale,
+			GeneratorScale:       dgc.Scale,
+			GeneratorConstructor: logs.NewInstance,
+		}
+	case common.UseCaseSmartMeter:
+		ret = &smartmeter.SimulatorConfig{
+			Start: tsStart,
+			End:   tsEnd,
+
+			MeterCount: dgc.Scale,
+		}
+	case common.UseCaseCustom:
+		schema, err := custom.LoadSchema(dgc.SchemaFile)
+		if err != nil {
+			return nil, err
+		}
+		ret = &custom.SimulatorConfig{
+			Start: tsStart,
+			End:   tsEnd,
+
+			Scale:  dgc.Scale,
+			Schema: schema,
+		}
 	case common.UseCaseCPUOnly:
 		ret = &devops.CPUOnlySimulatorConfig{
 			Start: tsStart,
@@ -2,8 +2,16 @@ package usecases
 
 import (
 	"github.com/timescale/tsbs/pkg/data/usecases/common"
+	"github.com/timescale/tsbs/pkg/data/usecases/custom"
 	"github.com/timescale/tsbs/pkg/data/usecases/devops"
+	"github.com/timescale/tsbs/pkg/data/usecases/finance"
 	"github.com/timescale/tsbs/pkg/data/usecases/iot"
+	"github.com/timescale/tsbs/pkg/data/usecases/k8s"
+	"github.com/timescale/tsbs/pkg/data/usecases/logs"
+	"github.com/timescale/tsbs/pkg/data/usecases/smartmeter"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -36,6 +44,10 @@ func TestGetSimulatorConfig(t *testing.T) {
 
 	checkType(common.UseCaseDevops, &devops.DevopsSimulatorConfig{})
 	checkType(common.UseCaseIoT, &iot.SimulatorConfig{})
+	checkType(common.UseCaseFinance, &finance.SimulatorConfig{})
+	checkType(common.UseCaseK8s, &k8s.SimulatorConfig{})
+	checkType(common.UseCaseLogs, &logs.SimulatorConfig{})
+	checkType(common.UseCaseSmartMeter, &smartmeter.SimulatorConfig{})
 	checkType(common.UseCaseCPUOnly, &devops.CPUOnlySimulatorConfig{})
 	checkType(common.UseCaseCPUSingle, &devops.CPUOnlySimulatorConfig{})
 
@@ -45,3 +57,53 @@ func TestGetSimulatorConfig(t *testing.T) {
 		t.Errorf("unexpected lack of error for bogus use case")
 	}
 }
+
+func TestGetSimulatorConfigCustom(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	schema := `
+tags:
+  - key: device
+generators:
+  - measurements:
+      - name: power
+        fields:
+          - name: watts
+            distribution: {type: uniform, low: 0, high: 100}
+`
+	path := filepath.Join(dir, "schema.yaml")
+	if err := ioutil.WriteFile(path, []byte(schema), 0644); err != nil {
+		t.Fatalf("could not write schema: %v", err)
+	}
+
+	dgc := &common.DataGeneratorConfig{
+		BaseConfig: common.BaseConfig{
+			Use:       common.UseCaseCustom,
+			Scale:     1,
+			TimeStart: "2020-01-01T00:00:00Z",
+			TimeEnd:   "2020-01-01T00:00:01Z",
+		},
+		LogInterval: defaultLogInterval,
+		SchemaFile:  path,
+	}
+	scfg, err := GetSimulatorConfig(dgc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, ok := scfg.(*custom.SimulatorConfig)
+	if !ok {
+		t.Fatalf("custom use case does not give right scfg: got %T", scfg)
+	}
+	if cfg.Schema == nil || cfg.Scale != 1 {
+		t.Errorf("incorrect custom config: %+v", cfg)
+	}
+
+	dgc.SchemaFile = filepath.Join(dir, "missing.yaml")
+	if _, err := GetSimulatorConfig(dgc); err == nil {
+		t.Errorf("unexpected lack of error for missing schema file")
+	}
+}
@@ -25,19 +25,22 @@ const (
 
 // BenchmarkRunnerConfig is the configuration of the benchmark runner.
 type BenchmarkRunnerConfig struct {
-	DBName           string `mapstructure:"db-name"`
-	Limit            uint64 `mapstructure:"max-queries"`
-	LimitRPS         uint64 `mapstructure:"max-rps"`
-	MemProfile       string `mapstructure:"memprofile"`
-	HDRLatenciesFile string `mapstructure:"hdr-latencies"`
-	Workers          uint   `mapstructure:"workers"`
-	PrintResponses   bool   `mapstructure:"print-responses"`
-	Debug            int    `mapstructure:"debug"`
-	FileName         string `mapstructure:"file"`
-	BurnIn           uint64 `mapstructure:"burn-in"`
-	PrintInterval    uint64 `mapstructure:"print-interval"`
-	PrewarmQueries   bool   `mapstructure:"prewarm-queries"`
-	ResultsFile      string `mapstructure:"results-file"`
+	DBName           string        `mapstructure:"db-name"`
+	Limit            uint64        `mapstructure:"max-queries"`
+	LimitRPS         uint64        `mapstructure:"max-rps"`
+	MemProfile       string        `mapstructure:"memprofile"`
+	HDRLatenciesFile string        `mapstructure:"hdr-latencies"`
+	HDRIntervalLog   string        `mapstructure:"hdr-interval-log"`
+	HDRIntervalCSV   string        `mapstructure:"hdr-interval-csv"`
+	HDRInterval      time.Duration `mapstructure:"hdr-interval"`
+	Workers          uint          `mapstructure:"workers"`
+	PrintResponses   bool          `mapstructure:"print-responses"`
+	Debug            int           `mapstructure:"debug"`
+	FileName         string        `mapstructure:"file"`
+	BurnIn           uint64        `mapstructure:"burn-in"`
+	PrintInterval    uint64        `mapstructure:"print-interval"`
+	PrewarmQueries   bool          `mapstructure:"prewarm-queries"`
+	ResultsFile      string        `mapstructure:"results-file"`
 }
 
 // AddToFlagSet adds command line flags needed by the BenchmarkRunnerConfig to the flag set.
@@ -49,6 +52,9 @@ func (c BenchmarkRunnerConfig) AddToFlagSet(fs *pflag.FlagSet) {
 	fs.Uint64("print-interval", 100, "Print timing stats to stderr after this many queries (0 to disable)")
 	fs.String("memprofile", "", "Write a memory profile to this file.")
 	fs.String("hdr-latencies", "", "Write the High Dynamic Range (HDR) Histogram of Response Latencies to this file.")
+	fs.String("hdr-interval-log", "", "Write per-label HDR Histograms of Response Latencies for every interval to this file, in the HdrHistogram log format.")
+	fs.String("hdr-interval-csv", "", "Write per-label latency percentiles for every interval to this CSV file.")
+	fs.Duration("hdr-interval", defaultHDRInterval, "Length of the intervals written by --hdr-interval-log and --hdr-interval-csv.")
 	fs.Uint("workers", 1, "Number of concurrent requests to make.")
 	fs.Bool("prewarm-queries", false, "Run each query twice in a row so the warm query is guaranteed to be a cache hit")
 	fs.Bool("print-responses", false, "Pretty print response bodies for correctness checking (default false).")
@@ -78,6 +84,9 @@ func NewBenchmarkRunner(config BenchmarkRunnerConfig) *BenchmarkRunner {
 		prewarmQueries:   runner.PrewarmQueries,
 		burnIn:           runner.BurnIn,
 		hdrLatenciesFile: runner.HDRLatenciesFile,
+		hdrIntervalLog:   runner.HDRIntervalLog,
+		hdrIntervalCSV:   runner.HDRIntervalCSV,
+		hdrInterval:      runner.HDRInterval,
 	}
 
 	runner.sp = newStatProcessor(spArgs)
@@ -116,6 +125,13 @@ type Processor interface {
 	ProcessQuery(q Query, isWarm bool) ([]*Stat, error)
 }
 
+// ProcessorCloser is a Processor that also needs to close or cleanup afterwards
+type ProcessorCloser interface {
+	Processor
+	// Close cleans up after a Processor
+	Close()
+}
+
 // GetBufferedReader returns the buffered Reader that should be used by the loader
 func (b *BenchmarkRunner) GetBufferedReader() *bufio.Reader {
 	if b.br == nil {
@@ -242,6 +258,13 @@ func (b *BenchmarkRunner) processorHandler(wg *sync.WaitGroup, rateLimiter *rate
 		}
 		queryPool.Put(query)
 	}
+
+	// Close processor if necessary
+	switch c := processor.(type) {
+	case ProcessorCloser:
+		c.Close()
+	}
+
 	wg.Done()
 }
 
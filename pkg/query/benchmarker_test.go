@@ -63,6 +63,35 @@ func TestProcessorHandler(t *testing.T) {
 	}
 }
 
+type testProcessorCloser struct {
+	testProcessor
+	closed bool
+}
+
+func (p *testProcessorCloser) Close() {
+	p.closed = true
+}
+
+func TestProcessorHandlerClose(t *testing.T) {
+	p := &testProcessorCloser{}
+	b := NewBenchmarkRunner(BenchmarkRunnerConfig{})
+	b.ch = make(chan Query, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go b.processorHandler(&wg, rate.NewLimiter(rate.Inf, 0), &testQueryPool, p, 0)
+	b.ch <- testQueryPool.Get().(*testQuery)
+	close(b.ch)
+	wg.Wait()
+
+	if p.count != 1 {
+		t.Errorf("incorrect number of queries: got %d want 1", p.count)
+	}
+	if !p.closed {
+		t.Errorf("processor not closed")
+	}
+}
+
 func TestProcessorHandlerPreWarm(t *testing.T) {
 	qLimit := 17
 	p1Num := 0
@@ -13,7 +13,10 @@ type ClickHouse struct {
 
 	Table    []byte // e.g. "cpu"
 	SqlQuery []byte
-	id       uint64
+	// SqlArgs holds the values for the placeholders of a parameterized
+	// SqlQuery. It is empty when SqlQuery is a literal statement.
+	SqlArgs []string
+	id      uint64
 }
 
 // ClickHousePool is a sync.Pool of ClickHouse Query types
@@ -24,6 +27,7 @@ var ClickHousePool = sync.Pool{
 			HumanDescription: make([]byte, 0, 1024),
 			Table:            make([]byte, 0, 1024),
 			SqlQuery:         make([]byte, 0, 1024),
+			SqlArgs:          make([]string, 0, 16),
 		}
 	},
 }
@@ -45,6 +49,9 @@ func (ch *ClickHouse) SetID(n uint64) {
 
 // String produces a debug-ready description of a Query.
 func (ch *ClickHouse) String() string {
+	if len(ch.SqlArgs) > 0 {
+		return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Table: %s, Query: %s, Args: %v", ch.HumanLabel, ch.HumanDescription, ch.Table, ch.SqlQuery, ch.SqlArgs)
+	}
 	return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Table: %s, Query: %s", ch.HumanLabel, ch.HumanDescription, ch.Table, ch.SqlQuery)
 }
 
@@ -65,6 +72,7 @@ func (ch *ClickHouse) Release() {
 
 	ch.Table = ch.Table[:0]
 	ch.SqlQuery = ch.SqlQuery[:0]
+	ch.SqlArgs = ch.SqlArgs[:0]
 
 	ClickHousePool.Put(ch)
 }
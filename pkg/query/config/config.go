@@ -20,6 +20,13 @@ type QueryGeneratorConfig struct {
 	InterleavedGroupID   uint   `mapstructure:"interleaved-generation-group-id"`
 	InterleavedNumGroups uint   `mapstructure:"interleaved-generation-groups"`
 
+	MaxMetricCountPerHost uint64 `mapstructure:"max-metric-count"`
+
+	WindowPlacement string `mapstructure:"window-placement"`
+
+	QueryMatrixFile    string `mapstructure:"query-matrix"`
+	QueryTemplatesFile string `mapstructure:"query-templates"`
+
 	// TODO - I think this needs some rethinking, but a simple, elegant solution escapes me right now
 	TimescaleUseJSON       bool `mapstructure:"timescale-use-json"`
 	TimescaleUseTags       bool `mapstructure:"timescale-use-tags"`
@@ -27,6 +34,12 @@ type QueryGeneratorConfig struct {
 
 	ClickhouseUseTags bool `mapstructure:"clickhouse-use-tags"`
 
+	SQLPreparedStatements bool `mapstructure:"sql-prepared-statements"`
+
+	PrometheusUseRemoteRead bool `mapstructure:"prometheus-use-remote-read"`
+
+	InfluxUseFlux bool `mapstructure:"influx-use-flux"`
+
 	MongoUseNaive bool   `mapstructure:"mongo-use-native"`
 	DbName        string `mapstructure:"db-name"`
 }
@@ -55,12 +68,19 @@ func (c *QueryGeneratorConfig) AddToFlagSet(fs *pflag.FlagSet) {
 		"Group (0-indexed) to perform round-robin serialization within. Use this to scale up data generation to multiple processes.")
 	fs.Uint("interleaved-generation-groups", 1,
 		"The number of round-robin serialization groups. Use this to scale up data generation to multiple processes.")
+	fs.Uint64("max-metric-count", 100, "Max number of metric fields generated per host. Used only in devops-generic use-case")
+	fs.String("window-placement", "uniform", "How the time windows of queries are placed in the time range. (Choices are uniform, latest, exponential and zipf; the last three favor recent data.)")
+	fs.String("query-matrix", "", "YAML file with additional query types for the devops and cpu-only use cases")
+	fs.String("query-templates", "", "YAML file with additional query types generated from query templates")
 
 	fs.Bool("clickhouse-use-tags", true, "ClickHouse only: Use separate tags table when querying")
+	fs.Bool("sql-prepared-statements", false, "TimescaleDB, CrateDB and ClickHouse only: Emit parameterized query templates with an argument list instead of literal SQL")
+	fs.Bool("influx-use-flux", false, "Influx only: Generate Flux queries for the InfluxDB 2.x query API, reading the bucket named by db-name")
+	fs.Bool("prometheus-use-remote-read", false, "Prometheus only: Read the raw samples of the queried series through the remote-read protocol instead of the query API")
 	fs.Bool("mongo-use-naive", true, "MongoDB only: Generate queries for the 'naive' data storage format for Mongo")
 	fs.Bool("timescale-use-json", false, "TimescaleDB only: Use separate JSON tags table when querying")
 	fs.Bool("timescale-use-tags", true, "TimescaleDB only: Use separate tags table when querying")
 	fs.Bool("timescale-use-time-bucket", true, "TimescaleDB only: Use time bucket. Set to false to test on native PostgreSQL")
 
-	fs.String("db-name", "benchmark", "Specify database name. Timestream requires it in order to generate the queries")
+	fs.String("db-name", "benchmark", "Specify database name. Timestream requires it in order to generate the queries, Influx Flux queries read the bucket of that name")
 }
@@ -13,7 +13,10 @@ type CrateDB struct {
 
 	Table    []byte // e.g. "cpu"
 	SqlQuery []byte
-	id       uint64
+	// SqlArgs holds the values for the placeholders of a parameterized
+	// SqlQuery. It is empty when SqlQuery is a literal statement.
+	SqlArgs []string
+	id      uint64
 }
 
 var CrateDBPool = sync.Pool{
@@ -23,6 +26,7 @@ var CrateDBPool = sync.Pool{
 			HumanDescription: make([]byte, 0, 1024),
 			Table:            make([]byte, 0, 1024),
 			SqlQuery:         make([]byte, 0, 1024),
+			SqlArgs:          make([]string, 0, 16),
 		}
 	},
 }
@@ -41,6 +45,10 @@ func (q *CrateDB) SetID(n uint64) {
 
 // String produces a debug-ready description of a Query.
 func (q *CrateDB) String() string {
+	if len(q.SqlArgs) > 0 {
+		return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Table: %s, Query: %s, Args: %v",
+			q.HumanLabel, q.HumanDescription, q.Table, q.SqlQuery, q.SqlArgs)
+	}
 	return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Table: %s, Query: %s",
 		q.HumanLabel, q.HumanDescription, q.Table, q.SqlQuery)
 }
@@ -61,6 +69,7 @@ func (q *CrateDB) Release() {
 
 	q.Table = q.Table[:0]
 	q.SqlQuery = q.SqlQuery[:0]
+	q.SqlArgs = q.SqlArgs[:0]
 
 	CrateDBPool.Put(q)
 }
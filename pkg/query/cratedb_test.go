@@ -11,6 +11,9 @@ func TestNewCrateDB(t *testing.T) {
 		if got := len(tq.SqlQuery); got != 0 {
 			t.Errorf("new query has non-0 sql query: got %d", got)
 		}
+		if got := len(tq.SqlArgs); got != 0 {
+			t.Errorf("new query has non-0 sql args: got %d", got)
+		}
 	}
 	tq := NewCrateDB()
 	check(tq)
@@ -18,6 +21,7 @@ func TestNewCrateDB(t *testing.T) {
 	tq.HumanDescription = []byte("bar")
 	tq.Table = []byte("table")
 	tq.SqlQuery = []byte("SELECT * FROM *")
+	tq.SqlArgs = append(tq.SqlArgs, "foo")
 	tq.SetID(1)
 	if got := string(tq.HumanLabelName()); got != "foo" {
 		t.Errorf("incorrect label name: got %s", got)
@@ -7,6 +7,7 @@ import (
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/cratedb"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/influx"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/mongo"
+	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/prometheus"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/questdb"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/siridb"
 	"github.com/timescale/tsbs/cmd/tsbs_generate_queries/databases/timescaledb"
@@ -20,14 +21,21 @@ func InitQueryFactories(config *config.QueryGeneratorConfig) map[string]interfac
 	factories := make(map[string]interface{})
 	factories[constants.FormatCassandra] = &cassandra.BaseGenerator{}
 	factories[constants.FormatClickhouse] = &clickhouse.BaseGenerator{
-		UseTags: config.ClickhouseUseTags,
+		UseTags:               config.ClickhouseUseTags,
+		UsePreparedStatements: config.SQLPreparedStatements,
+	}
+	factories[constants.FormatCrateDB] = &cratedb.BaseGenerator{
+		UsePreparedStatements: config.SQLPreparedStatements,
+	}
+	factories[constants.FormatInflux] = &influx.BaseGenerator{
+		UseFlux: config.InfluxUseFlux,
+		Bucket:  config.DbName,
 	}
-	factories[constants.FormatCrateDB] = &cratedb.BaseGenerator{}
-	factories[constants.FormatInflux] = &influx.BaseGenerator{}
 	factories[constants.FormatTimescaleDB] = &timescaledb.BaseGenerator{
-		UseJSON:       config.TimescaleUseJSON,
-		UseTags:       config.TimescaleUseTags,
-		UseTimeBucket: config.TimescaleUseTimeBucket,
+		UseJSON:               config.TimescaleUseJSON,
+		UseTags:               config.TimescaleUseTags,
+		UseTimeBucket:         config.TimescaleUseTimeBucket,
+		UsePreparedStatements: config.SQLPreparedStatements,
 	}
 	factories[constants.FormatSiriDB] = &siridb.BaseGenerator{}
 	factories[constants.FormatMongo] = &mongo.BaseGenerator{
@@ -39,5 +47,8 @@ func InitQueryFactories(config *config.QueryGeneratorConfig) map[string]interfac
 		DBName: config.DbName,
 	}
 	factories[constants.FormatQuestDB] = &questdb.BaseGenerator{}
+	factories[constants.FormatPrometheus] = &prometheus.BaseGenerator{
+		UseRemoteRead: config.PrometheusUseRemoteRead,
+	}
 	return factories
 }
@@ -0,0 +1,221 @@
+package query
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// defaultHDRInterval is how often interval histograms are written when no
+// explicit interval is set
+const defaultHDRInterval = 10 * time.Second
+
+var hdrIntervalCSVHeader = []string{
+	"interval_start", "interval_end", "label", "count",
+	"min", "mean", "p50", "p90", "p95", "p99", "p999", "max",
+}
+
+// hdrIntervalLogger records latencies per label into histograms that are
+// written out and reset every interval. Histograms are written to an
+// HdrHistogram interval log (one tagged line per label and interval, readable
+// by the standard HdrHistogram tooling) and percentiles to a CSV file.
+// Either output may be nil. Timestamps are relative to the logger start.
+type hdrIntervalLogger struct {
+	interval      time.Duration
+	start         time.Time
+	intervalStart time.Time
+	hists         map[string]*hdrhistogram.Histogram
+
+	log *hdrhistogram.HistogramLogWriter
+	w   io.Writer
+	csv *csv.Writer
+
+	closers []io.Closer
+}
+
+// newHDRIntervalLogger creates a logger writing to the given files, either of
+// which may be empty to skip that output.
+func newHDRIntervalLogger(logFile, csvFile string, interval time.Duration, start time.Time) (*hdrIntervalLogger, error) {
+	var closers []io.Closer
+	closeAll := func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}
+	var logW, csvW io.Writer
+	if len(logFile) > 0 {
+		f, err := os.Create(logFile)
+		if err != nil {
+			return nil, err
+		}
+		logW = f
+		closers = append(closers, f)
+	}
+	if len(csvFile) > 0 {
+		f, err := os.Create(csvFile)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		csvW = f
+		closers = append(closers, f)
+	}
+	l, err := newHDRIntervalLoggerWriters(logW, csvW, interval, start)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	l.closers = closers
+	return l, nil
+}
+
+// newHDRIntervalLoggerWriters creates a logger writing to logW and csvW,
+// either of which may be nil, and writes their headers.
+func newHDRIntervalLoggerWriters(logW, csvW io.Writer, interval time.Duration, start time.Time) (*hdrIntervalLogger, error) {
+	if interval <= 0 {
+		interval = defaultHDRInterval
+	}
+	l := &hdrIntervalLogger{
+		interval:      interval,
+		start:         start,
+		intervalStart: start,
+		hists:         make(map[string]*hdrhistogram.Histogram),
+	}
+	if logW != nil {
+		l.w = logW
+		l.log = hdrhistogram.NewHistogramLogWriter(logW)
+		startSec := float64(start.UnixNano()) / 1e9
+		if err := l.log.OutputLogFormatVersion(); err != nil {
+			return nil, err
+		}
+		// StartTime/BaseTime written by hand: the library helpers drop the
+		// fractional seconds, which shifts every relative timestamp
+		if _, err := fmt.Fprintf(logW, "#[StartTime: %.3f (seconds since epoch), %s]\n", startSec, start.UTC().Format(time.RFC3339Nano)); err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintf(logW, "#[BaseTime: %.3f (seconds since epoch)]\n", startSec); err != nil {
+			return nil, err
+		}
+		if err := l.log.OutputComment("Latencies are recorded in microseconds; Interval_Max is in milliseconds"); err != nil {
+			return nil, err
+		}
+		if err := l.log.OutputLegend(); err != nil {
+			return nil, err
+		}
+	}
+	if csvW != nil {
+		l.csv = csv.NewWriter(csvW)
+		if err := l.csv.Write(hdrIntervalCSVHeader); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// record adds a latency (in milliseconds) observed at now for label,
+// first writing out any intervals that ended before now.
+func (l *hdrIntervalLogger) record(label string, value float64, now time.Time) error {
+	if err := l.advance(now); err != nil {
+		return err
+	}
+	h, ok := l.hists[label]
+	if !ok {
+		h = hdrhistogram.New(1, 3600000000, 4)
+		h.SetTag(stripRegex(label))
+		l.hists[label] = h
+	}
+	return h.RecordValue(int64(value * hdrScaleFactor))
+}
+
+// advance writes out and resets the histograms of every whole interval that
+// ended before now.
+func (l *hdrIntervalLogger) advance(now time.Time) error {
+	for !now.Before(l.intervalStart.Add(l.interval)) {
+		end := l.intervalStart.Add(l.interval)
+		if err := l.flush(end); err != nil {
+			return err
+		}
+		l.intervalStart = end
+	}
+	return nil
+}
+
+// flush writes the histograms of the current interval, ending at end, for
+// every label with at least one value, and resets them.
+func (l *hdrIntervalLogger) flush(end time.Time) error {
+	labels := make([]string, 0, len(l.hists))
+	for label, h := range l.hists {
+		if h.TotalCount() > 0 {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	startSec := l.intervalStart.Sub(l.start).Seconds()
+	lengthSec := end.Sub(l.intervalStart).Seconds()
+	for _, label := range labels {
+		h := l.hists[label]
+		if l.log != nil {
+			payload, err := h.Encode(hdrhistogram.V2CompressedEncodingCookieBase)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(l.w, "Tag=%s,%.3f,%.3f,%.3f,%s\n", h.Tag(), startSec, lengthSec, float64(h.Max())/hdrScaleFactor, payload)
+			if err != nil {
+				return err
+			}
+		}
+		if l.csv != nil {
+			if err := l.csv.Write(intervalCSVRecord(label, startSec, startSec+lengthSec, h)); err != nil {
+				return err
+			}
+		}
+		h.Reset()
+	}
+	if l.csv != nil {
+		l.csv.Flush()
+		return l.csv.Error()
+	}
+	return nil
+}
+
+func intervalCSVRecord(label string, startSec, endSec float64, h *hdrhistogram.Histogram) []string {
+	ms := func(v int64) string {
+		return strconv.FormatFloat(float64(v)/hdrScaleFactor, 'f', 3, 64)
+	}
+	return []string{
+		strconv.FormatFloat(startSec, 'f', 3, 64),
+		strconv.FormatFloat(endSec, 'f', 3, 64),
+		label,
+		strconv.FormatInt(h.TotalCount(), 10),
+		ms(h.Min()),
+		strconv.FormatFloat(h.Mean()/hdrScaleFactor, 'f', 3, 64),
+		ms(h.ValueAtQuantile(50.0)),
+		ms(h.ValueAtQuantile(90.0)),
+		ms(h.ValueAtQuantile(95.0)),
+		ms(h.ValueAtQuantile(99.0)),
+		ms(h.ValueAtQuantile(99.9)),
+		ms(h.Max()),
+	}
+}
+
+// close writes out the last, possibly partial, interval ending at end and
+// closes the underlying files.
+func (l *hdrIntervalLogger) close(end time.Time) error {
+	err := l.advance(end)
+	if err == nil && end.After(l.intervalStart) {
+		err = l.flush(end)
+	}
+	for _, c := range l.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
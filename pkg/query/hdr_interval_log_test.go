@@ -0,0 +1,129 @@
+package query
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+func TestHDRIntervalLogger(t *testing.T) {
+	start := time.Unix(1600000000, 250*int64(time.Millisecond))
+	var logBuf, csvBuf bytes.Buffer
+	l, err := newHDRIntervalLoggerWriters(&logBuf, &csvBuf, 10*time.Second, start)
+	if err != nil {
+		t.Fatalf("unexpected error creating logger: %v", err)
+	}
+
+	records := []struct {
+		label string
+		value float64
+		at    time.Duration
+	}{
+		{"cpu max, 1 host", 1.5, time.Second},
+		{"cpu max, 1 host", 2.5, 2 * time.Second},
+		{labelAllQueries, 1.5, time.Second},
+		{labelAllQueries, 2.5, 2 * time.Second},
+		// second interval is empty, third has a single value
+		{labelAllQueries, 40, 25 * time.Second},
+	}
+	for _, r := range records {
+		if err := l.record(r.label, r.value, start.Add(r.at)); err != nil {
+			t.Fatalf("unexpected error recording: %v", err)
+		}
+	}
+	if err := l.close(start.Add(27 * time.Second)); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	// histogram log
+	wantHeader := "#[StartTime: 1600000000.250 (seconds since epoch)"
+	if !strings.Contains(logBuf.String(), wantHeader) {
+		t.Errorf("log is missing start time %q:\n%s", wantHeader, logBuf.String())
+	}
+	wantHists := []struct {
+		tag     string
+		startMs int64
+		endMs   int64
+		count   int64
+		max     int64
+	}{
+		{"all_queries", 1600000000250, 1600000010250, 2, 2500},
+		{"cpu_max_1_host", 1600000000250, 1600000010250, 2, 2500},
+		{"all_queries", 1600000020250, 1600000027250, 1, 40000},
+	}
+	r := hdrhistogram.NewHistogramLogReader(&logBuf)
+	for i, want := range wantHists {
+		h, err := r.NextIntervalHistogram()
+		if err != nil {
+			t.Fatalf("unexpected error reading histogram %d: %v", i, err)
+		}
+		if h == nil {
+			t.Fatalf("missing histogram %d", i)
+		}
+		if got := h.Tag(); got != want.tag {
+			t.Errorf("histogram %d: incorrect tag: got %s want %s", i, got, want.tag)
+		}
+		if got := h.StartTimeMs(); got != want.startMs {
+			t.Errorf("histogram %d: incorrect start: got %d want %d", i, got, want.startMs)
+		}
+		if got := h.EndTimeMs(); got != want.endMs {
+			t.Errorf("histogram %d: incorrect end: got %d want %d", i, got, want.endMs)
+		}
+		if got := h.TotalCount(); got != want.count {
+			t.Errorf("histogram %d: incorrect count: got %d want %d", i, got, want.count)
+		}
+		if got := h.Max(); got < want.max || got > want.max+want.max/1000 {
+			t.Errorf("histogram %d: incorrect max: got %d want %d", i, got, want.max)
+		}
+	}
+	if h, err := r.NextIntervalHistogram(); err != nil || h != nil {
+		t.Errorf("unexpected extra histogram: %v, %v", h, err)
+	}
+
+	// percentiles CSV
+	rows, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error reading csv: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("incorrect number of csv rows: got %d want 4:\n%v", len(rows), rows)
+	}
+	if got := strings.Join(rows[0], ","); got != strings.Join(hdrIntervalCSVHeader, ",") {
+		t.Errorf("incorrect csv header: got %s", got)
+	}
+	wantRows := [][]string{
+		{"0.000", "10.000", labelAllQueries, "2", "1.500"},
+		{"0.000", "10.000", "cpu max, 1 host", "2", "1.500"},
+		{"20.000", "27.000", labelAllQueries, "1", "40.000"},
+	}
+	for i, want := range wantRows {
+		got := rows[i+1][:len(want)]
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("csv row %d: got %v want %v", i, got, want)
+		}
+	}
+	if got := rows[3][len(rows[3])-1]; !strings.HasPrefix(got, "40.0") {
+		t.Errorf("incorrect max in last csv row: got %s", got)
+	}
+}
+
+func TestHDRIntervalLoggerNoOutputs(t *testing.T) {
+	start := time.Now()
+	l, err := newHDRIntervalLoggerWriters(nil, nil, 0, start)
+	if err != nil {
+		t.Fatalf("unexpected error creating logger: %v", err)
+	}
+	if l.interval != defaultHDRInterval {
+		t.Errorf("incorrect default interval: got %v want %v", l.interval, defaultHDRInterval)
+	}
+	if err := l.record("q", 1, start.Add(25*time.Second)); err != nil {
+		t.Errorf("unexpected error recording: %v", err)
+	}
+	if err := l.close(start.Add(30 * time.Second)); err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+}
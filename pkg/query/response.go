@@ -0,0 +1,42 @@
+package query
+
+import "encoding/json"
+
+// ResponseInfo describes the size of a query response and how long it took
+// until its first byte arrived, as recorded by Stat.SetResponse.
+type ResponseInfo struct {
+	Rows        uint64
+	Bytes       uint64
+	TimeToFirst float64
+}
+
+// CountPrometheusRows counts the samples in a Prometheus query API response:
+// one per series of an instant vector, or all the values of a range matrix.
+// Label values responses count one row per value.
+func CountPrometheusRows(body []byte) uint64 {
+	var values struct {
+		Data []string `json:"data"`
+	}
+	if err := json.Unmarshal(body, &values); err == nil {
+		return uint64(len(values.Data))
+	}
+	var r struct {
+		Data struct {
+			Result []struct {
+				Values []json.RawMessage `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return 0
+	}
+	rows := uint64(0)
+	for _, series := range r.Data.Result {
+		if len(series.Values) == 0 {
+			rows++
+			continue
+		}
+		rows += uint64(len(series.Values))
+	}
+	return rows
+}
@@ -0,0 +1,46 @@
+package query
+
+import "testing"
+
+func TestCountPrometheusRows(t *testing.T) {
+	cases := []struct {
+		desc string
+		body string
+		want uint64
+	}{
+		{
+			desc: "instant vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"hostname":"host_0"},"value":[1451606400,"1"]},` +
+				`{"metric":{"hostname":"host_1"},"value":[1451606400,"2"]}]}}`,
+			want: 2,
+		},
+		{
+			desc: "range matrix",
+			body: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"hostname":"host_0"},"values":[[1451606400,"1"],[1451606460,"2"],[1451606520,"3"]]},` +
+				`{"metric":{"hostname":"host_1"},"values":[[1451606400,"4"]]}]}}`,
+			want: 4,
+		},
+		{
+			desc: "label values",
+			body: `{"status":"success","data":["host_0","host_1","host_2"]}`,
+			want: 3,
+		},
+		{
+			desc: "empty result",
+			body: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			want: 0,
+		},
+		{
+			desc: "invalid JSON",
+			body: `not json`,
+			want: 0,
+		},
+	}
+	for _, c := range cases {
+		if got := CountPrometheusRows([]byte(c.body)); got != c.want {
+			t.Errorf("%s: incorrect rows: got %d want %d", c.desc, got, c.want)
+		}
+	}
+}
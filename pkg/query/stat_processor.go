@@ -25,12 +25,14 @@ type statProcessor interface {
 }
 
 type statProcessorArgs struct {
-	prewarmQueries   bool    // PrewarmQueries tells the StatProcessor whether we're running each query twice to prewarm the cache
-	limit            *uint64 // limit is the number of statistics to analyze before stopping
-	burnIn           uint64  // burnIn is the number of statistics to ignore before analyzing
-	printInterval    uint64  // printInterval is how often print intermediate stats (number of queries)
-	hdrLatenciesFile string  // hdrLatenciesFile is the filename to Write the High Dynamic Range (HDR) Histogram of Response Latencies to
-
+	prewarmQueries   bool          // PrewarmQueries tells the StatProcessor whether we're running each query twice to prewarm the cache
+	limit            *uint64       // limit is the number of statistics to analyze before stopping
+	burnIn           uint64        // burnIn is the number of statistics to ignore before analyzing
+	printInterval    uint64        // printInterval is how often print intermediate stats (number of queries)
+	hdrLatenciesFile string        // hdrLatenciesFile is the filename to Write the High Dynamic Range (HDR) Histogram of Response Latencies to
+	hdrIntervalLog   string        // hdrIntervalLog is the filename to write per-interval HDR Histograms of each label to
+	hdrIntervalCSV   string        // hdrIntervalCSV is the filename to write per-interval latency percentiles of each label to
+	hdrInterval      time.Duration // hdrInterval is the length of the intervals written to hdrIntervalLog and hdrIntervalCSV
 }
 
 // statProcessor is used to collect, analyze, and print query execution statistics.
@@ -42,6 +44,7 @@ type defaultStatProcessor struct {
 	startTime   time.Time
 	endTime     time.Time
 	statMapping map[string]*statGroup
+	intervalLog *hdrIntervalLogger // intervalLog is nil unless per-interval output was requested
 }
 
 func newStatProcessor(args *statProcessorArgs) statProcessor {
@@ -93,6 +96,13 @@ func (sp *defaultStatProcessor) process(workers uint) {
 
 	i := uint64(0)
 	sp.startTime = time.Now()
+	if len(sp.args.hdrIntervalLog) > 0 || len(sp.args.hdrIntervalCSV) > 0 {
+		var err error
+		sp.intervalLog, err = newHDRIntervalLogger(sp.args.hdrIntervalLog, sp.args.hdrIntervalCSV, sp.args.hdrInterval, sp.startTime)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 	prevTime := sp.startTime
 	prevRequestCount := uint64(0)
 
@@ -112,17 +122,18 @@ func (sp *defaultStatProcessor) process(workers uint) {
 			sp.statMapping[string(stat.label)] = newStatGroup(*sp.args.limit)
 		}
 
-		sp.statMapping[string(stat.label)].push(stat.value)
+		now := time.Now()
+		sp.pushStat(string(stat.label), stat, now)
 
 		if !stat.isPartial {
-			sp.statMapping[allQueriesLabel].push(stat.value)
+			sp.pushStat(allQueriesLabel, stat, now)
 
 			// Only needed when differentiating between cold & warm
 			if sp.args.prewarmQueries {
 				if stat.isWarm {
-					sp.statMapping[labelWarmQueries].push(stat.value)
+					sp.pushStat(labelWarmQueries, stat, now)
 				} else {
-					sp.statMapping[labelColdQueries].push(stat.value)
+					sp.pushStat(labelColdQueries, stat, now)
 				}
 			}
 
@@ -138,7 +149,6 @@ func (sp *defaultStatProcessor) process(workers uint) {
 
 		// print stats to stderr (if printInterval is greater than zero):
 		if sp.args.printInterval > 0 && i > 0 && i%sp.args.printInterval == 0 && (i < *sp.args.limit || *sp.args.limit == 0) {
-			now := time.Now()
 			sinceStart := now.Sub(sp.startTime)
 			took := now.Sub(prevTime)
 			intervalQueryRate := float64(sp.opsCount-prevRequestCount) / float64(took.Seconds())
@@ -164,7 +174,8 @@ func (sp *defaultStatProcessor) process(workers uint) {
 			prevTime = now
 		}
 	}
-	sinceStart := time.Now().Sub(sp.startTime)
+	end := time.Now()
+	sinceStart := end.Sub(sp.startTime)
 	overallQueryRate := float64(sp.opsCount) / float64(sinceStart.Seconds())
 	// the final stats output goes to stdout:
 	_, err := fmt.Printf("Run complete after %d queries with %d workers (Overall query rate %0.2f queries/sec):\n", i-sp.args.burnIn, workers, overallQueryRate)
@@ -191,9 +202,33 @@ func (sp *defaultStatProcessor) process(workers uint) {
 
 	}
 
+	if sp.intervalLog != nil {
+		err = sp.intervalLog.close(end)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(sp.args.hdrIntervalLog) > 0 {
+			_, _ = fmt.Printf("Saved interval HDR Histograms of Response Latencies to %s\n", sp.args.hdrIntervalLog)
+		}
+		if len(sp.args.hdrIntervalCSV) > 0 {
+			_, _ = fmt.Printf("Saved interval latency percentiles to %s\n", sp.args.hdrIntervalCSV)
+		}
+	}
+
 	sp.wg.Done()
 }
 
+// pushStat adds stat to the statGroup of label and, when enabled, to the
+// interval histogram of label.
+func (sp *defaultStatProcessor) pushStat(label string, stat *Stat, now time.Time) {
+	sp.statMapping[label].pushStat(stat)
+	if sp.intervalLog != nil {
+		if err := sp.intervalLog.record(label, stat.value, now); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
 func generateQuantileMap(hist *hdrhistogram.Histogram) (int64, map[string]float64) {
 	ops := hist.TotalCount()
 	q0 := 0.0
@@ -238,6 +273,25 @@ func (sp *defaultStatProcessor) GetTotalsMap() map[string]interface{} {
 		quantiles[stripRegex(label)] = all
 	}
 	totals["overallQuantiles"] = quantiles
+	// calculate response sizes and time to first row/byte, for the labels
+	// whose runner recorded them
+	responseSizes := make(map[string]interface{})
+	timeToFirstQuantiles := make(map[string]interface{})
+	for label, statGroup := range sp.statMapping {
+		if statGroup.responseCount == 0 {
+			continue
+		}
+		responseSizes[stripRegex(label)] = map[string]float64{
+			"meanRows":  statGroup.MeanRows(),
+			"meanBytes": statGroup.MeanBytes(),
+			"sumRows":   float64(statGroup.rowsSum),
+			"sumBytes":  float64(statGroup.bytesSum),
+		}
+		_, all := generateQuantileMap(statGroup.timeToFirstHDRHistogram)
+		timeToFirstQuantiles[stripRegex(label)] = all
+	}
+	totals["overallResponseSizes"] = responseSizes
+	totals["overallTimeToFirstQuantiles"] = timeToFirstQuantiles
 	return totals
 }
 
@@ -14,12 +14,18 @@ var (
 )
 
 // Stat represents one statistical measurement, typically used to store the
-// latency of a query (or part of query).
+// latency of a query (or part of query). Optionally it also describes the
+// response the query produced, see SetResponse.
 type Stat struct {
 	label     []byte
 	value     float64
 	isWarm    bool
 	isPartial bool
+
+	hasResponse bool
+	rows        uint64
+	bytes       uint64
+	timeToFirst float64
 }
 
 var statPool = &sync.Pool{
@@ -52,11 +58,27 @@ func (s *Stat) Init(label []byte, value float64) *Stat {
 	return s
 }
 
+// SetResponse records the size of the response to a query, as the number of
+// rows (or points/documents) and bytes received, together with the time in
+// milliseconds until its first row or byte arrived. A runner that cannot
+// observe one of the sizes passes 0 for it.
+func (s *Stat) SetResponse(rows, bytes uint64, timeToFirst float64) *Stat {
+	s.hasResponse = true
+	s.rows = rows
+	s.bytes = bytes
+	s.timeToFirst = timeToFirst
+	return s
+}
+
 func (s *Stat) reset() *Stat {
 	s.label = s.label[:0]
 	s.value = 0.0
 	s.isWarm = false
 	s.isPartial = false
+	s.hasResponse = false
+	s.rows = 0
+	s.bytes = 0
+	s.timeToFirst = 0.0
 	return s
 }
 
@@ -65,6 +87,12 @@ type statGroup struct {
 	latencyHDRHistogram *hdrhistogram.Histogram
 	sum                 float64
 	count               int64
+
+	// response statistics, only tracked for Stats that carry a response
+	timeToFirstHDRHistogram *hdrhistogram.Histogram
+	rowsSum                 uint64
+	bytesSum                uint64
+	responseCount           int64
 }
 
 // newStatGroup returns a new StatGroup with an initial size
@@ -90,6 +118,27 @@ func (s *statGroup) push(n float64) {
 	s.count++
 }
 
+// pushResponse updates a StatGroup with the response of a query.
+func (s *statGroup) pushResponse(rows, bytes uint64, timeToFirst float64) {
+	if s.timeToFirstHDRHistogram == nil {
+		// same range and precision as the latency histogram
+		s.timeToFirstHDRHistogram = hdrhistogram.New(1, 3600000000, 4)
+	}
+	s.timeToFirstHDRHistogram.RecordValue(int64(timeToFirst * hdrScaleFactor))
+	s.rowsSum += rows
+	s.bytesSum += bytes
+	s.responseCount++
+}
+
+// pushStat updates a StatGroup with the latency of a Stat and, when it
+// carries one, its response.
+func (s *statGroup) pushStat(stat *Stat) {
+	s.push(stat.value)
+	if stat.hasResponse {
+		s.pushResponse(stat.rows, stat.bytes, stat.timeToFirst)
+	}
+}
+
 // string makes a simple description of a statGroup.
 func (s *statGroup) string() string {
 	return fmt.Sprintf("min: %8.2fms, med: %8.2fms, mean: %8.2fms, max: %7.2fms, stddev: %8.2fms, sum: %5.1fsec, count: %d",
@@ -102,8 +151,29 @@ func (s *statGroup) string() string {
 		s.count)
 }
 
+// responseString makes a simple description of the responses of a
+// statGroup, or returns an empty string if none were recorded.
+func (s *statGroup) responseString() string {
+	if s.responseCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("rows mean: %8.1f, bytes mean: %10.1f, rows sum: %d, bytes sum: %d, first row med: %8.2fms, first row mean: %8.2fms",
+		s.MeanRows(),
+		s.MeanBytes(),
+		s.rowsSum,
+		s.bytesSum,
+		s.MedianTimeToFirst(),
+		s.MeanTimeToFirst())
+}
+
 func (s *statGroup) write(w io.Writer) error {
 	_, err := fmt.Fprintln(w, s.string())
+	if err != nil {
+		return err
+	}
+	if rs := s.responseString(); rs != "" {
+		_, err = fmt.Fprintln(w, rs)
+	}
 	return err
 }
 
@@ -132,6 +202,38 @@ func (s *statGroup) StdDev() float64 {
 	return float64(s.latencyHDRHistogram.StdDev()) / hdrScaleFactor
 }
 
+// MeanRows returns the mean number of rows per recorded response
+func (s *statGroup) MeanRows() float64 {
+	if s.responseCount == 0 {
+		return 0
+	}
+	return float64(s.rowsSum) / float64(s.responseCount)
+}
+
+// MeanBytes returns the mean number of bytes per recorded response
+func (s *statGroup) MeanBytes() float64 {
+	if s.responseCount == 0 {
+		return 0
+	}
+	return float64(s.bytesSum) / float64(s.responseCount)
+}
+
+// MedianTimeToFirst returns the Median time to first row/byte of the StatGroup in milliseconds
+func (s *statGroup) MedianTimeToFirst() float64 {
+	if s.timeToFirstHDRHistogram == nil {
+		return 0
+	}
+	return float64(s.timeToFirstHDRHistogram.ValueAtQuantile(50.0)) / hdrScaleFactor
+}
+
+// MeanTimeToFirst returns the Mean time to first row/byte of the StatGroup in milliseconds
+func (s *statGroup) MeanTimeToFirst() float64 {
+	if s.timeToFirstHDRHistogram == nil {
+		return 0
+	}
+	return float64(s.timeToFirstHDRHistogram.Mean()) / hdrScaleFactor
+}
+
 // writeStatGroupMap writes a map of StatGroups in an ordered fashion by
 // key that they are stored by
 func writeStatGroupMap(w io.Writer, statGroups map[string]*statGroup) error {
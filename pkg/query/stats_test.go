@@ -49,7 +49,11 @@ func TestStatReset(t *testing.T) {
 	s.isWarm = true
 	s.label = []byte("foo")
 	s.value = 100.0
+	s.SetResponse(1, 2, 3.0)
 	s.reset()
+	if s.hasResponse || s.rows != 0 || s.bytes != 0 || s.timeToFirst != 0.0 {
+		t.Errorf("reset() failed - response is not cleared")
+	}
 	if s.isPartial {
 		t.Errorf("reset() failed - isPartial = true")
 	}
@@ -281,6 +285,40 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestStatGroupPushStat(t *testing.T) {
+	sg := newStatGroup(0)
+	sg.pushStat(GetStat().Init([]byte("foo"), 10.0))
+	if sg.count != 1 || sg.responseCount != 0 {
+		t.Errorf("stat without response: got count %d response count %d", sg.count, sg.responseCount)
+	}
+	if got := sg.responseString(); got != "" {
+		t.Errorf("stat without response: got non-empty response string %s", got)
+	}
+
+	sg.pushStat(GetStat().Init([]byte("foo"), 10.0).SetResponse(10, 100, 2.0))
+	sg.pushStat(GetStat().Init([]byte("foo"), 20.0).SetResponse(30, 300, 4.0))
+	if sg.count != 3 || sg.responseCount != 2 {
+		t.Errorf("stats with response: got count %d response count %d", sg.count, sg.responseCount)
+	}
+	if got := sg.MeanRows(); got != 20.0 {
+		t.Errorf("incorrect mean rows: got %f want %f", got, 20.0)
+	}
+	if got := sg.MeanBytes(); got != 200.0 {
+		t.Errorf("incorrect mean bytes: got %f want %f", got, 200.0)
+	}
+	if got := sg.MeanTimeToFirst(); got != 3.0 {
+		t.Errorf("incorrect mean time to first: got %f want %f", got, 3.0)
+	}
+
+	var buf bytes.Buffer
+	if err := sg.write(&buf); err != nil {
+		t.Errorf("unexpected error for write: %v", err)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("incorrect number of lines written: got %d want %d", got, 2)
+	}
+}
+
 func TestWriteStatGroupMap(t *testing.T) {
 	cases := []struct {
 		desc           string
@@ -13,7 +13,10 @@ type TimescaleDB struct {
 
 	Hypertable []byte // e.g. "cpu"
 	SqlQuery   []byte
-	id         uint64
+	// SqlArgs holds the values for the placeholders of a parameterized
+	// SqlQuery. It is empty when SqlQuery is a literal statement.
+	SqlArgs []string
+	id      uint64
 }
 
 // TimescaleDBPool is a sync.Pool of TimescaleDB Query types
@@ -24,6 +27,7 @@ var TimescaleDBPool = sync.Pool{
 			HumanDescription: make([]byte, 0, 1024),
 			Hypertable:       make([]byte, 0, 1024),
 			SqlQuery:         make([]byte, 0, 1024),
+			SqlArgs:          make([]string, 0, 16),
 		}
 	},
 }
@@ -45,6 +49,9 @@ func (q *TimescaleDB) SetID(n uint64) {
 
 // String produces a debug-ready description of a Query.
 func (q *TimescaleDB) String() string {
+	if len(q.SqlArgs) > 0 {
+		return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Hypertable: %s, Query: %s, Args: %v", q.HumanLabel, q.HumanDescription, q.Hypertable, q.SqlQuery, q.SqlArgs)
+	}
 	return fmt.Sprintf("HumanLabel: %s, HumanDescription: %s, Hypertable: %s, Query: %s", q.HumanLabel, q.HumanDescription, q.Hypertable, q.SqlQuery)
 }
 
@@ -66,6 +73,7 @@ func (q *TimescaleDB) Release() {
 
 	q.Hypertable = q.Hypertable[:0]
 	q.SqlQuery = q.SqlQuery[:0]
+	q.SqlArgs = q.SqlArgs[:0]
 
 	TimescaleDBPool.Put(q)
 }
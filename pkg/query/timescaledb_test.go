@@ -11,6 +11,9 @@ func TestNewTimescaleDB(t *testing.T) {
 		if got := len(tq.SqlQuery); got != 0 {
 			t.Errorf("new query has non-0 sql query: got %d", got)
 		}
+		if got := len(tq.SqlArgs); got != 0 {
+			t.Errorf("new query has non-0 sql args: got %d", got)
+		}
 	}
 	tq := NewTimescaleDB()
 	check(tq)
@@ -18,6 +21,7 @@ func TestNewTimescaleDB(t *testing.T) {
 	tq.HumanDescription = []byte("bar")
 	tq.Hypertable = []byte("table")
 	tq.SqlQuery = []byte("SELECT * FROM *")
+	tq.SqlArgs = append(tq.SqlArgs, "foo")
 	tq.SetID(1)
 	if got := string(tq.HumanLabelName()); got != "foo" {
 		t.Errorf("incorrect label name: got %s", got)
@@ -64,7 +64,7 @@ func (d *dbCreator) CreateDB(dbName string) error {
 	if err := d.globalSession.Query(fmt.Sprintf("create keyspace %s with replication = %s;", dbName, replicationConfiguration)).Exec(); err != nil {
 		return err
 	}
-	for _, cassandraTypename := range []string{"bigint", "float", "double", "boolean", "blob"} {
+	for _, cassandraTypename := range []string{"bigint", "float", "double", "boolean", "blob", "text"} {
 		q := fmt.Sprintf(`CREATE TABLE %s.series_%s (
 					series_id text,
 					timestamp_ns bigint,
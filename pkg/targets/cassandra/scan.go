@@ -38,6 +38,12 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 // other functions here to support other formats.
 func singleMetricToInsertStatement(text string) string {
 	insertStatement := "INSERT INTO %s(series_id, timestamp_ns, value) VALUES('%s#%s#%s', %s, %s)"
+	// string values are quoted CQL literals that may contain commas, so they
+	// are cut off before splitting the rest of the line
+	var stringValue string
+	if i := strings.Index(text, ",'"); i >= 0 {
+		text, stringValue = text[:i+1], text[i+1:]
+	}
 	parts := strings.Split(text, ",")
 	tagsBeginIndex := 1                  // list of tags begins after the table name
 	tagsEndIndex := (len(parts) - 1) - 4 // list of tags ends right before the last 4 parts of the line
@@ -48,6 +54,9 @@ func singleMetricToInsertStatement(text string) string {
 	dayBucket := parts[tagsEndIndex+2]                              // offset: table + numTags + measurementName
 	timestampNS := parts[tagsEndIndex+3]                            // offset: table + numTags + numTags + measurementName + dayBucket
 	value := parts[tagsEndIndex+4]                                  // offset: table + numTags + timestamp + measurementName + dayBucket + timestampNS
+	if stringValue != "" {
+		value = stringValue
+	}
 
 	return fmt.Sprintf(insertStatement, table, tags, measurementName, dayBucket, timestampNS, value)
 }
@@ -20,6 +20,11 @@ func TestSingleMetricToInsertStatement(t *testing.T) {
 			inputCSV:              "series_bigint,redis,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b,rack=67,os=Ubuntu16.10,arch=x86,team=NYC,service=7,service_version=0,service_environment=production,port=6379,server=redis_1,used_cpu_user,2016-01-01,1451606400000000000,388",
 			outputInsertStatement: "INSERT INTO series_bigint(series_id, timestamp_ns, value) VALUES('redis,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b,rack=67,os=Ubuntu16.10,arch=x86,team=NYC,service=7,service_version=0,service_environment=production,port=6379,server=redis_1#used_cpu_user#2016-01-01', 1451606400000000000, 388)",
 		},
+		{
+			desc:                  "A CSV line with a quoted string value containing commas and quotes should keep the value intact",
+			inputCSV:              "series_text,logs,hostname=host_0,region=eu-west-1,message,2016-01-01,1451606400000000000,'timeout, it''s \"db\"'",
+			outputInsertStatement: "INSERT INTO series_text(series_id, timestamp_ns, value) VALUES('logs,hostname=host_0,region=eu-west-1#message#2016-01-01', 1451606400000000000, 'timeout, it''s \"db\"')",
+		},
 	}
 
 	for _, c := range cases {
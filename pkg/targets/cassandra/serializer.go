@@ -66,7 +66,9 @@ func typeNameForCassandra(v interface{}) string {
 		return "float"
 	case bool:
 		return "boolean"
-	case []byte, string:
+	case string:
+		return "text"
+	case []byte:
 		return "blob"
 	default:
 		panic(fmt.Sprintf("unknown field type for %#v", v))
@@ -87,8 +89,25 @@ func generateFieldBuf(tsNanos int64, tsBucket string, seriesIDPrefix, key []byte
 	buf = append(buf, []byte(tsBucket)...)
 	buf = append(buf, comma...)
 	buf = append(buf, []byte(fmt.Sprintf("%d,", tsNanos))...)
-	buf = serialize.FastFormatAppend(value, buf)
+	if str, ok := value.(string); ok {
+		buf = appendCQLString(str, buf)
+	} else {
+		buf = serialize.FastFormatAppend(value, buf)
+	}
 
 	buf = append(buf, []byte("\n")...)
 	return buf
 }
+
+// appendCQLString appends s to buf as a single-quoted CQL string literal,
+// doubling the single quotes it contains, so the loader can insert it as is.
+func appendCQLString(s string, buf []byte) []byte {
+	buf = append(buf, '\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' {
+			buf = append(buf, '\'')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '\'')
+}
@@ -22,6 +22,12 @@ func TestCassandraSerializerSerialize(t *testing.T) {
 			InputPoint: serialize.TestPointNoTags(),
 			Output:     "series_double,cpu,usage_guest_nice,2016-01-01,1451606400000000000,38.24311829\n",
 		},
+		{
+			Desc:       "a Point with a string field",
+			InputPoint: serialize.TestPointString(),
+			Output: "series_text,cpu,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b,message,2016-01-01,1451606400000000000,'timeout, retrying \"db\"'\n" +
+				"series_double,cpu,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b,usage_guest_nice,2016-01-01,1451606400000000000,38.24311829\n",
+		},
 	}
 	serialize.SerializerTest(t, cases, &Serializer{})
 }
@@ -77,7 +83,7 @@ func TestTypeNameForCassandra(t *testing.T) {
 		{
 			desc: "type string",
 			v:    "test",
-			want: "blob",
+			want: "text",
 		},
 		{
 			desc:        "unknown type",
@@ -105,3 +111,21 @@ func TestTypeNameForCassandra(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendCQLString(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{input: "plain", want: "values,'plain'"},
+		{input: "a, b", want: "values,'a, b'"},
+		{input: "it's", want: "values,'it''s'"},
+		{input: "", want: "values,''"},
+	}
+	for _, c := range cases {
+		got := appendCQLString(c.input, []byte("values,"))
+		if string(got) != c.want {
+			t.Errorf("incorrect output for %q: got %s want %s", c.input, got, c.want)
+		}
+	}
+}
@@ -31,6 +31,10 @@ type insertData struct {
 
 var tableCols map[string][]string
 
+// tableColTypes holds the serialized types of the field columns of the tables
+// that declare them in the data header
+var tableColTypes = make(map[string][]string)
+
 var tagColumnTypes []string
 
 // allows for testing
@@ -142,6 +142,7 @@ func TestHeaders(t *testing.T) {
 		wantTags     []string
 		wantCols     map[string][]string
 		wantTypes    []string
+		wantColTypes map[string][]string
 		shouldFatal  bool
 		wantBuffered int
 	}{
@@ -177,6 +178,15 @@ func TestHeaders(t *testing.T) {
 			wantCols:     map[string][]string{"cols": {"col1", "col2"}, "cols2": {"col21", "col22"}},
 			wantBuffered: len([]byte("row1\nrow2\n")),
 		},
+		{
+			desc:         "typed field columns",
+			input:        "tags,tag1 string\ncols,col1 string,col2 float64\ncols2,col21,col22\n\n",
+			wantTags:     []string{"tag1"},
+			wantTypes:    []string{"string"},
+			wantCols:     map[string][]string{"cols": {"col1", "col2"}, "cols2": {"col21", "col22"}},
+			wantColTypes: map[string][]string{"cols": {"string", "float64"}},
+			wantBuffered: 0,
+		},
 		{
 			desc:        "too few lines",
 			input:       "tags\ncols\n",
@@ -220,6 +230,11 @@ func TestHeaders(t *testing.T) {
 					t.Errorf("%s: cols row incorrect: got\n%v\nwant\n%v\n", c.desc, got, want)
 				}
 			}
+			for key, want := range c.wantColTypes {
+				if got := headers.FieldTypes[key]; !strArrEq(got, want) {
+					t.Errorf("%s: col types incorrect: got\n%v\nwant\n%v\n", c.desc, got, want)
+				}
+			}
 		}
 	}
 }
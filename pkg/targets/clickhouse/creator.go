@@ -91,7 +91,7 @@ func (d *dbCreator) CreateDB(dbName string) error {
 		//tableName: cpu
 		// fieldColumns content:
 		// usage_user,usage_system,usage_idle,usage_nice,usage_iowait,usage_irq,usage_softirq,usage_steal,usage_guest,usage_guest_nice
-		createMetricsTable(d.config, db, tableName, fieldColumns)
+		createMetricsTable(d.config, db, tableName, fieldColumns, d.headers.FieldTypes[tableName])
 	}
 
 	return nil
@@ -110,8 +110,9 @@ func createTagsTable(conf *ClickhouseConfig, db *sqlx.DB, tagNames, tagTypes []s
 }
 
 // createMetricsTable builds CREATE TABLE SQL statement and runs it
-func createMetricsTable(conf *ClickhouseConfig, db *sqlx.DB, tableName string, fieldColumns []string) {
+func createMetricsTable(conf *ClickhouseConfig, db *sqlx.DB, tableName string, fieldColumns, fieldTypes []string) {
 	tableCols[tableName] = fieldColumns
+	tableColTypes[tableName] = fieldTypes
 
 	// We'll have some service columns in table to be created and columnNames contains all column names to be created
 	var columnNames []string
@@ -124,15 +125,21 @@ func createMetricsTable(conf *ClickhouseConfig, db *sqlx.DB, tableName string, f
 
 	// Add all column names from fieldColumns into columnNames
 	columnNames = append(columnNames, fieldColumns...)
+	// Number of service columns preceding the field columns
+	extraCols := len(columnNames) - len(fieldColumns)
 
 	// columnsWithType - column specifications with type. Ex.: "cpu_usage Float64"
 	var columnsWithType []string
-	for _, column := range columnNames {
+	for i, column := range columnNames {
 		if len(column) == 0 {
 			// Skip nameless columns
 			continue
 		}
-		columnsWithType = append(columnsWithType, fmt.Sprintf("%s Nullable(Float64)", column))
+		columnType := "Nullable(Float64)"
+		if i >= extraCols {
+			columnType = serializedTypeToClickHouseType(fieldColumnType(tableName, i-extraCols))
+		}
+		columnsWithType = append(columnsWithType, fmt.Sprintf("%s %s", column, columnType))
 	}
 
 	sql := fmt.Sprintf(`
@@ -185,6 +192,16 @@ func generateTagsTableQuery(tagNames, tagTypes []string) string {
 		index)
 }
 
+// fieldColumnType returns the serialized type of the i-th field column of the
+// table, which is a float64 unless the data header declared otherwise.
+func fieldColumnType(tableName string, i int) string {
+	types := tableColTypes[tableName]
+	if i >= len(types) {
+		return common.DefaultFieldType
+	}
+	return types[i]
+}
+
 func serializedTypeToClickHouseType(serializedType string) string {
 	switch serializedType {
 	case "string":
@@ -121,6 +121,7 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 	}
 	tagNames, tagTypes := extractTagNamesAndTypes(parts[1:])
 	fieldKeys := make(map[string][]string)
+	var fieldTypesByTable map[string][]string
 	// cols content are lines (metrics descriptions) as:
 	// cpu,usage_user,usage_system,usage_idle,usage_nice,usage_iowait,usage_irq,usage_softirq,usage_steal,usage_guest,usage_guest_nice
 	// disk,total,free,used,used_percent,inodes_total,inodes_free,inodes_used
@@ -136,12 +137,20 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 
 		// Ex.: cpu OR disk OR nginx
 		tableName := tableSpec[0]
-		fieldKeys[tableName] = tableSpec[1:]
+		fieldNames, fieldTypes := common.ParseFieldColumns(tableSpec[1:])
+		fieldKeys[tableName] = fieldNames
+		if fieldTypes != nil {
+			if fieldTypesByTable == nil {
+				fieldTypesByTable = make(map[string][]string)
+			}
+			fieldTypesByTable[tableName] = fieldTypes
+		}
 	}
 	d.headers = &common.GeneratedDataHeaders{
-		TagKeys:   tagNames,
-		TagTypes:  tagTypes,
-		FieldKeys: fieldKeys,
+		TagKeys:    tagNames,
+		TagTypes:   tagTypes,
+		FieldKeys:  fieldKeys,
+		FieldTypes: fieldTypesByTable,
 	}
 	return d.headers
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/timescale/tsbs/pkg/targets"
+	"github.com/timescale/tsbs/pkg/targets/timescaledb"
 	"strconv"
 	"strings"
 	"sync"
@@ -126,7 +127,7 @@ func (p *processor) processCSI(tableName string, rows []*insertData) uint64 {
 
 		// fields line ex.:
 		// 1451606400000000000,58,2,24,61,22,63,6,44,80,38
-		metrics := strings.Split(row.fields, ",")
+		metrics := timescaledb.SplitFields(row.fields)
 
 		// Count number of metrics processed
 		ret += uint64(len(metrics) - 1) // 1-st field is timestamp, do not count it
@@ -163,16 +164,8 @@ func (p *processor) processCSI(tableName string, rows []*insertData) uint64 {
 		if p.conf.InTableTag {
 			r = append(r, tags[0]) // tags[0] = hostname
 		}
-		for _, v := range metrics[1:] {
-			if v == "" {
-				r = append(r, nil)
-				continue
-			}
-			f64, err := strconv.ParseFloat(v, 64)
-			if err != nil {
-				panic(err)
-			}
-			r = append(r, f64)
+		for i, v := range metrics[1:] {
+			r = append(r, convertBasedOnType(fieldColumnType(tableName, i), v))
 		}
 
 		dataRows = append(dataRows, r)
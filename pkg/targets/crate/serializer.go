@@ -17,6 +17,9 @@ type Serializer struct{}
 // measurement type, tags with keys and values as a JSON object, timestamp,
 // and metric values.
 //
+// Tags that are not strings are written as JSON numbers or booleans, and
+// missing (nil) tags as JSON null, so they keep their type in the tags object.
+//
 // An example of a serialized point:
 //     cpu\t{"hostname":"host_0","rack":"1"}\t1451606400000000000\t38\t0\t50\t41234
 func (s *Serializer) Serialize(p *data.Point, w io.Writer) error {
@@ -34,9 +37,9 @@ func (s *Serializer) Serialize(p *data.Point, w io.Writer) error {
 		for i, key := range tagKeys {
 			buf = append(buf, '"')
 			buf = append(buf, key...)
-			buf = append(buf, []byte("\":\"")...)
-			buf = serialize.FastFormatAppend(tagValues[i], buf)
-			buf = append(buf, []byte("\",")...)
+			buf = append(buf, []byte("\":")...)
+			buf = appendTagValue(tagValues[i], buf)
+			buf = append(buf, ',')
 		}
 		buf = buf[:len(buf)-1]
 		buf = append(buf, '}')
@@ -59,3 +62,17 @@ func (s *Serializer) Serialize(p *data.Point, w io.Writer) error {
 	_, err := w.Write(buf)
 	return err
 }
+
+// appendTagValue appends the JSON representation of a tag value to buf.
+func appendTagValue(v interface{}, buf []byte) []byte {
+	switch v.(type) {
+	case nil:
+		return append(buf, []byte("null")...)
+	case string, []byte:
+		buf = append(buf, '"')
+		buf = serialize.FastFormatAppend(v, buf)
+		return append(buf, '"')
+	default:
+		return serialize.FastFormatAppend(v, buf)
+	}
+}
@@ -1,6 +1,7 @@
 package crate
 
 import (
+	"github.com/timescale/tsbs/pkg/data"
 	"github.com/timescale/tsbs/pkg/data/serialize"
 	"testing"
 )
@@ -27,6 +28,21 @@ func TestCrateDBSerializerSerialize(t *testing.T) {
 			InputPoint: serialize.TestPointNoTags(),
 			Output:     "cpu\tnull\t1451606400000000000\t38.24311829\n",
 		},
+		{
+			Desc:       "a Point with a nil tag",
+			InputPoint: serialize.TestPointWithNilTag(),
+			Output:     "cpu\t{\"hostname\":null}\t1451606400000000000\t38.24311829\n",
+		},
+		{
+			Desc:       "a Point with a numeric tag",
+			InputPoint: testPointNumericTag(),
+			Output:     "readings\t{\"name\":\"truck_0\",\"load_capacity\":1500}\t1451606400000000000\t38.24311829\n",
+		},
+		{
+			Desc:       "a Point with a nil field",
+			InputPoint: serialize.TestPointWithNilField(),
+			Output:     "cpu\tnull\t1451606400000000000\t\t38.24311829\n",
+		},
 	}
 
 	serialize.SerializerTest(t, cases, &Serializer{})
@@ -42,3 +58,13 @@ func TestCrateDBSerializerSerializeErr(t *testing.T) {
 		t.Errorf("unexpected writer error: %v", err)
 	}
 }
+
+func testPointNumericTag() *data.Point {
+	p := &data.Point{}
+	p.SetMeasurementName([]byte("readings"))
+	p.SetTimestamp(&serialize.TestNow)
+	p.AppendTag([]byte("name"), "truck_0")
+	p.AppendTag([]byte("load_capacity"), float32(1500))
+	p.AppendField(serialize.TestColFloat, serialize.TestFloat)
+	return p
+}
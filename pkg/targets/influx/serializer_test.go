@@ -35,6 +35,11 @@ func TestInfluxSerializerSerialize(t *testing.T) {
 			InputPoint: serialize.TestPointWithNilField(),
 			Output:     "cpu usage_guest_nice=38.24311829 1451606400000000000\n",
 		},
+		{
+			Desc:       "a Point with a string field",
+			InputPoint: serialize.TestPointString(),
+			Output:     "cpu,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b message=\"timeout, retrying \\\"db\\\"\",usage_guest_nice=38.24311829 1451606400000000000\n",
+		},
 	}
 
 	serialize.SerializerTest(t, cases, &Serializer{})
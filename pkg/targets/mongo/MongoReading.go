@@ -46,8 +46,16 @@ func (rcv *MongoReading) MutateValue(n float64) bool {
 	return rcv._tab.MutateFloat64Slot(6, n)
 }
 
+func (rcv *MongoReading) StringValue() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
 func MongoReadingStart(builder *flatbuffers.Builder) {
-	builder.StartObject(2)
+	builder.StartObject(3)
 }
 func MongoReadingAddKey(builder *flatbuffers.Builder, key flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(key), 0)
@@ -55,6 +63,9 @@ func MongoReadingAddKey(builder *flatbuffers.Builder, key flatbuffers.UOffsetT)
 func MongoReadingAddValue(builder *flatbuffers.Builder, value float64) {
 	builder.PrependFloat64Slot(1, value, 0.0)
 }
+func MongoReadingAddStringValue(builder *flatbuffers.Builder, stringValue flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(stringValue), 0)
+}
 func MongoReadingEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"github.com/timescale/tsbs/pkg/data"
+	"github.com/timescale/tsbs/pkg/data/serialize"
 	"io"
 	"sync"
 
@@ -30,20 +31,26 @@ func (s *Serializer) Serialize(p *data.Point, w io.Writer) (err error) {
 	tagKeys := p.TagKeys()
 	tagValues := p.TagValues()
 	for i := len(tagKeys); i > 0; i-- {
-		switch v := tagValues[i-1].(type) {
+		var v string
+		switch tv := tagValues[i-1].(type) {
 		case string:
-			k := string(tagKeys[i-1])
-			key := b.CreateString(k)
-			val := b.CreateString(v)
-			MongoTagStart(b)
-			MongoTagAddKey(b, key)
-			MongoTagAddValue(b, val)
-			tags = append(tags, MongoTagEnd(b))
+			v = tv
+		case float32, float64, int, int64:
+			// numeric tags (e.g. the truck capacities of the iot use case)
+			// are stored as strings and converted back by the queries
+			v = string(serialize.FastFormatAppend(tv, nil))
 		case nil:
 			continue
 		default:
 			panic("non-string tags not implemented for mongo db")
 		}
+		k := string(tagKeys[i-1])
+		key := b.CreateString(k)
+		val := b.CreateString(v)
+		MongoTagStart(b)
+		MongoTagAddKey(b, key)
+		MongoTagAddValue(b, val)
+		tags = append(tags, MongoTagEnd(b))
 	}
 	MongoPointStartTagsVector(b, len(tags))
 	for _, t := range tags {
@@ -103,11 +110,29 @@ func (s *Serializer) Serialize(p *data.Point, w io.Writer) (err error) {
 
 func createField(b *flatbuffers.Builder, key []byte, val interface{}) flatbuffers.UOffsetT {
 	keyStr := b.CreateString(string(key))
+	// strings can't be created while the reading is being built, so string
+	// values are created up front and stored apart from numeric ones
+	if str, ok := val.(string); ok {
+		strVal := b.CreateString(str)
+		MongoReadingStart(b)
+		MongoReadingAddKey(b, keyStr)
+		MongoReadingAddStringValue(b, strVal)
+		return MongoReadingEnd(b)
+	}
 	MongoReadingStart(b)
 	MongoReadingAddKey(b, keyStr)
 	prependValue(b, val)
 	return MongoReadingEnd(b)
 }
+
+// ReadingValue returns the value of the reading, a string for string fields
+// and a float64 otherwise.
+func ReadingValue(r *MongoReading) interface{} {
+	if str := r.StringValue(); str != nil {
+		return string(str)
+	}
+	return r.Value()
+}
 func prependValue(b *flatbuffers.Builder, value interface{}) {
 	switch val := value.(type) {
 	case float64:
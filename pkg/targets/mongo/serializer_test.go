@@ -76,6 +76,30 @@ func TestMongoSerializerSerialize(t *testing.T) {
 				readingVals: serialize.TestPointNoTags().FieldValues(),
 			},
 		},
+		{
+			desc:       "a Point with a string field",
+			inputPoint: serialize.TestPointString(),
+			want: output{
+				name:        string(serialize.TestMeasurement),
+				ts:          serialize.TestNow.UnixNano(),
+				tagKeys:     serialize.TestTagKeys,
+				tagVals:     serialize.TestTagVals,
+				readingKeys: serialize.TestPointString().FieldKeys(),
+				readingVals: serialize.TestPointString().FieldValues(),
+			},
+		},
+		{
+			desc:       "a Point with a numeric tag",
+			inputPoint: testPointNumericTag(),
+			want: output{
+				name:        "readings",
+				ts:          serialize.TestNow.UnixNano(),
+				tagKeys:     [][]byte{[]byte("name"), []byte("load_capacity")},
+				tagVals:     []interface{}{"truck_0", "1500"},
+				readingKeys: testPointNumericTag().FieldKeys(),
+				readingVals: testPointNumericTag().FieldValues(),
+			},
+		},
 	}
 
 	ps := &Serializer{}
@@ -128,7 +152,7 @@ func TestMongoSerializerSerialize(t *testing.T) {
 				t.Errorf("%s: incorrect reading key %d: got %s want %s", c.desc, i, got, want)
 			}
 
-			var wantVal float64
+			var wantVal interface{}
 			switch x := c.want.readingVals[i].(type) {
 			case int:
 				wantVal = float64(x)
@@ -136,8 +160,10 @@ func TestMongoSerializerSerialize(t *testing.T) {
 				wantVal = float64(x)
 			case float64:
 				wantVal = x
+			case string:
+				wantVal = x
 			}
-			if got := reading.Value(); got != wantVal {
+			if got := ReadingValue(reading); got != wantVal {
 				t.Errorf("%s: incorrect reading val %d: got %v want %v", c.desc, i, got, wantVal)
 			}
 		}
@@ -189,7 +215,7 @@ func TestMongoSerializerTypePanic(t *testing.T) {
 		p := &data.Point{}
 		p.SetMeasurementName(serialize.TestMeasurement)
 		p.SetTimestamp(&serialize.TestNow)
-		p.AppendField([]byte("broken"), "a string?")
+		p.AppendField([]byte("broken"), true)
 		ps := &Serializer{}
 		b := new(bytes.Buffer)
 
@@ -216,3 +242,13 @@ func TestMongoSerializerSerializeErr(t *testing.T) {
 		t.Errorf("unexpected writer error: %v", err)
 	}
 }
+
+func testPointNumericTag() *data.Point {
+	p := &data.Point{}
+	p.SetMeasurementName([]byte("readings"))
+	p.SetTimestamp(&serialize.TestNow)
+	p.AppendTag([]byte("name"), "truck_0")
+	p.AppendTag([]byte("load_capacity"), float32(1500))
+	p.AppendField(serialize.TestColFloat, serialize.TestFloat)
+	return p
+}
@@ -83,12 +83,15 @@ func appendField(buf, key []byte, v interface{}) []byte {
 	buf = append(buf, key...)
 	buf = append(buf, '=')
 
-	buf = serialize.FastFormatAppend(v, buf)
-
-	// Influx uses 'i' to indicate integers:
-	switch v.(type) {
+	// Influx uses 'i' to indicate integers and double quotes for strings:
+	switch s := v.(type) {
+	case string:
+		return serialize.AppendQuotedString(s, buf)
 	case int, int64:
+		buf = serialize.FastFormatAppend(v, buf)
 		buf = append(buf, 'i')
+	default:
+		buf = serialize.FastFormatAppend(v, buf)
 	}
 
 	return buf
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/timescale/tsbs/pkg/data/usecases/common"
 	"github.com/timescale/tsbs/pkg/targets"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -24,6 +25,10 @@ var fatal = log.Fatalf
 
 var tableCols = make(map[string][]string)
 
+// tableColTypes holds the serialized types of the columns of the tables that
+// declare them in the data header, see common.GeneratedDataHeaders.
+var tableColTypes = make(map[string][]string)
+
 type dbCreator struct {
 	driver  string
 	ds      targets.DataSource
@@ -100,6 +105,7 @@ func (d *dbCreator) PostCreateDB(dbName string) error {
 	for tableName, columns := range headers.FieldKeys {
 		// tableCols is a global map. Globally cache the available columns for the given table
 		tableCols[tableName] = columns
+		tableColTypes[tableName] = headers.FieldTypes[tableName]
 		fieldDefs, indexDefs := d.getFieldAndIndexDefinitions(tableName, columns)
 		if d.opts.CreateMetricsTable {
 			d.createTableAndIndexes(dbBench, tableName, fieldDefs, indexDefs)
@@ -138,19 +144,28 @@ func (d *dbCreator) getFieldAndIndexDefinitions(tableName string, columns []stri
 
 	allCols = append(allCols, columns...)
 	extraCols := 0 // set to 1 when hostname is kept in-table
+	if d.opts.InTableTag {
+		extraCols = 1
+	}
 	for idx, field := range allCols {
 		if len(field) == 0 {
 			continue
 		}
 		fieldType := "DOUBLE PRECISION"
 		idxType := d.opts.FieldIndex
+		if idx >= extraCols {
+			fieldType = serializedTypeToPgType(columnType(tableName, idx-extraCols))
+		}
+		// Indexes on free-form text values are of no use to the benchmark queries
+		if fieldType == "TEXT" {
+			idxType = ""
+		}
 		// This condition handles the case where we keep the primary tag key in the table
 		// and partition on it. Since under the current implementation this tag is always
 		// hostname, we set it to a TEXT field instead of DOUBLE PRECISION
 		if d.opts.InTableTag && idx == 0 {
 			fieldType = "TEXT"
 			idxType = ""
-			extraCols = 1
 		}
 
 		fieldDefs = append(fieldDefs, fmt.Sprintf("%s %s", field, fieldType))
@@ -228,6 +243,16 @@ func (d *dbCreator) createTableAndIndexes(dbBench *sql.DB, tableName string, fie
 	}
 }
 
+// columnType returns the serialized type of the i-th column of the table,
+// which is a float64 unless the data header declared otherwise.
+func columnType(tableName string, i int) string {
+	types := tableColTypes[tableName]
+	if i >= len(types) {
+		return common.DefaultFieldType
+	}
+	return types[i]
+}
+
 func (d *dbCreator) getCreateIndexOnFieldCmds(hypertable, field, idxType string) []string {
 	var ret []string
 	for _, idx := range strings.Split(idxType, ",") {
@@ -179,7 +179,18 @@ func TestDBCreatorGetFieldAndIndexDefinitions(t *testing.T) {
 			wantFieldDefs:   []string{"usage_user DOUBLE PRECISION", "usage_system DOUBLE PRECISION", "usage_idle DOUBLE PRECISION", "usage_nice DOUBLE PRECISION"},
 			wantIndexDefs:   []string{"CREATE INDEX ON cpu (usage_user, time DESC)", "CREATE INDEX ON cpu (usage_system, time DESC)"},
 		},
+		{
+			desc:            "string fields",
+			tableName:       "logs",
+			columns:         []string{"message", "duration_ms"},
+			fieldIndexCount: 2,
+			inTableTag:      false,
+			wantFieldDefs:   []string{"message TEXT", "duration_ms DOUBLE PRECISION"},
+			wantIndexDefs:   []string{"CREATE INDEX ON logs (duration_ms, time DESC)"},
+		},
 	}
+	tableColTypes["logs"] = []string{"string", "float64"}
+	defer delete(tableColTypes, "logs")
 
 	for _, c := range cases {
 		// Set the global in-table-tag flag based on the test case
@@ -61,16 +61,24 @@ func (d *fileDataSource) Headers() *common.GeneratedDataHeaders {
 	}
 	tagNames, tagTypes := extractTagNamesAndTypes(tagsarr[1:])
 	fieldKeys := make(map[string][]string)
+	var fieldTypes map[string][]string
 	for _, tableDef := range cols {
 		columns := strings.Split(tableDef, ",")
 		tableName := columns[0]
-		colNames := columns[1:]
+		colNames, colTypes := common.ParseFieldColumns(columns[1:])
 		fieldKeys[tableName] = colNames
+		if colTypes != nil {
+			if fieldTypes == nil {
+				fieldTypes = make(map[string][]string)
+			}
+			fieldTypes[tableName] = colTypes
+		}
 	}
 	d.headers = &common.GeneratedDataHeaders{
-		TagTypes:  tagTypes,
-		TagKeys:   tagNames,
-		FieldKeys: fieldKeys,
+		TagTypes:   tagTypes,
+		TagKeys:    tagNames,
+		FieldKeys:  fieldKeys,
+		FieldTypes: fieldTypes,
 	}
 	return d.headers
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -126,7 +127,7 @@ func (p *processor) sqlTagsToCacheLine(res *sql.Rows, err error, tagCols []strin
 // divides the tags from data into appropriate slices that can then be used in
 // SQL queries to insert into their respective tables. Additionally, it also
 // returns the number of metrics (i.e., non-tag fields) for the data processed.
-func (p *processor) splitTagsAndMetrics(rows []*insertData, dataCols int) ([][]string, [][]interface{}, uint64) {
+func (p *processor) splitTagsAndMetrics(hypertable string, rows []*insertData, dataCols int) ([][]string, [][]interface{}, uint64) {
 	tagRows := make([][]string, 0, len(rows))
 	dataRows := make([][]interface{}, 0, len(rows))
 	numMetrics := uint64(0)
@@ -147,7 +148,7 @@ func (p *processor) splitTagsAndMetrics(rows []*insertData, dataCols int) ([][]s
 			json = subsystemTagsToJSON(strings.Split(tags[commonTagsLen], ","))
 		}
 
-		metrics := strings.Split(data.fields, ",")
+		metrics := SplitFields(data.fields)
 		numMetrics += uint64(len(metrics) - 1) // 1 field is timestamp
 
 		timeInt, err := strconv.ParseInt(metrics[0], 10, 64)
@@ -162,11 +163,15 @@ func (p *processor) splitTagsAndMetrics(rows []*insertData, dataCols int) ([][]s
 		if p.opts.InTableTag {
 			r = append(r, tags[0])
 		}
-		for _, v := range metrics[1:] {
+		for i, v := range metrics[1:] {
 			if v == "" {
 				r = append(r, nil)
 				continue
 			}
+			if columnType(hypertable, i) == "string" {
+				r = append(r, v)
+				continue
+			}
 
 			num, err := strconv.ParseFloat(v, 64)
 			if err != nil {
@@ -183,12 +188,28 @@ func (p *processor) splitTagsAndMetrics(rows []*insertData, dataCols int) ([][]s
 	return tagRows, dataRows, numMetrics
 }
 
+// SplitFields splits a CSV row of field values, as written by the Serializer,
+// into its values. Rows without quoted string values take the fast path.
+func SplitFields(row string) []string {
+	if !strings.Contains(row, `"`) {
+		return strings.Split(row, ",")
+	}
+	r := csv.NewReader(strings.NewReader(row))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	values, err := r.Read()
+	if err != nil {
+		panic(err)
+	}
+	return values
+}
+
 func (p *processor) processCSI(hypertable string, rows []*insertData) uint64 {
 	colLen := len(tableCols[hypertable]) + numExtraCols
 	if p.opts.InTableTag {
 		colLen++
 	}
-	tagRows, dataRows, numMetrics := p.splitTagsAndMetrics(rows, colLen)
+	tagRows, dataRows, numMetrics := p.splitTagsAndMetrics(hypertable, rows, colLen)
 
 	// Check if any of these tags has yet to be inserted
 	newTags := make([][]string, 0, len(rows))
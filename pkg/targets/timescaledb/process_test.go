@@ -194,13 +194,13 @@ func TestSplitTagsAndMetrics(t *testing.T) {
 					t.Errorf("%s: did not panic when should", c.desc)
 				}
 			}()
-			p.splitTagsAndMetrics(c.rows, numCols+numExtraCols)
+			p.splitTagsAndMetrics("cpu", c.rows, numCols+numExtraCols)
 		}
 
 		oldInTableTag := p.opts.InTableTag
 		p.opts.InTableTag = c.inTableTag
 
-		gotTags, gotData, numMetrics := p.splitTagsAndMetrics(c.rows, numCols+numExtraCols)
+		gotTags, gotData, numMetrics := p.splitTagsAndMetrics("cpu", c.rows, numCols+numExtraCols)
 		if numMetrics != c.wantMetrics {
 			t.Errorf("%s: number of metrics incorrect: got %d want %d", c.desc, numMetrics, c.wantMetrics)
 		}
@@ -254,6 +254,53 @@ func TestSplitTagsAndMetrics(t *testing.T) {
 	}
 }
 
+func TestSplitTagsAndMetricsStringFields(t *testing.T) {
+	tableCols[tagsKey] = []string{"instance", "service"}
+	tableColTypes["logs"] = []string{"string", "string", "float64"}
+	defer delete(tableColTypes, "logs")
+
+	rows := []*insertData{
+		{
+			tags:   "instance=instance_0,service=api",
+			fields: `100,ERROR,"timeout, retrying ""db""",12.5`,
+		},
+		{
+			tags:   "instance=instance_1,service=auth",
+			fields: "200,INFO,user logged in,",
+		},
+	}
+	p := &processor{opts: &LoadingOptions{}}
+	_, gotData, numMetrics := p.splitTagsAndMetrics("logs", rows, 3+numExtraCols)
+	if numMetrics != 6 {
+		t.Errorf("number of metrics incorrect: got %d want %d", numMetrics, 6)
+	}
+	want := [][]interface{}{
+		{"ERROR", `timeout, retrying "db"`, 12.5},
+		{"INFO", "user logged in", nil},
+	}
+	for i, row := range gotData {
+		if got := row[3:]; !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("incorrect values for row %d: got %v want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestSplitFields(t *testing.T) {
+	cases := []struct {
+		row  string
+		want []string
+	}{
+		{row: "100,1,5,42", want: []string{"100", "1", "5", "42"}},
+		{row: "100,,5", want: []string{"100", "", "5"}},
+		{row: `100,"a, b","say ""hi""",5`, want: []string{"100", "a, b", `say "hi"`, "5"}},
+	}
+	for _, c := range cases {
+		if got := SplitFields(c.row); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("incorrect split of %s: got %v want %v", c.row, got, c.want)
+		}
+	}
+}
+
 func TestGenBatchInsertStmt(t *testing.T) {
 	cols := []string{"col1", "col2", "col3"}
 	stmt := genBatchInsertStmt("test", cols, 2)
@@ -177,12 +177,13 @@ func TestDecodeEOF(t *testing.T) {
 
 func TestFileDataSourceHeaders(t *testing.T) {
 	cases := []struct {
-		desc        string
-		input       string
-		wantTags    string
-		wantTypes   string
-		wantCols    map[string]string
-		shouldFatal bool
+		desc         string
+		input        string
+		wantTags     string
+		wantTypes    string
+		wantCols     map[string]string
+		wantColTypes map[string]string
+		shouldFatal  bool
 	}{
 		{
 			desc:      "min case: exactly three lines",
@@ -212,6 +213,14 @@ func TestFileDataSourceHeaders(t *testing.T) {
 			wantTypes: "tagT,tag2",
 			wantCols:  map[string]string{"cols": "col1,col2", "cols2": "col21,col22"},
 		},
+		{
+			desc:         "typed field columns",
+			input:        "tags,tag1 string\ncols,col1 string,col2 float64\ncols2,col21,col22\n\n",
+			wantTags:     "tag1",
+			wantTypes:    "string",
+			wantCols:     map[string]string{"cols": "col1,col2", "cols2": "col21,col22"},
+			wantColTypes: map[string]string{"cols": "string,float64"},
+		},
 		{
 			desc:        "too few lines",
 			input:       "tags\ncols\n",
@@ -261,6 +270,11 @@ func TestFileDataSourceHeaders(t *testing.T) {
 					t.Errorf("%s: cols for table %s, incorrect: got\n%s\nwant\n%s\n", c.desc, table, got, c.wantCols[table])
 				}
 			}
+			for table, want := range c.wantColTypes {
+				if got := strings.Join(headers.FieldTypes[table], ","); got != want {
+					t.Errorf("%s: col types for table %s, incorrect: got\n%s\nwant\n%s\n", c.desc, table, got, want)
+				}
+			}
 		}
 	}
 }
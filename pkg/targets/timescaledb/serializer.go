@@ -43,9 +43,18 @@ func (s *Serializer) Serialize(p *data.Point, w io.Writer) error {
 	fieldValues := p.FieldValues()
 	for _, v := range fieldValues {
 		buf = append(buf, ',')
-		buf = serialize.FastFormatAppend(v, buf)
+		buf = appendFieldValue(v, buf)
 	}
 	buf = append(buf, '\n')
 	_, err = w.Write(buf)
 	return err
 }
+
+// appendFieldValue appends a field value to the CSV row in buf, quoting
+// string values that would otherwise break the row apart.
+func appendFieldValue(v interface{}, buf []byte) []byte {
+	if s, ok := v.(string); ok {
+		return serialize.AppendCSVString(s, buf)
+	}
+	return serialize.FastFormatAppend(v, buf)
+}
@@ -27,6 +27,11 @@ func TestTimescaleDBSerializerSerialize(t *testing.T) {
 			InputPoint: serialize.TestPointNoTags(),
 			Output:     "tags\ncpu,1451606400000000000,38.24311829\n",
 		},
+		{
+			Desc:       "a Point with a string field",
+			InputPoint: serialize.TestPointString(),
+			Output:     "tags,hostname=host_0,region=eu-west-1,datacenter=eu-west-1b\ncpu,1451606400000000000,\"timeout, retrying \"\"db\"\"\",38.24311829\n",
+		},
 	}
 
 	serialize.SerializerTest(t, cases, &Serializer{})
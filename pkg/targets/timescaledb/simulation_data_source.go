@@ -66,7 +66,7 @@ func (d *simulationDataSource) NextItem() data.LoadedPoint {
 	fieldValues := newSimulatorPoint.FieldValues()
 	for _, v := range fieldValues {
 		buf = append(buf, ',')
-		buf = serialize.FastFormatAppend(v, buf)
+		buf = appendFieldValue(v, buf)
 	}
 
 	newLoadPoint.fields = string(buf)
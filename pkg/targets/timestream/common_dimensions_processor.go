@@ -57,7 +57,7 @@ func (c *commonDimensionsProcessor) writeToTable(table string, rows []deserializ
 	for _, row := range rows {
 		c.expandDimensionBuffer(len(row.tagKeys))
 		numDimensions := convertTagsToDimensions(row.tagKeys, row.tags, c._dimensionsBuffer)
-		numRecords := convertPointToRecords(&row, c.headers.FieldKeys[table], c._recordsBuffer)
+		numRecords := convertPointToRecords(&row, c.headers, table, c._recordsBuffer)
 		writeRecordsInput := &timestreamwrite.WriteRecordsInput{
 			DatabaseName: &c.dbName,
 			TableName:    &table,
@@ -91,8 +91,9 @@ func convertTagsToDimensions(tagKeys, tagValues []string, buffer []*timestreamwr
 	return len(tagValues)
 }
 
-func convertPointToRecords(point *deserializedPoint, fieldKeys []string, buffer []*timestreamwrite.Record) (numFields int) {
+func convertPointToRecords(point *deserializedPoint, headers *common.GeneratedDataHeaders, table string, buffer []*timestreamwrite.Record) (numFields int) {
 	numFields = 0
+	fieldKeys := headers.FieldKeys[table]
 	for i, fieldVal := range point.fields {
 		if fieldVal == nil {
 			continue
@@ -102,9 +103,18 @@ func convertPointToRecords(point *deserializedPoint, fieldKeys []string, buffer
 		}
 
 		buffer[numFields].SetMeasureName(fieldKeys[i])
-		buffer[numFields].SetMeasureValueType(timestreamwrite.MeasureValueTypeDouble)
+		buffer[numFields].SetMeasureValueType(measureValueType(headers, table, i))
 		buffer[numFields].SetMeasureValue(*fieldVal)
 		numFields++
 	}
 	return numFields
 }
+
+// measureValueType returns the Timestream type of the i-th field of the
+// table. String fields are stored as VARCHAR, all others as DOUBLE.
+func measureValueType(headers *common.GeneratedDataHeaders, table string, i int) string {
+	if headers.FieldType(table, i) == "string" {
+		return timestreamwrite.MeasureValueTypeVarchar
+	}
+	return timestreamwrite.MeasureValueTypeDouble
+}
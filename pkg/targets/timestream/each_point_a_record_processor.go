@@ -73,10 +73,11 @@ func (p *eachValueARecordProcessor) writeBatch(table string, rows []deserialized
 
 func (p *eachValueARecordProcessor) convertToRecords(table string, row deserializedPoint) []*timestreamwrite.Record {
 	dimensions := createDimensions(row.tagKeys, row.tags)
-	return createRecords(&row, p.headers.FieldKeys[table], dimensions, row.timeUnixNano)
+	return createRecords(&row, p.headers, table, dimensions, row.timeUnixNano)
 }
 
-func createRecords(point *deserializedPoint, fieldKeys []string, dimensions []*timestreamwrite.Dimension, ts string) (buffer []*timestreamwrite.Record) {
+func createRecords(point *deserializedPoint, headers *common.GeneratedDataHeaders, table string, dimensions []*timestreamwrite.Dimension, ts string) (buffer []*timestreamwrite.Record) {
+	fieldKeys := headers.FieldKeys[table]
 	buffer = make([]*timestreamwrite.Record, 0, len(fieldKeys))
 	for i, fieldVal := range point.fields {
 		if fieldVal == nil {
@@ -85,7 +86,7 @@ func createRecords(point *deserializedPoint, fieldKeys []string, dimensions []*t
 		newRecord := &timestreamwrite.Record{}
 		newRecord.SetDimensions(dimensions)
 		newRecord.SetMeasureName(fieldKeys[i])
-		newRecord.SetMeasureValueType(timestreamwrite.MeasureValueTypeDouble)
+		newRecord.SetMeasureValueType(measureValueType(headers, table, i))
 		newRecord.SetMeasureValue(*fieldVal)
 		newRecord.SetTime(ts)
 		newRecord.SetTimeUnit(timestreamwrite.TimeUnitNanoseconds)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/timescale/tsbs/pkg/data"
 	"github.com/timescale/tsbs/pkg/data/usecases/common"
+	"github.com/timescale/tsbs/pkg/targets/timescaledb"
 	"log"
 	"strconv"
 	"strings"
@@ -67,16 +68,24 @@ func (f *fileDataSource) Headers() *common.GeneratedDataHeaders {
 		return nil
 	}
 	fieldKeys := make(map[string][]string)
+	var fieldTypes map[string][]string
 	for _, tableDef := range cols {
 		columns := strings.Split(tableDef, ",")
 		tableName := columns[0]
-		colNames := columns[1:]
+		colNames, colTypes := common.ParseFieldColumns(columns[1:])
 		fieldKeys[tableName] = colNames
+		if colTypes != nil {
+			if fieldTypes == nil {
+				fieldTypes = make(map[string][]string)
+			}
+			fieldTypes[tableName] = colTypes
+		}
 	}
 	f._headers = &common.GeneratedDataHeaders{
-		TagTypes:  tagTypes,
-		TagKeys:   tagNames,
-		FieldKeys: fieldKeys,
+		TagTypes:   tagTypes,
+		TagKeys:    tagNames,
+		FieldKeys:  fieldKeys,
+		FieldTypes: fieldTypes,
 	}
 	return f._headers
 }
@@ -118,7 +127,7 @@ func (f *fileDataSource) NextItem() data.LoadedPoint {
 	newPoint.timeUnixNano = f.prepareTimestamp(ts)
 	newPoint.fields = fields
 
-	return data.NewLoadedPoint(&newPoint)
+	return data.NewLoadedPoint(newPoint)
 }
 
 func (f *fileDataSource) prepareTimestamp(pointTs string) string {
@@ -157,16 +166,16 @@ func tagsLineToTagValues(tagsLine string) (tagKeys, tagValues []string) {
 }
 
 func fieldsLineToFieldValues(fieldsLine string) (time string, fieldValues []*string) {
-	metrics := strings.Split(fieldsLine, ",")
+	metrics := timescaledb.SplitFields(fieldsLine)
 	fieldValues = make([]*string, len(metrics)-1)
 	// use nil at 2nd position as placeholder for tagKey
-	for i, v := range metrics[1:] {
-		if v == "" {
+	for i := range metrics[1:] {
+		if metrics[i+1] == "" {
 			fieldValues[i] = nil
 			continue
 		}
 
-		fieldValues[i] = &v
+		fieldValues[i] = &metrics[i+1]
 	}
 
 	return metrics[0], fieldValues
@@ -0,0 +1,67 @@
+package timestream
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/timestreamwrite"
+)
+
+func TestFieldsLineToFieldValues(t *testing.T) {
+	ts, fields := fieldsLineToFieldValues(`1451606400000000000,1.5,,"a, ""b""",2`)
+	if ts != "1451606400000000000" {
+		t.Errorf("incorrect timestamp: got %s", ts)
+	}
+	want := []string{"1.5", "", `a, "b"`, "2"}
+	if len(fields) != len(want) {
+		t.Fatalf("incorrect number of fields: got %d want %d", len(fields), len(want))
+	}
+	for i, w := range want {
+		if w == "" {
+			if fields[i] != nil {
+				t.Errorf("field %d: got %s, want nil", i, *fields[i])
+			}
+			continue
+		}
+		if fields[i] == nil || *fields[i] != w {
+			t.Errorf("field %d: got %v, want %s", i, fields[i], w)
+		}
+	}
+}
+
+func TestFileDataSourceStringFields(t *testing.T) {
+	input := "tags,hostname string\n" +
+		"status,code float64,message string\n" +
+		"\n" +
+		"tags,hostname=host_0\n" +
+		"status,1451606400000000000,200,\"ok, done\"\n"
+	ds := &fileDataSource{scanner: bufio.NewScanner(strings.NewReader(input))}
+	headers := ds.Headers()
+	if got := strings.Join(headers.FieldKeys["status"], ","); got != "code,message" {
+		t.Errorf("incorrect field keys: got %s", got)
+	}
+
+	p := ds.NextItem().Data.(*deserializedPoint)
+	records := createRecords(p, headers, "status", nil, p.timeUnixNano)
+	if len(records) != 2 {
+		t.Fatalf("incorrect number of records: got %d", len(records))
+	}
+	if got := *records[0].MeasureValueType; got != timestreamwrite.MeasureValueTypeDouble {
+		t.Errorf("incorrect type of code: got %s", got)
+	}
+	if got := *records[1].MeasureValueType; got != timestreamwrite.MeasureValueTypeVarchar {
+		t.Errorf("incorrect type of message: got %s", got)
+	}
+	if got := *records[1].MeasureValue; got != "ok, done" {
+		t.Errorf("incorrect message: got %s", got)
+	}
+
+	buffer := make([]*timestreamwrite.Record, 2)
+	if n := convertPointToRecords(p, headers, "status", buffer); n != 2 {
+		t.Fatalf("incorrect number of records: got %d", n)
+	}
+	if got := *buffer[1].MeasureValueType; got != timestreamwrite.MeasureValueTypeVarchar {
+		t.Errorf("incorrect type of message: got %s", got)
+	}
+}
@@ -12,6 +12,8 @@ BULK_DATA_DIR=${BULK_DATA_DIR:-"/tmp/bulk_queries"}
 MAX_QUERIES=${MAX_QUERIES:-"0"}
 # How many concurrent worker would run queries - match num of cores, or default to 4
 NUM_WORKERS=${NUM_WORKERS:-$(grep -c ^processor /proc/cpuinfo 2> /dev/null || echo 4)}
+# Protocol to run the queries with - rest or pgwire
+PROTOCOL=${PROTOCOL:-"rest"}
 
 #
 # Run test for one file
@@ -46,6 +48,7 @@ function run_file()
         | $EXE_FILE_NAME \
             --max-queries $MAX_QUERIES \
             --workers $NUM_WORKERS \
+            --protocol $PROTOCOL \
         | tee $OUT_FULL_FILE_NAME
 }
 